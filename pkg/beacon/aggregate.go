@@ -0,0 +1,43 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+)
+
+// phase0AttestationVersions are the forks whose attestations have the phase0 shape.
+var phase0AttestationVersions = map[string]bool{
+	"phase0":    true,
+	"altair":    true,
+	"bellatrix": true,
+	"capella":   true,
+	"deneb":     true,
+}
+
+func (n *node) FetchAggregateAttestation(ctx context.Context, slot phase0.Slot, attestationDataRoot phase0.Root, committeeIndex phase0.CommitteeIndex) (*api.RawResponse, error) {
+	return n.currentAPI().AggregateAttestation(ctx, slot, attestationDataRoot, committeeIndex)
+}
+
+// SubmitAggregateAndProofs submits signed aggregate and proofs to the node for gossip.
+func (n *node) SubmitAggregateAndProofs(ctx context.Context, version string, aggregateAndProofs json.RawMessage) error {
+	return n.currentAPI().SubmitAggregateAndProofs(ctx, version, aggregateAndProofs)
+}
+
+// ParseAggregateAttestation decodes an aggregate attestation as returned by FetchAggregateAttestation. Only
+// attestations of forks before Electra can be decoded, as go-eth2-client has no types for later ones.
+func ParseAggregateAttestation(rsp *api.RawResponse) (*phase0.Attestation, error) {
+	if !phase0AttestationVersions[rsp.Version] {
+		return nil, fmt.Errorf("unsupported attestation version %q", rsp.Version)
+	}
+
+	attestation := &phase0.Attestation{}
+	if err := json.Unmarshal(rsp.Data, attestation); err != nil {
+		return nil, err
+	}
+
+	return attestation, nil
+}
@@ -4,13 +4,34 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
+	"sync/atomic"
+	"time"
 
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
-	"github.com/sirupsen/logrus"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+)
+
+var (
+	// ErrNotFound is returned when the node responds with a 404.
+	ErrNotFound = errors.New("not found")
+	// ErrNotImplemented is returned when the node responds with a 501.
+	ErrNotImplemented = errors.New("not implemented")
+	// ErrUnsupportedContentType is returned when the node responds with a 406 or 415 because it cannot serve
+	// the requested content type.
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+)
+
+const (
+	// ContentTypeJSON is the content type of JSON encoded responses.
+	ContentTypeJSON = "application/json"
+	// ContentTypeSSZ is the content type of SSZ encoded responses.
+	ContentTypeSSZ = "application/octet-stream"
 )
 
 // ConsensusClient is an interface for executing RPC calls to the Ethereum node.
@@ -18,33 +39,64 @@ type ConsensusClient interface {
 	NodePeer(ctx context.Context, peerID string) (types.Peer, error)
 	NodePeers(ctx context.Context) (types.Peers, error)
 	NodePeerCount(ctx context.Context) (types.PeerCount, error)
+	NodeSyncing(ctx context.Context) (*types.SyncState, error)
 	RawBlock(ctx context.Context, stateID string, contentType string) ([]byte, error)
 	RawDebugBeaconState(ctx context.Context, stateID string, contentType string) ([]byte, error)
+	RawDebugBeaconStateReader(ctx context.Context, stateID string) (io.ReadCloser, error)
+	RawBlockInto(ctx context.Context, stateID string, contentType string, dst []byte) ([]byte, error)
+	RawDebugBeaconStateInto(ctx context.Context, stateID string, contentType string, dst []byte) ([]byte, error)
+	RawBlockResponse(ctx context.Context, stateID string, contentType string, dst []byte) (*RawResponse, error)
+	RawDebugBeaconStateResponse(ctx context.Context, stateID string, contentType string, dst []byte) (*RawResponse, error)
 	DepositSnapshot(ctx context.Context) (*types.DepositSnapshot, error)
 	NodeIdentity(ctx context.Context) (*types.Identity, error)
+	StateValidator(ctx context.Context, stateID string, validatorID string) (*v1.Validator, error)
+	AggregateAttestation(ctx context.Context, slot phase0.Slot, attestationDataRoot phase0.Root, committeeIndex phase0.CommitteeIndex) (*RawResponse, error)
+	SubmitAggregateAndProofs(ctx context.Context, version string, aggregateAndProofs json.RawMessage) error
+	ThrottledRequests() uint64
+	EndpointStatus(ctx context.Context, path string) (int, error)
+	Events(ctx context.Context, topics []string, handler func(event *Event)) error
+	Date(ctx context.Context) (time.Time, error)
 }
 
 type consensusClient struct {
 	url     string
-	log     logrus.FieldLogger
+	log     *logging.Entry
 	client  http.Client
 	headers map[string]string
+
+	// maxResponseSize is the maximum size of a response body in bytes. Zero disables the limit.
+	maxResponseSize int64
+	// propagateRequestID sends the ID of every request to the node in the X-Request-Id header.
+	propagateRequestID bool
+
+	throttled atomic.Uint64
 }
 
 // NewConsensusClient creates a new ConsensusClient.
-func NewConsensusClient(ctx context.Context, log logrus.FieldLogger, url string, client http.Client, headers map[string]string) ConsensusClient {
-	return &consensusClient{
+func NewConsensusClient(ctx context.Context, log logging.Logger, url string, client http.Client, headers map[string]string, opts ...ClientOption) ConsensusClient {
+	c := &consensusClient{
 		url:     url,
-		log:     log,
+		log:     logging.New(log),
 		client:  client,
 		headers: headers,
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 type apiResponse struct {
 	Data json.RawMessage `json:"data"`
 }
 
+type versionedAPIResponse struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
 //nolint:unused // this is used in the future
 func (c *consensusClient) post(ctx context.Context, path string, body map[string]interface{}) (json.RawMessage, error) {
 	jsonData, err := json.Marshal(body)
@@ -52,28 +104,22 @@ func (c *consensusClient) post(ctx context.Context, path string, body map[string
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+path, bytes.NewBuffer(jsonData))
+	data, err := c.do(ctx, http.MethodPost, path, jsonData, "", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// Set headers from c.headers
-	for k, v := range c.headers {
-		req.Header.Set(k, v)
-	}
-
-	rsp, err := c.client.Do(req)
-	if err != nil {
+	resp := new(apiResponse)
+	if err := json.Unmarshal(data, resp); err != nil {
 		return nil, err
 	}
 
-	defer rsp.Body.Close()
-
-	if rsp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status code: %d", rsp.StatusCode)
-	}
+	return resp.Data, nil
+}
 
-	data, err := io.ReadAll(rsp.Body)
+//nolint:unparam // ctx will probably be used in the future
+func (c *consensusClient) get(ctx context.Context, path string) (json.RawMessage, error) {
+	data, err := c.do(ctx, http.MethodGet, path, nil, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -86,75 +132,208 @@ func (c *consensusClient) post(ctx context.Context, path string, body map[string
 	return resp.Data, nil
 }
 
-//nolint:unparam // ctx will probably be used in the future
-func (c *consensusClient) get(ctx context.Context, path string) (json.RawMessage, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.url+path, nil)
-	if err != nil {
-		return nil, err
+// getRawResponse is getRaw, returning the body together with the headers that describe it. Unlike getRaw,
+// requests for SSZ fall back to JSON if the node cannot serve SSZ, as the content type the body was actually
+// served as is in the response's ContentType.
+func (c *consensusClient) getRawResponse(ctx context.Context, path string, contentType string, dst []byte) (*RawResponse, error) {
+	if contentType == "" {
+		contentType = ContentTypeJSON
 	}
 
-	// Set headers from c.headers
-	for k, v := range c.headers {
-		req.Header.Set(k, v)
+	rsp, err := c.doResponse(ctx, http.MethodGet, path, nil, contentType, dst)
+	if err != nil && contentType == ContentTypeSSZ && errors.Is(err, ErrUnsupportedContentType) {
+		c.log.WithField("path", path).Debug("Node does not serve SSZ, falling back to JSON")
+
+		return c.doResponse(ctx, http.MethodGet, path, nil, ContentTypeJSON, dst)
 	}
 
-	rsp, err := c.client.Do(req)
+	return rsp, err
+}
+
+// getRaw returns the unparsed response body. If dst is non-nil the body is read into it, see readBody.
+// The body is always of the requested content type, so nodes that cannot serve it fail with
+// ErrUnsupportedContentType.
+func (c *consensusClient) getRaw(ctx context.Context, path string, contentType string, dst []byte) ([]byte, error) {
+	if contentType == "" {
+		contentType = ContentTypeJSON
+	}
+
+	return c.do(ctx, http.MethodGet, path, nil, contentType, dst)
+}
+
+// do executes the request and returns the response body. Requests that are throttled by the node are
+// retried after the duration given in the Retry-After header, up to maxThrottleRetries times.
+// The request keeps the same ID across retries.
+func (c *consensusClient) do(ctx context.Context, method, path string, body []byte, accept string, dst []byte) ([]byte, error) {
+	rsp, err := c.doResponse(ctx, method, path, body, accept, dst)
 	if err != nil {
 		return nil, err
 	}
 
-	defer rsp.Body.Close()
+	return rsp.Data, nil
+}
 
-	if rsp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status code: %d", rsp.StatusCode)
-	}
+// doResponse executes the request like do, returning the body together with the headers that describe it.
+func (c *consensusClient) doResponse(ctx context.Context, method, path string, body []byte, accept string, dst []byte) (*RawResponse, error) {
+	return c.doResponseWithHeader(ctx, method, path, nil, body, accept, dst)
+}
 
-	data, err := io.ReadAll(rsp.Body)
+// doResponseWithHeader executes the request like doResponse, setting the given headers on top of the configured
+// ones.
+func (c *consensusClient) doResponseWithHeader(ctx context.Context, method, path string, header http.Header, body []byte, accept string, dst []byte) (*RawResponse, error) {
+	requestID := newRequestID()
+
+	rsp, err := c.doWithID(ctx, method, path, header, body, accept, dst, requestID)
 	if err != nil {
-		return nil, err
+		return nil, wrapRequestError(err, requestID, method, path)
 	}
 
-	resp := new(apiResponse)
-	if err := json.Unmarshal(data, resp); err != nil {
-		return nil, err
+	return rsp, nil
+}
+
+func (c *consensusClient) doWithID(ctx context.Context, method, path string, header http.Header, body []byte, accept string, dst []byte, requestID string) (*RawResponse, error) {
+	for attempt := 0; ; attempt++ {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		req, err := c.newRequest(ctx, method, path, reader, requestID)
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+
+		rsp, err := c.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if rsp.StatusCode == http.StatusTooManyRequests {
+			rsp.Body.Close()
+
+			c.throttled.Add(1)
+
+			retryAfter := parseRetryAfter(rsp.Header.Get("Retry-After"), time.Now())
+
+			if attempt >= maxThrottleRetries || retryAfter > maxRetryAfter {
+				return nil, &TooManyRequestsError{RetryAfter: retryAfter}
+			}
+
+			c.log.WithField("retry_after", retryAfter).WithField("path", path).WithField("request_id", requestID).Debug("Request throttled by beacon node, retrying")
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(retryAfter):
+			}
+
+			continue
+		}
+
+		data, err := c.readResponse(rsp, dst)
+		if err != nil {
+			return nil, err
+		}
+
+		return newRawResponse(rsp, data), nil
 	}
+}
 
-	return resp.Data, nil
+// readResponse maps the status code of the response to an error and reads the body, up to the configured
+// maximum response size.
+func (c *consensusClient) readResponse(rsp *http.Response, dst []byte) ([]byte, error) {
+	rsp.Body = limitBody(rsp.Body, c.maxResponseSize)
+
+	// Only trust the Content-Length to pre-size the buffer if it is within the limit.
+	sizeHint := rsp.ContentLength
+	if c.maxResponseSize > 0 && sizeHint > c.maxResponseSize {
+		sizeHint = 0
+	}
+
+	return readResponse(rsp, sizeHint, dst)
 }
 
-func (c *consensusClient) getRaw(ctx context.Context, path string, contentType string) ([]byte, error) {
-	if contentType == "" {
-		contentType = "application/json"
+func readResponse(rsp *http.Response, sizeHint int64, dst []byte) ([]byte, error) {
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: status code: %d", ErrNotFound, rsp.StatusCode)
 	}
-	u, err := url.Parse(c.url + path)
-	if err != nil {
-		return nil, err
+
+	if rsp.StatusCode == http.StatusNotImplemented {
+		return nil, fmt.Errorf("%w: status code: %d", ErrNotImplemented, rsp.StatusCode)
+	}
+
+	if rsp.StatusCode == http.StatusNotAcceptable || rsp.StatusCode == http.StatusUnsupportedMediaType {
+		return nil, fmt.Errorf("%w: status code: %d", ErrUnsupportedContentType, rsp.StatusCode)
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code: %d", rsp.StatusCode)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	return readBody(rsp.Body, sizeHint, dst)
+}
+
+// EndpointStatus requests the given path and returns the status code of the response.
+func (c *consensusClient) EndpointStatus(ctx context.Context, path string) (int, error) {
+	requestID := newRequestID()
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil, requestID)
 	if err != nil {
-		return nil, err
+		return 0, wrapRequestError(err, requestID, http.MethodGet, path)
 	}
 
-	// Set headers from c.headers
-	for k, v := range c.headers {
-		req.Header.Set(k, v)
+	req.Header.Set("Accept", "application/json")
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return 0, wrapRequestError(err, requestID, http.MethodGet, path)
 	}
 
-	req.Header.Set("Accept", contentType)
+	// The body is not needed, some of the probed endpoints (e.g. fork choice) can be large.
+	rsp.Body.Close()
+
+	return rsp.StatusCode, nil
+}
+
+// Date requests the node's health endpoint and returns the time in the Date header of the response.
+func (c *consensusClient) Date(ctx context.Context) (time.Time, error) {
+	const path = "/eth/v1/node/health"
+
+	requestID := newRequestID()
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil, requestID)
+	if err != nil {
+		return time.Time{}, wrapRequestError(err, requestID, http.MethodGet, path)
+	}
 
 	rsp, err := c.client.Do(req)
 	if err != nil {
-		return nil, err
+		return time.Time{}, wrapRequestError(err, requestID, http.MethodGet, path)
 	}
 
-	defer rsp.Body.Close()
+	rsp.Body.Close()
 
-	if rsp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status code: %d", rsp.StatusCode)
+	header := rsp.Header.Get("Date")
+	if header == "" {
+		return time.Time{}, wrapRequestError(errors.New("response does not include a date header"), requestID, http.MethodGet, path)
 	}
 
-	return io.ReadAll(rsp.Body)
+	return http.ParseTime(header)
+}
+
+// ThrottledRequests returns the number of requests that the node has responded to with a 429.
+func (c *consensusClient) ThrottledRequests() uint64 {
+	return c.throttled.Load()
 }
 
 // NodePeers returns the list of peers connected to the node.
@@ -204,7 +383,7 @@ func (c *consensusClient) NodePeerCount(ctx context.Context) (types.PeerCount, e
 
 // RawDebugBeaconState returns the beacon state in the requested format.
 func (c *consensusClient) RawDebugBeaconState(ctx context.Context, stateID string, contentType string) ([]byte, error) {
-	data, err := c.getRaw(ctx, fmt.Sprintf("/eth/v2/debug/beacon/states/%s", stateID), contentType)
+	data, err := c.getRaw(ctx, fmt.Sprintf("/eth/v2/debug/beacon/states/%s", stateID), contentType, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -212,9 +391,53 @@ func (c *consensusClient) RawDebugBeaconState(ctx context.Context, stateID strin
 	return data, nil
 }
 
+// RawDebugBeaconStateInto returns the beacon state in the requested format, read into dst. The returned
+// slice shares dst's backing array unless the state did not fit.
+func (c *consensusClient) RawDebugBeaconStateInto(ctx context.Context, stateID string, contentType string, dst []byte) ([]byte, error) {
+	if dst == nil {
+		dst = []byte{}
+	}
+
+	return c.getRaw(ctx, fmt.Sprintf("/eth/v2/debug/beacon/states/%s", stateID), contentType, dst)
+}
+
+// RawDebugBeaconStateReader returns a reader for the SSZ encoded beacon state, leaving it to the caller to
+// consume the body. The reader must be closed.
+func (c *consensusClient) RawDebugBeaconStateReader(ctx context.Context, stateID string) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/eth/v2/debug/beacon/states/%s", stateID)
+	requestID := newRequestID()
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil, requestID)
+	if err != nil {
+		return nil, wrapRequestError(err, requestID, http.MethodGet, path)
+	}
+
+	req.Header.Set("Accept", ContentTypeSSZ)
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return nil, wrapRequestError(err, requestID, http.MethodGet, path)
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		// readResponse maps the status code to an error and closes the body.
+		_, err := c.readResponse(rsp, nil)
+
+		return nil, wrapRequestError(err, requestID, http.MethodGet, path)
+	}
+
+	return limitBody(rsp.Body, c.maxResponseSize), nil
+}
+
+// RawDebugBeaconStateResponse returns the beacon state in the requested format together with its fork
+// version and the content type it was served as. If dst is non-nil the state is read into it.
+func (c *consensusClient) RawDebugBeaconStateResponse(ctx context.Context, stateID string, contentType string, dst []byte) (*RawResponse, error) {
+	return c.getRawResponse(ctx, fmt.Sprintf("/eth/v2/debug/beacon/states/%s", stateID), contentType, dst)
+}
+
 // RawBlock returns the block in the requested format.
 func (c *consensusClient) RawBlock(ctx context.Context, stateID string, contentType string) ([]byte, error) {
-	data, err := c.getRaw(ctx, fmt.Sprintf("/eth/v2/beacon/blocks/%s", stateID), contentType)
+	data, err := c.getRaw(ctx, fmt.Sprintf("/eth/v2/beacon/blocks/%s", stateID), contentType, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -222,6 +445,22 @@ func (c *consensusClient) RawBlock(ctx context.Context, stateID string, contentT
 	return data, nil
 }
 
+// RawBlockInto returns the block in the requested format, read into dst. The returned slice shares dst's
+// backing array unless the block did not fit.
+func (c *consensusClient) RawBlockInto(ctx context.Context, stateID string, contentType string, dst []byte) ([]byte, error) {
+	if dst == nil {
+		dst = []byte{}
+	}
+
+	return c.getRaw(ctx, fmt.Sprintf("/eth/v2/beacon/blocks/%s", stateID), contentType, dst)
+}
+
+// RawBlockResponse returns the block in the requested format together with its fork version and the content
+// type it was served as. If dst is non-nil the block is read into it.
+func (c *consensusClient) RawBlockResponse(ctx context.Context, stateID string, contentType string, dst []byte) (*RawResponse, error) {
+	return c.getRawResponse(ctx, fmt.Sprintf("/eth/v2/beacon/blocks/%s", stateID), contentType, dst)
+}
+
 // DepositSnapshot returns the deposit snapshot in the requested format.
 func (c *consensusClient) DepositSnapshot(ctx context.Context) (*types.DepositSnapshot, error) {
 	data, err := c.get(ctx, "/eth/v1/beacon/deposit_snapshot")
@@ -237,6 +476,21 @@ func (c *consensusClient) DepositSnapshot(ctx context.Context) (*types.DepositSn
 	return &rsp, nil
 }
 
+// NodeSyncing returns the sync status of the node.
+func (c *consensusClient) NodeSyncing(ctx context.Context) (*types.SyncState, error) {
+	data, err := c.get(ctx, "/eth/v1/node/syncing")
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := types.SyncState{}
+	if err := json.Unmarshal(data, &rsp); err != nil {
+		return nil, err
+	}
+
+	return &rsp, nil
+}
+
 func (c *consensusClient) NodeIdentity(ctx context.Context) (*types.Identity, error) {
 	data, err := c.get(ctx, "/eth/v1/node/identity")
 	if err != nil {
@@ -250,3 +504,56 @@ func (c *consensusClient) NodeIdentity(ctx context.Context) (*types.Identity, er
 
 	return &rsp, nil
 }
+
+// StateValidator returns a single validator at the given state. The validator id is either the index or the
+// hex encoded public key of the validator.
+func (c *consensusClient) StateValidator(ctx context.Context, stateID string, validatorID string) (*v1.Validator, error) {
+	data, err := c.get(ctx, fmt.Sprintf("/eth/v1/beacon/states/%s/validators/%s", stateID, validatorID))
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := v1.Validator{}
+	if err := json.Unmarshal(data, &rsp); err != nil {
+		return nil, err
+	}
+
+	return &rsp, nil
+}
+
+// AggregateAttestation returns the aggregate attestation for the given slot, attestation data root and committee
+// index from the v2 endpoint. The attestation is returned unparsed together with its fork, as its shape changed
+// in Electra.
+func (c *consensusClient) AggregateAttestation(ctx context.Context, slot phase0.Slot, attestationDataRoot phase0.Root, committeeIndex phase0.CommitteeIndex) (*RawResponse, error) {
+	path := fmt.Sprintf("/eth/v2/validator/aggregate_attestation?attestation_data_root=%#x&slot=%d&committee_index=%d", attestationDataRoot, slot, committeeIndex)
+
+	rsp, err := c.doResponse(ctx, http.MethodGet, path, nil, ContentTypeJSON, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	versioned := new(versionedAPIResponse)
+	if err := json.Unmarshal(rsp.Data, versioned); err != nil {
+		return nil, err
+	}
+
+	rsp.Data = versioned.Data
+
+	if rsp.Version == "" {
+		rsp.Version = versioned.Version
+	}
+
+	return rsp, nil
+}
+
+// SubmitAggregateAndProofs submits the JSON encoded signed aggregate and proofs of the given fork to the v2
+// endpoint.
+func (c *consensusClient) SubmitAggregateAndProofs(ctx context.Context, version string, aggregateAndProofs json.RawMessage) error {
+	header := http.Header{}
+	header.Set(consensusVersionHeader, version)
+	header.Set("Content-Type", ContentTypeJSON)
+
+	_, err := c.doResponseWithHeader(ctx, http.MethodPost, "/eth/v2/beacon/pool/aggregate_and_proofs", header, aggregateAndProofs, "", nil)
+
+	return err
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// bufferPool holds the buffers that response bodies are read into, so that repeated fetches of large raw
+// states and blocks do not grow a fresh buffer for every response.
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// readBody reads the body, pre-sizing the buffer to sizeHint if it is positive. If dst is non-nil the body is
+// read into dst, reusing its capacity, and the possibly grown slice is returned. Otherwise the body is read
+// into a pooled buffer and copied out, so that only the returned slice is allocated.
+func readBody(body io.Reader, sizeHint int64, dst []byte) ([]byte, error) {
+	if dst != nil {
+		return readInto(body, sizeHint, dst[:0])
+	}
+
+	buf, _ := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+
+	defer bufferPool.Put(buf)
+
+	if sizeHint > 0 {
+		buf.Grow(int(sizeHint))
+	}
+
+	if _, err := buf.ReadFrom(body); err != nil {
+		return nil, err
+	}
+
+	return bytes.Clone(buf.Bytes()), nil
+}
+
+// readInto appends the body to dst. Unlike bytes.Buffer.ReadFrom it only grows dst once it is full, so a dst
+// that is large enough is never reallocated.
+func readInto(body io.Reader, sizeHint int64, dst []byte) ([]byte, error) {
+	if sizeHint > int64(cap(dst)) {
+		dst = append(dst, make([]byte, sizeHint)...)[:0]
+	}
+
+	for {
+		if len(dst) == cap(dst) {
+			// Add more capacity, letting append pick how much.
+			dst = append(dst, 0)[:len(dst)]
+		}
+
+		n, err := body.Read(dst[len(dst):cap(dst)])
+		dst = dst[:len(dst)+n]
+
+		if err == io.EOF {
+			return dst, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
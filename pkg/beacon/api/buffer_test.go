@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBody(t *testing.T) {
+	data, err := readBody(strings.NewReader("abcdef"), 6, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef", string(data))
+
+	// The pooled buffer is reused, so the returned data must not share it.
+	other, err := readBody(strings.NewReader("ghijkl"), 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef", string(data))
+	assert.Equal(t, "ghijkl", string(other))
+}
+
+func TestReadBodyIntoDst(t *testing.T) {
+	dst := make([]byte, 3, 16)
+
+	data, err := readBody(strings.NewReader("abcdef"), 6, dst)
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef", string(data))
+	assert.Same(t, &dst[:1][0], &data[0])
+
+	data, err = readBody(strings.NewReader(strings.Repeat("a", 32)), 32, dst)
+	require.NoError(t, err)
+	assert.Len(t, data, 32)
+}
+
+func TestConsensusClientRawBlockInto(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/eth/v2/beacon/blocks/head", r.URL.Path)
+
+		_, _ = w.Write([]byte("block"))
+	}))
+	defer server.Close()
+
+	client := NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{}, nil)
+
+	dst := make([]byte, 0, 64)
+
+	data, err := client.RawBlockInto(context.Background(), "head", "application/octet-stream", dst)
+	require.NoError(t, err)
+	assert.Equal(t, "block", string(data))
+	assert.Equal(t, "block", string(dst[:len(data)]))
+}
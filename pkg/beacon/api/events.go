@@ -0,0 +1,113 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxEventSize is the maximum size of a single line in the event stream.
+const maxEventSize = 10 * 1024 * 1024
+
+// eventStreamConnectTimeout bounds how long connecting to the event stream may take, up to and including the
+// response headers. The stream itself is long-lived, so the client's timeout for the whole request does not
+// apply to it.
+const eventStreamConnectTimeout = 30 * time.Second
+
+// Event is an event received from the node's event stream.
+type Event struct {
+	Topic string
+	Data  []byte
+	// ReceivedAt is the time the event was read from the event stream.
+	ReceivedAt time.Time
+}
+
+// Events streams the given topics from the node's event stream, calling handler for every event received.
+// It blocks until the stream ends or the context is cancelled. It is intended for topics that are not yet
+// supported by go-eth2-client.
+func (c *consensusClient) Events(ctx context.Context, topics []string, handler func(event *Event)) error {
+	query := url.Values{}
+	for _, topic := range topics {
+		query.Add("topics", topic)
+	}
+
+	path := "/eth/v1/events?" + query.Encode()
+	requestID := newRequestID()
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req, err := c.newRequest(streamCtx, http.MethodGet, path, nil, requestID)
+	if err != nil {
+		return wrapRequestError(err, requestID, http.MethodGet, path)
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+
+	client := c.client
+	client.Timeout = 0
+
+	connectTimer := time.AfterFunc(eventStreamConnectTimeout, cancel)
+
+	rsp, err := client.Do(req)
+
+	if !connectTimer.Stop() && ctx.Err() == nil {
+		err = fmt.Errorf("timed out connecting to the event stream after %s", eventStreamConnectTimeout)
+	}
+
+	if err != nil {
+		if rsp != nil {
+			rsp.Body.Close()
+		}
+
+		return wrapRequestError(err, requestID, http.MethodGet, path)
+	}
+
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return wrapRequestError(fmt.Errorf("status code: %d", rsp.StatusCode), requestID, http.MethodGet, path)
+	}
+
+	scanner := bufio.NewScanner(rsp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+
+	event := &Event{}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			// A blank line terminates the event.
+			if event.Topic != "" {
+				event.ReceivedAt = time.Now()
+
+				handler(event)
+			}
+
+			event = &Event{}
+		case strings.HasPrefix(line, "event:"):
+			event.Topic = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data := bytes.TrimPrefix([]byte(strings.TrimPrefix(line, "data:")), []byte(" "))
+
+			if event.Data != nil {
+				event.Data = append(event.Data, '\n')
+			}
+
+			event.Data = append(event.Data, data...)
+		}
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return wrapRequestError(err, requestID, http.MethodGet, path)
+	}
+
+	return ctx.Err()
+}
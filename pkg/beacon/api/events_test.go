@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusClientEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"data_column_sidecar", "head"}, r.URL.Query()["topics"])
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+
+		_, _ = w.Write([]byte("event: data_column_sidecar\ndata: {\"index\":\"1\"}\n\n: keepalive\n\nevent: head\ndata: {}\n\n"))
+	}))
+	defer server.Close()
+
+	client := NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{}, nil)
+
+	var events []*Event
+
+	err := client.Events(context.Background(), []string{"data_column_sidecar", "head"}, func(event *Event) {
+		events = append(events, event)
+	})
+	require.NoError(t, err)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, "data_column_sidecar", events[0].Topic)
+	assert.Equal(t, `{"index":"1"}`, string(events[0].Data))
+	assert.Equal(t, "head", events[1].Topic)
+}
+
+func TestConsensusClientEventsOutliveClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok)
+
+		_, _ = w.Write([]byte("event: head\ndata: {}\n\n"))
+		flusher.Flush()
+
+		time.Sleep(150 * time.Millisecond)
+
+		_, _ = w.Write([]byte("event: head\ndata: {}\n\n"))
+	}))
+	defer server.Close()
+
+	// The client timeout covers whole requests, which must not cut long-lived event streams short.
+	client := NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{Timeout: 50 * time.Millisecond}, nil)
+
+	var events []*Event
+
+	err := client.Events(context.Background(), []string{"head"}, func(event *Event) {
+		events = append(events, event)
+	})
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+}
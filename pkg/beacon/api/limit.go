@@ -0,0 +1,66 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrResponseTooLarge is returned when the node's response exceeds the configured maximum response size.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ResponseTooLargeError is returned when the node's response exceeds the configured maximum response size.
+type ResponseTooLargeError struct {
+	// Limit is the maximum response size in bytes.
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("%s: exceeds %d bytes", ErrResponseTooLarge, e.Limit)
+}
+
+// Unwrap allows errors.Is to match ErrResponseTooLarge.
+func (e *ResponseTooLargeError) Unwrap() error {
+	return ErrResponseTooLarge
+}
+
+// limitedReader reads from r until more than limit bytes have been read, after which it returns a
+// ResponseTooLargeError.
+type limitedReader struct {
+	r     io.ReadCloser
+	limit int64
+	read  int64
+}
+
+// limitBody wraps body so that reading more than limit bytes fails. A limit of zero returns body unchanged.
+func limitBody(body io.ReadCloser, limit int64) io.ReadCloser {
+	if limit <= 0 {
+		return body
+	}
+
+	return &limitedReader{r: body, limit: limit}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.read > l.limit {
+		return 0, &ResponseTooLargeError{Limit: l.limit}
+	}
+
+	// Read at most one byte past the limit to detect that it was exceeded.
+	if remaining := l.limit + 1 - l.read; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+
+	if l.read > l.limit {
+		return n - int(l.read-l.limit), &ResponseTooLargeError{Limit: l.limit}
+	}
+
+	return n, err
+}
+
+func (l *limitedReader) Close() error {
+	return l.r.Close()
+}
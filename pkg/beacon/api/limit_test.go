@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusClientMaxResponseSize(t *testing.T) {
+	body := `{"data":{"connected":"1","disconnected":"0","connecting":"0","disconnecting":"0"}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{}, nil, WithMaxResponseSize(int64(len(body))))
+
+	_, err := client.NodePeerCount(context.Background())
+	require.NoError(t, err)
+
+	client = NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{}, nil, WithMaxResponseSize(int64(len(body)-1)))
+
+	_, err = client.NodePeerCount(context.Background())
+	require.ErrorIs(t, err, ErrResponseTooLarge)
+
+	var tooLarge *ResponseTooLargeError
+	require.ErrorAs(t, err, &tooLarge)
+	assert.Equal(t, int64(len(body)-1), tooLarge.Limit)
+}
+
+func TestLimitBody(t *testing.T) {
+	data, err := io.ReadAll(limitBody(io.NopCloser(strings.NewReader("abcdef")), 6))
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef", string(data))
+
+	data, err = io.ReadAll(limitBody(io.NopCloser(strings.NewReader("abcdef")), 4))
+	require.ErrorIs(t, err, ErrResponseTooLarge)
+	assert.Equal(t, "abcd", string(data))
+
+	data, err = io.ReadAll(limitBody(io.NopCloser(strings.NewReader("abcdef")), 0))
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef", string(data))
+}
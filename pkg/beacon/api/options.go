@@ -0,0 +1,18 @@
+package api
+
+// ClientOption configures a ConsensusClient.
+type ClientOption func(c *consensusClient)
+
+// WithMaxResponseSize limits the size of response bodies read by the client. Zero disables the limit.
+func WithMaxResponseSize(limit int64) ClientOption {
+	return func(c *consensusClient) {
+		c.maxResponseSize = limit
+	}
+}
+
+// WithRequestIDPropagation sends the ID generated for every request to the node in the X-Request-Id header.
+func WithRequestIDPropagation() ClientOption {
+	return func(c *consensusClient) {
+		c.propagateRequestID = true
+	}
+}
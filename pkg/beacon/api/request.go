@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+)
+
+// RequestIDHeader is the header the request ID is sent in when request ID propagation is enabled.
+const RequestIDHeader = "X-Request-Id"
+
+// RequestError wraps the error of a request to the node with the ID of the request, so that it can be
+// cross-referenced with the node's logs.
+type RequestError struct {
+	RequestID string
+	Method    string
+	Path      string
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%s %s (request id %s): %s", e.Method, e.Path, e.RequestID, e.Err)
+}
+
+// Unwrap returns the error of the request.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// newRequestID generates a random ID for a request.
+func newRequestID() string {
+	id := make([]byte, 8)
+
+	// crypto/rand.Read never returns an error.
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// newRequest creates a request for the path on the node, setting the configured headers and, if request ID
+// propagation is enabled, the request ID header.
+func (c *consensusClient) newRequest(ctx context.Context, method, path string, body io.Reader, requestID string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set headers from c.headers
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	if c.propagateRequestID {
+		req.Header.Set(RequestIDHeader, requestID)
+	}
+
+	c.log.WithField("request_id", requestID).WithField("method", method).WithField("path", path).Debug("Sending request to beacon node")
+
+	return req, nil
+}
+
+// requestIDTransport sends a new request ID with every request, for HTTP clients other than the raw API client.
+type requestIDTransport struct {
+	log  *logging.Entry
+	base http.RoundTripper
+}
+
+// NewRequestIDTransport returns a transport that sends a new request ID in the X-Request-Id header with every
+// request made through base, and includes it in debug logs and errors. It lets requests of other clients of the
+// node, e.g. go-eth2-client's, be correlated like those of the raw API client.
+func NewRequestIDTransport(log logging.Logger, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &requestIDTransport{
+		log:  logging.New(log),
+		base: base,
+	}
+}
+
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID := newRequestID()
+
+	// A RoundTripper must not modify the request it is given.
+	req = req.Clone(req.Context())
+	req.Header.Set(RequestIDHeader, requestID)
+
+	t.log.WithField("request_id", requestID).WithField("method", req.Method).WithField("path", req.URL.Path).Debug("Sending request to beacon node")
+
+	rsp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, wrapRequestError(err, requestID, req.Method, req.URL.Path)
+	}
+
+	return rsp, nil
+}
+
+// wrapRequestError wraps err with the details of the request. A nil err is returned as is.
+func wrapRequestError(err error, requestID, method, path string) error {
+	if err == nil {
+		return nil
+	}
+
+	return &RequestError{
+		RequestID: requestID,
+		Method:    method,
+		Path:      path,
+		Err:       err,
+	}
+}
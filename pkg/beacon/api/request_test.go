@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusClientRequestID(t *testing.T) {
+	var received string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(RequestIDHeader)
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{}, nil)
+
+	_, err := client.NodePeerCount(context.Background())
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Empty(t, received)
+
+	var reqErr *RequestError
+	require.ErrorAs(t, err, &reqErr)
+	assert.Len(t, reqErr.RequestID, 16)
+	assert.Equal(t, http.MethodGet, reqErr.Method)
+	assert.Equal(t, "/eth/v1/node/peer_count", reqErr.Path)
+	assert.Contains(t, err.Error(), reqErr.RequestID)
+
+	client = NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{}, nil, WithRequestIDPropagation())
+
+	_, err = client.NodePeerCount(context.Background())
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, reqErr.RequestID, received)
+}
+
+func TestRequestIDTransport(t *testing.T) {
+	var received []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(RequestIDHeader))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRequestIDTransport(logging.New(nil), nil)}
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/eth/v1/node/version", http.NoBody)
+		require.NoError(t, err)
+
+		rsp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, rsp.Body.Close())
+
+		// The request passed in is left untouched.
+		assert.Empty(t, req.Header.Get(RequestIDHeader))
+	}
+
+	// Every request gets an ID of its own.
+	require.Len(t, received, 2)
+	assert.Len(t, received[0], 16)
+	assert.NotEqual(t, received[0], received[1])
+
+	server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/eth/v1/node/version", http.NoBody)
+	require.NoError(t, err)
+
+	_, err = client.Do(req) //nolint:bodyclose // the request fails.
+
+	var reqErr *RequestError
+	require.ErrorAs(t, err, &reqErr)
+	assert.Len(t, reqErr.RequestID, 16)
+	assert.Equal(t, "/eth/v1/node/version", reqErr.Path)
+}
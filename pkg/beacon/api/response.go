@@ -0,0 +1,32 @@
+package api
+
+import (
+	"mime"
+	"net/http"
+)
+
+// consensusVersionHeader is the header the beacon API uses to name the fork of a returned object.
+const consensusVersionHeader = "Eth-Consensus-Version"
+
+// RawResponse is an unparsed response body together with the headers needed to decode it.
+type RawResponse struct {
+	Data []byte
+	// Version is the fork of the returned object, e.g. "deneb", as named by the Eth-Consensus-Version header.
+	// It is empty if the node did not send the header.
+	Version string
+	// ContentType is the media type the node served the body as, without parameters.
+	ContentType string
+}
+
+func newRawResponse(rsp *http.Response, data []byte) *RawResponse {
+	contentType := rsp.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+
+	return &RawResponse{
+		Data:        data,
+		Version:     rsp.Header.Get(consensusVersionHeader),
+		ContentType: contentType,
+	}
+}
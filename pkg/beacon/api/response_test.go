@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawBlockResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v2/beacon/blocks/head" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		assert.Equal(t, "application/octet-stream", r.Header.Get("Accept"))
+
+		w.Header().Set("Content-Type", "application/octet-stream; charset=binary")
+		w.Header().Set("Eth-Consensus-Version", "deneb")
+		_, _ = w.Write([]byte{0x01, 0x02})
+	}))
+	defer server.Close()
+
+	client := NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{}, nil)
+
+	rsp, err := client.RawBlockResponse(context.Background(), "head", "application/octet-stream", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x02}, rsp.Data)
+	assert.Equal(t, "deneb", rsp.Version)
+	assert.Equal(t, "application/octet-stream", rsp.ContentType)
+
+	_, err = client.RawDebugBeaconStateResponse(context.Background(), "head", "application/octet-stream", nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRawResponseFallsBackToJSON(t *testing.T) {
+	var accepts []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accepts = append(accepts, r.Header.Get("Accept"))
+
+		if r.Header.Get("Accept") == ContentTypeSSZ {
+			w.WriteHeader(http.StatusNotAcceptable)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		w.Header().Set("Eth-Consensus-Version", "deneb")
+		_, _ = w.Write([]byte(`{"version":"deneb","data":{}}`))
+	}))
+	defer server.Close()
+
+	client := NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{}, nil)
+
+	rsp, err := client.RawBlockResponse(context.Background(), "head", ContentTypeSSZ, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ContentTypeJSON, rsp.ContentType)
+	assert.Equal(t, []string{ContentTypeSSZ, ContentTypeJSON}, accepts)
+
+	// Only the response variants, which report the served content type, fall back.
+	accepts = nil
+
+	_, err = client.RawBlock(context.Background(), "head", ContentTypeSSZ)
+	require.ErrorIs(t, err, ErrUnsupportedContentType)
+	assert.Equal(t, []string{ContentTypeSSZ}, accepts)
+
+	// Requests for JSON are not retried.
+	accepts = nil
+
+	data, err := client.RawBlock(context.Background(), "head", ContentTypeJSON)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":"deneb","data":{}}`, string(data))
+	assert.Equal(t, []string{ContentTypeJSON}, accepts)
+}
+
+func TestAggregateAttestationV2(t *testing.T) {
+	var (
+		query     string
+		version   string
+		submitted string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v2/validator/aggregate_attestation":
+			query = r.URL.RawQuery
+
+			_, _ = w.Write([]byte(`{"version":"electra","data":{"committee_bits":"0x01"}}`))
+		case "/eth/v2/beacon/pool/aggregate_and_proofs":
+			version = r.Header.Get(consensusVersionHeader)
+
+			body, _ := io.ReadAll(r.Body)
+			submitted = string(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{}, nil)
+
+	rsp, err := client.AggregateAttestation(context.Background(), 10, phase0.Root{0x01}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "attestation_data_root=0x0100000000000000000000000000000000000000000000000000000000000000&slot=10&committee_index=2", query)
+	assert.Equal(t, "electra", rsp.Version)
+	assert.JSONEq(t, `{"committee_bits":"0x01"}`, string(rsp.Data))
+
+	require.NoError(t, client.SubmitAggregateAndProofs(context.Background(), "electra", []byte(`[{}]`)))
+	assert.Equal(t, "electra", version)
+	assert.Equal(t, `[{}]`, submitted)
+}
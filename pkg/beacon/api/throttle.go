@@ -0,0 +1,59 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxThrottleRetries is the number of times a throttled request is retried before giving up.
+	maxThrottleRetries = 2
+	// maxRetryAfter is the longest Retry-After that is waited for before a request is retried.
+	maxRetryAfter = 12 * time.Second
+	// defaultRetryAfter is used when the node does not send a valid Retry-After header.
+	defaultRetryAfter = time.Second
+)
+
+// ErrTooManyRequests is returned when the node responds with a 429.
+var ErrTooManyRequests = errors.New("too many requests")
+
+// TooManyRequestsError is returned when the node keeps throttling a request.
+type TooManyRequestsError struct {
+	// RetryAfter is the duration the node asked to wait before retrying.
+	RetryAfter time.Duration
+}
+
+func (e *TooManyRequestsError) Error() string {
+	return fmt.Sprintf("%s: status code: %d: retry after %s", ErrTooManyRequests, http.StatusTooManyRequests, e.RetryAfter)
+}
+
+// Unwrap allows errors.Is to match ErrTooManyRequests.
+func (e *TooManyRequestsError) Unwrap() error {
+	return ErrTooManyRequests
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return defaultRetryAfter
+	}
+
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+
+		return 0
+	}
+
+	return defaultRetryAfter
+}
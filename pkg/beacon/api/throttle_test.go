@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{name: "empty", value: "", expected: defaultRetryAfter},
+		{name: "seconds", value: "3", expected: 3 * time.Second},
+		{name: "http date", value: now.Add(5 * time.Second).Format(http.TimeFormat), expected: 5 * time.Second},
+		{name: "http date in the past", value: now.Add(-5 * time.Second).Format(http.TimeFormat), expected: 0},
+		{name: "invalid", value: "soon", expected: defaultRetryAfter},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, parseRetryAfter(test.value, now))
+		})
+	}
+}
+
+func TestConsensusClientThrottled(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"connected":"1","disconnected":"0","connecting":"0","disconnecting":"0"}}`))
+	}))
+	defer server.Close()
+
+	client := NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{}, nil)
+
+	_, err := client.NodePeerCount(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, uint64(1), client.ThrottledRequests())
+}
+
+func TestConsensusClientThrottledRetryAfterTooLong(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{}, nil)
+
+	_, err := client.NodePeerCount(context.Background())
+	require.ErrorIs(t, err, ErrTooManyRequests)
+
+	var throttled *TooManyRequestsError
+	require.ErrorAs(t, err, &throttled)
+	assert.Equal(t, 60*time.Second, throttled.RetryAfter)
+	assert.Equal(t, uint64(1), client.ThrottledRequests())
+}
@@ -14,6 +14,7 @@ func TestAgentParsing(t *testing.T) {
 		{"nimbus", AgentNimbus},
 		{"teku/teku/v22.9.0/linux-x86_64/-privatebuild-openjdk64bitservervm-java-17", AgentTeku},
 		{"Lodestar/v0.32.0-rc.0-1-gc3b5b6a9/linux-x64/nodejs", AgentLodestar},
+		{"Grandine/0.4.0-5bcd5ba/x86_64-linux", AgentGrandine},
 	}
 
 	for _, test := range tests {
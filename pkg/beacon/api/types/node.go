@@ -14,6 +14,8 @@ type Identity struct {
 		SeqNumber string `json:"seq_number"`
 		Attnets   string `json:"attnets"`
 		Syncnets  string `json:"syncnets"`
+		// CustodyGroupCount is only reported by nodes that support PeerDAS.
+		CustodyGroupCount string `json:"custody_group_count,omitempty"`
 	} `json:"metadata"`
 }
 
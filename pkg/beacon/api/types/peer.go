@@ -112,8 +112,58 @@ func (p *Peers) AgentCount() AgentCount {
 			count.Prysm = numberOfAgents
 		case AgentLodestar:
 			count.Lodestar = numberOfAgents
+		case AgentGrandine:
+			count.Grandine = numberOfAgents
 		}
 	}
 
 	return count
 }
+
+// Diff returns the peers that have been added and removed compared to the previous list of peers.
+// Peers are matched by their peer ID.
+func (p *Peers) Diff(previous Peers) (added, removed Peers) {
+	current := make(map[string]struct{}, len(*p))
+	for _, peer := range *p {
+		current[peer.PeerID] = struct{}{}
+	}
+
+	before := make(map[string]struct{}, len(previous))
+	for _, peer := range previous {
+		before[peer.PeerID] = struct{}{}
+	}
+
+	for _, peer := range *p {
+		if _, exists := before[peer.PeerID]; !exists {
+			added = append(added, peer)
+		}
+	}
+
+	for _, peer := range previous {
+		if _, exists := current[peer.PeerID]; !exists {
+			removed = append(removed, peer)
+		}
+	}
+
+	return added, removed
+}
+
+// Equal returns true if both lists contain the same peers with identical fields, regardless of order.
+func (p *Peers) Equal(other Peers) bool {
+	if len(*p) != len(other) {
+		return false
+	}
+
+	byID := make(map[string]Peer, len(other))
+	for _, peer := range other {
+		byID[peer.PeerID] = peer
+	}
+
+	for _, peer := range *p {
+		if o, exists := byID[peer.PeerID]; !exists || o != peer {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeersDiff(t *testing.T) {
+	previous := Peers{
+		{PeerID: "a", State: "connected"},
+		{PeerID: "b", State: "connected"},
+	}
+
+	current := Peers{
+		{PeerID: "b", State: "connected"},
+		{PeerID: "c", State: "connecting"},
+	}
+
+	added, removed := current.Diff(previous)
+
+	assert.Equal(t, Peers{{PeerID: "c", State: "connecting"}}, added)
+	assert.Equal(t, Peers{{PeerID: "a", State: "connected"}}, removed)
+}
+
+func TestPeersEqual(t *testing.T) {
+	peers := Peers{
+		{PeerID: "a", State: "connected"},
+		{PeerID: "b", State: "connected"},
+	}
+
+	assert.True(t, peers.Equal(Peers{
+		{PeerID: "b", State: "connected"},
+		{PeerID: "a", State: "connected"},
+	}))
+
+	assert.False(t, peers.Equal(Peers{
+		{PeerID: "a", State: "connected"},
+		{PeerID: "b", State: "disconnected"},
+	}))
+
+	assert.False(t, peers.Equal(Peers{
+		{PeerID: "a", State: "connected"},
+	}))
+}
@@ -0,0 +1,15 @@
+package types
+
+import "github.com/attestantio/go-eth2-client/spec/phase0"
+
+// SyncState represents the sync status of the node. Unlike the go-eth2-client type it includes whether the
+// node reports its execution client as offline.
+type SyncState struct {
+	HeadSlot     phase0.Slot `json:"head_slot,string"`
+	SyncDistance phase0.Slot `json:"sync_distance,string"`
+	IsSyncing    bool        `json:"is_syncing"`
+	IsOptimistic bool        `json:"is_optimistic"`
+	// ELOffline is true if the node reports that its execution client is offline. Nodes that predate the
+	// field never report it.
+	ELOffline bool `json:"el_offline"`
+}
@@ -0,0 +1,24 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncStateUnmarshal(t *testing.T) {
+	var state SyncState
+
+	require.NoError(t, json.Unmarshal([]byte(`{"head_slot":"100","sync_distance":"2","is_syncing":false,"is_optimistic":true,"el_offline":true}`), &state))
+
+	assert.Equal(t, SyncState{HeadSlot: 100, SyncDistance: 2, IsOptimistic: true, ELOffline: true}, state)
+
+	// Nodes that predate el_offline omit it.
+	state = SyncState{}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"head_slot":"1","sync_distance":"0","is_syncing":true}`), &state))
+
+	assert.Equal(t, SyncState{HeadSlot: 1, IsSyncing: true}, state)
+}
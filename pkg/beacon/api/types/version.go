@@ -0,0 +1,127 @@
+package types
+
+import (
+	"strings"
+)
+
+// NodeVersion is a parsed beacon node version string.
+type NodeVersion struct {
+	// Raw is the unparsed version string as reported by the node.
+	Raw string `json:"raw"`
+	// Client is the client that the node is running.
+	Client Agent `json:"client"`
+	// Version is the semantic version of the client, without a leading "v".
+	Version string `json:"version"`
+	// Commit is the commit hash the client was built from, if reported.
+	Commit string `json:"commit"`
+	// Platform is the platform the client was built for, if reported.
+	Platform string `json:"platform"`
+}
+
+// ParseNodeVersion parses a beacon node version string as returned by /eth/v1/node/version.
+// Examples of supported formats:
+//
+//	Lighthouse/v4.5.0-441fc16/x86_64-linux
+//	Prysm/v4.1.1/9b6cd9ac0a0e8bf2c1b5d8b4cd8f1e06e9a13d20
+//	Prysm/v5.0.3 (linux amd64)
+//	teku/v23.10.0/linux-x86_64/-eclipseadoptium-openjdk64bitservervm-java-17
+//	Nimbus/v23.10.0-8b07f4-stateofus
+//	Lodestar/v1.12.0/a6b1a9d
+//	Grandine/0.4.0-5bcd5ba/x86_64-linux
+func ParseNodeVersion(raw string) NodeVersion {
+	v := NodeVersion{
+		Raw:    raw,
+		Client: AgentFromString(raw),
+	}
+
+	trimmed := strings.TrimSpace(raw)
+
+	// Prysm reports its platform in trailing parentheses.
+	if start := strings.Index(trimmed, "("); start != -1 {
+		if end := strings.LastIndex(trimmed, ")"); end > start {
+			v.Platform = strings.TrimSpace(trimmed[start+1 : end])
+			trimmed = strings.TrimSpace(trimmed[:start])
+		}
+	}
+
+	parts := strings.Split(trimmed, "/")
+	if len(parts) < 2 {
+		return v
+	}
+
+	name := parts[0]
+	rest := parts[1:]
+
+	// Teku repeats its name (e.g. teku/teku/v22.9.0/...).
+	if len(rest) > 1 && strings.EqualFold(rest[0], name) {
+		rest = rest[1:]
+	}
+
+	v.Version, v.Commit = parseVersionAndCommit(rest[0])
+
+	for _, part := range rest[1:] {
+		if part == "" || strings.HasPrefix(part, "-") {
+			continue
+		}
+
+		if v.Commit == "" && isCommitHash(part) {
+			v.Commit = part
+
+			continue
+		}
+
+		if v.Platform == "" && !isCommitHash(part) {
+			v.Platform = part
+		}
+	}
+
+	return v
+}
+
+// parseVersionAndCommit splits a version such as "v4.5.0-441fc16" or "v0.32.0-rc.0-1-gc3b5b6a9" into
+// its semantic version and commit components.
+func parseVersionAndCommit(s string) (version, commit string) {
+	segments := strings.Split(strings.TrimPrefix(strings.TrimPrefix(s, "v"), "V"), "-")
+
+	version = segments[0]
+
+	for _, segment := range segments[1:] {
+		switch {
+		case commit == "" && isCommitHash(segment):
+			commit = segment
+		case commit == "" && strings.HasPrefix(segment, "g") && isCommitHash(segment[1:]):
+			// git describe output (e.g. 1-gc3b5b6a9).
+			commit = segment[1:]
+		case commit == "" && isPreRelease(segment):
+			version += "-" + segment
+		}
+	}
+
+	return version, commit
+}
+
+func isPreRelease(s string) bool {
+	lower := strings.ToLower(s)
+
+	for _, prefix := range []string{"alpha", "beta", "rc", "dev", "pre"} {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isCommitHash(s string) bool {
+	if len(s) < 6 || len(s) > 40 {
+		return false
+	}
+
+	for _, c := range strings.ToLower(s) {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
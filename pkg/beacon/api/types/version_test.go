@@ -0,0 +1,101 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNodeVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input  string
+		expect NodeVersion
+	}{
+		{
+			input: "Lighthouse/v4.5.0-441fc16/x86_64-linux",
+			expect: NodeVersion{
+				Client:   AgentLighthouse,
+				Version:  "4.5.0",
+				Commit:   "441fc16",
+				Platform: "x86_64-linux",
+			},
+		},
+		{
+			input: "Prysm/v2.0.2/4a4a7e97dfd2285a5e48a178f693d870e9a4ff60",
+			expect: NodeVersion{
+				Client:  AgentPrysm,
+				Version: "2.0.2",
+				Commit:  "4a4a7e97dfd2285a5e48a178f693d870e9a4ff60",
+			},
+		},
+		{
+			input: "Prysm/v5.0.3 (linux amd64)",
+			expect: NodeVersion{
+				Client:   AgentPrysm,
+				Version:  "5.0.3",
+				Platform: "linux amd64",
+			},
+		},
+		{
+			input: "teku/teku/v22.9.0/linux-x86_64/-privatebuild-openjdk64bitservervm-java-17",
+			expect: NodeVersion{
+				Client:   AgentTeku,
+				Version:  "22.9.0",
+				Platform: "linux-x86_64",
+			},
+		},
+		{
+			input: "Nimbus/v23.10.0-8b07f4-stateofus",
+			expect: NodeVersion{
+				Client:  AgentNimbus,
+				Version: "23.10.0",
+				Commit:  "8b07f4",
+			},
+		},
+		{
+			input: "Lodestar/v0.32.0-rc.0-1-gc3b5b6a9/linux-x64/nodejs",
+			expect: NodeVersion{
+				Client:   AgentLodestar,
+				Version:  "0.32.0-rc.0",
+				Commit:   "c3b5b6a9",
+				Platform: "linux-x64",
+			},
+		},
+		{
+			input: "Lodestar/v1.12.0/a6b1a9d",
+			expect: NodeVersion{
+				Client:  AgentLodestar,
+				Version: "1.12.0",
+				Commit:  "a6b1a9d",
+			},
+		},
+		{
+			input: "Grandine/0.4.0-5bcd5ba/x86_64-linux",
+			expect: NodeVersion{
+				Client:   AgentGrandine,
+				Version:  "0.4.0",
+				Commit:   "5bcd5ba",
+				Platform: "x86_64-linux",
+			},
+		},
+		{
+			input: "unknown",
+			expect: NodeVersion{
+				Client: AgentUnknown,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			te := test
+			t.Parallel()
+
+			te.expect.Raw = te.input
+
+			assert.Equal(t, te.expect, ParseNodeVersion(te.input))
+		})
+	}
+}
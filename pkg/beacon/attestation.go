@@ -0,0 +1,62 @@
+package beacon
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// GetCommitteeIndicesFromAttestation returns the committee indices that the attestation covers.
+func GetCommitteeIndicesFromAttestation(attestation *phase0.Attestation) ([]phase0.CommitteeIndex, error) {
+	if attestation == nil || attestation.Data == nil {
+		return nil, errors.New("attestation data is nil")
+	}
+
+	return []phase0.CommitteeIndex{attestation.Data.Index}, nil
+}
+
+// GetAttestingValidatorCountFromAttestation returns the number of validators that contributed to the attestation.
+func GetAttestingValidatorCountFromAttestation(attestation *phase0.Attestation) int {
+	if attestation == nil {
+		return 0
+	}
+
+	return int(attestation.AggregationBits.Count())
+}
+
+// GetAttestingIndicesFromAttestation resolves the aggregation bits of the attestation to validator indices
+// using the given beacon committee.
+func GetAttestingIndicesFromAttestation(attestation *phase0.Attestation, committee []phase0.ValidatorIndex) ([]phase0.ValidatorIndex, error) {
+	if attestation == nil {
+		return nil, errors.New("attestation is nil")
+	}
+
+	if attestation.AggregationBits.Len() != uint64(len(committee)) {
+		return nil, fmt.Errorf("aggregation bits length %d does not match committee size %d", attestation.AggregationBits.Len(), len(committee))
+	}
+
+	bits := attestation.AggregationBits.BitIndices()
+	indices := make([]phase0.ValidatorIndex, 0, len(bits))
+
+	for _, bit := range bits {
+		indices = append(indices, committee[bit])
+	}
+
+	return indices, nil
+}
+
+// GetAttesterIndexFromAttestation returns the index of the single validator that signed an unaggregated
+// attestation, using the given beacon committee.
+func GetAttesterIndexFromAttestation(attestation *phase0.Attestation, committee []phase0.ValidatorIndex) (phase0.ValidatorIndex, error) {
+	indices, err := GetAttestingIndicesFromAttestation(attestation, committee)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(indices) != 1 {
+		return 0, fmt.Errorf("attestation is not unaggregated: %d attesters", len(indices))
+	}
+
+	return indices[0], nil
+}
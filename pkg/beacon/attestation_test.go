@@ -0,0 +1,47 @@
+package beacon_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationHelpers(t *testing.T) {
+	committee := []phase0.ValidatorIndex{10, 20, 30, 40}
+
+	bits := bitfield.NewBitlist(4)
+	bits.SetBitAt(2, true)
+
+	attestation := &phase0.Attestation{
+		AggregationBits: bits,
+		Data: &phase0.AttestationData{
+			Index: 3,
+		},
+	}
+
+	indices, err := beacon.GetCommitteeIndicesFromAttestation(attestation)
+	require.NoError(t, err)
+	assert.Equal(t, []phase0.CommitteeIndex{3}, indices)
+
+	assert.Equal(t, 1, beacon.GetAttestingValidatorCountFromAttestation(attestation))
+
+	attester, err := beacon.GetAttesterIndexFromAttestation(attestation, committee)
+	require.NoError(t, err)
+	assert.Equal(t, phase0.ValidatorIndex(30), attester)
+
+	bits.SetBitAt(0, true)
+
+	attesting, err := beacon.GetAttestingIndicesFromAttestation(attestation, committee)
+	require.NoError(t, err)
+	assert.Equal(t, []phase0.ValidatorIndex{10, 30}, attesting)
+
+	_, err = beacon.GetAttesterIndexFromAttestation(attestation, committee)
+	assert.Error(t, err)
+
+	_, err = beacon.GetAttestingIndicesFromAttestation(attestation, committee[:3])
+	assert.Error(t, err)
+}
@@ -2,10 +2,12 @@ package beacon
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
+	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -13,26 +15,28 @@ import (
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
-	"github.com/attestantio/go-eth2-client/spec/deneb"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/chuckpreslar/emission"
 	"github.com/ethpandaops/beacon/pkg/beacon/api"
 	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
+	"github.com/ethpandaops/beacon/pkg/beacon/headtracker"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
 	"github.com/ethpandaops/beacon/pkg/beacon/state"
 	"github.com/ethpandaops/ethwallclock"
 	"github.com/go-co-op/gocron"
 	"github.com/rs/zerolog"
-	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
 )
 
+// Node is an Ethereum beacon node. It embeds smaller interfaces so that consumers can depend on only
+// the parts they use.
 type Node interface {
-	// Lifecycle
-	// Start starts the node.
-	Start(ctx context.Context) error
-	// StartAsync starts the node asynchronously.
-	StartAsync(ctx context.Context)
-	// Stop stops the node.
-	Stop(ctx context.Context) error
+	Lifecycle
+	HealthReporter
+	BlockReader
+	StateReader
+	EventSubscriber
+	FetchHooks
 
 	// Service returns the Service client for the node.
 	Service() eth2client.Service
@@ -47,36 +51,90 @@ type Node interface {
 	// Eth getters. These are all cached.
 	// Spec returns the spec for the node.
 	Spec() (*state.Spec, error)
+	// RawSpec returns a copy of the cached raw, unparsed spec for the node.
+	RawSpec() (map[string]any, error)
+	// SpecFetchedAt returns the time the cached spec was last fetched from the node.
+	SpecFetchedAt() time.Time
 	// SyncState returns the sync state for the node.
 	SyncState() (*v1.SyncState, error)
-	// Genesis returns the genesis for the node.
+	// Genesis returns the genesis for the node, fetching it from the node if it is not cached yet.
 	Genesis() (*v1.Genesis, error)
+	// GenesisValidatorsRoot returns the genesis validators root for the node.
+	GenesisValidatorsRoot() (phase0.Root, error)
 	// NodeVersion returns the node version.
 	NodeVersion() (string, error)
-	// Status returns the status of the ndoe.
-	Status() *Status
-	// Finality returns the finality checkpoint for the node.
+	// NodeVersionInfo returns the parsed node version.
+	NodeVersionInfo() (*types.NodeVersion, error)
+	// ClientType returns the consensus client the node is running, derived from the node version.
+	ClientType() types.Agent
+	// Quirks returns the known behavioral differences of the consensus client the node is running.
+	Quirks() ClientQuirks
+	// NodeIdentity returns a copy of the cached node identity.
+	NodeIdentity() (*types.Identity, error)
+	// CustodyColumns returns the data columns the node custodies, derived from its cached identity.
+	CustodyColumns() ([]uint64, error)
+	// IsCustodyColumn returns true if the node custodies the data column with the given index.
+	IsCustodyColumn(index uint64) (bool, error)
+	// DataColumnRetentionStartSlot returns the earliest slot the spec requires nodes to retain data column
+	// sidecars for. It is derived from the spec and the wallclock, the node may still hold older sidecars or,
+	// while backfilling, not yet hold all of them.
+	DataColumnRetentionStartSlot() (phase0.Slot, error)
+	// FetchValidatorsCustodyRequirement returns the number of custody groups a node running the given
+	// validators is required to custody.
+	FetchValidatorsCustodyRequirement(ctx context.Context, stateID string, indices []phase0.ValidatorIndex) (uint64, error)
+	// Finality returns a copy of the finality checkpoint for the node.
 	Finality() (*v1.Finality, error)
-	// Healthy returns true if the node is healthy.
-	Healthy() bool
+	// Peers returns a copy of the most recently fetched peers of the node.
+	Peers() (types.Peers, error)
+	// PeersFetchedAt returns the time the cached peers were last fetched from the node.
+	PeersFetchedAt() time.Time
+	// HeadSlot returns the most recent head slot reported by the node via head events or the sync status.
+	HeadSlot() (phase0.Slot, error)
+	// ClockSkew returns the most recently measured skew between the node's clock and the local clock.
+	ClockSkew() (time.Duration, error)
+	// CanonicalRootAtSlot returns the root of the canonical block at the given slot, as tracked by the head
+	// tracker. It returns false if the slot is empty, untracked or the head tracker is disabled.
+	CanonicalRootAtSlot(slot phase0.Slot) (phase0.Root, bool)
+	// IsCanonical returns true if the block is part of the canonical chain tracked by the head tracker.
+	IsCanonical(root phase0.Root) bool
+	// ThrottledRequests returns the number of requests that the node has responded to with a 429.
+	ThrottledRequests() uint64
+	// SampledOutAttestations returns the number of attestation events dropped by attestation sampling.
+	SampledOutAttestations() uint64
+	// SubscribedTopics returns the topics requested in the beacon subscription, those skipped because the
+	// client or this library does not support them and those the upstream event streams are open for.
+	SubscribedTopics() *TopicSubscription
+	// SupportedTopics returns the upstream event topics this library can decode.
+	SupportedTopics() EventTopics
+	// TrackedDeposits returns the deposit count and root tracked from blocks, or nil if deposit tracking is
+	// disabled or has not been seeded from a deposit snapshot yet.
+	TrackedDeposits() *TrackedDeposits
+	// SnapshotValidators returns the head validator set. It is fetched once and then kept up to date from the
+	// deposits, voluntary exits and slashings in head blocks instead of being fetched again.
+	SnapshotValidators(ctx context.Context) (map[phase0.ValidatorIndex]*v1.Validator, error)
+	// ForEachEpoch calls fn for every epoch in the given inclusive range, in order, with the epoch's blocks and
+	// proposer duties. Epochs that are not finalized yet are skipped, waited on or included according to
+	// Options.EpochIterator.
+	ForEachEpoch(ctx context.Context, fromEpoch, toEpoch phase0.Epoch, fn func(ctx context.Context, epoch *EpochData) error) error
+	// ReplayEvents replays an event stream recorded with Options.RecordEventsTo through the node's event
+	// handlers. A speed of 1 keeps the original time between events, higher speeds accelerate the replay and
+	// zero replays the events as fast as possible.
+	ReplayEvents(ctx context.Context, r io.Reader, speed float64) error
+	// SupportsEndpoint returns false if the node is known not to implement the endpoint with the given name
+	// (see the Endpoint constants). Endpoints are probed on startup.
+	SupportsEndpoint(name string) bool
+	// CurrentFork returns the fork that is active at the current wallclock epoch.
+	CurrentFork() (*state.ForkEpoch, error)
+	// NextScheduledFork returns the earliest fork that is not active yet at the current wallclock epoch. An error
+	// is returned if no fork is scheduled.
+	NextScheduledFork() (*state.ForkEpoch, error)
+	// ForkDigest returns the fork digest of the fork that is active at the given epoch.
+	ForkDigest(epoch phase0.Epoch) (phase0.ForkDigest, error)
+	// Domain returns the signing domain for the given domain type at the given epoch. The deposit and builder
+	// domains always use the genesis fork version and an empty genesis validators root, as in the spec.
+	Domain(domainType phase0.DomainType, epoch phase0.Epoch) (phase0.Domain, error)
 
 	// Fetchers - these are not cached and will always fetch from the node.
-	// FetchBlock fetches the block for the given state id.
-	FetchBlock(ctx context.Context, stateID string) (*spec.VersionedSignedBeaconBlock, error)
-	// FetchRawBlock fetches the raw, unparsed block for the given state id.
-	FetchRawBlock(ctx context.Context, stateID string, contentType string) ([]byte, error)
-	// FetchBlockRoot fetches the block root for the given state id.
-	FetchBlockRoot(ctx context.Context, stateID string) (*phase0.Root, error)
-	// FetchBeaconState fetches the beacon state for the given state id.
-	FetchBeaconState(ctx context.Context, stateID string) (*spec.VersionedBeaconState, error)
-	// FetchBeaconStateRoot fetches the state root for the given state id.
-	FetchBeaconStateRoot(ctx context.Context, stateID string) (phase0.Root, error)
-	// FetchRawBeaconState fetches the raw, unparsed beacon state for the given state id.
-	FetchRawBeaconState(ctx context.Context, stateID string, contentType string) ([]byte, error)
-	// FetchValidators fetches the validators for the given state id and validator ids.
-	FetchValidators(ctx context.Context, state string, indices []phase0.ValidatorIndex, pubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*v1.Validator, error)
-	// FetchFinality fetches the finality checkpoint for the state id.
-	FetchFinality(ctx context.Context, stateID string) (*v1.Finality, error)
 	// FetchGenesis fetches the genesis configuration.
 	FetchGenesis(ctx context.Context) (*v1.Genesis, error)
 	// FetchPeers fetches the peers from the beacon node.
@@ -95,107 +153,192 @@ type Node interface {
 	FetchForkChoice(ctx context.Context) (*v1.ForkChoice, error)
 	// FetchDepositSnapshot fetches the deposit snapshot.
 	FetchDepositSnapshot(ctx context.Context) (*types.DepositSnapshot, error)
-	// FetchBeaconCommittees fetches the committees for the given epoch at the given state.
-	FetchBeaconCommittees(ctx context.Context, state string, epoch *phase0.Epoch) ([]*v1.BeaconCommittee, error)
 	// FetchAttestationData fetches the attestation data for the given slot and committee index.
+	// Results are cached until the end of the slot.
 	FetchAttestationData(ctx context.Context, slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, error)
-	// FetchBeaconBlockBlobs fetches blob sidecars for the given block id.
-	FetchBeaconBlockBlobs(ctx context.Context, blockID string) ([]*deneb.BlobSidecar, error)
-	// FetchBeaconBlockHeader fetches beacon block headers.
-	FetchBeaconBlockHeader(ctx context.Context, opts *eapi.BeaconBlockHeaderOpts) (*v1.BeaconBlockHeader, error)
+	// FetchAggregateAttestation fetches the aggregate attestation for the given slot, attestation data root and
+	// committee index from the v2 endpoint. It is returned unparsed together with its fork, use
+	// ParseAggregateAttestation to decode attestations of forks before Electra.
+	FetchAggregateAttestation(ctx context.Context, slot phase0.Slot, attestationDataRoot phase0.Root, committeeIndex phase0.CommitteeIndex) (*api.RawResponse, error)
+	// FetchSyncCommitteeContribution produces the sync committee contribution for the given slot, subcommittee and block root.
+	FetchSyncCommitteeContribution(ctx context.Context, slot phase0.Slot, subcommitteeIndex uint64, beaconBlockRoot phase0.Root) (*altair.SyncCommitteeContribution, error)
 	// FetchNodeIdentity fetches the node identity.
 	FetchNodeIdentity(ctx context.Context) (*types.Identity, error)
 
-	// Subscriptions
-	// - Proxied Beacon events
-	// OnEvent is called when a beacon event is received.
-	OnEvent(ctx context.Context, handler func(ctx context.Context, ev *v1.Event) error)
-	// OnBlock is called when a block is received.
-	OnBlock(ctx context.Context, handler func(ctx context.Context, ev *v1.BlockEvent) error)
-	// OnAttestation is called when an attestation is received.
-	OnAttestation(ctx context.Context, handler func(ctx context.Context, ev *phase0.Attestation) error)
-	// OnFinalizedCheckpoint is called when a finalized checkpoint is received.
-	OnFinalizedCheckpoint(ctx context.Context, handler func(ctx context.Context, ev *v1.FinalizedCheckpointEvent) error)
-	// OnHead is called when the head is received.
-	OnHead(ctx context.Context, handler func(ctx context.Context, ev *v1.HeadEvent) error)
-	// OnChainReOrg is called when a chain reorg is received.
-	OnChainReOrg(ctx context.Context, handler func(ctx context.Context, ev *v1.ChainReorgEvent) error)
-	// OnVoluntaryExit is called when a voluntary exit is received.
-	OnVoluntaryExit(ctx context.Context, handler func(ctx context.Context, ev *phase0.SignedVoluntaryExit) error)
-	// OnContributionAndProof is called when a contribution and proof is received.
-	OnContributionAndProof(ctx context.Context, handler func(ctx context.Context, ev *altair.SignedContributionAndProof) error)
-	// OnBlobSidecar is called when a blob sidecar is received.
-	OnBlobSidecar(ctx context.Context, handler func(ctx context.Context, ev *v1.BlobSidecarEvent) error)
-
-	// - Custom events
-	// OnReady is called when the node is ready.
-	OnReady(ctx context.Context, handler func(ctx context.Context, event *ReadyEvent) error)
-	// OnSyncStatus is called when the sync status changes.
-	OnSyncStatus(ctx context.Context, handler func(ctx context.Context, event *SyncStatusEvent) error)
-	// OnNodeVersionUpdated is called when the node version is updated.
-	OnNodeVersionUpdated(ctx context.Context, handler func(ctx context.Context, event *NodeVersionUpdatedEvent) error)
-	// OnPeersUpdated is called when the peers are updated.
-	OnPeersUpdated(ctx context.Context, handler func(ctx context.Context, event *PeersUpdatedEvent) error)
-	// OnSpecUpdated is called when the spec is updated.
-	OnSpecUpdated(ctx context.Context, handler func(ctx context.Context, event *SpecUpdatedEvent) error)
-	// OnEmptySlot is called when an empty slot is detected.
-	OnEmptySlot(ctx context.Context, handler func(ctx context.Context, event *EmptySlotEvent) error)
-	// OnHealthCheckFailed is called when a health check fails.
-	OnHealthCheckFailed(ctx context.Context, handler func(ctx context.Context, event *HealthCheckFailedEvent) error)
-	// OnHealthCheckSucceeded is called when a health check succeeds.
-	OnHealthCheckSucceeded(ctx context.Context, handler func(ctx context.Context, event *HealthCheckSucceededEvent) error)
-	// OnFinalityCheckpointUpdated is called when a the head finality checkpoint is updated.
-	OnFinalityCheckpointUpdated(ctx context.Context, handler func(ctx context.Context, event *FinalityCheckpointUpdated) error)
-	// OnFirstTimeHealthy is called when the node is healthy for the first time.
-	OnFirstTimeHealthy(ctx context.Context, handler func(ctx context.Context, event *FirstTimeHealthyEvent) error)
+	// Validator
+	// ProduceBlock requests an unsigned block proposal for the given slot via the v3 block production endpoint.
+	ProduceBlock(ctx context.Context, slot phase0.Slot, randaoReveal phase0.BLSSignature, graffiti [32]byte, opts *ProduceBlockOpts) (*eapi.VersionedProposal, error)
+	// SubmitBeaconCommitteeSubscriptions instructs the node to subscribe to beacon committee subnets.
+	SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscriptions []*v1.BeaconCommitteeSubscription) error
+	// SubmitSyncCommitteeSubscriptions instructs the node to subscribe to sync committee subnets.
+	SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptions []*v1.SyncCommitteeSubscription) error
+	// SubmitAggregateAndProofs submits JSON encoded signed aggregate and proofs of the given fork, e.g. "deneb",
+	// to the v2 endpoint.
+	SubmitAggregateAndProofs(ctx context.Context, version string, aggregateAndProofs json.RawMessage) error
+	// SubmitSyncCommitteeMessages submits sync committee messages to the node.
+	SubmitSyncCommitteeMessages(ctx context.Context, messages []*altair.SyncCommitteeMessage) error
+	// SubmitSyncCommitteeContributions submits signed sync committee contribution and proofs to the node.
+	SubmitSyncCommitteeContributions(ctx context.Context, contributionAndProofs []*altair.SignedContributionAndProof) error
+	// PrepareBeaconProposer provides the node with the fee recipients of validators that may propose soon.
+	PrepareBeaconProposer(ctx context.Context, preparations []*v1.ProposalPreparation) error
+	// RegisterValidators passes signed builder registrations through the node to its builder.
+	RegisterValidators(ctx context.Context, registrations []*eapi.VersionedSignedValidatorRegistration) error
 
 	// GetZeroLogLevel returns the zerolog level for the node.
 	GetZeroLogLevel() zerolog.Level
 }
 
+var _ Node = (*node)(nil)
+
 // Node represents an Ethereum beacon node. It computes values based on the spec.
 type node struct {
 	// Helpers
-	log    logrus.FieldLogger
+	log    *logging.Entry
 	ctx    context.Context
 	cancel context.CancelFunc
+	// handlersCtx is the context the node's own event handlers, wallclock callbacks and metrics are registered
+	// with. It outlives a failed Start, so that a retried Start does not register them again, and is cancelled
+	// once the node is stopped.
+	handlersCtx    context.Context
+	handlersCancel context.CancelFunc
+	metricsOnce    sync.Once
+	downstreamOnce sync.Once
 
 	// Configuration
 	// Config should roughly be driven by end users.
-	config *Config
-	// Options should be driven by code.
+	config   *Config
+	configMu sync.RWMutex
+	// Options should be driven by code. They are replaced as a whole when reloaded, under configMu.
 	options *Options
+	// reloadMu serializes ReloadConfig, which reads, copies and writes back the config and options.
+	reloadMu sync.Mutex
 
 	// Clients
-	api    api.ConsensusClient
-	client eth2client.Service
-	broker *emission.Emitter
+	// clientsMu protects api, client and cancelClients, which are swapped together when the config is reloaded.
+	api           api.ConsensusClient
+	client        eth2client.Service
+	cancelClients context.CancelFunc
+	clientsMu     sync.RWMutex
+	broker        *emission.Emitter
+
+	// inflight deduplicates identical concurrent requests to the upstream node.
+	inflight singleflight.Group
+	// throttled counts the requests made through the eth2 client that the node responded to with a 429.
+	throttled atomic.Uint64
+	// beforeFetch and afterFetch are the hooks registered with OnBeforeFetch and OnAfterFetch.
+	beforeFetch  []BeforeFetchHook
+	afterFetch   []AfterFetchHook
+	fetchHooksMu sync.RWMutex
 
 	// Internal data stores
-	genesis       *v1.Genesis
-	lastEventTime time.Time
-	nodeVersion   string
-	peers         types.Peers
-	finality      *v1.Finality
-	spec          *state.Spec
-	wallclock     *ethwallclock.EthereumBeaconChain
+	genesis         *v1.Genesis
+	genesisMu       sync.RWMutex
+	lastEventTime   time.Time
+	lastEventTimeMu sync.RWMutex
+	nodeVersion     types.NodeVersion
+	nodeVersionMu   sync.RWMutex
+	peers           types.Peers
+	peersFetchedAt  time.Time
+	syncState       *v1.SyncState
+	syncStateMu     sync.Mutex
+	peersMu         sync.RWMutex
+	finality        *v1.Finality
+	finalityMu      sync.RWMutex
+	headSlot        *phase0.Slot
+	headSlotMu      sync.RWMutex
+	clockSkew       *time.Duration
+	clockSkewMu     sync.RWMutex
+	identity        *types.Identity
+	identityMu      sync.RWMutex
+	spec            *state.Spec
+	rawSpec         map[string]any
+	specFetchedAt   time.Time
+	specMu          sync.RWMutex
+	wallclock       *ethwallclock.EthereumBeaconChain
+	wallclockMu     sync.RWMutex
 
 	stat *Status
 
+	orphans            *orphanTracker
+	dedup              *eventDeduplicator
+	blocks             *blockCache
+	blockRoots         *blockRootCache
+	attestationSampler *eventSampler
+	deposits           *depositTracker
+	equivocations      *equivocationDetector
+	cache              Cache
+	capabilities       *capabilities
+	subscriptions      *subscriptionRegistry
+
+	headTracker   *headtracker.Tracker
+	headTrackerMu sync.RWMutex
+
+	// attestationDataHead is the latest head event. Attestation data cached for its slot that votes for another
+	// block was cached before the head arrived and is stale.
+	attestationDataHead   *v1.HeadEvent
+	attestationDataHeadMu sync.RWMutex
+
 	metrics *Metrics
+	// metricsErr is the error NewMetricsWithLabels returned, Start returns it.
+	metricsErr error
 
+	// Ready is true once the node is ready.
+	//
+	// Deprecated: Ready is written without synchronization, use IsReady instead.
 	Ready bool
+	ready atomic.Bool
 
 	hasEmittedFirstTimeHealthy bool
 	firstHealthyMutex          sync.Mutex
 
-	crons *gocron.Scheduler
+	// pollingPaused is true while the periodic fetches are skipped, see HealthCheckOptions.PausePolling.
+	pollingPaused atomic.Bool
+
+	bootstrapped   bool
+	bootstrappedMu sync.RWMutex
+	readyOnce      sync.Once
+
+	crons   *gocron.Scheduler
+	cronsMu sync.Mutex
+	// cronJobs are the jobs of crons that ReloadConfig reschedules, by name.
+	cronJobs map[string]*cronJob
+
+	validatorSnapshot   *validatorSnapshot
+	validatorSnapshotMu sync.Mutex
+
+	// withdrawalsChain are the recent canonical blocks whose withdrawals have been emitted, oldest first.
+	withdrawalsChain   []withdrawalsBlock
+	withdrawalsChainMu sync.Mutex
+
+	// forkImminent holds the forks a ForkImminentEvent has been published for.
+	forkImminent   map[spec.DataVersion]struct{}
+	forkImminentMu sync.Mutex
+
+	// cancelEvents stops the upstream event stream.
+	cancelEvents   context.CancelFunc
+	cancelEventsMu sync.Mutex
+
+	// recorder records the upstream event stream if Options.RecordEventsTo is set.
+	recorder   *eventRecorder
+	recorderMu sync.Mutex
+
+	// state is the lifecycle state of the node, see Start and Stop.
+	state   NodeState
+	stateMu sync.Mutex
+
+	// unsupportedTopics are the requested topics the client does not serve, streamTopics the topics of the
+	// open upstream event streams and connectedStreams the streams that have connected before.
+	unsupportedTopics EventTopics
+	streamTopics      []openStream
+	connectedStreams  map[string]bool
+	topicsMu          sync.RWMutex
 }
 
-// NewNode creates a new beacon node.
-func NewNode(log logrus.FieldLogger, config *Config, namespace string, options Options) Node {
+// NewNode creates a new beacon node that logs to the given logger. Loggers of other libraries can be adapted
+// with logging.FromSlog, logging.FromZerolog or logrusadapter.FromLogrus.
+func NewNode(log logging.Logger, config *Config, namespace string, options Options) Node {
 	n := &node{
-		log: log.WithField("module", "consensus/beacon"),
+		log: logging.New(log).WithField("module", "consensus/beacon"),
 
 		config:  config,
 		options: &options,
@@ -205,6 +348,32 @@ func NewNode(log logrus.FieldLogger, config *Config, namespace string, options O
 		stat: NewStatus(options.HealthCheck.SuccessfulResponses, options.HealthCheck.FailedResponses),
 
 		firstHealthyMutex: sync.Mutex{},
+
+		orphans:       newOrphanTracker(),
+		dedup:         newEventDeduplicator(options.EventDeduplication.Window.Duration),
+		cache:         options.Cache,
+		capabilities:  newCapabilities(),
+		subscriptions: newSubscriptionRegistry(),
+	}
+
+	if n.cache == nil {
+		n.cache = NewMemoryCache(defaultMemoryCacheSize)
+	}
+
+	if options.BlockCache.Enabled {
+		n.blocks = newBlockCache(options.BlockCache.Slots)
+	}
+
+	if options.BlockRootCache.Enabled {
+		n.blockRoots = newBlockRootCache(options.BlockRootCache.Slots)
+	}
+
+	if options.AttestationSampling.Enabled {
+		n.attestationSampler = newEventSampler(options.AttestationSampling.Every, options.AttestationSampling.PerSecond)
+	}
+
+	if options.TrackDeposits {
+		n.deposits = newDepositTracker()
 	}
 
 	if options.PrometheusMetrics {
@@ -212,115 +381,172 @@ func NewNode(log logrus.FieldLogger, config *Config, namespace string, options O
 			namespace = "eth"
 		}
 
-		n.metrics = NewMetrics(n.log, namespace, config.Name, n)
+		n.metrics, n.metricsErr = NewMetricsWithLabels(n.log, namespace, config.Name, config.Labels, n)
 	}
 
 	return n
 }
 
-func (n *node) Start(ctx context.Context) error {
+// NewNodeWithLogger creates a new beacon node that logs to the given logger.
+//
+// Deprecated: NewNode takes a logging.Logger, use it instead.
+func NewNodeWithLogger(log logging.Logger, config *Config, namespace string, options Options) Node {
+	return NewNode(log, config, namespace, options)
+}
+
+// start starts the node with the context created by Start.
+func (n *node) start(ctx context.Context) error {
 	n.log.Info("Starting beacon...")
 
-	ctx, cancel := context.WithCancel(ctx)
-	n.ctx = ctx
-	n.cancel = cancel
+	if n.currentOptions().PrometheusMetrics {
+		if n.metricsErr != nil {
+			return n.metricsErr
+		}
+
+		var err error
+
+		n.metricsOnce.Do(func() {
+			err = n.metrics.Start(n.handlersCtx)
+		})
 
-	if n.options.PrometheusMetrics {
-		if err := n.metrics.Start(ctx); err != nil {
+		if err != nil {
 			return err
 		}
 	}
 
-	if err := n.ensureClients(ctx); err != nil {
+	if err := n.startRecording(); err != nil {
 		return err
 	}
 
-	if err := n.bootstrap(ctx); err != nil {
+	if err := n.ensureClients(ctx); err != nil {
 		return err
 	}
 
-	if _, err := n.FetchSyncStatus(ctx); err != nil {
-		return err
-	}
+	go n.probeCapabilities(ctx)
 
-	if _, err := n.FetchFinality(ctx, "head"); err != nil {
-		n.log.WithError(err).Error("Failed to fetch initial head finality")
+	if n.currentOptions().LenientStartup || n.currentOptions().WaitForGenesis {
+		go n.bootstrapInBackground(ctx)
+	} else {
+		if err := n.bootstrapWithRetry(ctx); err != nil {
+			return err
+		}
+
+		if _, err := n.FetchSyncStatus(ctx); err != nil {
+			return err
+		}
+
+		if _, err := n.FetchFinality(ctx, "head"); err != nil {
+			n.log.WithError(err).Error("Failed to fetch initial head finality")
+		}
 	}
 
 	s := gocron.NewScheduler(time.Local)
 
-	if _, err := s.Every(n.options.HealthCheck.Interval.String()).Do(func() {
-		n.runHealthcheck(ctx)
-	}); err != nil {
-		return err
+	tasks := map[string]func(){
+		jobHealthCheck: func() {
+			n.runHealthcheck(ctx)
+		},
+		jobSyncStatus: n.poll(func() {
+			if _, err := n.FetchSyncStatus(ctx); err != nil {
+				n.log.WithError(err).Debug("Failed to fetch sync status")
+			}
+		}),
+		jobNodeVersion: n.poll(func() {
+			if _, err := n.FetchNodeVersion(ctx); err != nil {
+				n.log.WithError(err).Debug("Failed to fetch node version")
+			}
+		}),
+		jobPeers: n.poll(func() {
+			if _, err := n.FetchPeers(ctx); err != nil {
+				n.log.WithError(err).Debug("Failed to fetch peers")
+			}
+		}),
+		jobNodeIdentity: n.poll(func() {
+			if _, err := n.FetchNodeIdentity(ctx); err != nil {
+				n.log.WithError(err).Debug("Failed to fetch node identity")
+			}
+		}),
 	}
 
-	if _, err := s.Every("15s").Do(func() {
-		if _, err := n.FetchSyncStatus(ctx); err != nil {
-			n.log.WithError(err).Debug("Failed to fetch sync status")
-		}
-	}); err != nil {
-		return err
-	}
+	jobs := make(map[string]*cronJob, len(tasks))
 
-	if _, err := s.Every("15m").Do(func() {
-		if _, err := n.FetchNodeVersion(ctx); err != nil {
-			n.log.WithError(err).Debug("Failed to fetch node version")
+	for name, interval := range jobIntervals(n.currentOptions()) {
+		job, err := s.Every(interval.String()).Do(tasks[name])
+		if err != nil {
+			return err
 		}
-	}); err != nil {
-		return err
+
+		jobs[name] = &cronJob{job: job, task: tasks[name]}
 	}
 
-	if _, err := s.Every("60s").Do(func() {
-		if _, err := n.FetchPeers(ctx); err != nil {
-			n.log.WithError(err).Debug("Failed to fetch peers")
+	if n.currentOptions().ClockSkew.Enabled {
+		if _, err := s.Every(n.currentOptions().ClockSkew.Interval.String()).Do(n.poll(func() {
+			if _, err := n.measureClockSkew(ctx); err != nil {
+				n.log.WithError(err).Debug("Failed to measure clock skew")
+			}
+		})); err != nil {
+			return err
 		}
-	}); err != nil {
-		return err
 	}
 
 	s.StartAsync()
 
+	n.cronsMu.Lock()
+	n.crons = s
+	n.cronJobs = jobs
+	n.cronsMu.Unlock()
+
 	n.log.Info("Beacon started!")
 
 	return nil
 }
 
-func (n *node) StartAsync(ctx context.Context) {
-	go func() {
-		if err := n.Start(ctx); err != nil {
-			n.log.WithError(err).Error("Failed to start beacon node")
-		}
-	}()
-}
-
-func (n *node) Stop(ctx context.Context) error {
-	if n.options.PrometheusMetrics {
-		if err := n.metrics.Stop(); err != nil {
-			return err
-		}
-	}
-
+// teardown stops everything a single call to start started. The metrics and handlers registered with
+// handlersCtx are left in place for a retried Start, shutdown stops those too.
+func (n *node) teardown() error {
+	n.cronsMu.Lock()
 	if n.crons != nil {
 		n.crons.Stop()
 	}
+	n.cronsMu.Unlock()
 
 	if n.cancel != nil {
 		n.cancel()
 	}
 
-	return nil
+	return n.stopRecording()
+}
+
+// shutdown stops the node for good. Every step is run, even if an earlier one fails.
+func (n *node) shutdown() error {
+	err := n.teardown()
+
+	if n.currentOptions().PrometheusMetrics && n.metrics != nil {
+		err = errors.Join(err, n.metrics.Stop())
+	}
+
+	if n.handlersCancel != nil {
+		n.handlersCancel()
+	}
+
+	return err
 }
 
 func (n *node) Options() *Options {
-	return n.options
+	return n.currentOptions()
 }
 
 func (n *node) Wallclock() *ethwallclock.EthereumBeaconChain {
+	n.wallclockMu.RLock()
+	defer n.wallclockMu.RUnlock()
+
 	return n.wallclock
 }
 
 func (n *node) Spec() (*state.Spec, error) {
+	n.specMu.RLock()
+	defer n.specMu.RUnlock()
+
 	if n.spec == nil {
 		return nil, errors.New("spec is not available")
 	}
@@ -328,6 +554,39 @@ func (n *node) Spec() (*state.Spec, error) {
 	return n.spec, nil
 }
 
+func (n *node) RawSpec() (map[string]any, error) {
+	n.specMu.RLock()
+	defer n.specMu.RUnlock()
+
+	if n.rawSpec == nil {
+		return nil, errors.New("raw spec is not available")
+	}
+
+	raw := make(map[string]any, len(n.rawSpec))
+	for k, v := range n.rawSpec {
+		raw[k] = v
+	}
+
+	return raw, nil
+}
+
+func (n *node) SpecFetchedAt() time.Time {
+	n.specMu.RLock()
+	defer n.specMu.RUnlock()
+
+	return n.specFetchedAt
+}
+
+// setSpec updates the cached raw and typed spec together.
+func (n *node) setSpec(raw map[string]any, sp *state.Spec) {
+	n.specMu.Lock()
+	defer n.specMu.Unlock()
+
+	n.rawSpec = raw
+	n.spec = sp
+	n.specFetchedAt = time.Now()
+}
+
 func (n *node) SyncState() (*v1.SyncState, error) {
 	state := n.stat.SyncState()
 
@@ -339,15 +598,42 @@ func (n *node) SyncState() (*v1.SyncState, error) {
 }
 
 func (n *node) Service() eth2client.Service {
-	return n.client
+	return n.currentClient()
 }
 
-func (n *node) Genesis() (*v1.Genesis, error) {
-	return n.genesis, nil
+func (n *node) NodeVersion() (string, error) {
+	n.nodeVersionMu.RLock()
+	defer n.nodeVersionMu.RUnlock()
+
+	return n.nodeVersion.Raw, nil
 }
 
-func (n *node) NodeVersion() (string, error) {
-	return n.nodeVersion, nil
+func (n *node) NodeVersionInfo() (*types.NodeVersion, error) {
+	n.nodeVersionMu.RLock()
+	defer n.nodeVersionMu.RUnlock()
+
+	if n.nodeVersion.Raw == "" {
+		return nil, errors.New("node version not available")
+	}
+
+	info := n.nodeVersion
+
+	return &info, nil
+}
+
+func (n *node) NodeIdentity() (*types.Identity, error) {
+	n.identityMu.RLock()
+	defer n.identityMu.RUnlock()
+
+	if n.identity == nil {
+		return nil, errors.New("node identity not available")
+	}
+
+	identity := *n.identity
+	identity.P2PAddresses = append([]string(nil), n.identity.P2PAddresses...)
+	identity.DiscoveryAddresses = append([]string(nil), n.identity.DiscoveryAddresses...)
+
+	return &identity, nil
 }
 
 func (n *node) Status() *Status {
@@ -355,11 +641,95 @@ func (n *node) Status() *Status {
 }
 
 func (n *node) Finality() (*v1.Finality, error) {
+	n.finalityMu.RLock()
+	defer n.finalityMu.RUnlock()
+
 	if n.finality == nil {
 		return nil, errors.New("finality not available")
 	}
 
-	return n.finality, nil
+	return copyFinality(n.finality), nil
+}
+
+func (n *node) Peers() (types.Peers, error) {
+	n.peersMu.RLock()
+	defer n.peersMu.RUnlock()
+
+	if n.peers == nil {
+		return nil, errors.New("peers not available")
+	}
+
+	peers := make(types.Peers, len(n.peers))
+	copy(peers, n.peers)
+
+	return peers, nil
+}
+
+func (n *node) PeersFetchedAt() time.Time {
+	n.peersMu.RLock()
+	defer n.peersMu.RUnlock()
+
+	return n.peersFetchedAt
+}
+
+func (n *node) HeadSlot() (phase0.Slot, error) {
+	n.headSlotMu.RLock()
+	defer n.headSlotMu.RUnlock()
+
+	if n.headSlot == nil {
+		return 0, errors.New("head slot not available")
+	}
+
+	return *n.headSlot, nil
+}
+
+// setHeadSlot records the slot of the node's head. The head slot only moves forward, so that events arriving
+// out of order cannot take it back; a reorg onto a lower slot is recorded with reorgHeadSlot instead.
+func (n *node) setHeadSlot(slot phase0.Slot) {
+	n.headSlotMu.Lock()
+	defer n.headSlotMu.Unlock()
+
+	if n.headSlot != nil && slot < *n.headSlot {
+		return
+	}
+
+	n.headSlot = &slot
+}
+
+// reorgHeadSlot records the slot of the new head after a chain reorg, even if it is lower than the current one.
+func (n *node) reorgHeadSlot(slot phase0.Slot) {
+	n.headSlotMu.Lock()
+	defer n.headSlotMu.Unlock()
+
+	n.headSlot = &slot
+}
+
+func (n *node) LastEventTime() time.Time {
+	n.lastEventTimeMu.RLock()
+	defer n.lastEventTimeMu.RUnlock()
+
+	return n.lastEventTime
+}
+
+func copyFinality(finality *v1.Finality) *v1.Finality {
+	cp := &v1.Finality{}
+
+	if finality.Finalized != nil {
+		checkpoint := *finality.Finalized
+		cp.Finalized = &checkpoint
+	}
+
+	if finality.Justified != nil {
+		checkpoint := *finality.Justified
+		cp.Justified = &checkpoint
+	}
+
+	if finality.PreviousJustified != nil {
+		checkpoint := *finality.PreviousJustified
+		cp.PreviousJustified = &checkpoint
+	}
+
+	return cp
 }
 
 func (n *node) bootstrap(ctx context.Context) error {
@@ -367,22 +737,46 @@ func (n *node) bootstrap(ctx context.Context) error {
 		return err
 	}
 
-	if err := n.subscribeDownstream(ctx); err != nil {
+	// A retried Start bootstraps again, but the downstream handlers are only registered once.
+	var err error
+
+	n.downstreamOnce.Do(func() {
+		err = n.subscribeDownstream(n.handlersCtx)
+	})
+
+	if err != nil {
 		return err
 	}
 
 	//nolint:errcheck // we dont care if this errors out since it runs indefinitely in a goroutine
 	go n.ensureBeaconSubscription(ctx)
 
-	n.Ready = true
+	n.bootstrappedMu.Lock()
+	n.bootstrapped = true
+	n.bootstrappedMu.Unlock()
 
-	go n.publishReady(ctx)
+	// In lenient mode readiness is additionally gated on the node being healthy.
+	if !n.currentOptions().LenientStartup {
+		n.markReady(ctx)
+	}
 
 	return nil
 }
 
+// markReady marks the node as ready and publishes the ready event, once.
+func (n *node) markReady(ctx context.Context) {
+	n.readyOnce.Do(func() {
+		n.ready.Store(true)
+		n.Ready = true
+
+		go n.publishReady(ctx)
+	})
+}
+
 func (n *node) subscribeDownstream(ctx context.Context) error {
-	n.wallclock.OnEpochChanged(func(epoch ethwallclock.Epoch) {
+	wallclock := n.Wallclock()
+
+	wallclock.OnEpochChanged(func(epoch ethwallclock.Epoch) {
 		time.Sleep(time.Second * 3)
 
 		if _, err := n.FetchFinality(ctx, "head"); err != nil {
@@ -390,8 +784,8 @@ func (n *node) subscribeDownstream(ctx context.Context) error {
 		}
 	})
 
-	n.wallclock.OnSlotChanged(func(slot ethwallclock.Slot) {
-		if !n.options.DetectEmptySlots {
+	wallclock.OnSlotChanged(func(slot ethwallclock.Slot) {
+		if !n.currentOptions().DetectEmptySlots {
 			return
 		}
 
@@ -399,16 +793,75 @@ func (n *node) subscribeDownstream(ctx context.Context) error {
 			return
 		}
 
-		_, err := n.FetchBlock(ctx, fmt.Sprintf("%v", slot.Number()-1))
+		grace := uint64(n.currentOptions().EmptySlotGrace)
+		if grace == 0 {
+			grace = 1
+		}
+
+		if slot.Number() < grace {
+			return
+		}
+
+		checked := slot.Number() - grace
+
+		_, err := n.FetchBlock(ctx, fmt.Sprintf("%v", checked))
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
-				n.publishEmptySlot(ctx, phase0.Slot(slot.Number()))
+			if errors.Is(err, ErrBlockNotFound) {
+				n.publishEmptySlot(ctx, phase0.Slot(checked))
 			}
 
 			return
 		}
 	})
 
+	if n.currentOptions().DetectOrphanedBlocks {
+		n.subscribeOrphanedBlockDetection(ctx)
+	}
+
+	if n.currentOptions().DetectEquivocations {
+		n.subscribeEquivocationDetection(ctx)
+	}
+
+	if n.currentOptions().HeadTracker.Enabled {
+		n.subscribeHeadTracker(ctx)
+	}
+
+	if n.currentOptions().BlockCache.Enabled {
+		n.subscribeBlockCache(ctx)
+	}
+
+	if n.currentOptions().BlockRootCache.Enabled {
+		n.subscribeBlockRootCache(ctx)
+	}
+
+	if n.currentOptions().ExtractGraffiti {
+		n.subscribeGraffitiExtraction(ctx)
+	}
+
+	if n.currentOptions().TrackDeposits {
+		n.subscribeDepositTracking(ctx)
+	}
+
+	if n.currentOptions().ExtractWithdrawals {
+		n.subscribeWithdrawalExtraction(ctx)
+	}
+
+	n.subscribeValidatorSnapshot(ctx)
+
+	n.subscribeAttestationDataInvalidation(ctx)
+
+	if n.currentOptions().ForkImminentEpochs > 0 {
+		n.subscribeForkImminent(ctx)
+	}
+
+	n.subscribeForkActivation(ctx)
+
+	if n.currentOptions().HeadLag.Enabled {
+		wallclock.OnSlotChanged(func(slot ethwallclock.Slot) {
+			n.checkHeadLag(ctx, phase0.Slot(slot.Number()))
+		})
+	}
+
 	n.OnFinalizedCheckpoint(ctx, func(ctx context.Context, ev *v1.FinalizedCheckpointEvent) error {
 		time.Sleep(3 * time.Second) // Sleep to give time for the beacon node to update its state.
 
@@ -423,16 +876,20 @@ func (n *node) subscribeDownstream(ctx context.Context) error {
 }
 
 func (n *node) fetchIsHealthy(ctx context.Context) error {
-	provider, isProvider := n.client.(eth2client.NodeSyncingProvider)
+	provider, isProvider := n.currentClient().(eth2client.NodeSyncingProvider)
 	if !isProvider {
 		return errors.New("client does not implement eth2client.NodeSyncingProvider")
 	}
 
-	_, err := provider.NodeSyncing(ctx, &eapi.NodeSyncingOpts{})
+	rsp, err := provider.NodeSyncing(ctx, &eapi.NodeSyncingOpts{})
 	if err != nil {
 		return err
 	}
 
+	if n.currentOptions().HealthCheck.OptimisticIsUnhealthy && rsp.Data != nil && rsp.Data.IsOptimistic {
+		return ErrNodeOptimistic
+	}
+
 	return nil
 }
 
@@ -442,6 +899,7 @@ func (n *node) runHealthcheck(ctx context.Context) {
 	err := n.fetchIsHealthy(ctx)
 	if err != nil {
 		n.stat.Health().RecordFail(err)
+		n.updatePolling()
 
 		n.publishHealthCheckFailed(ctx, time.Since(start))
 
@@ -449,6 +907,7 @@ func (n *node) runHealthcheck(ctx context.Context) {
 	}
 
 	n.stat.Health().RecordSuccess()
+	n.updatePolling()
 
 	n.firstHealthyMutex.Lock()
 	defer n.firstHealthyMutex.Unlock()
@@ -473,48 +932,82 @@ func (n *node) initializeState(ctx context.Context) error {
 		return err
 	}
 
-	n.wallclock = ethwallclock.NewEthereumBeaconChain(genesis.GenesisTime, spec.SecondsPerSlot.AsDuration(), uint64(spec.SlotsPerEpoch))
+	n.wallclockMu.Lock()
+	defer n.wallclockMu.Unlock()
+
+	// A retried Start keeps the wallclock the downstream callbacks were registered with.
+	if n.wallclock == nil {
+		n.wallclock = ethwallclock.NewEthereumBeaconChain(genesis.GenesisTime, spec.SecondsPerSlot.AsDuration(), uint64(spec.SlotsPerEpoch))
+	}
 
 	return nil
 }
 
 func (n *node) getBlock(ctx context.Context, blockID string) (*spec.VersionedSignedBeaconBlock, error) {
-	provider, isProvider := n.client.(eth2client.SignedBeaconBlockProvider)
+	result, err := n.getBlockWithMetadata(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+func (n *node) getBlockWithMetadata(ctx context.Context, blockID string) (*FetchResult[*spec.VersionedSignedBeaconBlock], error) {
+	provider, isProvider := n.currentClient().(eth2client.SignedBeaconBlockProvider)
 	if !isProvider {
 		return nil, errors.New("client does not implement eth2client.SignedBeaconBlockProvider")
 	}
 
-	signedBeaconBlock, err := provider.SignedBeaconBlock(ctx, &eapi.SignedBeaconBlockOpts{
-		Block: blockID,
+	verify := n.currentOptions().VerifyBlockRoots
+	fetchID := blockID
+
+	var expected phase0.Root
+
+	if verify {
+		root, err := n.resolveBlockRoot(ctx, blockID)
+		if err != nil {
+			return nil, err
+		}
+
+		expected = root
+		fetchID = fmt.Sprintf("%#x", root)
+	}
+
+	signedBeaconBlock, err := retryThrottled(ctx, n, provider.SignedBeaconBlock, &eapi.SignedBeaconBlockOpts{
+		Block: fetchID,
 	})
 	if err != nil {
 		var apiErr *eapi.Error
-		if errors.As(err, &apiErr) {
-			switch apiErr.StatusCode {
-			case 404:
-				return nil, nil
-			case 503:
-				return nil, errors.New("beacon node is syncing")
-			}
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 503 {
+			return nil, errors.New("beacon node is syncing")
 		}
 
-		return nil, err
+		return nil, wrapNotFound(err, ErrBlockNotFound)
+	}
+
+	if verify {
+		if err := n.verifyBlockRoot(ctx, blockID, expected, signedBeaconBlock.Data); err != nil {
+			return nil, err
+		}
 	}
 
-	return signedBeaconBlock.Data, nil
+	return &FetchResult[*spec.VersionedSignedBeaconBlock]{
+		ResponseMetadata: parseResponseMetadata(signedBeaconBlock.Metadata),
+		Data:             signedBeaconBlock.Data,
+	}, nil
 }
 
 func (n *node) getBlockRoot(ctx context.Context, blockID string) (*phase0.Root, error) {
-	provider, isProvider := n.client.(eth2client.BeaconBlockRootProvider)
+	provider, isProvider := n.currentClient().(eth2client.BeaconBlockRootProvider)
 	if !isProvider {
 		return nil, errors.New("client does not implement eth2client.SignedBeaconBlockProvider")
 	}
 
-	blockRoot, err := provider.BeaconBlockRoot(ctx, &eapi.BeaconBlockRootOpts{
+	blockRoot, err := retryThrottled(ctx, n, provider.BeaconBlockRoot, &eapi.BeaconBlockRootOpts{
 		Block: blockID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, wrapNotFound(err, ErrBlockNotFound)
 	}
 
 	return blockRoot.Data, nil
@@ -2,34 +2,37 @@ package beacon
 
 import (
 	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/ethpandaops/beacon/pkg/beacon/blockutil"
 )
 
 // GetDepositCountsFromBeaconBlock returns the number of deposits in a beacon block
 func GetDepositCountsFromBeaconBlock(block *spec.VersionedSignedBeaconBlock) int {
-	deposits, err := block.Deposits()
-	if err == nil {
-		return len(deposits)
-	}
-
-	return 0
+	return blockutil.DepositCount(block)
 }
 
 // GetVoluntaryExitsFromBeaconBlock returns the number of voluntary exits in a beacon block
 func GetVoluntaryExitsFromBeaconBlock(block *spec.VersionedSignedBeaconBlock) int {
-	exits, err := block.VoluntaryExits()
-	if err == nil {
-		return len(exits)
-	}
-
-	return 0
+	return blockutil.VoluntaryExitCount(block)
 }
 
 // GetTransactionsCountFromBeaconBlock returns the number of transactions in a beacon block
 func GetTransactionsCountFromBeaconBlock(block *spec.VersionedSignedBeaconBlock) int {
-	transactions, err := block.ExecutionTransactions()
-	if err == nil {
-		return len(transactions)
-	}
+	return blockutil.TransactionCount(block)
+}
+
+// ExecutionRequestCounts holds the number of execution layer requests contained in an Electra+ beacon block.
+type ExecutionRequestCounts = blockutil.ExecutionRequestCounts
+
+// GetExecutionRequestCountsFromRawBlock returns the version and execution request counts of a JSON encoded
+// beacon block as served by the /eth/v2/beacon/blocks endpoint. Blocks prior to Electra do not contain
+// execution requests, in which case the returned counts are nil.
+func GetExecutionRequestCountsFromRawBlock(data []byte) (string, *ExecutionRequestCounts, error) {
+	return blockutil.ExecutionRequestCountsFromRawBlock(data)
+}
 
-	return 0
+// ParseRawBlock decodes a raw block as returned by FetchRawBlock into a versioned block. JSON blocks carry
+// their version in the response envelope, so version can be spec.DataVersionUnknown. SSZ blocks carry no
+// version, so it must be given.
+func ParseRawBlock(data []byte, contentType string, version spec.DataVersion) (*spec.VersionedSignedBeaconBlock, error) {
+	return blockutil.ParseRawBlock(data, contentType, version)
 }
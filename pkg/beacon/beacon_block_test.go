@@ -0,0 +1,46 @@
+package beacon_test
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/beacon/pkg/beacon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetExecutionRequestCountsFromRawBlock(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		expectedVersion string
+		expected        *beacon.ExecutionRequestCounts
+	}{
+		{
+			name:            "deneb block",
+			data:            `{"version":"deneb","data":{"message":{"body":{"deposits":[]}}}}`,
+			expectedVersion: "deneb",
+			expected:        nil,
+		},
+		{
+			name: "electra block",
+			data: `{"version":"electra","data":{"message":{"body":{"execution_requests":{` +
+				`"deposits":[{},{}],"withdrawals":[{}],"consolidations":[]}}}}}`,
+			expectedVersion: "electra",
+			expected: &beacon.ExecutionRequestCounts{
+				Deposits:       2,
+				Withdrawals:    1,
+				Consolidations: 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			version, counts, err := beacon.GetExecutionRequestCountsFromRawBlock([]byte(test.data))
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedVersion, version)
+			assert.Equal(t, test.expected, counts)
+		})
+	}
+}
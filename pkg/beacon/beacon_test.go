@@ -0,0 +1,132 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawSpec(t *testing.T) {
+	n := &node{}
+
+	_, err := n.RawSpec()
+	assert.Error(t, err)
+	assert.True(t, n.SpecFetchedAt().IsZero())
+
+	raw := map[string]any{"CONFIG_NAME": "mainnet"}
+	sp := state.NewSpec(raw)
+
+	n.setSpec(raw, &sp)
+
+	cached, err := n.RawSpec()
+	require.NoError(t, err)
+	assert.Equal(t, raw, cached)
+	assert.False(t, n.SpecFetchedAt().IsZero())
+
+	// The returned map is a copy.
+	cached["CONFIG_NAME"] = "changed"
+
+	cached, err = n.RawSpec()
+	require.NoError(t, err)
+	assert.Equal(t, "mainnet", cached["CONFIG_NAME"])
+
+	typed, err := n.Spec()
+	require.NoError(t, err)
+	assert.Equal(t, &sp, typed)
+}
+
+func TestFetchRawSpecPublishesSpecUpdated(t *testing.T) {
+	n := &node{
+		log:     logging.New(nil),
+		broker:  emission.NewEmitter(),
+		options: DefaultOptions(),
+		client:  &fakeClient{spec: map[string]any{"CONFIG_NAME": "mainnet", "SLOTS_PER_EPOCH": "32"}},
+	}
+
+	var events []*SpecUpdatedEvent
+
+	n.OnSpecUpdated(context.Background(), func(ctx context.Context, event *SpecUpdatedEvent) error {
+		events = append(events, event)
+
+		return nil
+	})
+
+	raw, err := n.FetchRawSpec(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "mainnet", raw["CONFIG_NAME"])
+
+	require.Len(t, events, 1)
+	assert.Equal(t, "mainnet", events[0].Spec.ConfigName)
+	assert.Equal(t, phase0.Slot(32), events[0].Spec.SlotsPerEpoch)
+}
+
+func TestFetchSpecRejectsInvalidBlobSchedule(t *testing.T) {
+	client := &fakeClient{spec: map[string]any{"CONFIG_NAME": "mainnet"}}
+
+	n := &node{
+		log:     logging.New(nil),
+		broker:  emission.NewEmitter(),
+		options: DefaultOptions(),
+		client:  client,
+	}
+
+	_, err := n.FetchSpec(context.Background())
+	require.NoError(t, err)
+
+	client.spec = map[string]any{
+		"CONFIG_NAME": "devnet",
+		"BLOB_SCHEDULE": []any{
+			map[string]any{"EPOCH": "10", "MAX_BLOBS_PER_BLOCK": "9"},
+			map[string]any{"EPOCH": "10", "MAX_BLOBS_PER_BLOCK": "12"},
+		},
+	}
+
+	_, err = n.FetchSpec(context.Background())
+	assert.Error(t, err)
+
+	_, err = n.FetchRawSpec(context.Background())
+	assert.Error(t, err)
+
+	// The previously loaded spec is kept.
+	sp, err := n.Spec()
+	require.NoError(t, err)
+	assert.Equal(t, "mainnet", sp.ConfigName)
+
+	raw, err := n.RawSpec()
+	require.NoError(t, err)
+	assert.Equal(t, "mainnet", raw["CONFIG_NAME"])
+}
+
+func TestPeers(t *testing.T) {
+	n := &node{
+		log:    logging.New(nil),
+		broker: emission.NewEmitter(),
+		api:    &fakeAPI{peers: types.Peers{{PeerID: "a"}}},
+	}
+
+	_, err := n.Peers()
+	assert.Error(t, err)
+	assert.True(t, n.PeersFetchedAt().IsZero())
+
+	_, err = n.FetchPeers(context.Background())
+	require.NoError(t, err)
+
+	peers, err := n.Peers()
+	require.NoError(t, err)
+	assert.Equal(t, types.Peers{{PeerID: "a"}}, peers)
+	assert.False(t, n.PeersFetchedAt().IsZero())
+
+	// The returned list is a copy.
+	peers[0].PeerID = "changed"
+
+	peers, err = n.Peers()
+	require.NoError(t, err)
+	assert.Equal(t, "a", peers[0].PeerID)
+}
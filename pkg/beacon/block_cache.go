@@ -0,0 +1,211 @@
+package beacon
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+type blockCacheEntry struct {
+	slot  phase0.Slot
+	block *spec.VersionedSignedBeaconBlock
+}
+
+// blockCache caches recently seen blocks by root, along with the canonical root of recent slots. Blocks are
+// evicted once they are more than the configured number of slots behind the most recent block.
+type blockCache struct {
+	mu     sync.Mutex
+	slots  phase0.Slot
+	blocks map[phase0.Root]blockCacheEntry
+	roots  map[phase0.Slot]phase0.Root
+	latest phase0.Slot
+}
+
+func newBlockCache(slots uint64) *blockCache {
+	return &blockCache{
+		slots:  phase0.Slot(slots),
+		blocks: make(map[phase0.Root]blockCacheEntry),
+		roots:  make(map[phase0.Slot]phase0.Root),
+	}
+}
+
+// add stores the block. If canonical is true the block is also stored as the canonical block of its slot.
+func (c *blockCache) add(root phase0.Root, slot phase0.Slot, block *spec.VersionedSignedBeaconBlock, canonical bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if slot > c.latest {
+		c.latest = slot
+
+		c.evict()
+	}
+
+	if c.latest >= c.slots && slot < c.latest-c.slots {
+		return
+	}
+
+	c.blocks[root] = blockCacheEntry{slot: slot, block: block}
+
+	if canonical {
+		c.roots[slot] = root
+	}
+}
+
+func (c *blockCache) evict() {
+	if c.latest < c.slots {
+		return
+	}
+
+	cutoff := c.latest - c.slots
+
+	for root, entry := range c.blocks {
+		if entry.slot < cutoff {
+			delete(c.blocks, root)
+		}
+	}
+
+	for slot := range c.roots {
+		if slot < cutoff {
+			delete(c.roots, slot)
+		}
+	}
+}
+
+// setCanonical marks the cached block with the given root as the canonical block of the slot. It is a noop if
+// the block is not cached.
+func (c *blockCache) setCanonical(root phase0.Root, slot phase0.Slot) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.blocks[root]; !exists {
+		return
+	}
+
+	c.roots[slot] = root
+}
+
+func (c *blockCache) byRoot(root phase0.Root) (*spec.VersionedSignedBeaconBlock, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, exists := c.blocks[root]
+	if !exists {
+		return nil, false
+	}
+
+	return entry.block, true
+}
+
+func (c *blockCache) bySlot(slot phase0.Slot) (*spec.VersionedSignedBeaconBlock, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	root, exists := c.roots[slot]
+	if !exists {
+		return nil, false
+	}
+
+	entry, exists := c.blocks[root]
+	if !exists {
+		return nil, false
+	}
+
+	return entry.block, true
+}
+
+// invalidate forgets the canonical roots of the slots in the inclusive range [from, to]. Blocks stay cached by
+// root since their contents do not change.
+func (c *blockCache) invalidate(from, to phase0.Slot) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for slot := range c.roots {
+		if slot >= from && slot <= to {
+			delete(c.roots, slot)
+		}
+	}
+}
+
+// get returns the cached block for the block id, if the block id is a root or a slot.
+func (c *blockCache) get(blockID string) (*spec.VersionedSignedBeaconBlock, bool) {
+	if root, ok := parseBlockIDRoot(blockID); ok {
+		return c.byRoot(root)
+	}
+
+	if slot, err := strconv.ParseUint(blockID, 10, 64); err == nil {
+		return c.bySlot(phase0.Slot(slot))
+	}
+
+	return nil, false
+}
+
+// store caches a block fetched with the given block id. Only blocks fetched by slot, or as the head, are
+// known to be canonical.
+func (c *blockCache) store(blockID string, block *spec.VersionedSignedBeaconBlock) error {
+	root, err := block.Root()
+	if err != nil {
+		return err
+	}
+
+	slot, err := block.Slot()
+	if err != nil {
+		return err
+	}
+
+	_, byRoot := parseBlockIDRoot(blockID)
+
+	c.add(root, slot, block, !byRoot)
+
+	return nil
+}
+
+// parseBlockIDRoot returns the root if the block id is a 0x prefixed hex root.
+func parseBlockIDRoot(blockID string) (phase0.Root, bool) {
+	if !strings.HasPrefix(blockID, "0x") {
+		return phase0.Root{}, false
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(blockID, "0x"))
+	if err != nil || len(data) != len(phase0.Root{}) {
+		return phase0.Root{}, false
+	}
+
+	return phase0.Root(data), true
+}
+
+func (n *node) subscribeBlockCache(ctx context.Context) {
+	// Blocks are fetched as soon as they are announced so that subscribers to head and block events can
+	// read them without a roundtrip to the node.
+	n.OnBlock(ctx, func(ctx context.Context, event *v1.BlockEvent) error {
+		if _, err := n.FetchBlock(ctx, fmt.Sprintf("%#x", event.Block)); err != nil {
+			n.log.WithError(err).WithField("root", fmt.Sprintf("%#x", event.Block)).Debug("Failed to fetch block for the block cache")
+		}
+
+		return nil
+	})
+
+	n.OnHead(ctx, func(ctx context.Context, event *v1.HeadEvent) error {
+		n.blocks.setCanonical(event.Block, event.Slot)
+
+		return nil
+	})
+
+	n.OnChainReOrg(ctx, func(ctx context.Context, event *v1.ChainReorgEvent) error {
+		from := phase0.Slot(0)
+		if uint64(event.Slot) > event.Depth {
+			from = event.Slot - phase0.Slot(event.Depth)
+		}
+
+		n.blocks.invalidate(from, event.Slot)
+		n.blocks.setCanonical(event.NewHeadBlock, event.Slot)
+
+		return nil
+	})
+}
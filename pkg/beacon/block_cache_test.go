@@ -0,0 +1,66 @@
+package beacon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockCache(t *testing.T) {
+	c := newBlockCache(4)
+
+	block := &spec.VersionedSignedBeaconBlock{Version: spec.DataVersionPhase0}
+	root := phase0.Root{0x01}
+
+	c.add(root, 10, block, false)
+
+	cached, exists := c.get(fmt.Sprintf("%#x", root))
+	assert.True(t, exists)
+	assert.Equal(t, block, cached)
+
+	// Blocks fetched by root are not known to be canonical until a head event confirms them.
+	_, exists = c.get("10")
+	assert.False(t, exists)
+
+	c.setCanonical(root, 10)
+
+	cached, exists = c.get("10")
+	assert.True(t, exists)
+	assert.Equal(t, block, cached)
+
+	_, exists = c.get("head")
+	assert.False(t, exists)
+
+	// A reorg drops the canonical roots of the affected slots, but keeps the blocks by root.
+	c.invalidate(9, 11)
+
+	_, exists = c.get("10")
+	assert.False(t, exists)
+
+	_, exists = c.get(fmt.Sprintf("%#x", root))
+	assert.True(t, exists)
+
+	// Blocks are evicted once they fall out of the window.
+	c.add(phase0.Root{0x02}, 20, block, true)
+
+	_, exists = c.get(fmt.Sprintf("%#x", root))
+	assert.False(t, exists)
+
+	_, exists = c.get("20")
+	assert.True(t, exists)
+}
+
+func TestParseBlockIDRoot(t *testing.T) {
+	root, ok := parseBlockIDRoot(fmt.Sprintf("%#x", phase0.Root{0xab}))
+	require.True(t, ok)
+	assert.Equal(t, phase0.Root{0xab}, root)
+
+	for _, blockID := range []string{"head", "123", "0x1234", "0xzz"} {
+		_, ok := parseBlockIDRoot(blockID)
+		assert.False(t, ok, blockID)
+	}
+}
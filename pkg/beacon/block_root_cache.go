@@ -0,0 +1,85 @@
+package beacon
+
+import (
+	"context"
+	"sync"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// blockRootCache caches the block roots of recent slots. Entries are evicted once they are more than the
+// configured number of slots behind the most recent cached slot.
+type blockRootCache struct {
+	mu     sync.Mutex
+	slots  phase0.Slot
+	roots  map[phase0.Slot]phase0.Root
+	latest phase0.Slot
+}
+
+func newBlockRootCache(slots uint64) *blockRootCache {
+	return &blockRootCache{
+		slots: phase0.Slot(slots),
+		roots: make(map[phase0.Slot]phase0.Root),
+	}
+}
+
+func (c *blockRootCache) get(slot phase0.Slot) (phase0.Root, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	root, exists := c.roots[slot]
+
+	return root, exists
+}
+
+func (c *blockRootCache) set(slot phase0.Slot, root phase0.Root) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if slot > c.latest {
+		c.latest = slot
+	}
+
+	if c.latest >= c.slots {
+		cutoff := c.latest - c.slots
+
+		for s := range c.roots {
+			if s < cutoff {
+				delete(c.roots, s)
+			}
+		}
+
+		if slot < cutoff {
+			return
+		}
+	}
+
+	c.roots[slot] = root
+}
+
+// invalidate forgets the roots of every slot from the given slot onwards.
+func (c *blockRootCache) invalidate(from phase0.Slot) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for slot := range c.roots {
+		if slot >= from {
+			delete(c.roots, slot)
+		}
+	}
+}
+
+func (n *node) subscribeBlockRootCache(ctx context.Context) {
+	n.OnChainReOrg(ctx, func(ctx context.Context, event *v1.ChainReorgEvent) error {
+		// Slots after the common ancestor may now have a different block, or none at all.
+		from := phase0.Slot(0)
+		if uint64(event.Slot) > event.Depth {
+			from = event.Slot - phase0.Slot(event.Depth)
+		}
+
+		n.blockRoots.invalidate(from)
+
+		return nil
+	})
+}
@@ -0,0 +1,68 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockRootCache(t *testing.T) {
+	c := newBlockRootCache(4)
+
+	c.set(10, phase0.Root{0x0a})
+	c.set(11, phase0.Root{0x0b})
+
+	root, exists := c.get(10)
+	assert.True(t, exists)
+	assert.Equal(t, phase0.Root{0x0a}, root)
+
+	// Slots that fall out of the window are evicted.
+	c.set(20, phase0.Root{0x14})
+
+	_, exists = c.get(10)
+	assert.False(t, exists)
+
+	c.set(10, phase0.Root{0x0a})
+
+	_, exists = c.get(10)
+	assert.False(t, exists)
+}
+
+func TestBlockRootCacheReorg(t *testing.T) {
+	n := &node{
+		log:        logging.New(nil),
+		broker:     emission.NewEmitter(),
+		options:    DefaultOptions().EnableBlockRootCache(),
+		blockRoots: newBlockRootCache(64),
+	}
+
+	for slot := phase0.Slot(1); slot <= 10; slot++ {
+		n.blockRoots.set(slot, phase0.Root{byte(slot)})
+	}
+
+	n.subscribeBlockRootCache(context.Background())
+
+	n.publishChainReOrg(context.Background(), &v1.ChainReorgEvent{Slot: 10, Depth: 3})
+
+	assert.Eventually(t, func() bool {
+		_, exists := n.blockRoots.get(7)
+
+		return !exists
+	}, time.Second, 10*time.Millisecond)
+
+	for slot := phase0.Slot(1); slot < 7; slot++ {
+		_, exists := n.blockRoots.get(slot)
+		assert.True(t, exists, slot)
+	}
+
+	for slot := phase0.Slot(7); slot <= 10; slot++ {
+		_, exists := n.blockRoots.get(slot)
+		assert.False(t, exists, slot)
+	}
+}
@@ -0,0 +1,151 @@
+// Package blockutil provides fork-agnostic helpers for extracting data from versioned beacon blocks.
+package blockutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// ErrNoExecutionPayload is returned when the block was produced before the merge and has no execution payload.
+var ErrNoExecutionPayload = errors.New("block does not have an execution payload")
+
+// hasExecutionPayload returns true if the block version carries an execution payload.
+func hasExecutionPayload(block *spec.VersionedSignedBeaconBlock) bool {
+	return block.Version != spec.DataVersionPhase0 && block.Version != spec.DataVersionAltair
+}
+
+// ExecutionBlockHash returns the execution block hash of the block.
+func ExecutionBlockHash(block *spec.VersionedSignedBeaconBlock) (phase0.Hash32, error) {
+	if block == nil {
+		return phase0.Hash32{}, errors.New("block is nil")
+	}
+
+	if !hasExecutionPayload(block) {
+		return phase0.Hash32{}, ErrNoExecutionPayload
+	}
+
+	return block.ExecutionBlockHash()
+}
+
+// ExecutionBlockNumber returns the execution block number of the block.
+func ExecutionBlockNumber(block *spec.VersionedSignedBeaconBlock) (uint64, error) {
+	if block == nil {
+		return 0, errors.New("block is nil")
+	}
+
+	if !hasExecutionPayload(block) {
+		return 0, ErrNoExecutionPayload
+	}
+
+	return block.ExecutionBlockNumber()
+}
+
+// Graffiti returns the graffiti of the block as a string. Trailing null bytes are removed and invalid UTF-8
+// sequences are replaced.
+func Graffiti(block *spec.VersionedSignedBeaconBlock) (string, error) {
+	if block == nil {
+		return "", errors.New("block is nil")
+	}
+
+	graffiti, err := block.Graffiti()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToValidUTF8(string(bytes.TrimRight(graffiti[:], "\x00")), string(utf8.RuneError)), nil
+}
+
+// BlobKZGCommitments returns the blob KZG commitments of the block. Blocks produced before Deneb have none.
+func BlobKZGCommitments(block *spec.VersionedSignedBeaconBlock) ([]deneb.KZGCommitment, error) {
+	if block == nil {
+		return nil, errors.New("block is nil")
+	}
+
+	switch block.Version {
+	case spec.DataVersionPhase0, spec.DataVersionAltair, spec.DataVersionBellatrix, spec.DataVersionCapella:
+		return []deneb.KZGCommitment{}, nil
+	}
+
+	return block.BlobKZGCommitments()
+}
+
+// DepositCount returns the number of deposits in the block.
+func DepositCount(block *spec.VersionedSignedBeaconBlock) int {
+	deposits, err := block.Deposits()
+	if err == nil {
+		return len(deposits)
+	}
+
+	return 0
+}
+
+// VoluntaryExitCount returns the number of voluntary exits in the block.
+func VoluntaryExitCount(block *spec.VersionedSignedBeaconBlock) int {
+	exits, err := block.VoluntaryExits()
+	if err == nil {
+		return len(exits)
+	}
+
+	return 0
+}
+
+// TransactionCount returns the number of execution transactions in the block.
+func TransactionCount(block *spec.VersionedSignedBeaconBlock) int {
+	transactions, err := block.ExecutionTransactions()
+	if err == nil {
+		return len(transactions)
+	}
+
+	return 0
+}
+
+// ExecutionRequestCounts holds the number of execution layer requests contained in an Electra+ beacon block.
+type ExecutionRequestCounts struct {
+	Deposits       int
+	Withdrawals    int
+	Consolidations int
+}
+
+type rawExecutionRequestsBlock struct {
+	Version string `json:"version"`
+	Data    struct {
+		Message struct {
+			Body struct {
+				ExecutionRequests *struct {
+					Deposits       []json.RawMessage `json:"deposits"`
+					Withdrawals    []json.RawMessage `json:"withdrawals"`
+					Consolidations []json.RawMessage `json:"consolidations"`
+				} `json:"execution_requests"`
+			} `json:"body"`
+		} `json:"message"`
+	} `json:"data"`
+}
+
+// ExecutionRequestCountsFromRawBlock returns the version and execution request counts of a JSON encoded
+// beacon block as served by the /eth/v2/beacon/blocks endpoint. Execution requests are read from the raw
+// block since the versioned block does not support Electra yet. Blocks prior to Electra do not contain
+// execution requests, in which case the returned counts are nil.
+func ExecutionRequestCountsFromRawBlock(data []byte) (string, *ExecutionRequestCounts, error) {
+	block := rawExecutionRequestsBlock{}
+	if err := json.Unmarshal(data, &block); err != nil {
+		return "", nil, err
+	}
+
+	requests := block.Data.Message.Body.ExecutionRequests
+	if requests == nil {
+		return block.Version, nil, nil
+	}
+
+	return block.Version, &ExecutionRequestCounts{
+		Deposits:       len(requests.Deposits),
+		Withdrawals:    len(requests.Withdrawals),
+		Consolidations: len(requests.Consolidations),
+	}, nil
+}
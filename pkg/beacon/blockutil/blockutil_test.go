@@ -0,0 +1,95 @@
+package blockutil_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/blockutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func graffiti(s string) [32]byte {
+	var g [32]byte
+
+	copy(g[:], s)
+
+	return g
+}
+
+func TestPhase0Block(t *testing.T) {
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.SignedBeaconBlock{
+			Message: &phase0.BeaconBlock{
+				Body: &phase0.BeaconBlockBody{
+					Graffiti: graffiti("phase0"),
+					Deposits: []*phase0.Deposit{{}, {}},
+				},
+			},
+		},
+	}
+
+	_, err := blockutil.ExecutionBlockHash(block)
+	assert.ErrorIs(t, err, blockutil.ErrNoExecutionPayload)
+
+	_, err = blockutil.ExecutionBlockNumber(block)
+	assert.ErrorIs(t, err, blockutil.ErrNoExecutionPayload)
+
+	g, err := blockutil.Graffiti(block)
+	require.NoError(t, err)
+	assert.Equal(t, "phase0", g)
+
+	commitments, err := blockutil.BlobKZGCommitments(block)
+	require.NoError(t, err)
+	assert.Empty(t, commitments)
+
+	assert.Equal(t, 2, blockutil.DepositCount(block))
+	assert.Equal(t, 0, blockutil.TransactionCount(block))
+}
+
+func TestDenebBlock(t *testing.T) {
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionDeneb,
+		Deneb: &deneb.SignedBeaconBlock{
+			Message: &deneb.BeaconBlock{
+				Body: &deneb.BeaconBlockBody{
+					Graffiti: graffiti("deneb \xff"),
+					ExecutionPayload: &deneb.ExecutionPayload{
+						BlockHash:   phase0.Hash32{0x01},
+						BlockNumber: 100,
+					},
+					BlobKZGCommitments: []deneb.KZGCommitment{{}, {}, {}},
+				},
+			},
+		},
+	}
+
+	hash, err := blockutil.ExecutionBlockHash(block)
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Hash32{0x01}, hash)
+
+	number, err := blockutil.ExecutionBlockNumber(block)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), number)
+
+	g, err := blockutil.Graffiti(block)
+	require.NoError(t, err)
+	assert.Equal(t, "deneb �", g)
+
+	commitments, err := blockutil.BlobKZGCommitments(block)
+	require.NoError(t, err)
+	assert.Len(t, commitments, 3)
+}
+
+func TestExecutionRequestCountsFromRawBlock(t *testing.T) {
+	version, counts, err := blockutil.ExecutionRequestCountsFromRawBlock([]byte(
+		`{"version":"electra","data":{"message":{"body":{"execution_requests":{"deposits":[{}],"withdrawals":[],"consolidations":[{}]}}}}}`,
+	))
+	require.NoError(t, err)
+
+	assert.Equal(t, "electra", version)
+	assert.Equal(t, &blockutil.ExecutionRequestCounts{Deposits: 1, Consolidations: 1}, counts)
+}
@@ -0,0 +1,155 @@
+package blockutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	// ContentTypeJSON is the content type of JSON encoded blocks.
+	ContentTypeJSON = "application/json"
+	// ContentTypeSSZ is the content type of SSZ encoded blocks.
+	ContentTypeSSZ = "application/octet-stream"
+)
+
+var (
+	// ErrUnsupportedVersion is returned when the block version cannot be decoded.
+	ErrUnsupportedVersion = errors.New("unsupported block version")
+	// ErrUnsupportedContentType is returned when the content type is neither JSON nor SSZ.
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+)
+
+type rawBlockEnvelope struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type sszUnmarshaler interface {
+	UnmarshalSSZ(buf []byte) error
+}
+
+// ParseRawBlock decodes a raw block as returned by the /eth/v2/beacon/blocks endpoint into a versioned block.
+// JSON blocks may be wrapped in the response envelope, in which case the version can be left as
+// spec.DataVersionUnknown and is taken from the envelope. SSZ blocks carry no version, so it must be given.
+func ParseRawBlock(data []byte, contentType string, version spec.DataVersion) (*spec.VersionedSignedBeaconBlock, error) {
+	switch mediaType(contentType) {
+	case ContentTypeJSON, "":
+		return parseJSONBlock(data, version)
+	case ContentTypeSSZ:
+		return parseSSZBlock(data, version)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedContentType, contentType)
+	}
+}
+
+// mediaType strips parameters such as the charset from the content type.
+func mediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+
+	return strings.TrimSpace(mediaType)
+}
+
+func parseJSONBlock(data []byte, version spec.DataVersion) (*spec.VersionedSignedBeaconBlock, error) {
+	envelope := rawBlockEnvelope{}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+
+	if envelope.Data != nil {
+		data = envelope.Data
+
+		if version == spec.DataVersionUnknown {
+			if err := version.UnmarshalJSON([]byte(fmt.Sprintf("%q", envelope.Version))); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrUnsupportedVersion, envelope.Version)
+			}
+		}
+	}
+
+	block, target, err := newVersionedBlock(version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		return nil, fmt.Errorf("failed to decode %s block: %w", version, err)
+	}
+
+	return block, nil
+}
+
+func parseSSZBlock(data []byte, version spec.DataVersion) (*spec.VersionedSignedBeaconBlock, error) {
+	block, target, err := newVersionedBlock(version)
+	if err != nil {
+		return nil, err
+	}
+
+	unmarshaler, ok := target.(sszUnmarshaler)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedVersion, version)
+	}
+
+	if err := unmarshaler.UnmarshalSSZ(data); err != nil {
+		return nil, fmt.Errorf("failed to decode %s block: %w", version, err)
+	}
+
+	return block, nil
+}
+
+// MarshalBlockSSZ encodes the fork specific block of a versioned block as SSZ. Like the /eth/v2/beacon/blocks
+// endpoint, the encoding does not include the version, which is needed to decode it with ParseRawBlock.
+func MarshalBlockSSZ(block *spec.VersionedSignedBeaconBlock) ([]byte, error) {
+	switch {
+	case block.Version == spec.DataVersionPhase0 && block.Phase0 != nil:
+		return block.Phase0.MarshalSSZ()
+	case block.Version == spec.DataVersionAltair && block.Altair != nil:
+		return block.Altair.MarshalSSZ()
+	case block.Version == spec.DataVersionBellatrix && block.Bellatrix != nil:
+		return block.Bellatrix.MarshalSSZ()
+	case block.Version == spec.DataVersionCapella && block.Capella != nil:
+		return block.Capella.MarshalSSZ()
+	case block.Version == spec.DataVersionDeneb && block.Deneb != nil:
+		return block.Deneb.MarshalSSZ()
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedVersion, block.Version)
+	}
+}
+
+// newVersionedBlock returns a versioned block for the version along with the fork specific block that the
+// raw block should be decoded into.
+func newVersionedBlock(version spec.DataVersion) (*spec.VersionedSignedBeaconBlock, any, error) {
+	block := &spec.VersionedSignedBeaconBlock{Version: version}
+
+	switch version {
+	case spec.DataVersionPhase0:
+		block.Phase0 = &phase0.SignedBeaconBlock{}
+
+		return block, block.Phase0, nil
+	case spec.DataVersionAltair:
+		block.Altair = &altair.SignedBeaconBlock{}
+
+		return block, block.Altair, nil
+	case spec.DataVersionBellatrix:
+		block.Bellatrix = &bellatrix.SignedBeaconBlock{}
+
+		return block, block.Bellatrix, nil
+	case spec.DataVersionCapella:
+		block.Capella = &capella.SignedBeaconBlock{}
+
+		return block, block.Capella, nil
+	case spec.DataVersionDeneb:
+		block.Deneb = &deneb.SignedBeaconBlock{}
+
+		return block, block.Deneb, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnsupportedVersion, version)
+	}
+}
@@ -0,0 +1,77 @@
+package blockutil_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/blockutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func phase0Block() *phase0.SignedBeaconBlock {
+	return &phase0.SignedBeaconBlock{
+		Message: &phase0.BeaconBlock{
+			Slot:          12,
+			ProposerIndex: 3,
+			Body: &phase0.BeaconBlockBody{
+				ETH1Data: &phase0.ETH1Data{
+					BlockHash: make([]byte, 32),
+				},
+				Graffiti:          graffiti("raw"),
+				ProposerSlashings: []*phase0.ProposerSlashing{},
+				AttesterSlashings: []*phase0.AttesterSlashing{},
+				Attestations:      []*phase0.Attestation{},
+				Deposits:          []*phase0.Deposit{},
+				VoluntaryExits:    []*phase0.SignedVoluntaryExit{},
+			},
+		},
+	}
+}
+
+func TestParseRawBlockSSZ(t *testing.T) {
+	data, err := phase0Block().MarshalSSZ()
+	require.NoError(t, err)
+
+	block, err := blockutil.ParseRawBlock(data, blockutil.ContentTypeSSZ, spec.DataVersionPhase0)
+	require.NoError(t, err)
+	assert.Equal(t, spec.DataVersionPhase0, block.Version)
+	assert.Equal(t, phase0.Slot(12), block.Phase0.Message.Slot)
+
+	_, err = blockutil.ParseRawBlock(data, blockutil.ContentTypeSSZ, spec.DataVersionUnknown)
+	assert.ErrorIs(t, err, blockutil.ErrUnsupportedVersion)
+}
+
+func TestMarshalBlockSSZ(t *testing.T) {
+	data, err := blockutil.MarshalBlockSSZ(&spec.VersionedSignedBeaconBlock{Version: spec.DataVersionPhase0, Phase0: phase0Block()})
+	require.NoError(t, err)
+
+	block, err := blockutil.ParseRawBlock(data, blockutil.ContentTypeSSZ, spec.DataVersionPhase0)
+	require.NoError(t, err)
+	assert.Equal(t, phase0Block(), block.Phase0)
+
+	_, err = blockutil.MarshalBlockSSZ(&spec.VersionedSignedBeaconBlock{Version: spec.DataVersionAltair})
+	assert.ErrorIs(t, err, blockutil.ErrUnsupportedVersion)
+}
+
+func TestParseRawBlockJSON(t *testing.T) {
+	data, err := json.Marshal(phase0Block())
+	require.NoError(t, err)
+
+	envelope := []byte(fmt.Sprintf(`{"version":"phase0","execution_optimistic":false,"finalized":true,"data":%s}`, data))
+
+	block, err := blockutil.ParseRawBlock(envelope, "application/json; charset=utf-8", spec.DataVersionUnknown)
+	require.NoError(t, err)
+	assert.Equal(t, spec.DataVersionPhase0, block.Version)
+	assert.Equal(t, phase0.ValidatorIndex(3), block.Phase0.Message.ProposerIndex)
+
+	block, err = blockutil.ParseRawBlock(data, blockutil.ContentTypeJSON, spec.DataVersionPhase0)
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Slot(12), block.Phase0.Message.Slot)
+
+	_, err = blockutil.ParseRawBlock(data, "text/plain", spec.DataVersionPhase0)
+	assert.ErrorIs(t, err, blockutil.ErrUnsupportedContentType)
+}
@@ -2,6 +2,8 @@ package beacon
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
 	"time"
 
@@ -10,15 +12,18 @@ import (
 	"github.com/ethpandaops/beacon/pkg/beacon/api"
 )
 
+const (
+	// genesisPollInterval is the interval at which the spec and genesis are polled while waiting for genesis.
+	genesisPollInterval = 12 * time.Second
+)
+
 // ensureClients ensures that the node has a client and an API client.
 func (n *node) ensureClients(ctx context.Context) error {
 	failures := 0
 
-	zerologLevel := n.GetZeroLogLevel()
-
 	for {
-		if n.client != nil {
-			_, isProvider := n.client.(eth2client.NodeSyncingProvider)
+		if client := n.currentClient(); client != nil {
+			_, isProvider := client.(eth2client.NodeSyncingProvider)
 			if isProvider {
 				break
 			}
@@ -28,14 +33,7 @@ func (n *node) ensureClients(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			timeout := 10 * time.Minute
-
-			client, err := ehttp.New(ctx,
-				ehttp.WithAddress(n.config.Addr),
-				ehttp.WithLogLevel(zerologLevel),
-				ehttp.WithTimeout(timeout),
-				ehttp.WithExtraHeaders(n.config.Headers),
-			)
+			client, apiClient, cancel, err := n.newClients(ctx, n.currentConfig())
 			if err != nil {
 				failures++
 
@@ -53,17 +51,227 @@ func (n *node) ensureClients(ctx context.Context) error {
 				continue
 			}
 
-			n.client = client
+			n.setClients(client, apiClient, cancel)
 
-			httpClient := http.Client{
-				Timeout: timeout,
-			}
+			break
+		}
+	}
+
+	return nil
+}
+
+// newClients dials the node at the configured address and creates the eth2 and raw API clients for it. The
+// returned cancel func closes the eth2 client.
+func (n *node) newClients(ctx context.Context, config *Config) (eth2client.Service, api.ConsensusClient, context.CancelFunc, error) {
+	timeout := 10 * time.Minute
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	params := []ehttp.Parameter{
+		ehttp.WithAddress(config.Addr),
+		ehttp.WithLogLevel(n.GetZeroLogLevel()),
+		ehttp.WithTimeout(timeout),
+		ehttp.WithExtraHeaders(config.Headers),
+	}
+
+	if n.currentOptions().PropagateRequestID {
+		// The transport has the same settings as the one go-eth2-client creates when it isn't given a client.
+		transport := &http.Transport{
+			DialContext: (&net.Dialer{
+				Timeout:   timeout,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			MaxIdleConns:        64,
+			MaxConnsPerHost:     64,
+			MaxIdleConnsPerHost: 64,
+			IdleConnTimeout:     600 * time.Second,
+		}
 
-			n.api = api.NewConsensusClient(ctx, n.log, n.config.Addr, httpClient, n.config.Headers)
+		params = append(params, ehttp.WithHTTPClient(&http.Client{
+			Transport: api.NewRequestIDTransport(n.log, transport),
+		}))
+	}
+
+	client, err := ehttp.New(ctx, params...)
+	if err != nil {
+		cancel()
+
+		return nil, nil, nil, err
+	}
+
+	httpClient := http.Client{
+		Timeout: timeout,
+	}
+
+	opts := []api.ClientOption{
+		api.WithMaxResponseSize(n.currentOptions().MaxResponseSize),
+	}
+
+	if n.currentOptions().PropagateRequestID {
+		opts = append(opts, api.WithRequestIDPropagation())
+	}
 
+	return client, api.NewConsensusClient(ctx, n.log, config.Addr, httpClient, config.Headers, opts...), cancel, nil
+}
+
+// bootstrapInBackground bootstraps the node, retrying with backoff until it succeeds. If WaitForGenesis is
+// enabled the spec and genesis are polled until they are available first. With LenientStartup the node is
+// only marked as ready once it is bootstrapped and has been healthy for the first time.
+func (n *node) bootstrapInBackground(ctx context.Context) {
+	if n.currentOptions().LenientStartup {
+		n.OnFirstTimeHealthy(ctx, func(ctx context.Context, event *FirstTimeHealthyEvent) error {
+			n.markReadyIfBootstrappedAndHealthy(ctx)
+
+			return nil
+		})
+	}
+
+	if n.currentOptions().WaitForGenesis {
+		if err := n.waitForGenesis(ctx); err != nil {
+			return
+		}
+	}
+
+	failures := 0
+
+	for {
+		err := n.bootstrap(ctx)
+		if err == nil {
 			break
 		}
+
+		failures++
+
+		sleepFor := bootstrapBackoff(failures)
+
+		n.publishBootstrapFailed(ctx, err, failures, true, sleepFor)
+
+		n.log.WithError(err).Errorf("failed to bootstrap node.. will retry in %s", sleepFor.String())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(sleepFor):
+		}
+	}
+
+	if _, err := n.FetchSyncStatus(ctx); err != nil {
+		n.log.WithError(err).Error("Failed to fetch initial sync status")
+	}
+
+	if _, err := n.FetchFinality(ctx, "head"); err != nil {
+		n.log.WithError(err).Error("Failed to fetch initial head finality")
+	}
+
+	if n.currentOptions().LenientStartup {
+		n.markReadyIfBootstrappedAndHealthy(ctx)
+	}
+}
+
+// bootstrapWithRetry bootstraps the node, retrying with backoff until Options.StartupRetryWindow has elapsed
+// since the first attempt.
+func (n *node) bootstrapWithRetry(ctx context.Context) error {
+	deadline := time.Now().Add(n.currentOptions().StartupRetryWindow.Duration)
+
+	for attempt := 1; ; attempt++ {
+		err := n.bootstrap(ctx)
+		if err == nil {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 || ctx.Err() != nil {
+			n.publishBootstrapFailed(ctx, err, attempt, false, 0)
+
+			return err
+		}
+
+		sleepFor := bootstrapBackoff(attempt)
+
+		// The last attempt is made when the window elapses.
+		if sleepFor > remaining {
+			sleepFor = remaining
+		}
+
+		n.publishBootstrapFailed(ctx, err, attempt, true, sleepFor)
+
+		n.log.WithError(err).Warnf("Failed to bootstrap node, will retry in %s", sleepFor)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sleepFor):
+		}
+	}
+}
+
+// bootstrapBackoff returns how long to wait before the next attempt to bootstrap the node after the given
+// number of failed attempts.
+func bootstrapBackoff(failures int) time.Duration {
+	sleepFor := time.Duration(failures) * (time.Second * 5)
+
+	// Clamp the sleep time to a maximum of 5 minutes.
+	if sleepFor > time.Minute*5 {
+		sleepFor = time.Minute * 5
+	}
+
+	return sleepFor
+}
+
+// waitForGenesis polls the node until both its spec and genesis are available.
+func (n *node) waitForGenesis(ctx context.Context) error {
+	for {
+		err := n.checkGenesisAvailable(ctx)
+		if err == nil {
+			return nil
+		}
+
+		n.log.WithError(err).Info("Genesis is not available yet, waiting..")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(genesisPollInterval):
+		}
+	}
+}
+
+func (n *node) checkGenesisAvailable(ctx context.Context) error {
+	spec, err := n.FetchRawSpec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(spec) == 0 {
+		return errors.New("spec is empty")
+	}
+
+	if _, err := n.FetchGenesis(ctx); err != nil {
+		return err
 	}
 
 	return nil
 }
+
+func (n *node) IsReady() bool {
+	return n.ready.Load()
+}
+
+func (n *node) Bootstrapped() bool {
+	n.bootstrappedMu.RLock()
+	defer n.bootstrappedMu.RUnlock()
+
+	return n.bootstrapped
+}
+
+func (n *node) markReadyIfBootstrappedAndHealthy(ctx context.Context) {
+	bootstrapped := n.Bootstrapped()
+
+	n.firstHealthyMutex.Lock()
+	healthy := n.hasEmittedFirstTimeHealthy
+	n.firstHealthyMutex.Unlock()
+
+	if bootstrapped && healthy {
+		n.markReady(ctx)
+	}
+}
@@ -0,0 +1,200 @@
+package beacon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/ethpandaops/beacon/pkg/beacon/simulator"
+	"github.com/ethpandaops/beacon/pkg/human"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkReadyIfBootstrappedAndHealthy(t *testing.T) {
+	n := &node{
+		log:     logging.New(nil),
+		config:  &Config{Name: "node-a"},
+		options: DefaultOptions().EnableLenientStartup(),
+		broker:  emission.NewEmitter(),
+	}
+
+	ctx := context.Background()
+
+	n.markReadyIfBootstrappedAndHealthy(ctx)
+	assert.False(t, n.IsReady())
+	assert.False(t, n.Bootstrapped())
+
+	n.bootstrapped = true
+	assert.True(t, n.Bootstrapped())
+
+	n.markReadyIfBootstrappedAndHealthy(ctx)
+	assert.False(t, n.IsReady())
+
+	n.hasEmittedFirstTimeHealthy = true
+
+	n.markReadyIfBootstrappedAndHealthy(ctx)
+	assert.True(t, n.IsReady())
+	assert.True(t, n.Ready)
+}
+
+// newUnavailableSpecNode returns a node for a simulated beacon node that responds to the first failures spec
+// requests with 503 Service Unavailable.
+func newUnavailableSpecNode(t *testing.T, failures int32, window time.Duration) Node {
+	t.Helper()
+
+	handler := simulator.New(simulator.DefaultOptions()).Handler()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/eth/v1/config/spec" && requests.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	options := DefaultOptions().DisablePrometheusMetrics()
+	options.StartupRetryWindow = human.Duration{Duration: window}
+
+	return NewNode(logging.New(nil), &Config{Name: "bootstrap", Addr: server.URL}, "bootstrap", *options)
+}
+
+func TestStartRetriesBootstrap(t *testing.T) {
+	n := newUnavailableSpecNode(t, 1, 300*time.Millisecond)
+
+	failures := make(chan *BootstrapFailedEvent, 1)
+
+	n.OnBootstrapFailed(context.Background(), func(ctx context.Context, event *BootstrapFailedEvent) error {
+		failures <- event
+
+		return nil
+	})
+
+	require.NoError(t, n.Start(context.Background()))
+	t.Cleanup(func() { _ = n.Stop(context.Background()) })
+
+	assert.True(t, n.Bootstrapped())
+
+	select {
+	case event := <-failures:
+		assert.Equal(t, 1, event.Attempt)
+		assert.True(t, event.WillRetry)
+		assert.LessOrEqual(t, event.RetryIn, 300*time.Millisecond)
+		assert.Error(t, event.Err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for bootstrap failed event")
+	}
+}
+
+func TestStartGivesUpBootstrap(t *testing.T) {
+	n := newUnavailableSpecNode(t, 100, 0)
+
+	failures := make(chan *BootstrapFailedEvent, 1)
+
+	n.OnBootstrapFailed(context.Background(), func(ctx context.Context, event *BootstrapFailedEvent) error {
+		failures <- event
+
+		return nil
+	})
+
+	require.Error(t, n.Start(context.Background()))
+	assert.False(t, n.Bootstrapped())
+
+	select {
+	case event := <-failures:
+		assert.Equal(t, 1, event.Attempt)
+		assert.False(t, event.WillRetry)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for bootstrap failed event")
+	}
+}
+
+func TestLenientStartupWallclock(t *testing.T) {
+	server := httptest.NewServer(simulator.New(simulator.DefaultOptions()).Handler())
+	t.Cleanup(server.Close)
+
+	options := DefaultOptions().DisablePrometheusMetrics().EnableLenientStartup()
+
+	n := NewNode(logging.New(nil), &Config{Name: "lenient", Addr: server.URL}, "lenient", *options)
+
+	require.NoError(t, n.Start(context.Background()))
+	t.Cleanup(func() { _ = n.Stop(context.Background()) })
+
+	// Bootstrap sets the wallclock in the background while it is read here, which the race detector checks.
+	require.Eventually(t, func() bool {
+		_, _ = n.CurrentFork()
+
+		return n.Wallclock() != nil
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestCheckGenesisAvailable(t *testing.T) {
+	spec := map[string]any{"CONFIG_NAME": "devnet", "SLOTS_PER_EPOCH": "32"}
+	genesis := &v1.Genesis{GenesisTime: time.Unix(1700000000, 0)}
+
+	tests := []struct {
+		name      string
+		client    *fakeClient
+		err       error
+		available bool
+	}{
+		{
+			name:   "spec not available",
+			client: &fakeClient{genesis: genesis},
+		},
+		{
+			name:   "spec empty",
+			client: &fakeClient{spec: map[string]any{}, genesis: genesis},
+		},
+		{
+			name:   "genesis not available",
+			client: &fakeClient{spec: spec},
+			err:    ErrGenesisNotAvailable,
+		},
+		{
+			name:      "spec and genesis available",
+			client:    &fakeClient{spec: spec, genesis: genesis},
+			available: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := &node{
+				log:     logging.New(nil),
+				options: DefaultOptions().EnableWaitForGenesis(),
+				broker:  emission.NewEmitter(),
+				client:  test.client,
+			}
+
+			err := n.checkGenesisAvailable(context.Background())
+
+			if !test.available {
+				assert.Error(t, err)
+
+				if test.err != nil {
+					assert.ErrorIs(t, err, test.err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			fetched, err := n.Genesis()
+			require.NoError(t, err)
+			assert.Equal(t, genesis, fetched)
+		})
+	}
+}
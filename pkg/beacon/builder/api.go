@@ -0,0 +1,139 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+)
+
+// ErrNoBid is returned when the builder has no bid for the requested slot.
+var ErrNoBid = errors.New("builder has no bid")
+
+// Client executes requests against the builder API.
+type Client interface {
+	// Status returns nil if the builder is ready to accept requests.
+	Status(ctx context.Context) error
+	// RegisterValidators registers the given validators with the builder.
+	RegisterValidators(ctx context.Context, registrations []*v1.SignedValidatorRegistration) error
+	// ExecutionPayloadHeader requests a bid for the given slot, parent hash and proposer. Returns ErrNoBid if
+	// the builder has no bid.
+	ExecutionPayloadHeader(ctx context.Context, slot phase0.Slot, parentHash phase0.Hash32, pubkey phase0.BLSPubKey) (*VersionedSignedBid, error)
+}
+
+type client struct {
+	url     string
+	log     *logging.Entry
+	client  http.Client
+	headers map[string]string
+}
+
+// NewClient creates a new builder API client.
+func NewClient(log logging.Logger, url string, httpClient http.Client, headers map[string]string) Client {
+	return &client{
+		url:     url,
+		log:     logging.New(log),
+		client:  httpClient,
+		headers: headers,
+	}
+}
+
+// do executes the request and returns the status code and body of the response.
+func (c *client) do(ctx context.Context, method, path string, body []byte) (int, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Set headers from c.headers
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	defer rsp.Body.Close()
+
+	data, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return rsp.StatusCode, nil, err
+	}
+
+	return rsp.StatusCode, data, nil
+}
+
+func (c *client) Status(ctx context.Context) error {
+	status, _, err := c.do(ctx, http.MethodGet, "/eth/v1/builder/status", nil)
+	if err != nil {
+		return err
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("status code: %d", status)
+	}
+
+	return nil
+}
+
+func (c *client) RegisterValidators(ctx context.Context, registrations []*v1.SignedValidatorRegistration) error {
+	body, err := json.Marshal(registrations)
+	if err != nil {
+		return err
+	}
+
+	status, data, err := c.do(ctx, http.MethodPost, "/eth/v1/builder/validators", body)
+	if err != nil {
+		return err
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("status code: %d: %s", status, bytes.TrimSpace(data))
+	}
+
+	return nil
+}
+
+func (c *client) ExecutionPayloadHeader(ctx context.Context, slot phase0.Slot, parentHash phase0.Hash32, pubkey phase0.BLSPubKey) (*VersionedSignedBid, error) {
+	path := fmt.Sprintf("/eth/v1/builder/header/%d/%#x/%#x", slot, parentHash, pubkey)
+
+	status, data, err := c.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == http.StatusNoContent {
+		return nil, ErrNoBid
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("status code: %d", status)
+	}
+
+	bid := &VersionedSignedBid{}
+	if err := json.Unmarshal(data, bid); err != nil {
+		return nil, err
+	}
+
+	return bid, nil
+}
@@ -0,0 +1,58 @@
+package builder_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/builder"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	var registered []json.RawMessage
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/eth/v1/builder/status":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/eth/v1/builder/validators" && r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &registered)
+
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/eth/v1/builder/header/1/0x0100000000000000000000000000000000000000000000000000000000000000/0x"+
+			"020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000":
+			_, _ = w.Write([]byte(`{"version":"deneb","data":{"message":{"header":{"block_hash":"0x03","block_number":"100"},"value":"1000","pubkey":"0x04"},"signature":"0x05"}}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := builder.NewClient(logging.New(nil), server.URL, http.Client{}, nil)
+	ctx := context.Background()
+
+	require.NoError(t, client.Status(ctx))
+
+	require.NoError(t, client.RegisterValidators(ctx, []*v1.SignedValidatorRegistration{
+		{Message: &v1.ValidatorRegistration{GasLimit: 30_000_000}},
+	}))
+	assert.Len(t, registered, 1)
+
+	bid, err := client.ExecutionPayloadHeader(ctx, 1, phase0.Hash32{0x01}, phase0.BLSPubKey{0x02})
+	require.NoError(t, err)
+	assert.Equal(t, "deneb", bid.Version)
+	assert.Equal(t, "1000", bid.Data.Message.Value)
+	assert.Equal(t, "100", bid.Data.Message.Header.BlockNumber)
+
+	_, err = client.ExecutionPayloadHeader(ctx, 2, phase0.Hash32{0x01}, phase0.BLSPubKey{0x02})
+	assert.ErrorIs(t, err, builder.ErrNoBid)
+}
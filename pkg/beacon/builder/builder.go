@@ -0,0 +1,216 @@
+// Package builder provides a client for builders and relays speaking the builder API, with the same lifecycle,
+// health checks and metrics as a beacon node.
+package builder
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/go-co-op/gocron"
+)
+
+// requestTimeout is how long a request to the builder can take before it is abandoned.
+const requestTimeout = 10 * time.Second
+
+// Config is the configuration for a builder.
+type Config struct {
+	// Name is the human-readable name of the builder.
+	Name string `yaml:"name"`
+	// Address is the address of the builder.
+	Addr string `yaml:"addr"`
+	// Headers are the headers to send with every request.
+	Headers map[string]string `yaml:"headers"`
+	// Labels are arbitrary labels describing the builder. They are added to the const labels of the
+	// builder's metrics.
+	Labels map[string]string `yaml:"labels"`
+}
+
+// Options holds the options for a builder.
+type Options struct {
+	HealthCheck       beacon.HealthCheckOptions
+	PrometheusMetrics bool
+}
+
+// DefaultOptions returns the default options.
+func DefaultOptions() *Options {
+	return &Options{
+		HealthCheck:       beacon.DefaultHealthCheckOptions(),
+		PrometheusMetrics: true,
+	}
+}
+
+// Node is a builder or relay speaking the builder API.
+type Node interface {
+	Client
+
+	// Start starts the health checks of the builder.
+	Start(ctx context.Context) error
+	// Stop stops the health checks of the builder.
+	Stop(ctx context.Context) error
+	// Healthy returns true if the builder is healthy.
+	Healthy() bool
+	// Health returns a snapshot of the health status of the builder.
+	Health() *beacon.Health
+	// Config returns the configuration of the builder.
+	Config() *Config
+}
+
+type node struct {
+	log     *logging.Entry
+	config  *Config
+	options *Options
+
+	client  Client
+	metrics *Metrics
+
+	healthMu sync.Mutex
+	health   *beacon.Health
+
+	crons *gocron.Scheduler
+}
+
+// NewNode creates a new builder.
+func NewNode(log logging.Logger, config *Config, namespace string, options Options) Node {
+	n := &node{
+		log:     logging.New(log).WithField("module", "consensus/builder").WithField("builder", config.Name),
+		config:  config,
+		options: &options,
+		health:  beacon.NewHealth(options.HealthCheck.SuccessfulResponses, options.HealthCheck.FailedResponses),
+	}
+
+	n.client = NewClient(n.log, config.Addr, http.Client{Timeout: requestTimeout}, config.Headers)
+
+	if options.PrometheusMetrics {
+		if namespace == "" {
+			namespace = "eth"
+		}
+
+		n.metrics = NewMetrics(namespace, config.Name, config.Labels)
+	}
+
+	return n
+}
+
+func (n *node) Start(ctx context.Context) error {
+	n.log.Info("Starting builder...")
+
+	s := gocron.NewScheduler(time.Local)
+
+	if _, err := s.Every(n.options.HealthCheck.Interval.String()).Do(func() {
+		n.runHealthcheck(ctx)
+	}); err != nil {
+		return err
+	}
+
+	s.StartAsync()
+
+	n.crons = s
+
+	return nil
+}
+
+func (n *node) Stop(ctx context.Context) error {
+	if n.crons != nil {
+		n.crons.Stop()
+	}
+
+	return nil
+}
+
+func (n *node) Config() *Config {
+	return n.config
+}
+
+func (n *node) Healthy() bool {
+	n.healthMu.Lock()
+	defer n.healthMu.Unlock()
+
+	return n.health.Healthy()
+}
+
+func (n *node) Health() *beacon.Health {
+	n.healthMu.Lock()
+	defer n.healthMu.Unlock()
+
+	health := *n.health
+
+	return &health
+}
+
+func (n *node) runHealthcheck(ctx context.Context) {
+	err := n.Status(ctx)
+
+	n.healthMu.Lock()
+
+	if err != nil {
+		n.health.RecordFail(err)
+	} else {
+		n.health.RecordSuccess()
+	}
+
+	healthy := n.health.Healthy()
+
+	n.healthMu.Unlock()
+
+	if err != nil {
+		n.log.WithError(err).Debug("Builder health check failed")
+	}
+
+	if n.metrics != nil {
+		n.metrics.observeHealthCheck(err == nil, healthy)
+	}
+}
+
+func (n *node) Status(ctx context.Context) error {
+	start := time.Now()
+
+	err := n.client.Status(ctx)
+
+	n.observeRequest("status", start, err)
+
+	return err
+}
+
+func (n *node) RegisterValidators(ctx context.Context, registrations []*v1.SignedValidatorRegistration) error {
+	start := time.Now()
+
+	err := n.client.RegisterValidators(ctx, registrations)
+
+	n.observeRequest("register_validators", start, err)
+
+	return err
+}
+
+func (n *node) ExecutionPayloadHeader(ctx context.Context, slot phase0.Slot, parentHash phase0.Hash32, pubkey phase0.BLSPubKey) (*VersionedSignedBid, error) {
+	start := time.Now()
+
+	bid, err := n.client.ExecutionPayloadHeader(ctx, slot, parentHash, pubkey)
+
+	n.observeRequest("get_header", start, err)
+
+	return bid, err
+}
+
+func (n *node) observeRequest(method string, start time.Time, err error) {
+	if n.metrics == nil {
+		return
+	}
+
+	result := "success"
+
+	switch {
+	case errors.Is(err, ErrNoBid):
+		result = "no_bid"
+	case err != nil:
+		result = "error"
+	}
+
+	n.metrics.observeRequest(method, result, time.Since(start))
+}
@@ -0,0 +1,94 @@
+package builder
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics reports metrics on the health and requests of a builder.
+type Metrics struct {
+	CheckResultsTotal *prometheus.CounterVec
+	Up                prometheus.Gauge
+	RequestsTotal     *prometheus.CounterVec
+	RequestDuration   *prometheus.HistogramVec
+}
+
+// NewMetrics returns a new Metrics instance. The labels are added to the const labels of every metric, next
+// to the builder name.
+func NewMetrics(namespace, name string, labels map[string]string) *Metrics {
+	constLabels := prometheus.Labels{}
+
+	for k, v := range labels {
+		constLabels[k] = v
+	}
+
+	constLabels["builder"] = name
+
+	namespace += "_builder"
+
+	m := &Metrics{
+		CheckResultsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   namespace,
+				Name:        "check_results_total",
+				Help:        "Total of health checks results.",
+				ConstLabels: constLabels,
+			},
+			[]string{"result"},
+		),
+		Up: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "up",
+				Help:        "Whether the builder is up or not.",
+				ConstLabels: constLabels,
+			},
+		),
+		RequestsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   namespace,
+				Name:        "requests_total",
+				Help:        "Total of requests made to the builder, by method and result.",
+				ConstLabels: constLabels,
+			},
+			[]string{"method", "result"},
+		),
+		RequestDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace:   namespace,
+				Name:        "request_duration_seconds",
+				Help:        "The duration of requests made to the builder.",
+				ConstLabels: constLabels,
+				Buckets:     prometheus.DefBuckets,
+			},
+			[]string{"method"},
+		),
+	}
+
+	prometheus.MustRegister(m.CheckResultsTotal)
+	prometheus.MustRegister(m.Up)
+	prometheus.MustRegister(m.RequestsTotal)
+	prometheus.MustRegister(m.RequestDuration)
+
+	return m
+}
+
+func (m *Metrics) observeHealthCheck(success, healthy bool) {
+	if success {
+		m.CheckResultsTotal.WithLabelValues("success").Inc()
+	} else {
+		m.CheckResultsTotal.WithLabelValues("fail").Inc()
+	}
+
+	if healthy {
+		m.Up.Set(1)
+	} else {
+		m.Up.Set(0)
+	}
+}
+
+func (m *Metrics) observeRequest(method, result string, duration time.Duration) {
+	m.RequestsTotal.WithLabelValues(method, result).Inc()
+	m.RequestDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
@@ -0,0 +1,34 @@
+package builder
+
+// ExecutionPayloadHeader holds the fields of an execution payload header that are common to every fork.
+type ExecutionPayloadHeader struct {
+	ParentHash   string `json:"parent_hash"`
+	FeeRecipient string `json:"fee_recipient"`
+	StateRoot    string `json:"state_root"`
+	BlockNumber  string `json:"block_number"`
+	GasLimit     string `json:"gas_limit"`
+	GasUsed      string `json:"gas_used"`
+	Timestamp    string `json:"timestamp"`
+	BlockHash    string `json:"block_hash"`
+}
+
+// Bid is a bid from a builder for an execution payload (BuilderBid in the builder specs).
+type Bid struct {
+	Header             *ExecutionPayloadHeader `json:"header"`
+	BlobKZGCommitments []string                `json:"blob_kzg_commitments,omitempty"`
+	// Value is the value of the bid in wei.
+	Value  string `json:"value"`
+	Pubkey string `json:"pubkey"`
+}
+
+// SignedBid is a signed bid from a builder (SignedBuilderBid in the builder specs).
+type SignedBid struct {
+	Message   *Bid   `json:"message"`
+	Signature string `json:"signature"`
+}
+
+// VersionedSignedBid is a signed bid along with the fork it was made for.
+type VersionedSignedBid struct {
+	Version string     `json:"version"`
+	Data    *SignedBid `json:"data"`
+}
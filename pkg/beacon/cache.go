@@ -0,0 +1,271 @@
+package beacon
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/blockutil"
+)
+
+// Cache is a key value store with expiry that backs the caches of a node, see Options.Cache. Implementations
+// backed by an external store, e.g. Redis or memcached, let several processes share the cached data. Keys are
+// not namespaced by network, so nodes of different networks should not share a keyspace.
+type Cache interface {
+	// Get returns the value stored for the key, or false if there is none or it has expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the value for the key until the ttl has elapsed. A zero ttl never expires.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// defaultMemoryCacheSize is the size of the memory cache used when Options.Cache is nil.
+const defaultMemoryCacheSize = 256 << 20
+
+type memoryCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// memoryCache is a Cache bounded by the total size of its values, which evicts the least recently used values
+// to make room for new ones. It is used when Options.Cache is nil.
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// lru holds the entries, most recently used first.
+	lru     *list.List
+	size    int64
+	maxSize int64
+	now     func() time.Time
+}
+
+// NewMemoryCache returns a Cache that keeps up to maxSize bytes of values in memory. Expired values are evicted
+// when they are read, and the least recently used values when the cache is full. Values larger than maxSize
+// are not cached.
+func NewMemoryCache(maxSize int64) Cache {
+	return newMemoryCache(maxSize)
+}
+
+func newMemoryCache(maxSize int64) *memoryCache {
+	return &memoryCache{
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		maxSize: maxSize,
+		now:     time.Now,
+	}
+}
+
+func (c *memoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return nil, false, nil
+	}
+
+	//nolint:forcetypeassert // the lru only holds entries.
+	entry := element.Value.(*memoryCacheEntry)
+
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.remove(element)
+
+		return nil, false, nil
+	}
+
+	c.lru.MoveToFront(element)
+
+	return entry.value, true, nil
+}
+
+func (c *memoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl < 0 {
+		return errors.New("ttl must not be negative")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, exists := c.entries[key]; exists {
+		c.remove(element)
+	}
+
+	if int64(len(value)) > c.maxSize {
+		return nil
+	}
+
+	entry := &memoryCacheEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expiresAt = c.now().Add(ttl)
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	c.size += int64(len(value))
+
+	for c.size > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+
+	return nil
+}
+
+func (c *memoryCache) remove(element *list.Element) {
+	//nolint:forcetypeassert // the lru only holds entries.
+	entry := c.lru.Remove(element).(*memoryCacheEntry)
+
+	delete(c.entries, entry.key)
+
+	c.size -= int64(len(entry.value))
+}
+
+// cacheGet returns the value cached for the key. Failures of the cache are logged and treated as a miss, so
+// the data is fetched from the node instead.
+func (n *node) cacheGet(ctx context.Context, key string) ([]byte, bool) {
+	value, exists, err := n.cache.Get(ctx, key)
+	if err != nil {
+		n.log.WithError(err).WithField("key", key).Warn("Failed to read from the cache")
+
+		return nil, false
+	}
+
+	return value, exists
+}
+
+// cacheSet caches the value for the key. Failures of the cache are logged.
+func (n *node) cacheSet(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	if err := n.cache.Set(ctx, key, value, ttl); err != nil {
+		n.log.WithError(err).WithField("key", key).Warn("Failed to write to the cache")
+	}
+}
+
+func blockCacheKey(root phase0.Root) string {
+	return fmt.Sprintf("block:%#x", root)
+}
+
+func validatorsCacheKey(stateRoot phase0.Root) string {
+	return fmt.Sprintf("validators:%#x", stateRoot)
+}
+
+func attestationDataCacheKey(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) string {
+	return fmt.Sprintf("attestation_data:%d:%d", slot, committeeIndex)
+}
+
+// cachedBlock returns the block with the given root from the cache. Blocks are encoded as their version
+// followed by the SSZ encoding of the block.
+func (n *node) cachedBlock(ctx context.Context, root phase0.Root) (*spec.VersionedSignedBeaconBlock, bool) {
+	data, exists := n.cacheGet(ctx, blockCacheKey(root))
+	if !exists || len(data) == 0 {
+		return nil, false
+	}
+
+	block, err := blockutil.ParseRawBlock(data[1:], blockutil.ContentTypeSSZ, spec.DataVersion(data[0]))
+	if err != nil {
+		n.log.WithError(err).WithField("root", fmt.Sprintf("%#x", root)).Warn("Failed to decode cached block")
+
+		return nil, false
+	}
+
+	return block, true
+}
+
+func (n *node) cacheBlock(ctx context.Context, root phase0.Root, block *spec.VersionedSignedBeaconBlock) {
+	data, err := blockutil.MarshalBlockSSZ(block)
+	if err != nil {
+		n.log.WithError(err).WithField("root", fmt.Sprintf("%#x", root)).Debug("Failed to encode block for the cache")
+
+		return
+	}
+
+	n.cacheSet(ctx, blockCacheKey(root), append([]byte{byte(block.Version)}, data...), n.currentOptions().BlockCache.TTL.Duration)
+}
+
+// cachedValidators returns the validators of the state with the given root from the cache.
+func (n *node) cachedValidators(ctx context.Context, stateRoot phase0.Root) (map[phase0.ValidatorIndex]*v1.Validator, bool) {
+	data, exists := n.cacheGet(ctx, validatorsCacheKey(stateRoot))
+	if !exists {
+		return nil, false
+	}
+
+	validators := make(map[phase0.ValidatorIndex]*v1.Validator)
+	if err := json.Unmarshal(data, &validators); err != nil {
+		n.log.WithError(err).WithField("state_root", fmt.Sprintf("%#x", stateRoot)).Warn("Failed to decode cached validators")
+
+		return nil, false
+	}
+
+	return validators, true
+}
+
+func (n *node) cacheValidators(ctx context.Context, stateRoot phase0.Root, validators map[phase0.ValidatorIndex]*v1.Validator) {
+	data, err := json.Marshal(validators)
+	if err != nil {
+		n.log.WithError(err).WithField("state_root", fmt.Sprintf("%#x", stateRoot)).Debug("Failed to encode validators for the cache")
+
+		return
+	}
+
+	n.cacheSet(ctx, validatorsCacheKey(stateRoot), data, n.currentOptions().ValidatorCache.TTL.Duration)
+}
+
+// cachedAttestationData returns the attestation data for the slot and committee index from the cache.
+func (n *node) cachedAttestationData(ctx context.Context, slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, bool) {
+	data, exists := n.cacheGet(ctx, attestationDataCacheKey(slot, committeeIndex))
+	if !exists {
+		return nil, false
+	}
+
+	attestationData := &phase0.AttestationData{}
+	if err := attestationData.UnmarshalSSZ(data); err != nil {
+		n.log.WithError(err).WithField("slot", slot).Warn("Failed to decode cached attestation data")
+
+		return nil, false
+	}
+
+	n.attestationDataHeadMu.RLock()
+	head := n.attestationDataHead
+	n.attestationDataHeadMu.RUnlock()
+
+	if head != nil && head.Slot == slot && head.Block != attestationData.BeaconBlockRoot {
+		return nil, false
+	}
+
+	return attestationData, true
+}
+
+// subscribeAttestationDataInvalidation records the head so that attestation data cached before the head block
+// of its slot arrived is fetched again rather than served for the rest of the slot.
+func (n *node) subscribeAttestationDataInvalidation(ctx context.Context) {
+	n.OnHead(ctx, func(ctx context.Context, event *v1.HeadEvent) error {
+		n.attestationDataHeadMu.Lock()
+		defer n.attestationDataHeadMu.Unlock()
+
+		n.attestationDataHead = event
+
+		return nil
+	})
+}
+
+// cacheAttestationData caches the attestation data until expiresAt. Attestation data that has already expired
+// is not cached.
+func (n *node) cacheAttestationData(ctx context.Context, slot phase0.Slot, committeeIndex phase0.CommitteeIndex, attestationData *phase0.AttestationData, expiresAt time.Time) {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return
+	}
+
+	data, err := attestationData.MarshalSSZ()
+	if err != nil {
+		n.log.WithError(err).WithField("slot", slot).Debug("Failed to encode attestation data for the cache")
+
+		return
+	}
+
+	n.cacheSet(ctx, attestationDataCacheKey(slot, committeeIndex), data, ttl)
+}
@@ -0,0 +1,232 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	now := time.Now()
+
+	cache := newMemoryCache(defaultMemoryCacheSize)
+	cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	_, exists, err := cache.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, cache.Set(ctx, "a", []byte("1"), 12*time.Second))
+	require.NoError(t, cache.Set(ctx, "b", []byte("2"), 0))
+
+	value, exists, err := cache.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, []byte("1"), value)
+
+	assert.Error(t, cache.Set(ctx, "c", []byte("3"), -time.Second))
+
+	// Entries expire once their ttl has elapsed, entries without a ttl never do.
+	now = now.Add(12 * time.Second)
+
+	_, exists, _ = cache.Get(ctx, "a")
+	assert.False(t, exists)
+
+	_, exists, _ = cache.Get(ctx, "b")
+	assert.True(t, exists)
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	cache := newMemoryCache(4)
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "a", []byte("12"), 0))
+	require.NoError(t, cache.Set(ctx, "b", []byte("34"), 0))
+
+	// Reading a makes b the least recently used value, which is evicted to make room for c.
+	_, exists, _ := cache.Get(ctx, "a")
+	require.True(t, exists)
+
+	require.NoError(t, cache.Set(ctx, "c", []byte("56"), 0))
+
+	_, exists, _ = cache.Get(ctx, "b")
+	assert.False(t, exists)
+
+	_, exists, _ = cache.Get(ctx, "a")
+	assert.True(t, exists)
+
+	// Replacing a value frees the space of the previous one.
+	require.NoError(t, cache.Set(ctx, "c", []byte("7"), 0))
+	assert.Equal(t, int64(3), cache.size)
+
+	// Values that do not fit at all are not cached.
+	require.NoError(t, cache.Set(ctx, "d", []byte("12345"), 0))
+
+	_, exists, _ = cache.Get(ctx, "d")
+	assert.False(t, exists)
+	assert.Len(t, cache.entries, 2)
+}
+
+func newCacheTestNode(cache Cache) *node {
+	options := DefaultOptions().EnableBlockCache().WithCache(cache)
+	options.ValidatorCache.Enabled = true
+
+	return &node{
+		log:     logging.New(nil),
+		options: options,
+		cache:   cache,
+		blocks:  newBlockCache(options.BlockCache.Slots),
+	}
+}
+
+func TestSharedBlockCache(t *testing.T) {
+	cache := NewMemoryCache(defaultMemoryCacheSize)
+
+	root := phase0.Root{0x01}
+	block := &spec.VersionedSignedBeaconBlock{Version: spec.DataVersionPhase0, Phase0: &phase0.SignedBeaconBlock{
+		Message: &phase0.BeaconBlock{
+			Slot: 12,
+			Body: &phase0.BeaconBlockBody{
+				ETH1Data: &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+			},
+		},
+	}}
+
+	newCacheTestNode(cache).cacheBlock(context.Background(), root, block)
+
+	// The node has no client, so the block can only be served from the shared cache.
+	n := newCacheTestNode(cache)
+
+	cached, err := n.FetchBlock(context.Background(), "0x0100000000000000000000000000000000000000000000000000000000000000")
+	require.NoError(t, err)
+	assert.Equal(t, spec.DataVersionPhase0, cached.Version)
+	assert.Equal(t, phase0.Slot(12), cached.Phase0.Message.Slot)
+
+	// Blocks by slot are not shared.
+	_, err = n.FetchBlock(context.Background(), "12")
+	assert.Error(t, err)
+}
+
+func TestSharedValidatorCache(t *testing.T) {
+	cache := NewMemoryCache(defaultMemoryCacheSize)
+
+	stateRoot := phase0.Root{0x02}
+	validators := map[phase0.ValidatorIndex]*v1.Validator{
+		7: {
+			Index:   7,
+			Balance: 32000000000,
+			Status:  v1.ValidatorStateActiveOngoing,
+			Validator: &phase0.Validator{
+				WithdrawalCredentials: make([]byte, 32),
+				EffectiveBalance:      32000000000,
+			},
+		},
+	}
+
+	newCacheTestNode(cache).cacheValidators(context.Background(), stateRoot, validators)
+
+	n := newCacheTestNode(cache)
+
+	cached, err := n.FetchValidators(context.Background(), "0x0200000000000000000000000000000000000000000000000000000000000000", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, validators, cached)
+
+	// Filtered validator sets are not shared.
+	_, err = n.FetchValidators(context.Background(), "0x0200000000000000000000000000000000000000000000000000000000000000", []phase0.ValidatorIndex{7}, nil)
+	assert.Error(t, err)
+}
+
+func TestSharedAttestationDataCache(t *testing.T) {
+	n := newCacheTestNode(NewMemoryCache(defaultMemoryCacheSize))
+
+	data := &phase0.AttestationData{
+		Slot:            10,
+		Index:           2,
+		Source:          &phase0.Checkpoint{},
+		Target:          &phase0.Checkpoint{},
+		BeaconBlockRoot: phase0.Root{0x03},
+	}
+
+	n.cacheAttestationData(context.Background(), 10, 2, data, time.Now().Add(12*time.Second))
+
+	cached, err := n.FetchAttestationData(context.Background(), 10, 2)
+	require.NoError(t, err)
+	assert.Equal(t, data, cached)
+
+	// Other committees in the same slot are cached separately.
+	_, exists := n.cachedAttestationData(context.Background(), 10, 3)
+	assert.False(t, exists)
+
+	// Data for slots that have already ended is never cached.
+	n.cacheAttestationData(context.Background(), 9, 2, data, time.Now())
+
+	_, exists = n.cachedAttestationData(context.Background(), 9, 2)
+	assert.False(t, exists)
+}
+
+func TestAttestationDataInvalidatedByHead(t *testing.T) {
+	n := newCacheTestNode(NewMemoryCache(defaultMemoryCacheSize))
+	n.broker = emission.NewEmitter()
+
+	n.subscribeAttestationDataInvalidation(context.Background())
+
+	data := &phase0.AttestationData{
+		Slot:            10,
+		Index:           2,
+		Source:          &phase0.Checkpoint{},
+		Target:          &phase0.Checkpoint{},
+		BeaconBlockRoot: phase0.Root{0x09},
+	}
+
+	n.cacheAttestationData(context.Background(), 10, 2, data, time.Now().Add(12*time.Second))
+
+	// The head of the previous slot doesn't affect the data.
+	n.publishHead(context.Background(), &v1.HeadEvent{Slot: 9, Block: phase0.Root{0x09}})
+
+	_, exists := n.cachedAttestationData(context.Background(), 10, 2)
+	assert.True(t, exists)
+
+	// Once the block of the slot arrives the data cached before it is stale.
+	n.publishHead(context.Background(), &v1.HeadEvent{Slot: 10, Block: phase0.Root{0x0a}})
+
+	_, exists = n.cachedAttestationData(context.Background(), 10, 2)
+	assert.False(t, exists)
+
+	data.BeaconBlockRoot = phase0.Root{0x0a}
+
+	n.cacheAttestationData(context.Background(), 10, 2, data, time.Now().Add(12*time.Second))
+
+	cached, exists := n.cachedAttestationData(context.Background(), 10, 2)
+	require.True(t, exists)
+	assert.Equal(t, data, cached)
+}
+
+func TestBlocksNotSharedWithoutCache(t *testing.T) {
+	cache := newMemoryCache(defaultMemoryCacheSize)
+
+	n := &node{
+		log:     logging.New(nil),
+		options: DefaultOptions().EnableBlockCache(),
+		cache:   cache,
+		blocks:  newBlockCache(32),
+		client: &fakeClient{blocks: byRoot(map[phase0.Root]*spec.VersionedSignedBeaconBlock{
+			{0x01}: capellaBlock(12, phase0.Root{}),
+		})},
+	}
+
+	_, err := n.FetchBlock(context.Background(), "0x0100000000000000000000000000000000000000000000000000000000000000")
+	require.NoError(t, err)
+
+	// The block cache already holds the block, so it is not encoded into the default memory cache as well.
+	assert.Empty(t, cache.entries)
+}
@@ -0,0 +1,149 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	eapi "github.com/attestantio/go-eth2-client/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+)
+
+// Endpoints that not all consensus clients implement.
+const (
+	EndpointDepositSnapshot             = "deposit_snapshot"
+	EndpointForkChoice                  = "fork_choice"
+	EndpointBlobSidecars                = "blob_sidecars"
+	EndpointNodeIdentity                = "node_identity"
+	EndpointLightClientFinalityUpdate   = "light_client_finality_update"
+	EndpointLightClientOptimisticUpdate = "light_client_optimistic_update"
+)
+
+const (
+	// endpointProbeTimeout is the timeout for each individual capability probe.
+	endpointProbeTimeout = 10 * time.Second
+)
+
+type endpointProbe struct {
+	name string
+	path string
+	// notFoundIsUnsupported is set for paths that always exist when the endpoint is implemented, so a 404
+	// can only come from the router.
+	notFoundIsUnsupported bool
+}
+
+var endpointProbes = []endpointProbe{
+	{name: EndpointDepositSnapshot, path: "/eth/v1/beacon/deposit_snapshot"},
+	{name: EndpointForkChoice, path: "/eth/v1/debug/fork_choice", notFoundIsUnsupported: true},
+	{name: EndpointBlobSidecars, path: "/eth/v1/beacon/blob_sidecars/head", notFoundIsUnsupported: true},
+	{name: EndpointNodeIdentity, path: "/eth/v1/node/identity", notFoundIsUnsupported: true},
+	// Nodes without light client support don't register the light client routes, and those that have them
+	// return an update as soon as they are synced.
+	{name: EndpointLightClientFinalityUpdate, path: "/eth/v1/beacon/light_client/finality_update", notFoundIsUnsupported: true},
+	{name: EndpointLightClientOptimisticUpdate, path: "/eth/v1/beacon/light_client/optimistic_update", notFoundIsUnsupported: true},
+}
+
+// capabilities records which optional endpoints the node supports.
+type capabilities struct {
+	mu        sync.RWMutex
+	supported map[string]bool
+}
+
+func newCapabilities() *capabilities {
+	return &capabilities{
+		supported: make(map[string]bool),
+	}
+}
+
+// supports returns false only if the endpoint is known to be unsupported.
+func (c *capabilities) supports(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	supported, known := c.supported[name]
+
+	return !known || supported
+}
+
+func (c *capabilities) set(name string, supported bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.supported[name] = supported
+}
+
+// reset forgets every recorded endpoint, so all are assumed supported until probed again.
+func (c *capabilities) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.supported = make(map[string]bool)
+}
+
+// isUnsupportedStatus returns true if the status code shows that the node does not implement the endpoint.
+func isUnsupportedStatus(status int, notFoundIsUnsupported bool) bool {
+	switch status {
+	case http.StatusNotImplemented, http.StatusMethodNotAllowed:
+		return true
+	case http.StatusNotFound:
+		return notFoundIsUnsupported
+	default:
+		return false
+	}
+}
+
+// probeCapabilities requests each optional endpoint and records which are not implemented by the node. It
+// takes a round trip per endpoint, so it is run in the background; endpoints are assumed to be supported until
+// their probe finishes.
+func (n *node) probeCapabilities(ctx context.Context) {
+	for _, probe := range endpointProbes {
+		probeCtx, cancel := context.WithTimeout(ctx, endpointProbeTimeout)
+
+		status, err := n.currentAPI().EndpointStatus(probeCtx, probe.path)
+
+		cancel()
+
+		if err != nil {
+			n.log.WithError(err).WithField("endpoint", probe.name).Debug("Failed to probe endpoint")
+
+			continue
+		}
+
+		supported := !isUnsupportedStatus(status, probe.notFoundIsUnsupported)
+
+		n.capabilities.set(probe.name, supported)
+
+		if !supported {
+			n.log.WithField("endpoint", probe.name).WithField("status", status).Info("Beacon node does not support endpoint")
+		}
+	}
+}
+
+func (n *node) SupportsEndpoint(name string) bool {
+	return n.capabilities.supports(name)
+}
+
+// requireEndpoint returns ErrNotSupported if the endpoint is known to be unsupported by the node.
+func (n *node) requireEndpoint(name string) error {
+	if !n.capabilities.supports(name) {
+		return fmt.Errorf("%w: %s", ErrNotSupported, name)
+	}
+
+	return nil
+}
+
+// wrapNotSupported records the endpoint as unsupported and wraps err with ErrNotSupported if the node
+// responded with a 501.
+func (n *node) wrapNotSupported(name string, err error) error {
+	var apiErr *eapi.Error
+	if (errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotImplemented) || errors.Is(err, api.ErrNotImplemented) {
+		n.capabilities.set(name, false)
+
+		return fmt.Errorf("%w: %s: %w", ErrNotSupported, name, err)
+	}
+
+	return err
+}
@@ -0,0 +1,92 @@
+package beacon
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	eapi "github.com/attestantio/go-eth2-client/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUnsupportedStatus(t *testing.T) {
+	tests := []struct {
+		name                  string
+		status                int
+		notFoundIsUnsupported bool
+		expected              bool
+	}{
+		{name: "ok", status: http.StatusOK, expected: false},
+		{name: "not implemented", status: http.StatusNotImplemented, expected: true},
+		{name: "method not allowed", status: http.StatusMethodNotAllowed, expected: true},
+		{name: "not found by route", status: http.StatusNotFound, notFoundIsUnsupported: true, expected: true},
+		{name: "not found by resource", status: http.StatusNotFound, expected: false},
+		{name: "internal error", status: http.StatusInternalServerError, notFoundIsUnsupported: true, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, isUnsupportedStatus(test.status, test.notFoundIsUnsupported))
+		})
+	}
+}
+
+func TestLightClientProbesTreatNotFoundAsUnsupported(t *testing.T) {
+	for _, probe := range endpointProbes {
+		if probe.name == EndpointLightClientFinalityUpdate || probe.name == EndpointLightClientOptimisticUpdate {
+			assert.True(t, isUnsupportedStatus(http.StatusNotFound, probe.notFoundIsUnsupported), probe.name)
+			assert.True(t, isUnsupportedStatus(http.StatusNotImplemented, probe.notFoundIsUnsupported), probe.name)
+		}
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	n := &node{capabilities: newCapabilities()}
+
+	// Endpoints that have not been probed are assumed to be supported.
+	assert.True(t, n.SupportsEndpoint(EndpointForkChoice))
+	assert.NoError(t, n.requireEndpoint(EndpointForkChoice))
+
+	n.capabilities.set(EndpointForkChoice, false)
+
+	assert.False(t, n.SupportsEndpoint(EndpointForkChoice))
+	assert.ErrorIs(t, n.requireEndpoint(EndpointForkChoice), ErrNotSupported)
+}
+
+func TestWrapNotSupported(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "eth2 client 501",
+			err:      &eapi.Error{StatusCode: 501},
+			expected: true,
+		},
+		{
+			name:     "consensus client 501",
+			err:      fmt.Errorf("%w: status code: 501", api.ErrNotImplemented),
+			expected: true,
+		},
+		{
+			name:     "eth2 client 500",
+			err:      &eapi.Error{StatusCode: 500},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := &node{capabilities: newCapabilities()}
+
+			err := n.wrapNotSupported(EndpointDepositSnapshot, test.err)
+
+			assert.Equal(t, test.expected, errors.Is(err, ErrNotSupported))
+			assert.Equal(t, !test.expected, n.SupportsEndpoint(EndpointDepositSnapshot))
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
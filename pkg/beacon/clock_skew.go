@@ -0,0 +1,59 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// dateHeaderPrecision is the precision of the HTTP Date header.
+const dateHeaderPrecision = time.Second
+
+// measureClockSkew measures the skew between the node's clock and the local clock using the Date header of a
+// health request, and emits a ClockSkewExceededEvent if it exceeds the configured threshold.
+func (n *node) measureClockSkew(ctx context.Context) (time.Duration, error) {
+	sent := time.Now()
+
+	date, err := n.currentAPI().Date(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	received := time.Now()
+
+	skew := clockSkew(date, sent, received)
+
+	n.clockSkewMu.Lock()
+	n.clockSkew = &skew
+	n.clockSkewMu.Unlock()
+
+	threshold := n.currentOptions().ClockSkew.Threshold.Duration
+	if threshold > 0 && (skew > threshold || skew < -threshold) {
+		n.log.WithField("skew", skew).WithField("threshold", threshold).Warn("Clock skew between the local clock and the beacon node exceeds the threshold")
+
+		n.publishClockSkewExceeded(ctx, skew)
+	}
+
+	return skew, nil
+}
+
+// clockSkew returns the skew between the node's date and the local time at the midpoint of the request. The
+// date is truncated to the second, so on average it is half a second behind the node's clock.
+func clockSkew(date, sent, received time.Time) time.Duration {
+	local := sent.Add(received.Sub(sent) / 2)
+
+	return date.Add(dateHeaderPrecision / 2).Sub(local)
+}
+
+// ClockSkew returns the most recently measured skew between the node's clock and the local clock. A positive
+// skew means the node's clock is ahead.
+func (n *node) ClockSkew() (time.Duration, error) {
+	n.clockSkewMu.RLock()
+	defer n.clockSkewMu.RUnlock()
+
+	if n.clockSkew == nil {
+		return 0, errors.New("clock skew not available")
+	}
+
+	return *n.clockSkew, nil
+}
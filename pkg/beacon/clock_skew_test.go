@@ -0,0 +1,65 @@
+package beacon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkew(t *testing.T) {
+	sent := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	received := sent.Add(200 * time.Millisecond)
+
+	// The node's date is truncated to the second, so half a second is added back.
+	assert.Equal(t, 400*time.Millisecond, clockSkew(sent, sent, received))
+	assert.Equal(t, 10*time.Second+400*time.Millisecond, clockSkew(sent.Add(10*time.Second), sent, received))
+	assert.Equal(t, -10*time.Second+400*time.Millisecond, clockSkew(sent.Add(-10*time.Second), sent, received))
+}
+
+func TestMeasureClockSkew(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	options := DefaultOptions().EnableClockSkewMeasurement()
+
+	n := &node{
+		log:     logging.New(nil),
+		broker:  emission.NewEmitter(),
+		options: options,
+		api:     api.NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{}, nil),
+	}
+
+	_, err := n.ClockSkew()
+	assert.Error(t, err)
+
+	var events []*ClockSkewExceededEvent
+
+	n.OnClockSkewExceeded(context.Background(), func(ctx context.Context, event *ClockSkewExceededEvent) error {
+		events = append(events, event)
+
+		return nil
+	})
+
+	skew, err := n.measureClockSkew(context.Background())
+	require.NoError(t, err)
+	assert.InDelta(t, time.Minute.Seconds(), skew.Seconds(), 1)
+
+	cached, err := n.ClockSkew()
+	require.NoError(t, err)
+	assert.Equal(t, skew, cached)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, skew, events[0].Skew)
+	assert.Equal(t, options.ClockSkew.Threshold.Duration, events[0].Threshold)
+}
@@ -8,4 +8,7 @@ type Config struct {
 	Addr string `yaml:"addr"`
 	// Headers are the headers to send with every request.
 	Headers map[string]string `yaml:"headers"`
+	// Labels are arbitrary labels describing the node, e.g. its datacenter, client or network. They are
+	// added to every event envelope and to the const labels of the node's metrics.
+	Labels map[string]string `yaml:"labels"`
 }
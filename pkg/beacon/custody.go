@@ -0,0 +1,281 @@
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethpandaops/beacon/pkg/beacon/state"
+)
+
+const (
+	// NumberOfColumns is the number of columns in the extended data matrix.
+	NumberOfColumns = 128
+	// NumberOfCustodyGroups is the number of custody groups the columns are divided into.
+	NumberOfCustodyGroups = 128
+
+	// defaultValidatorCustodyRequirement, defaultBalancePerAdditionalCustodyGroup and
+	// defaultMinEpochsForDataColumnSidecarsRequests are the mainnet values, used when the node's spec does
+	// not include them.
+	defaultValidatorCustodyRequirement            = 8
+	defaultBalancePerAdditionalCustodyGroup       = phase0.Gwei(32_000_000_000)
+	defaultMinEpochsForDataColumnSidecarsRequests = phase0.Epoch(4096)
+)
+
+var maxNodeID = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// GetCustodyGroups returns the custody groups of the node with the given node ID, following
+// get_custody_groups from the PeerDAS spec.
+func GetCustodyGroups(nodeID enode.ID, custodyGroupCount uint64) ([]uint64, error) {
+	if custodyGroupCount > NumberOfCustodyGroups {
+		return nil, fmt.Errorf("custody group count %d exceeds the number of custody groups %d", custodyGroupCount, NumberOfCustodyGroups)
+	}
+
+	groups := make([]uint64, 0, custodyGroupCount)
+
+	if custodyGroupCount == NumberOfCustodyGroups {
+		for i := uint64(0); i < NumberOfCustodyGroups; i++ {
+			groups = append(groups, i)
+		}
+
+		return groups, nil
+	}
+
+	current := new(big.Int).SetBytes(nodeID[:])
+	seen := make(map[uint64]struct{}, custodyGroupCount)
+
+	for uint64(len(groups)) < custodyGroupCount {
+		// The node ID is hashed as a 32 byte little-endian integer.
+		var buf [32]byte
+
+		current.FillBytes(buf[:])
+		slices.Reverse(buf[:])
+
+		hash := sha256.Sum256(buf[:])
+		group := binary.LittleEndian.Uint64(hash[:8]) % NumberOfCustodyGroups
+
+		if _, exists := seen[group]; !exists {
+			seen[group] = struct{}{}
+			groups = append(groups, group)
+		}
+
+		if current.Cmp(maxNodeID) == 0 {
+			current.SetUint64(0)
+		} else {
+			current.Add(current, big.NewInt(1))
+		}
+	}
+
+	slices.Sort(groups)
+
+	return groups, nil
+}
+
+// ComputeColumnsForCustodyGroup returns the columns that belong to the given custody group.
+func ComputeColumnsForCustodyGroup(group uint64) ([]uint64, error) {
+	if group >= NumberOfCustodyGroups {
+		return nil, fmt.Errorf("custody group %d exceeds the number of custody groups %d", group, NumberOfCustodyGroups)
+	}
+
+	columnsPerGroup := uint64(NumberOfColumns / NumberOfCustodyGroups)
+	columns := make([]uint64, 0, columnsPerGroup)
+
+	for i := uint64(0); i < columnsPerGroup; i++ {
+		columns = append(columns, NumberOfCustodyGroups*i+group)
+	}
+
+	return columns, nil
+}
+
+// GetCustodyColumns returns the sorted columns that the node with the given node ID custodies.
+func GetCustodyColumns(nodeID enode.ID, custodyGroupCount uint64) ([]uint64, error) {
+	groups, err := GetCustodyGroups(nodeID, custodyGroupCount)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]uint64, 0, len(groups)*(NumberOfColumns/NumberOfCustodyGroups))
+
+	for _, group := range groups {
+		groupColumns, err := ComputeColumnsForCustodyGroup(group)
+		if err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, groupColumns...)
+	}
+
+	slices.Sort(columns)
+
+	return columns, nil
+}
+
+// CustodyColumns returns the columns the node custodies, derived from its node ID and the custody group
+// count in its identity metadata.
+func (n *node) CustodyColumns() ([]uint64, error) {
+	identity, err := n.NodeIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	if identity.Metadata.CustodyGroupCount == "" {
+		return nil, errors.New("node identity does not include a custody group count")
+	}
+
+	custodyGroupCount, err := strconv.ParseUint(identity.Metadata.CustodyGroupCount, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid custody group count: %w", err)
+	}
+
+	enr, err := identity.GetEnode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse node ENR: %w", err)
+	}
+
+	return GetCustodyColumns(enr.ID(), custodyGroupCount)
+}
+
+// IsCustodyColumn returns true if the node custodies the column with the given index.
+func (n *node) IsCustodyColumn(index uint64) (bool, error) {
+	columns, err := n.CustodyColumns()
+	if err != nil {
+		return false, err
+	}
+
+	_, found := slices.BinarySearch(columns, index)
+
+	return found, nil
+}
+
+// ValidatorsCustodyRequirement returns the number of custody groups a node with validators holding the given
+// total effective balance is required to custody, following get_validators_custody_requirement from the
+// PeerDAS spec.
+func ValidatorsCustodyRequirement(sp *state.Spec, totalEffectiveBalance phase0.Gwei) uint64 {
+	minimum := uint64(defaultValidatorCustodyRequirement)
+	balancePerGroup := defaultBalancePerAdditionalCustodyGroup
+
+	if sp != nil {
+		if sp.ValidatorCustodyRequirement != 0 {
+			minimum = sp.ValidatorCustodyRequirement
+		}
+
+		if sp.BalancePerAdditionalCustodyGroup != 0 {
+			balancePerGroup = sp.BalancePerAdditionalCustodyGroup
+		}
+	}
+
+	count := uint64(totalEffectiveBalance / balancePerGroup)
+
+	return min(max(count, minimum), NumberOfCustodyGroups)
+}
+
+// FetchValidatorsCustodyRequirement fetches the given validators at the state and returns the number of custody
+// groups a node running them is required to custody.
+func (n *node) FetchValidatorsCustodyRequirement(ctx context.Context, stateID string, indices []phase0.ValidatorIndex) (uint64, error) {
+	sp, err := n.Spec()
+	if err != nil {
+		return 0, err
+	}
+
+	validators, err := n.FetchValidators(ctx, stateID, indices, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	total := phase0.Gwei(0)
+
+	for _, validator := range validators {
+		if validator.Validator == nil {
+			continue
+		}
+
+		total += validator.Validator.EffectiveBalance
+	}
+
+	return ValidatorsCustodyRequirement(sp, total), nil
+}
+
+// DataColumnRetentionStartSlot returns the earliest slot the spec requires nodes to retain data column
+// sidecars for: the start of the data column retention window, or the Fulu fork if it is more recent. This is a
+// lower bound from the spec, not the earliest slot the node actually holds.
+func (n *node) DataColumnRetentionStartSlot() (phase0.Slot, error) {
+	sp, err := n.Spec()
+	if err != nil {
+		return 0, err
+	}
+
+	wallclock := n.Wallclock()
+	if wallclock == nil {
+		return 0, errors.New("wallclock is not available")
+	}
+
+	epoch := wallclock.Epochs().Current()
+
+	return dataColumnRetentionStartSlot(sp, phase0.Epoch(epoch.Number()))
+}
+
+func dataColumnRetentionStartSlot(sp *state.Spec, current phase0.Epoch) (phase0.Slot, error) {
+	if current < sp.FuluForkEpoch {
+		return 0, errors.New("fulu is not active")
+	}
+
+	retention := sp.MinEpochsForDataColumnSidecarsRequests
+	if retention == 0 {
+		retention = defaultMinEpochsForDataColumnSidecarsRequests
+	}
+
+	start := sp.FuluForkEpoch
+	if current > retention && current-retention > start {
+		start = current - retention
+	}
+
+	return phase0.Slot(start) * sp.SlotsPerEpoch, nil
+}
+
+// dataColumnCoverage tracks the distinct columns seen per slot.
+type dataColumnCoverage struct {
+	mu    sync.Mutex
+	slots map[phase0.Slot]map[uint64]struct{}
+}
+
+func newDataColumnCoverage() *dataColumnCoverage {
+	return &dataColumnCoverage{
+		slots: make(map[phase0.Slot]map[uint64]struct{}),
+	}
+}
+
+// add records that the column with the given index was seen for the slot.
+func (c *dataColumnCoverage) add(slot phase0.Slot, index uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.slots[slot]; !exists {
+		c.slots[slot] = make(map[uint64]struct{})
+	}
+
+	c.slots[slot][index] = struct{}{}
+}
+
+// take returns the columns seen for the slot and forgets the slot and every slot before it.
+func (c *dataColumnCoverage) take(slot phase0.Slot) map[uint64]struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	seen := c.slots[slot]
+
+	for s := range c.slots {
+		if s <= slot {
+			delete(c.slots, s)
+		}
+	}
+
+	return seen
+}
@@ -0,0 +1,185 @@
+package beacon
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCustodyGroups(t *testing.T) {
+	nodeID := enode.ID{0x01, 0x02, 0x03}
+
+	for _, count := range []uint64{0, 1, 4, 8, 64, NumberOfCustodyGroups} {
+		groups, err := GetCustodyGroups(nodeID, count)
+		require.NoError(t, err)
+
+		assert.Len(t, groups, int(count))
+		assert.IsIncreasing(t, groups)
+
+		for _, group := range groups {
+			assert.Less(t, group, uint64(NumberOfCustodyGroups))
+		}
+	}
+
+	// Smaller counts are a subset of larger ones.
+	small, err := GetCustodyGroups(nodeID, 4)
+	require.NoError(t, err)
+
+	large, err := GetCustodyGroups(nodeID, 8)
+	require.NoError(t, err)
+
+	assert.Subset(t, large, small)
+
+	// The node ID wraps around at the maximum value.
+	var maxID enode.ID
+	for i := range maxID {
+		maxID[i] = 0xff
+	}
+
+	groups, err := GetCustodyGroups(maxID, 8)
+	require.NoError(t, err)
+	assert.Len(t, groups, 8)
+
+	_, err = GetCustodyGroups(nodeID, NumberOfCustodyGroups+1)
+	assert.Error(t, err)
+}
+
+func TestComputeColumnsForCustodyGroup(t *testing.T) {
+	columns, err := ComputeColumnsForCustodyGroup(5)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{5}, columns)
+
+	_, err = ComputeColumnsForCustodyGroup(NumberOfCustodyGroups)
+	assert.Error(t, err)
+}
+
+func TestIsCustodyColumn(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	record := &enr.Record{}
+	require.NoError(t, enode.SignV4(record, key))
+
+	en, err := enode.New(enode.ValidSchemes, record)
+	require.NoError(t, err)
+
+	identity := &types.Identity{ENR: en.String()}
+
+	n := &node{identity: identity}
+
+	_, err = n.IsCustodyColumn(0)
+	assert.Error(t, err, "custody group count is required")
+
+	identity.Metadata.CustodyGroupCount = "4"
+
+	expected, err := GetCustodyColumns(en.ID(), 4)
+	require.NoError(t, err)
+
+	columns, err := n.CustodyColumns()
+	require.NoError(t, err)
+	assert.Equal(t, expected, columns)
+
+	for i := uint64(0); i < NumberOfColumns; i++ {
+		custody, err := n.IsCustodyColumn(i)
+		require.NoError(t, err)
+		assert.Equal(t, slices.Contains(expected, i), custody)
+	}
+}
+
+func TestDataColumnCoverage(t *testing.T) {
+	coverage := newDataColumnCoverage()
+
+	coverage.add(phase0.Slot(1), 1)
+	coverage.add(phase0.Slot(1), 1)
+	coverage.add(phase0.Slot(1), 2)
+	coverage.add(phase0.Slot(2), 3)
+
+	assert.Len(t, coverage.take(phase0.Slot(1)), 2)
+	assert.Empty(t, coverage.take(phase0.Slot(1)))
+	assert.Len(t, coverage.take(phase0.Slot(2)), 1)
+}
+
+func TestHandleRawDataColumnSidecarEvent(t *testing.T) {
+	n := &node{
+		log:    logging.New(nil),
+		config: &Config{Name: "node"},
+		broker: emission.NewEmitter(),
+	}
+
+	var sidecars []*DataColumnSidecarEvent
+
+	n.OnDataColumnSidecar(context.Background(), func(ctx context.Context, event *DataColumnSidecarEvent) error {
+		sidecars = append(sidecars, event)
+
+		return nil
+	})
+
+	err := n.handleRawEvent(context.Background(), &api.Event{
+		Topic: topicDataColumnSidecar,
+		Data:  []byte(`{"block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","index":"7","slot":"12","kzg_commitments":[]}`),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, sidecars, 1)
+	assert.Equal(t, phase0.Root{0x01}, sidecars[0].BlockRoot)
+	assert.Equal(t, uint64(7), sidecars[0].Index)
+	assert.Equal(t, phase0.Slot(12), sidecars[0].Slot)
+
+	// Unknown topics are passed through to the generic event handlers.
+	assert.NoError(t, n.handleRawEvent(context.Background(), &api.Event{Topic: "unknown", Data: []byte(`{}`)}))
+}
+
+func TestValidatorsCustodyRequirement(t *testing.T) {
+	sp := state.NewSpec(map[string]interface{}{})
+
+	assert.Equal(t, uint64(8), ValidatorsCustodyRequirement(&sp, 0))
+	assert.Equal(t, uint64(8), ValidatorsCustodyRequirement(&sp, 32_000_000_000*8))
+	assert.Equal(t, uint64(10), ValidatorsCustodyRequirement(&sp, 32_000_000_000*10+1))
+	assert.Equal(t, uint64(NumberOfCustodyGroups), ValidatorsCustodyRequirement(&sp, 2048_000_000_000*100))
+
+	sp = state.NewSpec(map[string]interface{}{
+		"VALIDATOR_CUSTODY_REQUIREMENT":        "4",
+		"BALANCE_PER_ADDITIONAL_CUSTODY_GROUP": "1000",
+	})
+
+	assert.Equal(t, uint64(4), ValidatorsCustodyRequirement(&sp, 0))
+	assert.Equal(t, uint64(5), ValidatorsCustodyRequirement(&sp, 5000))
+}
+
+func TestDataColumnRetentionStartSlot(t *testing.T) {
+	sp := state.NewSpec(map[string]interface{}{
+		"SLOTS_PER_EPOCH": "32",
+	})
+
+	_, err := dataColumnRetentionStartSlot(&sp, 10)
+	assert.Error(t, err, "fulu is not scheduled")
+
+	sp = state.NewSpec(map[string]interface{}{
+		"SLOTS_PER_EPOCH": "32",
+		"FULU_FORK_EPOCH": "100",
+		"MIN_EPOCHS_FOR_DATA_COLUMN_SIDECARS_REQUESTS": "50",
+	})
+
+	_, err = dataColumnRetentionStartSlot(&sp, 99)
+	assert.Error(t, err)
+
+	slot, err := dataColumnRetentionStartSlot(&sp, 120)
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Slot(100*32), slot)
+
+	slot, err = dataColumnRetentionStartSlot(&sp, 200)
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Slot(150*32), slot)
+}
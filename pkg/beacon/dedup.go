@@ -0,0 +1,72 @@
+package beacon
+
+import (
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// eventDeduplicationKey identifies a head or block event.
+type eventDeduplicationKey struct {
+	topic string
+	slot  phase0.Slot
+	root  phase0.Root
+}
+
+// eventDeduplicator remembers recently published head and block events so that duplicates, e.g. replayed by the
+// node after a reconnect, are not published again.
+type eventDeduplicator struct {
+	mu     sync.Mutex
+	seen   map[eventDeduplicationKey]time.Time
+	window time.Duration
+}
+
+func newEventDeduplicator(window time.Duration) *eventDeduplicator {
+	return &eventDeduplicator{
+		seen:   make(map[eventDeduplicationKey]time.Time),
+		window: window,
+	}
+}
+
+// duplicate records the event and returns true if the same event was already recorded within the window.
+func (d *eventDeduplicator) duplicate(topic string, slot phase0.Slot, root phase0.Root, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for key, at := range d.seen {
+		if now.Sub(at) > d.window {
+			delete(d.seen, key)
+		}
+	}
+
+	key := eventDeduplicationKey{topic: topic, slot: slot, root: root}
+
+	if _, exists := d.seen[key]; exists {
+		return true
+	}
+
+	d.seen[key] = now
+
+	return false
+}
+
+// isDuplicateEvent returns true if event deduplication is enabled and the head or block event was already
+// published within the deduplication window.
+func (n *node) isDuplicateEvent(topic string, slot phase0.Slot, root phase0.Root) bool {
+	if n.dedup == nil || !n.currentOptions().EventDeduplication.Enabled {
+		return false
+	}
+
+	if !n.dedup.duplicate(topic, slot, root, time.Now()) {
+		return false
+	}
+
+	n.log.
+		WithField("topic", topic).
+		WithField("slot", slot).
+		WithField("root", root.String()).
+		Debug("Dropping duplicate event")
+
+	return true
+}
@@ -0,0 +1,81 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDeduplicator(t *testing.T) {
+	d := newEventDeduplicator(time.Minute)
+	now := time.Now()
+
+	assert.False(t, d.duplicate(topicHead, 1, phase0.Root{0x01}, now))
+	assert.True(t, d.duplicate(topicHead, 1, phase0.Root{0x01}, now.Add(time.Second)))
+
+	// The same block on another topic, a different root and a different slot are not duplicates.
+	assert.False(t, d.duplicate(topicBlock, 1, phase0.Root{0x01}, now))
+	assert.False(t, d.duplicate(topicHead, 1, phase0.Root{0x02}, now))
+	assert.False(t, d.duplicate(topicHead, 2, phase0.Root{0x01}, now))
+
+	// Events are forgotten once the window has passed.
+	assert.False(t, d.duplicate(topicHead, 1, phase0.Root{0x01}, now.Add(2*time.Minute)))
+}
+
+func TestHandleHeadDeduplication(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		options := DefaultOptions()
+		if enabled {
+			options.EnableEventDeduplication()
+		}
+
+		n := &node{
+			log:     logging.New(nil),
+			broker:  emission.NewEmitter(),
+			options: options,
+			dedup:   newEventDeduplicator(options.EventDeduplication.Window.Duration),
+		}
+
+		slots := make(chan phase0.Slot, 3)
+
+		n.OnHead(context.Background(), func(ctx context.Context, event *v1.HeadEvent) error {
+			slots <- event.Slot
+
+			return nil
+		})
+
+		event := &v1.Event{Topic: topicHead, Data: &v1.HeadEvent{Slot: 10, Block: phase0.Root{0x01}}}
+
+		require.NoError(t, n.handleHead(context.Background(), event))
+		require.NoError(t, n.handleHead(context.Background(), event))
+
+		// The head of the next slot is published after any duplicates, so once it arrives every event has.
+		require.NoError(t, n.handleHead(context.Background(), &v1.Event{
+			Topic: topicHead,
+			Data:  &v1.HeadEvent{Slot: 11, Block: phase0.Root{0x02}},
+		}))
+
+		received := 0
+
+		for slot := range slots {
+			if slot == 11 {
+				break
+			}
+
+			received++
+		}
+
+		if enabled {
+			assert.Equal(t, 1, received)
+		} else {
+			assert.Equal(t, 2, received)
+		}
+	}
+}
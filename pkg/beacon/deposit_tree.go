@@ -0,0 +1,163 @@
+package beacon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
+)
+
+const (
+	// depositTreeDepth is the depth of the deposit contract's merkle tree.
+	depositTreeDepth = 32
+	// depositTreeRootHistory is the number of deposit counts the root is remembered for, so that the tree can
+	// be compared against snapshots that lag behind the head.
+	depositTreeRootHistory = 4096
+)
+
+// depositZeroHashes holds the roots of empty subtrees at every height of the deposit tree.
+var depositZeroHashes = func() [depositTreeDepth]phase0.Root {
+	var zeroHashes [depositTreeDepth]phase0.Root
+
+	for h := 1; h < depositTreeDepth; h++ {
+		zeroHashes[h] = hashPair(zeroHashes[h-1], zeroHashes[h-1])
+	}
+
+	return zeroHashes
+}()
+
+func hashPair(left, right phase0.Root) phase0.Root {
+	return sha256.Sum256(append(left[:], right[:]...))
+}
+
+// depositTree is the incremental merkle tree of the deposit contract. It only holds the branch needed to
+// append deposits and compute the root, the same way the deposit contract does.
+type depositTree struct {
+	branch [depositTreeDepth]phase0.Root
+	count  uint64
+	// roots holds the root of the tree for recent deposit counts.
+	roots map[uint64]phase0.Root
+}
+
+// newDepositTreeFromSnapshot restores the tree from an EIP-4881 deposit snapshot.
+func newDepositTreeFromSnapshot(snapshot *types.DepositSnapshot) (*depositTree, error) {
+	if snapshot.DepositCount >= 1<<depositTreeDepth {
+		return nil, fmt.Errorf("deposit count %d exceeds the capacity of the deposit tree", snapshot.DepositCount)
+	}
+
+	if len(snapshot.Finalized) != bits.OnesCount64(snapshot.DepositCount) {
+		return nil, fmt.Errorf("snapshot has %d finalized roots for %d deposits", len(snapshot.Finalized), snapshot.DepositCount)
+	}
+
+	t := &depositTree{
+		count: snapshot.DepositCount,
+		roots: make(map[uint64]phase0.Root),
+	}
+
+	// The finalized roots are the roots of the full subtrees covering the deposits, from the largest to the
+	// smallest. They are exactly the left siblings the deposit contract keeps in its branch.
+	i := 0
+
+	for h := depositTreeDepth - 1; h >= 0; h-- {
+		if snapshot.DepositCount&(1<<h) != 0 {
+			t.branch[h] = snapshot.Finalized[i]
+			i++
+		}
+	}
+
+	root := t.computeRoot()
+	if root != snapshot.DepositRoot {
+		return nil, fmt.Errorf("snapshot deposit root %#x does not match the root of its finalized deposits %#x", snapshot.DepositRoot, root)
+	}
+
+	t.roots[t.count] = root
+
+	return t, nil
+}
+
+// push appends the deposit data root to the tree.
+func (t *depositTree) push(leaf phase0.Root) error {
+	if t.count+1 >= 1<<depositTreeDepth {
+		return errors.New("deposit tree is full")
+	}
+
+	t.count++
+
+	node := leaf
+	size := t.count
+
+	for h := 0; h < depositTreeDepth; h++ {
+		if size&1 == 1 {
+			t.branch[h] = node
+
+			break
+		}
+
+		node = hashPair(t.branch[h], node)
+		size >>= 1
+	}
+
+	t.roots[t.count] = t.computeRoot()
+	delete(t.roots, t.count-depositTreeRootHistory)
+
+	return nil
+}
+
+// root returns the deposit root for the given deposit count, if it is still remembered.
+func (t *depositTree) root(count uint64) (phase0.Root, bool) {
+	root, exists := t.roots[count]
+
+	return root, exists
+}
+
+// computeRoot returns the root of the tree with the deposit count mixed in, as returned by the deposit
+// contract's get_deposit_root.
+func (t *depositTree) computeRoot() phase0.Root {
+	var node phase0.Root
+
+	size := t.count
+
+	for h := 0; h < depositTreeDepth; h++ {
+		if size&1 == 1 {
+			node = hashPair(t.branch[h], node)
+		} else {
+			node = hashPair(node, depositZeroHashes[h])
+		}
+
+		size >>= 1
+	}
+
+	var length phase0.Root
+
+	binary.LittleEndian.PutUint64(length[:], t.count)
+
+	return hashPair(node, length)
+}
+
+// isValidDepositProof returns true if the proof shows that the leaf is the deposit at the given index of the
+// deposit tree with the given root. The last element of the proof is the mixed in deposit count.
+func isValidDepositProof(leaf phase0.Root, proof [][]byte, index uint64, root phase0.Root) bool {
+	if len(proof) != depositTreeDepth+1 {
+		return false
+	}
+
+	value := leaf
+
+	for i, sibling := range proof {
+		if len(sibling) != len(phase0.Root{}) {
+			return false
+		}
+
+		if (index>>i)&1 == 1 {
+			value = hashPair(phase0.Root(sibling), value)
+		} else {
+			value = hashPair(value, phase0.Root(sibling))
+		}
+	}
+
+	return value == root
+}
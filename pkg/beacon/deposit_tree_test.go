@@ -0,0 +1,244 @@
+package beacon
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math/bits"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// depositProof builds the proof for the leaf at index in the tree of the given leaves.
+func depositProof(leaves []phase0.Root, index int) [][]byte {
+	proof := make([][]byte, 0, depositTreeDepth+1)
+	level := append([]phase0.Root{}, leaves...)
+
+	for h := 0; h < depositTreeDepth; h++ {
+		sibling := depositZeroHashes[h]
+		if i := index ^ 1; i < len(level) {
+			sibling = level[i]
+		}
+
+		proof = append(proof, append([]byte{}, sibling[:]...))
+
+		next := make([]phase0.Root, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			right := depositZeroHashes[h]
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+
+			next = append(next, hashPair(level[i], right))
+		}
+
+		level = next
+		index /= 2
+	}
+
+	var length phase0.Root
+
+	binary.LittleEndian.PutUint64(length[:], uint64(len(leaves)))
+
+	return append(proof, length[:])
+}
+
+// snapshotOf builds a deposit snapshot of the tree.
+func snapshotOf(t *depositTree) *types.DepositSnapshot {
+	finalized := make([]phase0.Root, 0, bits.OnesCount64(t.count))
+
+	for h := depositTreeDepth - 1; h >= 0; h-- {
+		if t.count&(1<<h) != 0 {
+			finalized = append(finalized, t.branch[h])
+		}
+	}
+
+	return &types.DepositSnapshot{
+		Finalized:    finalized,
+		DepositRoot:  t.computeRoot(),
+		DepositCount: t.count,
+	}
+}
+
+func TestDepositTree(t *testing.T) {
+	tree, err := newDepositTreeFromSnapshot(&types.DepositSnapshot{
+		DepositRoot: phase0.Root(mustDecodeHex(t, "d70a234731285c6804c2a4f56711ddb8c82c99740f207854891028af34e27e5e")),
+	})
+	require.NoError(t, err)
+
+	leaves := []phase0.Root{}
+
+	for i := 0; i < 11; i++ {
+		leaf := phase0.Root{byte(i + 1)}
+		leaves = append(leaves, leaf)
+
+		require.NoError(t, tree.push(leaf))
+
+		root, exists := tree.root(tree.count)
+		require.True(t, exists)
+
+		// Every deposit can be proven against the root after it was added.
+		assert.True(t, isValidDepositProof(leaf, depositProof(leaves, i), uint64(i), root))
+		assert.False(t, isValidDepositProof(leaf, depositProof(leaves, i), uint64(i+1), root))
+	}
+
+	restored, err := newDepositTreeFromSnapshot(snapshotOf(tree))
+	require.NoError(t, err)
+
+	require.NoError(t, tree.push(phase0.Root{0xff}))
+	require.NoError(t, restored.push(phase0.Root{0xff}))
+	assert.Equal(t, tree.computeRoot(), restored.computeRoot())
+
+	snapshot := snapshotOf(tree)
+	snapshot.DepositRoot = phase0.Root{0x01}
+
+	_, err = newDepositTreeFromSnapshot(snapshot)
+	require.Error(t, err)
+}
+
+func TestDepositTrackerAddDeposits(t *testing.T) {
+	d := newDepositTracker()
+	require.NoError(t, d.reset(&types.DepositSnapshot{
+		DepositRoot: phase0.Root(mustDecodeHex(t, "d70a234731285c6804c2a4f56711ddb8c82c99740f207854891028af34e27e5e")),
+	}))
+
+	deposits := make([]*phase0.Deposit, 0, 3)
+	leaves := make([]phase0.Root, 0, 3)
+
+	for i := 0; i < 3; i++ {
+		data := &phase0.DepositData{
+			PublicKey:             phase0.BLSPubKey{byte(i)},
+			WithdrawalCredentials: make([]byte, 32),
+			Amount:                32_000_000_000,
+		}
+
+		leaf, err := data.HashTreeRoot()
+		require.NoError(t, err)
+
+		leaves = append(leaves, leaf)
+		deposits = append(deposits, &phase0.Deposit{Data: data})
+	}
+
+	for i := range deposits {
+		deposits[i].Proof = depositProof(leaves, i)
+	}
+
+	expected, err := newDepositTreeFromSnapshot(&types.DepositSnapshot{
+		DepositRoot: phase0.Root(mustDecodeHex(t, "d70a234731285c6804c2a4f56711ddb8c82c99740f207854891028af34e27e5e")),
+	})
+	require.NoError(t, err)
+
+	for _, leaf := range leaves {
+		require.NoError(t, expected.push(leaf))
+	}
+
+	depositRoot := expected.computeRoot()
+
+	// A block whose deposits start after the tracked deposits means deposits were missed.
+	_, err = d.addDeposits(deposits[2:], depositRoot, 4)
+	require.Error(t, err)
+
+	// Deposits that do not match the deposit root are reported.
+	added, err := d.addDeposits(deposits[:1], phase0.Root{0x01}, 1)
+	require.ErrorIs(t, err, ErrInvalidDepositProof)
+	assert.Equal(t, 0, added)
+
+	added, err = d.addDeposits(deposits[:2], depositRoot, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, added)
+
+	// Deposits that are already tracked, e.g. in a block on another fork, are skipped.
+	added, err = d.addDeposits(deposits, depositRoot, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	status := d.status()
+	require.NotNil(t, status)
+	assert.Equal(t, uint64(3), status.DepositCount)
+	assert.Equal(t, depositRoot, status.DepositRoot)
+}
+
+func TestDepositTrackerAddProvenDeposits(t *testing.T) {
+	d := newDepositTracker()
+	require.NoError(t, d.reset(&types.DepositSnapshot{
+		DepositRoot: phase0.Root(mustDecodeHex(t, "d70a234731285c6804c2a4f56711ddb8c82c99740f207854891028af34e27e5e")),
+	}))
+
+	expected, err := newDepositTreeFromSnapshot(&types.DepositSnapshot{
+		DepositRoot: phase0.Root(mustDecodeHex(t, "d70a234731285c6804c2a4f56711ddb8c82c99740f207854891028af34e27e5e")),
+	})
+	require.NoError(t, err)
+
+	leaves := make([]phase0.Root, 0, 4)
+	datas := make([]*phase0.DepositData, 0, 4)
+	eth1Data := make([]*phase0.ETH1Data, 0, 4)
+
+	for i := 0; i < 4; i++ {
+		data := &phase0.DepositData{
+			PublicKey:             phase0.BLSPubKey{byte(i)},
+			WithdrawalCredentials: make([]byte, 32),
+			Amount:                32_000_000_000,
+		}
+
+		leaf, err := data.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, expected.push(leaf))
+
+		leaves = append(leaves, leaf)
+		datas = append(datas, data)
+		eth1Data = append(eth1Data, &phase0.ETH1Data{DepositRoot: expected.computeRoot(), DepositCount: uint64(i + 1)})
+	}
+
+	// deposits returns the deposits [from, to) proven against the eth1_data of the first count deposits.
+	deposits := func(from, to, count int) []*phase0.Deposit {
+		deposits := make([]*phase0.Deposit, 0, to-from)
+		for i := from; i < to; i++ {
+			deposits = append(deposits, &phase0.Deposit{Data: datas[i], Proof: depositProof(leaves[:count], i)})
+		}
+
+		return deposits
+	}
+
+	// Without a previous eth1_data the deposits are proven against the block's vote.
+	proven, err := d.addProvenDeposits(deposits(0, 2, 2), eth1Data[1], 16)
+	require.NoError(t, err)
+	assert.True(t, proven)
+	assert.Equal(t, uint64(2), d.status().DepositCount)
+
+	// A stale vote and eth1_data do not prove later deposits, nor skip them as already tracked.
+	proven, err = d.addProvenDeposits(deposits(2, 3, 3), eth1Data[0], 16)
+	require.NoError(t, err)
+	assert.False(t, proven)
+	assert.Equal(t, uint64(2), d.status().DepositCount)
+
+	// A vote that reached a majority proves them.
+	proven, err = d.addProvenDeposits(deposits(2, 3, 3), eth1Data[2], 16)
+	require.NoError(t, err)
+	assert.True(t, proven)
+	assert.Equal(t, uint64(3), d.status().DepositCount)
+
+	// A full block continues the tracked deposits, proven against the previous eth1_data whatever the vote.
+	proven, err = d.addProvenDeposits(deposits(3, 4, 4), eth1Data[0], 1)
+	require.NoError(t, err)
+	assert.False(t, proven)
+
+	d.setETH1Data(eth1Data[3])
+
+	proven, err = d.addProvenDeposits(deposits(3, 4, 4), eth1Data[0], 1)
+	require.NoError(t, err)
+	assert.True(t, proven)
+	assert.Equal(t, uint64(4), d.status().DepositCount)
+	assert.Equal(t, eth1Data[3].DepositRoot, d.status().DepositRoot)
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	data, err := hex.DecodeString(s)
+	require.NoError(t, err)
+
+	return data
+}
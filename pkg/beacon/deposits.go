@@ -0,0 +1,380 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
+	"github.com/ethpandaops/beacon/pkg/beacon/blockutil"
+	"github.com/ethpandaops/beacon/pkg/beacon/stateutil"
+)
+
+// TrackedDeposits is the running deposit count and root tracked from blocks.
+type TrackedDeposits struct {
+	// DepositCount is the number of deposits in the deposit tree.
+	DepositCount uint64
+	// DepositRoot is the root of the deposit tree.
+	DepositRoot phase0.Root
+	// DepositRequests is the number of Electra deposit requests seen in blocks since tracking started.
+	// Deposit requests are not part of the deposit tree.
+	DepositRequests uint64
+}
+
+// depositTracker maintains the deposit tree from the deposits included in blocks. It is seeded from the
+// node's deposit snapshot and cross-checked against later snapshots.
+type depositTracker struct {
+	mu       sync.Mutex
+	tree     *depositTree
+	requests uint64
+	// eth1Data is the eth1_data the last deposits were proven against.
+	eth1Data *phase0.ETH1Data
+}
+
+func newDepositTracker() *depositTracker {
+	return &depositTracker{}
+}
+
+// reset replaces the tree with one restored from the snapshot.
+func (d *depositTracker) reset(snapshot *types.DepositSnapshot) error {
+	tree, err := newDepositTreeFromSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.tree = tree
+
+	return nil
+}
+
+// initialized returns true if the tree has been seeded from a snapshot.
+func (d *depositTracker) initialized() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.tree != nil
+}
+
+// addDeposits appends the deposits of a block to the tree. depositRoot and depositIndex are the deposit root
+// of the eth1_data and the eth1_deposit_index of the block's post-state, so the block's deposits end at
+// depositIndex. Deposits that are already in the tree, e.g. those of a block on another fork, are skipped. It
+// returns the number of deposits added.
+func (d *depositTracker) addDeposits(deposits []*phase0.Deposit, depositRoot phase0.Root, depositIndex uint64) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.pushDeposits(deposits, depositRoot, depositIndex)
+}
+
+// addProvenDeposits adds the deposits of a block if they can be proven against the eth1_data the last deposits
+// were proven against or the block's eth1 vote. The eth1_data of a state only changes when a vote reaches a
+// majority, at most once per eth1 voting period, so one of the two is the eth1_data of the block's post-state
+// unless the majority was reached by a block without deposits. It returns false if the deposits cannot be
+// proven against either, so that the eth1_data has to be read from the block's state, and an error if proven
+// deposits cannot be added.
+func (d *depositTracker) addProvenDeposits(deposits []*phase0.Deposit, vote *phase0.ETH1Data, maxDeposits uint64) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.tree == nil || len(deposits) == 0 {
+		return true, nil
+	}
+
+	for _, eth1Data := range []*phase0.ETH1Data{d.eth1Data, vote} {
+		if eth1Data == nil {
+			continue
+		}
+
+		// A block includes every pending deposit up to MAX_DEPOSITS, so a block with fewer deposits ends at the
+		// deposit count of the eth1_data. A full block is assumed to continue the tracked deposits.
+		depositIndex := eth1Data.DepositCount
+		if uint64(len(deposits)) >= maxDeposits {
+			depositIndex = d.tree.count + uint64(len(deposits))
+		}
+
+		// Every deposit is proven, including those that are already tracked, as a stale eth1_data would
+		// otherwise place the block's deposits before the tracked ones and skip them.
+		if !depositsProven(deposits, eth1Data.DepositRoot, depositIndex) {
+			continue
+		}
+
+		d.eth1Data = eth1Data
+
+		if _, err := d.pushDeposits(deposits, eth1Data.DepositRoot, depositIndex); err != nil {
+			return true, err
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// depositsProven returns true if every deposit of a block whose deposits end at depositIndex is proven against
+// the deposit root.
+func depositsProven(deposits []*phase0.Deposit, depositRoot phase0.Root, depositIndex uint64) bool {
+	if depositIndex < uint64(len(deposits)) {
+		return false
+	}
+
+	first := depositIndex - uint64(len(deposits))
+
+	for i, deposit := range deposits {
+		if deposit == nil || deposit.Data == nil {
+			return false
+		}
+
+		leaf, err := deposit.Data.HashTreeRoot()
+		if err != nil {
+			return false
+		}
+
+		if !isValidDepositProof(leaf, deposit.Proof, first+uint64(i), depositRoot) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// setETH1Data records the eth1_data that deposits were proven against.
+func (d *depositTracker) setETH1Data(eth1Data *phase0.ETH1Data) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.eth1Data = eth1Data
+}
+
+// pushDeposits is addDeposits for callers that hold the lock.
+func (d *depositTracker) pushDeposits(deposits []*phase0.Deposit, depositRoot phase0.Root, depositIndex uint64) (int, error) {
+	if d.tree == nil || len(deposits) == 0 {
+		return 0, nil
+	}
+
+	if depositIndex < uint64(len(deposits)) {
+		return 0, fmt.Errorf("deposit index %d is lower than the %d deposits of the block", depositIndex, len(deposits))
+	}
+
+	first := depositIndex - uint64(len(deposits))
+	if first > d.tree.count {
+		return 0, fmt.Errorf("block starts at deposit %d but only %d deposits are tracked", first, d.tree.count)
+	}
+
+	added := 0
+
+	for i, deposit := range deposits {
+		index := first + uint64(i)
+		if index < d.tree.count {
+			continue
+		}
+
+		if deposit == nil || deposit.Data == nil {
+			return added, fmt.Errorf("deposit %d is empty", index)
+		}
+
+		leaf, err := deposit.Data.HashTreeRoot()
+		if err != nil {
+			return added, fmt.Errorf("failed to compute deposit data root: %w", err)
+		}
+
+		if !isValidDepositProof(leaf, deposit.Proof, index, depositRoot) {
+			return added, fmt.Errorf("%w: deposit %d", ErrInvalidDepositProof, index)
+		}
+
+		if err := d.tree.push(leaf); err != nil {
+			return added, err
+		}
+
+		added++
+	}
+
+	return added, nil
+}
+
+func (d *depositTracker) addRequests(count int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.requests += uint64(count)
+}
+
+// status returns the tracked deposits, or nil if the tree has not been seeded yet.
+func (d *depositTracker) status() *TrackedDeposits {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.tree == nil {
+		return nil
+	}
+
+	root, _ := d.tree.root(d.tree.count)
+
+	return &TrackedDeposits{
+		DepositCount:    d.tree.count,
+		DepositRoot:     root,
+		DepositRequests: d.requests,
+	}
+}
+
+// compare returns the tracked root for the snapshot's deposit count and whether it can be compared. Snapshots
+// that are ahead of the tree or too far behind it cannot be compared.
+func (d *depositTracker) compare(snapshot *types.DepositSnapshot) (phase0.Root, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.tree == nil {
+		return phase0.Root{}, false
+	}
+
+	return d.tree.root(snapshot.DepositCount)
+}
+
+func (n *node) TrackedDeposits() *TrackedDeposits {
+	if n.deposits == nil {
+		return nil
+	}
+
+	return n.deposits.status()
+}
+
+func (n *node) subscribeDepositTracking(ctx context.Context) {
+	n.OnBlock(ctx, func(ctx context.Context, event *v1.BlockEvent) error {
+		if !n.deposits.initialized() {
+			if err := n.checkDepositSnapshot(ctx); err != nil {
+				return err
+			}
+		}
+
+		return n.trackBlockDeposits(ctx, fmt.Sprintf("%#x", event.Block))
+	})
+
+	n.OnFinalityCheckpointUpdated(ctx, func(ctx context.Context, event *FinalityCheckpointUpdated) error {
+		return n.checkDepositSnapshot(ctx)
+	})
+}
+
+// trackBlockDeposits adds the deposits and counts the deposit requests of the block. The block is fetched
+// raw as go-eth2-client cannot decode blocks that carry execution requests yet.
+func (n *node) trackBlockDeposits(ctx context.Context, blockID string) error {
+	data, err := n.FetchRawBlock(ctx, blockID, blockutil.ContentTypeJSON)
+	if err != nil {
+		return fmt.Errorf("failed to fetch block to track deposits: %w", err)
+	}
+
+	_, requests, err := blockutil.ExecutionRequestCountsFromRawBlock(data)
+	if err != nil {
+		return err
+	}
+
+	if requests != nil {
+		n.deposits.addRequests(requests.Deposits)
+	}
+
+	block, err := blockutil.ParseRawBlock(data, blockutil.ContentTypeJSON, spec.DataVersionUnknown)
+	if err != nil {
+		if requests != nil {
+			// Deposits of blocks that carry execution requests cannot be decoded yet.
+			n.log.WithError(err).Debug("Failed to decode block to track deposits")
+
+			return nil
+		}
+
+		return err
+	}
+
+	deposits, err := block.Deposits()
+	if err != nil {
+		return err
+	}
+
+	if len(deposits) == 0 {
+		return nil
+	}
+
+	// The deposits are proven against the eth1_data after the block's eth1 vote has been processed, which is
+	// the eth1_data of the block's post-state: the parent state's eth1_data, unless the block's vote reached
+	// a majority. The state is only fetched if the deposits cannot be proven without it.
+	vote, err := block.ETH1Data()
+	if err != nil {
+		return err
+	}
+
+	sp, err := n.Spec()
+	if err != nil {
+		return err
+	}
+
+	proven, err := n.deposits.addProvenDeposits(deposits, vote, sp.MaxDeposits)
+	if err != nil {
+		return fmt.Errorf("failed to track deposits of block %s: %w", blockID, err)
+	}
+
+	if proven {
+		return nil
+	}
+
+	stateRoot, err := block.StateRoot()
+	if err != nil {
+		return err
+	}
+
+	var (
+		eth1Data     *phase0.ETH1Data
+		depositIndex uint64
+	)
+
+	err = n.streamRawBeaconState(ctx, fmt.Sprintf("%#x", stateRoot), func(r io.Reader, layout stateutil.Layout) error {
+		eth1Data, depositIndex, err = stateutil.ReadETH1Data(r, layout)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch eth1 data of the block's state: %w", err)
+	}
+
+	if _, err := n.deposits.addDeposits(deposits, eth1Data.DepositRoot, depositIndex); err != nil {
+		return fmt.Errorf("failed to track deposits of block %s: %w", blockID, err)
+	}
+
+	n.deposits.setETH1Data(eth1Data)
+
+	return nil
+}
+
+// checkDepositSnapshot compares the tracked deposits against the node's deposit snapshot. The tracker is
+// seeded from the snapshot if it has not been yet, or if the snapshot is ahead of it, e.g. because blocks were
+// missed. A divergence is reported with a DepositDivergenceEvent and an error, and the tracked tree is kept so
+// that it can be inspected.
+func (n *node) checkDepositSnapshot(ctx context.Context) error {
+	snapshot, err := n.FetchDepositSnapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch deposit snapshot: %w", err)
+	}
+
+	status := n.deposits.status()
+	if status == nil || snapshot.DepositCount > status.DepositCount {
+		return n.deposits.reset(snapshot)
+	}
+
+	root, comparable := n.deposits.compare(snapshot)
+	if !comparable || root == snapshot.DepositRoot {
+		return nil
+	}
+
+	n.publishDepositDivergence(ctx, &DepositDivergenceEvent{
+		DepositCount:  snapshot.DepositCount,
+		SnapshotRoot:  snapshot.DepositRoot,
+		TrackedRoot:   root,
+		TrackedCount:  status.DepositCount,
+		SnapshotBlock: snapshot.ExecutionBlockHash,
+	})
+
+	return fmt.Errorf("tracked deposit root %#x does not match the snapshot's deposit root %#x at deposit count %d", root, snapshot.DepositRoot, snapshot.DepositCount)
+}
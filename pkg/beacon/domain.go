@@ -0,0 +1,35 @@
+package beacon
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// ForkDigest returns the fork digest of the fork that is active at the given epoch.
+func (n *node) ForkDigest(epoch phase0.Epoch) (phase0.ForkDigest, error) {
+	sp, err := n.Spec()
+	if err != nil {
+		return phase0.ForkDigest{}, err
+	}
+
+	root, err := n.GenesisValidatorsRoot()
+	if err != nil {
+		return phase0.ForkDigest{}, err
+	}
+
+	return sp.ForkDigest(epoch, root)
+}
+
+// Domain returns the signing domain for the given domain type at the given epoch.
+func (n *node) Domain(domainType phase0.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
+	sp, err := n.Spec()
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+
+	root, err := n.GenesisValidatorsRoot()
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+
+	return sp.Domain(domainType, epoch, root)
+}
@@ -0,0 +1,189 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// NonFinalizedEpochPolicy controls how ForEachEpoch handles epochs that are not finalized yet.
+type NonFinalizedEpochPolicy string
+
+const (
+	// NonFinalizedEpochPolicySkip stops iterating at the first epoch that is not finalized yet.
+	NonFinalizedEpochPolicySkip NonFinalizedEpochPolicy = "skip"
+	// NonFinalizedEpochPolicyWait waits for each epoch to be finalized before visiting it.
+	NonFinalizedEpochPolicyWait NonFinalizedEpochPolicy = "wait"
+	// NonFinalizedEpochPolicyInclude visits epochs that are not finalized yet, with EpochData.Finalized set
+	// to false.
+	NonFinalizedEpochPolicyInclude NonFinalizedEpochPolicy = "include"
+)
+
+// EpochData holds the blocks and proposer duties of an epoch visited by ForEachEpoch.
+type EpochData struct {
+	Epoch phase0.Epoch
+	// Finalized is true if every slot of the epoch is finalized.
+	Finalized bool
+	// Blocks holds the canonical block of every slot of the epoch, keyed by slot. Empty slots are omitted.
+	Blocks         map[phase0.Slot]*spec.VersionedSignedBeaconBlock
+	ProposerDuties []*v1.ProposerDuty
+}
+
+// ForEachEpoch calls fn for every epoch in the inclusive range [fromEpoch, toEpoch], in order, with the blocks
+// and proposer duties of the epoch. Epochs that are not finalized yet are handled according to
+// Options.EpochIterator. Iteration stops at the first error returned by fn.
+func (n *node) ForEachEpoch(ctx context.Context, fromEpoch, toEpoch phase0.Epoch, fn func(ctx context.Context, epoch *EpochData) error) error {
+	if toEpoch < fromEpoch {
+		return fmt.Errorf("invalid epoch range: from epoch %d is after to epoch %d", fromEpoch, toEpoch)
+	}
+
+	sp, err := n.Spec()
+	if err != nil {
+		return err
+	}
+
+	opts := n.currentOptions().EpochIterator
+
+	finalized, err := n.finalizedEpoch(ctx)
+	if err != nil {
+		return err
+	}
+
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		// An epoch is only fully finalized once a later epoch is the finalized checkpoint.
+		if epoch >= finalized {
+			if finalized, err = n.finalizedEpoch(ctx); err != nil {
+				return err
+			}
+		}
+
+		if epoch >= finalized {
+			switch opts.NonFinalized {
+			case NonFinalizedEpochPolicyInclude:
+			case NonFinalizedEpochPolicyWait:
+				if finalized, err = n.waitForFinalizedEpoch(ctx, epoch, opts.PollInterval.Duration); err != nil {
+					return err
+				}
+			default:
+				n.log.WithField("epoch", epoch).Debug("Epoch is not finalized yet, stopping epoch iteration")
+
+				return nil
+			}
+		}
+
+		data, err := n.fetchEpochData(ctx, epoch, sp.SlotsPerEpoch, opts.Concurrency)
+		if err != nil {
+			return err
+		}
+
+		data.Finalized = epoch < finalized
+
+		if err := fn(ctx, data); err != nil {
+			return err
+		}
+
+		// Guard against overflow when toEpoch is the maximum epoch.
+		if epoch == toEpoch {
+			break
+		}
+	}
+
+	return nil
+}
+
+// finalizedEpoch fetches the epoch of the node's finalized checkpoint.
+func (n *node) finalizedEpoch(ctx context.Context) (phase0.Epoch, error) {
+	finality, err := n.FetchFinality(ctx, "head")
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch finality: %w", err)
+	}
+
+	if finality.Finalized == nil {
+		return 0, errors.New("finality has no finalized checkpoint")
+	}
+
+	return finality.Finalized.Epoch, nil
+}
+
+// waitForFinalizedEpoch polls the node's finality until the given epoch is finalized, returning the epoch of
+// the finalized checkpoint.
+func (n *node) waitForFinalizedEpoch(ctx context.Context, epoch phase0.Epoch, interval time.Duration) (phase0.Epoch, error) {
+	if interval <= 0 {
+		interval = DefaultEpochIteratorOptions().PollInterval.Duration
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	n.log.WithField("epoch", epoch).Debug("Waiting for epoch to be finalized")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-ticker.C:
+		}
+
+		finalized, err := n.finalizedEpoch(ctx)
+		if err != nil {
+			n.log.WithError(err).Debug("Failed to fetch finality while waiting for epoch to be finalized")
+
+			continue
+		}
+
+		if epoch < finalized {
+			return finalized, nil
+		}
+	}
+}
+
+// fetchEpochData fetches the blocks and proposer duties of the given epoch.
+func (n *node) fetchEpochData(ctx context.Context, epoch phase0.Epoch, slotsPerEpoch phase0.Slot, concurrency int) (*EpochData, error) {
+	start := phase0.Slot(epoch) * slotsPerEpoch
+
+	ids := make([]string, 0, slotsPerEpoch)
+	for slot := start; slot < start+slotsPerEpoch; slot++ {
+		ids = append(ids, fmt.Sprintf("%d", slot))
+	}
+
+	results, err := n.FetchBlocks(ctx, ids, concurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make(map[phase0.Slot]*spec.VersionedSignedBeaconBlock, len(results))
+
+	for i, id := range ids {
+		result, exists := results[id]
+		if !exists {
+			continue
+		}
+
+		if result.Err != nil {
+			if errors.Is(result.Err, ErrBlockNotFound) {
+				// Empty slot.
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to fetch block for slot %s: %w", id, result.Err)
+		}
+
+		blocks[start+phase0.Slot(i)] = result.Block
+	}
+
+	duties, err := n.FetchProposerDuties(ctx, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch proposer duties for epoch %d: %w", epoch, err)
+	}
+
+	return &EpochData{
+		Epoch:          epoch,
+		Blocks:         blocks,
+		ProposerDuties: duties,
+	}, nil
+}
@@ -0,0 +1,118 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/ethpandaops/beacon/pkg/human"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEpochIteratorNode(client *fakeClient, policy NonFinalizedEpochPolicy) *node {
+	options := DefaultOptions()
+	options.EpochIterator.NonFinalized = policy
+	options.EpochIterator.PollInterval = human.Duration{Duration: 10 * time.Millisecond}
+
+	return &node{
+		log:     logging.New(nil),
+		broker:  emission.NewEmitter(),
+		options: options,
+		client:  client,
+		spec:    &state.Spec{SlotsPerEpoch: 4},
+	}
+}
+
+func TestForEachEpoch(t *testing.T) {
+	client := &fakeClient{
+		finalized: 2,
+		blocks:    map[string]*spec.VersionedSignedBeaconBlock{},
+		duties:    map[phase0.Epoch][]*v1.ProposerDuty{},
+	}
+
+	for _, slot := range []phase0.Slot{0, 2, 4, 5, 6, 7, 9} {
+		client.blocks[fmt.Sprintf("%d", slot)] = phase0Block(slot)
+	}
+
+	for epoch := phase0.Epoch(0); epoch <= 3; epoch++ {
+		client.duties[epoch] = []*v1.ProposerDuty{{Slot: phase0.Slot(epoch) * 4}}
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		n := newEpochIteratorNode(client, NonFinalizedEpochPolicySkip)
+
+		visited := []*EpochData{}
+
+		require.NoError(t, n.ForEachEpoch(context.Background(), 0, 3, func(ctx context.Context, epoch *EpochData) error {
+			visited = append(visited, epoch)
+
+			return nil
+		}))
+
+		require.Len(t, visited, 2)
+
+		assert.Equal(t, phase0.Epoch(0), visited[0].Epoch)
+		assert.True(t, visited[0].Finalized)
+		assert.Len(t, visited[0].Blocks, 2)
+		assert.Contains(t, visited[0].Blocks, phase0.Slot(2))
+		assert.Len(t, visited[0].ProposerDuties, 1)
+
+		assert.Equal(t, phase0.Epoch(1), visited[1].Epoch)
+		assert.Len(t, visited[1].Blocks, 4)
+	})
+
+	t.Run("include", func(t *testing.T) {
+		n := newEpochIteratorNode(client, NonFinalizedEpochPolicyInclude)
+
+		visited := []*EpochData{}
+
+		require.NoError(t, n.ForEachEpoch(context.Background(), 1, 3, func(ctx context.Context, epoch *EpochData) error {
+			visited = append(visited, epoch)
+
+			return nil
+		}))
+
+		require.Len(t, visited, 3)
+		assert.True(t, visited[0].Finalized)
+		assert.False(t, visited[1].Finalized)
+		assert.Len(t, visited[1].Blocks, 1)
+		assert.False(t, visited[2].Finalized)
+		assert.Empty(t, visited[2].Blocks)
+	})
+
+	t.Run("wait", func(t *testing.T) {
+		n := newEpochIteratorNode(client, NonFinalizedEpochPolicyWait)
+
+		visited := []phase0.Epoch{}
+
+		require.NoError(t, n.ForEachEpoch(context.Background(), 1, 2, func(ctx context.Context, epoch *EpochData) error {
+			visited = append(visited, epoch.Epoch)
+
+			assert.True(t, epoch.Finalized)
+
+			if epoch.Epoch == 1 {
+				go client.setFinalized(3)
+			}
+
+			return nil
+		}))
+
+		assert.Equal(t, []phase0.Epoch{1, 2}, visited)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		n := newEpochIteratorNode(client, NonFinalizedEpochPolicySkip)
+
+		require.Error(t, n.ForEachEpoch(context.Background(), 3, 1, func(ctx context.Context, epoch *EpochData) error {
+			return nil
+		}))
+	})
+}
@@ -0,0 +1,327 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	eapi "github.com/attestantio/go-eth2-client/api"
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	// equivocationWindowEpochs is the number of epochs behind the most recent attestation target that are tracked.
+	equivocationWindowEpochs = 8
+)
+
+// SlashingType is the type of offence described by a PossibleSlashingEvent.
+type SlashingType string
+
+const (
+	// SlashingTypeDoubleProposal is a proposer signing two different blocks for the same slot.
+	SlashingTypeDoubleProposal SlashingType = "double_proposal"
+	// SlashingTypeDoubleVote is an attester signing two different attestations for the same target epoch.
+	SlashingTypeDoubleVote SlashingType = "double_vote"
+	// SlashingTypeSurroundVote is an attester signing an attestation that surrounds (or is surrounded by) another.
+	SlashingTypeSurroundVote SlashingType = "surround_vote"
+)
+
+// equivocationDetector keeps a sliding window of proposals and votes so that conflicting messages can be detected.
+type equivocationDetector struct {
+	mu sync.Mutex
+
+	// proposals holds every block root seen per slot.
+	proposals    map[phase0.Slot][]phase0.Root
+	latestSlot   phase0.Slot
+	slotWindow   phase0.Slot
+	votes        map[phase0.ValidatorIndex][]*phase0.AttestationData
+	latestTarget phase0.Epoch
+
+	committeesMu sync.Mutex
+	committees   map[phase0.Epoch]map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex
+}
+
+func newEquivocationDetector(slotsPerEpoch phase0.Slot) *equivocationDetector {
+	return &equivocationDetector{
+		proposals:  make(map[phase0.Slot][]phase0.Root),
+		slotWindow: slotsPerEpoch * equivocationWindowEpochs,
+		votes:      make(map[phase0.ValidatorIndex][]*phase0.AttestationData),
+		committees: make(map[phase0.Epoch]map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex),
+	}
+}
+
+// addProposal records the block root for the slot and returns the roots previously seen for the same slot.
+// An empty result means the block does not conflict with anything in the window.
+func (e *equivocationDetector) addProposal(slot phase0.Slot, root phase0.Root) []phase0.Root {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.latestSlot > e.slotWindow && slot < e.latestSlot-e.slotWindow {
+		return nil
+	}
+
+	existing := e.proposals[slot]
+	for _, r := range existing {
+		if r == root {
+			return nil
+		}
+	}
+
+	e.proposals[slot] = append(existing, root)
+
+	if slot > e.latestSlot {
+		e.latestSlot = slot
+
+		if e.latestSlot > e.slotWindow {
+			cutoff := e.latestSlot - e.slotWindow
+
+			for s := range e.proposals {
+				if s < cutoff {
+					delete(e.proposals, s)
+				}
+			}
+		}
+	}
+
+	return existing
+}
+
+// addVote records the attestation data for the validator and returns any slashable conflicts with previously
+// seen votes by the same validator.
+func (e *equivocationDetector) addVote(index phase0.ValidatorIndex, data *phase0.AttestationData) map[SlashingType][]*phase0.AttestationData {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if data == nil || data.Source == nil || data.Target == nil {
+		return nil
+	}
+
+	if e.latestTarget > equivocationWindowEpochs && data.Target.Epoch < e.latestTarget-equivocationWindowEpochs {
+		return nil
+	}
+
+	conflicts := make(map[SlashingType][]*phase0.AttestationData)
+
+	existing := e.votes[index]
+	for _, vote := range existing {
+		if attestationDataEqual(vote, data) {
+			return nil
+		}
+
+		if isDoubleVote(vote, data) {
+			conflicts[SlashingTypeDoubleVote] = append(conflicts[SlashingTypeDoubleVote], vote)
+		}
+
+		if isSurroundVote(vote, data) || isSurroundVote(data, vote) {
+			conflicts[SlashingTypeSurroundVote] = append(conflicts[SlashingTypeSurroundVote], vote)
+		}
+	}
+
+	e.votes[index] = append(existing, data)
+
+	if data.Target.Epoch > e.latestTarget {
+		e.latestTarget = data.Target.Epoch
+
+		e.pruneVotes()
+	}
+
+	return conflicts
+}
+
+func (e *equivocationDetector) pruneVotes() {
+	if e.latestTarget <= equivocationWindowEpochs {
+		return
+	}
+
+	cutoff := e.latestTarget - equivocationWindowEpochs
+
+	for index, votes := range e.votes {
+		kept := votes[:0]
+
+		for _, vote := range votes {
+			if vote.Target.Epoch >= cutoff {
+				kept = append(kept, vote)
+			}
+		}
+
+		if len(kept) == 0 {
+			delete(e.votes, index)
+
+			continue
+		}
+
+		e.votes[index] = kept
+	}
+}
+
+// isDoubleVote returns true if the two distinct attestations share the same target epoch.
+func isDoubleVote(a, b *phase0.AttestationData) bool {
+	return a.Target.Epoch == b.Target.Epoch && !attestationDataEqual(a, b)
+}
+
+// isSurroundVote returns true if attestation a surrounds attestation b.
+func isSurroundVote(a, b *phase0.AttestationData) bool {
+	return a.Source.Epoch < b.Source.Epoch && b.Target.Epoch < a.Target.Epoch
+}
+
+func attestationDataEqual(a, b *phase0.AttestationData) bool {
+	return a.Slot == b.Slot &&
+		a.Index == b.Index &&
+		a.BeaconBlockRoot == b.BeaconBlockRoot &&
+		a.Source.Epoch == b.Source.Epoch &&
+		a.Source.Root == b.Source.Root &&
+		a.Target.Epoch == b.Target.Epoch &&
+		a.Target.Root == b.Target.Root
+}
+
+func (n *node) subscribeEquivocationDetection(ctx context.Context) {
+	sp, err := n.Spec()
+	if err != nil {
+		n.log.WithError(err).Error("Failed to start equivocation detection")
+
+		return
+	}
+
+	n.equivocations = newEquivocationDetector(sp.SlotsPerEpoch)
+
+	n.OnBlock(ctx, n.detectDoubleProposal)
+	n.OnAttestation(ctx, n.detectAttestationEquivocation)
+}
+
+func (n *node) detectDoubleProposal(ctx context.Context, event *v1.BlockEvent) error {
+	existing := n.equivocations.addProposal(event.Slot, event.Block)
+	if len(existing) == 0 {
+		return nil
+	}
+
+	proposer, err := n.fetchProposerIndex(ctx, event.Block)
+	if err != nil {
+		return err
+	}
+
+	for _, root := range existing {
+		other, err := n.fetchProposerIndex(ctx, root)
+		if err != nil {
+			return err
+		}
+
+		if other != proposer {
+			continue
+		}
+
+		n.publishPossibleSlashing(ctx, &PossibleSlashingEvent{
+			Type:           SlashingTypeDoubleProposal,
+			ValidatorIndex: proposer,
+			Slot:           event.Slot,
+			Roots:          []phase0.Root{root, event.Block},
+		})
+	}
+
+	return nil
+}
+
+func (n *node) fetchProposerIndex(ctx context.Context, root phase0.Root) (phase0.ValidatorIndex, error) {
+	header, err := n.FetchBeaconBlockHeader(ctx, &eapi.BeaconBlockHeaderOpts{
+		Block: fmt.Sprintf("%#x", root),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch block header %#x: %w", root, err)
+	}
+
+	if header.Header == nil || header.Header.Message == nil {
+		return 0, fmt.Errorf("block header %#x is missing its message", root)
+	}
+
+	return header.Header.Message.ProposerIndex, nil
+}
+
+func (n *node) detectAttestationEquivocation(ctx context.Context, attestation *phase0.Attestation) error {
+	if attestation.Data == nil {
+		return nil
+	}
+
+	indices, err := n.attestingIndices(ctx, attestation)
+	if err != nil {
+		return err
+	}
+
+	for _, index := range indices {
+		for slashingType, votes := range n.equivocations.addVote(index, attestation.Data) {
+			for _, vote := range votes {
+				n.publishPossibleSlashing(ctx, &PossibleSlashingEvent{
+					Type:           slashingType,
+					ValidatorIndex: index,
+					Slot:           attestation.Data.Slot,
+					Attestations:   []*phase0.AttestationData{vote, attestation.Data},
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// attestingIndices resolves the aggregation bits of the attestation to validator indices.
+func (n *node) attestingIndices(ctx context.Context, attestation *phase0.Attestation) ([]phase0.ValidatorIndex, error) {
+	sp, err := n.Spec()
+	if err != nil {
+		return nil, err
+	}
+
+	committees, err := n.equivocationCommittees(ctx, phase0.Epoch(attestation.Data.Slot/sp.SlotsPerEpoch))
+	if err != nil {
+		return nil, err
+	}
+
+	committee, exists := committees[attestation.Data.Slot][attestation.Data.Index]
+	if !exists {
+		return nil, fmt.Errorf("no committee %d found for slot %d", attestation.Data.Index, attestation.Data.Slot)
+	}
+
+	return GetAttestingIndicesFromAttestation(attestation, committee)
+}
+
+func (n *node) equivocationCommittees(ctx context.Context, epoch phase0.Epoch) (map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex, error) {
+	e := n.equivocations
+
+	e.committeesMu.Lock()
+	committees, exists := e.committees[epoch]
+	e.committeesMu.Unlock()
+
+	if exists {
+		return committees, nil
+	}
+
+	// The lock is not held while fetching, so that attestations of epochs that are already cached are not
+	// held up. Concurrent misses for the same epoch share one fetch.
+	return singleFlight(ctx, &n.inflight, fmt.Sprintf("equivocation_committees:%d", epoch), func(ctx context.Context) (map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex, error) {
+		rsp, err := n.FetchBeaconCommittees(ctx, "head", &epoch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch beacon committees for epoch %d: %w", epoch, err)
+		}
+
+		committees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+
+		for _, committee := range rsp {
+			if _, exists := committees[committee.Slot]; !exists {
+				committees[committee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+			}
+
+			committees[committee.Slot][committee.Index] = committee.Validators
+		}
+
+		e.committeesMu.Lock()
+		defer e.committeesMu.Unlock()
+
+		e.committees[epoch] = committees
+
+		for ep := range e.committees {
+			if ep+equivocationWindowEpochs < epoch {
+				delete(e.committees, ep)
+			}
+		}
+
+		return committees, nil
+	})
+}
@@ -0,0 +1,147 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func attestationData(source, target phase0.Epoch, root byte) *phase0.AttestationData {
+	return &phase0.AttestationData{
+		Slot:            phase0.Slot(target * 32),
+		BeaconBlockRoot: phase0.Root{root},
+		Source:          &phase0.Checkpoint{Epoch: source},
+		Target:          &phase0.Checkpoint{Epoch: target},
+	}
+}
+
+func TestEquivocationDetectorAddVote(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous *phase0.AttestationData
+		current  *phase0.AttestationData
+		expected []SlashingType
+	}{
+		{
+			name:     "identical vote",
+			previous: attestationData(1, 2, 0x01),
+			current:  attestationData(1, 2, 0x01),
+			expected: nil,
+		},
+		{
+			name:     "consecutive votes",
+			previous: attestationData(1, 2, 0x01),
+			current:  attestationData(2, 3, 0x01),
+			expected: nil,
+		},
+		{
+			name:     "double vote",
+			previous: attestationData(1, 2, 0x01),
+			current:  attestationData(1, 2, 0x02),
+			expected: []SlashingType{SlashingTypeDoubleVote},
+		},
+		{
+			name:     "surrounding vote",
+			previous: attestationData(2, 3, 0x01),
+			current:  attestationData(1, 4, 0x01),
+			expected: []SlashingType{SlashingTypeSurroundVote},
+		},
+		{
+			name:     "surrounded vote",
+			previous: attestationData(1, 4, 0x01),
+			current:  attestationData(2, 3, 0x01),
+			expected: []SlashingType{SlashingTypeSurroundVote},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			detector := newEquivocationDetector(32)
+
+			assert.Empty(t, detector.addVote(1, test.previous))
+
+			conflicts := detector.addVote(1, test.current)
+
+			found := make([]SlashingType, 0, len(conflicts))
+			for slashingType := range conflicts {
+				found = append(found, slashingType)
+			}
+
+			assert.ElementsMatch(t, test.expected, found)
+
+			// Votes from other validators never conflict.
+			assert.Empty(t, detector.addVote(2, test.current))
+		})
+	}
+}
+
+func TestEquivocationDetectorAddProposal(t *testing.T) {
+	detector := newEquivocationDetector(32)
+
+	assert.Empty(t, detector.addProposal(10, phase0.Root{0x01}))
+	assert.Empty(t, detector.addProposal(10, phase0.Root{0x01}))
+	assert.Equal(t, []phase0.Root{{0x01}}, detector.addProposal(10, phase0.Root{0x02}))
+
+	// Slots that have fallen outside the window are ignored.
+	assert.Empty(t, detector.addProposal(1000, phase0.Root{0x03}))
+	assert.Empty(t, detector.addProposal(10, phase0.Root{0x04}))
+}
+
+func TestEquivocationCommitteesDoesNotBlockCachedEpochs(t *testing.T) {
+	blocked := make(chan struct{})
+	started := make(chan struct{}, 1)
+
+	n := &node{
+		log:     logging.New(nil),
+		options: DefaultOptions(),
+		broker:  emission.NewEmitter(),
+		client: &fakeClient{
+			committees: map[phase0.Epoch][]*v1.BeaconCommittee{
+				1: {{Slot: 32, Index: 0, Validators: []phase0.ValidatorIndex{1}}},
+				2: {{Slot: 64, Index: 0, Validators: []phase0.ValidatorIndex{2}}},
+			},
+			blockedCommittees: map[phase0.Epoch]chan struct{}{2: blocked},
+			committeesStarted: started,
+		},
+		equivocations: newEquivocationDetector(32),
+	}
+
+	committees, err := n.equivocationCommittees(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []phase0.ValidatorIndex{1}, committees[32][0])
+
+	fetched := make(chan error, 1)
+
+	go func() {
+		_, err := n.equivocationCommittees(context.Background(), 2)
+		fetched <- err
+	}()
+
+	<-started
+
+	// The cached epoch is served while the fetch of epoch 2 is in flight.
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		_, err := n.equivocationCommittees(context.Background(), 1)
+		assert.NoError(t, err)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("cached committees were held up by an in flight fetch")
+	}
+
+	close(blocked)
+	require.NoError(t, <-fetched)
+}
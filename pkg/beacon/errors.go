@@ -0,0 +1,49 @@
+package beacon
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	eapi "github.com/attestantio/go-eth2-client/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+)
+
+var (
+	// ErrGenesisNotAvailable is returned when the genesis is not cached and could not be fetched from the node.
+	ErrGenesisNotAvailable = errors.New("genesis is not available")
+	// ErrBlockNotFound is returned when the requested block does not exist on the node.
+	ErrBlockNotFound = errors.New("block not found")
+	// ErrStateNotFound is returned when the requested state does not exist on the node.
+	ErrStateNotFound = errors.New("state not found")
+	// ErrValidatorNotFound is returned when the requested validator does not exist at the given state.
+	ErrValidatorNotFound = errors.New("validator not found")
+	// ErrTooManyRequests is returned when the node kept throttling the request with a 429.
+	ErrTooManyRequests = api.ErrTooManyRequests
+	// ErrResponseTooLarge is returned when a raw response exceeds the configured maximum response size.
+	ErrResponseTooLarge = api.ErrResponseTooLarge
+	// ErrNotSupported is returned when the node does not implement the requested endpoint.
+	ErrNotSupported = errors.New("endpoint not supported by node")
+	// ErrNoQuorum is returned when not enough nodes in a NodePool agree on an answer.
+	ErrNoQuorum = errors.New("nodes did not reach quorum")
+	// ErrRootMismatch is returned when a root computed from fetched data does not match the expected root.
+	ErrRootMismatch = errors.New("root mismatch")
+	// ErrNodeOptimistic is returned by health checks when the node is optimistically synced and optimistic
+	// nodes are treated as unhealthy.
+	ErrNodeOptimistic = errors.New("node is optimistically synced")
+	// ErrInvalidStateTransition is returned when Start or Stop is called in a state they cannot be called in.
+	ErrInvalidStateTransition = errors.New("invalid node state transition")
+	// ErrInvalidDepositProof is returned when the proof of a deposit in a block does not match the deposit root
+	// the block's deposits are verified against.
+	ErrInvalidDepositProof = errors.New("invalid deposit proof")
+)
+
+// wrapNotFound wraps err with the given sentinel if the node responded with a 404.
+func wrapNotFound(err, sentinel error) error {
+	var apiErr *eapi.Error
+	if (errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) || errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("%w: %w", sentinel, err)
+	}
+
+	return err
+}
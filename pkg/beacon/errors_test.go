@@ -0,0 +1,49 @@
+package beacon
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	eapi "github.com/attestantio/go-eth2-client/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapNotFound(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "eth2 client 404",
+			err:      &eapi.Error{StatusCode: 404},
+			expected: true,
+		},
+		{
+			name:     "consensus client 404",
+			err:      fmt.Errorf("%w: status code: 404", api.ErrNotFound),
+			expected: true,
+		},
+		{
+			name:     "eth2 client 500",
+			err:      &eapi.Error{StatusCode: 500},
+			expected: false,
+		},
+		{
+			name:     "other error",
+			err:      errors.New("connection refused"),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := wrapNotFound(test.err, ErrBlockNotFound)
+
+			assert.Equal(t, test.expected, errors.Is(err, ErrBlockNotFound))
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package beacon
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Eth1DataVote is a distinct eth1 data candidate voted for by block proposers.
+type Eth1DataVote struct {
+	DepositRoot  string
+	DepositCount uint64
+	BlockHash    string
+}
+
+// Eth1DataVotes is a summary of the eth1 data votes observed within a single voting period.
+type Eth1DataVotes struct {
+	Period uint64
+	// Votes holds the number of observed blocks voting for each candidate.
+	Votes map[Eth1DataVote]uint64
+	// Total is the number of observed blocks in the voting period.
+	Total uint64
+}
+
+// Leader returns the candidate with the most votes and its share of the total votes.
+func (e *Eth1DataVotes) Leader() (Eth1DataVote, float64) {
+	leader := Eth1DataVote{}
+	most := uint64(0)
+
+	for vote, count := range e.Votes {
+		if count > most {
+			leader = vote
+			most = count
+		}
+	}
+
+	if e.Total == 0 {
+		return leader, 0
+	}
+
+	return leader, float64(most) / float64(e.Total)
+}
+
+// eth1VoteTracker tracks the eth1 data votes of the blocks seen in the current voting period.
+type eth1VoteTracker struct {
+	mu     sync.Mutex
+	period uint64
+	votes  map[phase0.Slot]Eth1DataVote
+}
+
+func newEth1VoteTracker() *eth1VoteTracker {
+	return &eth1VoteTracker{
+		votes: make(map[phase0.Slot]Eth1DataVote),
+	}
+}
+
+// add records the eth1 data vote of the block at the given slot. Votes from a newer voting period reset
+// the tracker while votes from an older period are ignored. A vote for a slot that has already been seen
+// (e.g. after a reorg) replaces the previous vote.
+func (t *eth1VoteTracker) add(period uint64, slot phase0.Slot, data *phase0.ETH1Data) *Eth1DataVotes {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if period > t.period {
+		t.period = period
+		t.votes = make(map[phase0.Slot]Eth1DataVote)
+	}
+
+	if period == t.period && data != nil {
+		t.votes[slot] = Eth1DataVote{
+			DepositRoot:  fmt.Sprintf("%#x", data.DepositRoot),
+			DepositCount: data.DepositCount,
+			BlockHash:    fmt.Sprintf("%#x", data.BlockHash),
+		}
+	}
+
+	summary := &Eth1DataVotes{
+		Period: t.period,
+		Votes:  make(map[Eth1DataVote]uint64),
+		Total:  uint64(len(t.votes)),
+	}
+
+	for _, vote := range t.votes {
+		summary.Votes[vote]++
+	}
+
+	return summary
+}
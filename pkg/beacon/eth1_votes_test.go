@@ -0,0 +1,42 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEth1VoteTracker(t *testing.T) {
+	tracker := newEth1VoteTracker()
+
+	a := &phase0.ETH1Data{DepositRoot: phase0.Root{0x01}, DepositCount: 10, BlockHash: []byte{0x0a}}
+	b := &phase0.ETH1Data{DepositRoot: phase0.Root{0x02}, DepositCount: 11, BlockHash: []byte{0x0b}}
+
+	tracker.add(1, 100, a)
+	tracker.add(1, 101, a)
+	votes := tracker.add(1, 102, b)
+
+	assert.Equal(t, uint64(1), votes.Period)
+	assert.Equal(t, uint64(3), votes.Total)
+	assert.Len(t, votes.Votes, 2)
+
+	leader, share := votes.Leader()
+	assert.Equal(t, uint64(10), leader.DepositCount)
+	assert.InDelta(t, 2.0/3.0, share, 0.0001)
+
+	// A reorged slot replaces the previous vote.
+	votes = tracker.add(1, 101, b)
+	leader, _ = votes.Leader()
+	assert.Equal(t, uint64(11), leader.DepositCount)
+	assert.Equal(t, uint64(3), votes.Total)
+
+	// Votes from an older period are ignored.
+	votes = tracker.add(0, 50, a)
+	assert.Equal(t, uint64(3), votes.Total)
+
+	// A new period resets the votes.
+	votes = tracker.add(2, 200, a)
+	assert.Equal(t, uint64(2), votes.Period)
+	assert.Equal(t, uint64(1), votes.Total)
+}
@@ -1,9 +1,13 @@
 package beacon
 
 import (
+	"fmt"
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
 	"github.com/ethpandaops/beacon/pkg/beacon/state"
@@ -32,12 +36,34 @@ const (
 	topicSyncStatus                = "sync_status"
 	topicNodeVersionUpdated        = "node_version_updated"
 	topicPeersUpdated              = "peers_updated"
+	topicIdentityUpdated           = "identity_updated"
 	topicSpecUpdated               = "spec_updated"
 	topicEmptySlot                 = "slot_empty"
 	topicHealthCheckSucceeded      = "health_check_suceeded"
 	topicHealthCheckFailed         = "health_check_failed"
 	topicFinalityCheckpointUpdated = "finality_checkpoint_updated"
 	topicFirstTimeHealthy          = "first_time_healthy"
+	topicBlockOrphaned             = "block_orphaned"
+	topicPossibleSlashing          = "possible_slashing"
+	topicAnyEvent                  = "any_event"
+	topicAnyCustomEvent            = "any_custom_event"
+	topicHeadLag                   = "head_lag"
+	topicClockSkewExceeded         = "clock_skew_exceeded"
+	topicHandlerError              = "handler_error"
+	topicConfigReloaded            = "config_reloaded"
+	topicEventStreamConnected      = "event_stream_connected"
+	topicEventStreamDisconnected   = "event_stream_disconnected"
+	topicDataIntegrity             = "data_integrity"
+	topicBlockGraffiti             = "block_graffiti"
+	topicDepositDivergence         = "deposit_divergence"
+	topicWithdrawal                = "withdrawal"
+	topicWithdrawalReverted        = "withdrawal_reverted"
+	topicSyncStateChanged          = "sync_state_changed"
+	topicELOffline                 = "el_offline"
+	topicForkImminent              = "fork_imminent"
+	topicForkActivated             = "fork_activated"
+	topicBlobLimitChanged          = "blob_limit_changed"
+	topicBootstrapFailed           = "bootstrap_failed"
 
 	// Official beacon events that are proxied
 	topicAttestation          = "attestation"
@@ -48,52 +74,407 @@ const (
 	topicVoluntaryExit        = "voluntary_exit"
 	topicContributionAndProof = "contribution_and_proof"
 	topicBlobSidecar          = "blob_sidecar"
+	topicDataColumnSidecar    = "data_column_sidecar"
 	topicEvent                = "raw_event"
 )
 
+// proxiedTopics are the topics of the official beacon events that are proxied from the upstream node. Every
+// other topic is a custom event.
+var proxiedTopics = EventTopics{
+	topicAttestation,
+	topicBlock,
+	topicChainReorg,
+	topicFinalizedCheckpoint,
+	topicHead,
+	topicVoluntaryExit,
+	topicContributionAndProof,
+	topicBlobSidecar,
+	topicDataColumnSidecar,
+	topicEvent,
+}
+
+// EventMeta attributes a custom event to the node that published it. It is embedded in every custom event
+// and populated when the event is published.
+type EventMeta struct {
+	// At is the time the event was published.
+	At time.Time
+	// Node is the name of the node that published the event.
+	Node string
+}
+
+func (m *EventMeta) setEventMeta(node string, at time.Time) {
+	m.Node = node
+	m.At = at
+}
+
+// eventMetaSetter is implemented by every event that embeds EventMeta.
+type eventMetaSetter interface {
+	setEventMeta(node string, at time.Time)
+}
+
+// ReadyEvent is emitted when the node is ready.
 type ReadyEvent struct {
+	EventMeta
 }
 
 // SyncStatusEvent is emitted when the sync status is refreshed.
 type SyncStatusEvent struct {
+	EventMeta
+
 	State *v1.SyncState
+	// ELOffline is true if the node reports that its execution client is offline.
+	ELOffline bool
 }
 
-// NodeVersionUpdatedEvent is emitted when the node version is updated.
+// NodeVersionUpdatedEvent is emitted when the node version changes.
 type NodeVersionUpdatedEvent struct {
+	EventMeta
+
 	Version string
+	Info    types.NodeVersion
+}
+
+// IdentityUpdatedEvent is emitted when the node's ENR or metadata sequence number changes.
+type IdentityUpdatedEvent struct {
+	EventMeta
+
+	Identity *types.Identity
 }
 
-// PeersUpdatedEvent is emitted when the peer list is updated.
+// PeersUpdatedEvent is emitted when the peer list changes.
 type PeersUpdatedEvent struct {
+	EventMeta
+
+	// Peers is the full, current list of peers.
 	Peers types.Peers
+	// Added contains the peers that were not present in the previous list.
+	Added types.Peers
+	// Removed contains the peers that are no longer present.
+	Removed types.Peers
 }
 
 // SpecUpdatedEvent is emitted when the spec is updated.
 type SpecUpdatedEvent struct {
+	EventMeta
+
 	Spec *state.Spec
 }
 
 // EmptySlotEvent is emitted when an empty slot is detected.
 type EmptySlotEvent struct {
+	EventMeta
+
+	// Slot is the slot that was checked and has no block, Options.EmptySlotGrace slots before the wallclock
+	// slot the check ran at.
 	Slot phase0.Slot
 }
 
 // HealthCheckSucceededEvent is emitted when a health check succeeds.
 type HealthCheckSucceededEvent struct {
+	EventMeta
+
 	Duration time.Duration
 }
 
 // HealthCheckFailedEvent is emitted when a health check fails.
 type HealthCheckFailedEvent struct {
+	EventMeta
+
 	Duration time.Duration
 }
 
 // FinalityCheckpointUpdated is emitted when the finality checkpoint is updated.
 type FinalityCheckpointUpdated struct {
+	EventMeta
+
 	Finality *v1.Finality
+	// Previous is the finality before the update, nil for the first update.
+	Previous *v1.Finality
+	// EpochsAdvanced is the number of epochs the finalized checkpoint moved forward by. It is zero if only the
+	// justified checkpoints changed or there is no previous finality, and negative if finality moved backwards.
+	EpochsAdvanced int64
 }
 
 // FirstTimeHealthyEvent is emitted when the node is first considered healthy.
 type FirstTimeHealthyEvent struct {
+	EventMeta
+}
+
+// BlockOrphanedEvent is emitted when a previously seen block is no longer part of the canonical chain.
+type BlockOrphanedEvent struct {
+	EventMeta
+
+	Root          phase0.Root
+	Slot          phase0.Slot
+	ProposerIndex phase0.ValidatorIndex
+	// ExecutionOptimistic is true if the node had not fully verified the block's execution payload when it
+	// was found to be orphaned.
+	ExecutionOptimistic bool
+}
+
+// PossibleSlashingEvent is emitted when conflicting messages signed by the same validator are observed.
+// Conflicts are detected from gossip without verifying signatures, so they should be treated as leads.
+type PossibleSlashingEvent struct {
+	EventMeta
+
+	Type           SlashingType
+	ValidatorIndex phase0.ValidatorIndex
+	Slot           phase0.Slot
+	// Roots holds the conflicting block roots for double proposals.
+	Roots []phase0.Root
+	// Attestations holds the conflicting attestation data for double and surround votes.
+	Attestations []*phase0.AttestationData
+}
+
+// HeadLagEvent is emitted when the node's head slot falls behind the wallclock slot by more than the
+// configured threshold while the node reports that it is not syncing.
+type HeadLagEvent struct {
+	EventMeta
+
+	HeadSlot      phase0.Slot
+	WallclockSlot phase0.Slot
+	Lag           phase0.Slot
+}
+
+// ClockSkewExceededEvent is emitted when the skew between the local clock and the node's clock exceeds the
+// configured threshold.
+type ClockSkewExceededEvent struct {
+	EventMeta
+
+	// Skew is the node's clock minus the local clock.
+	Skew      time.Duration
+	Threshold time.Duration
+}
+
+// HandlerErrorEvent is emitted when an event handler returns an error.
+type HandlerErrorEvent struct {
+	EventMeta
+
+	// Topic is the topic of the event the handler failed on.
+	Topic string
+	Err   error
+	// Attempt is the attempt that failed, starting at 1.
+	Attempt int
+	// WillRetry is true if the handler will be called again for the event.
+	WillRetry bool
+	// DeadLettered is true if the event was forwarded to the dead letter channel.
+	DeadLettered bool
+}
+
+// DeadLetter is an event that a handler failed to process, forwarded to HandlerOptions.DeadLetters.
+type DeadLetter struct {
+	Topic    string
+	Event    any
+	Err      error
+	FailedAt time.Time
+}
+
+// DataColumnSidecarEvent is emitted when the node receives a data column sidecar that passes gossip
+// validation.
+type DataColumnSidecarEvent struct {
+	BlockRoot      phase0.Root           `json:"block_root"`
+	Index          uint64                `json:"index,string"`
+	Slot           phase0.Slot           `json:"slot"`
+	KZGCommitments []deneb.KZGCommitment `json:"kzg_commitments"`
+}
+
+// ConfigReloadedEvent is emitted when the node's configuration is reloaded with ReloadConfig.
+type ConfigReloadedEvent struct {
+	EventMeta
+
+	Previous *Config
+	Current  *Config
+	// Redialled is true if the address changed and the node reconnected to the new address.
+	Redialled bool
+	// Resubscribed is true if the upstream event stream was restarted.
+	Resubscribed bool
+}
+
+// EventEnvelope wraps every event published by the node with metadata so that events from multiple nodes can
+// be attributed and ordered.
+type EventEnvelope struct {
+	// Topic is the topic the event was published on.
+	Topic string
+	// Node is the name of the node that published the event.
+	Node string
+	// Labels are the labels of the node that published the event.
+	Labels map[string]string
+	// ReceivedAt is the time the event was read from the upstream event stream, or for custom events not
+	// derived from an upstream event, the time it was published.
+	ReceivedAt time.Time
+	// Event is the published event, e.g. *v1.BlockEvent or *EmptySlotEvent.
+	Event interface{}
+}
+
+const (
+	// EventStreamDefault is the upstream event stream for the topics supported by go-eth2-client that are not
+	// part of any of BeaconSubscriptionOptions.Groups.
+	EventStreamDefault = "default"
+	// EventStreamRaw is the upstream event stream for topics that go-eth2-client does not support yet.
+	EventStreamRaw = "raw"
+)
+
+// EventStreamGroup returns the name of the upstream event stream for the topic group at the given index of
+// BeaconSubscriptionOptions.Groups.
+func EventStreamGroup(index int) string {
+	return fmt.Sprintf("group_%d", index)
+}
+
+// EventStreamConnectedEvent is emitted when a stream of upstream events is started. go-eth2-client reconnects
+// its streams internally, so these reconnects are not reported for EventStreamDefault and EventStreamGroup,
+// only the subscriptions this library makes again.
+type EventStreamConnectedEvent struct {
+	EventMeta
+
+	// Stream is the stream that connected, EventStreamDefault, EventStreamRaw or an EventStreamGroup.
+	Stream string
+	Topics []string
+	// Reconnect is true if the stream has been connected before.
+	Reconnect bool
+}
+
+// Reasons an upstream event stream ends, see EventStreamDisconnectedEvent.Category.
+const (
+	// EventStreamDisconnectRestarted is used when the stream is stopped to subscribe again.
+	EventStreamDisconnectRestarted = "restarted"
+	// EventStreamDisconnectStopped is used when the stream is stopped along with the node.
+	EventStreamDisconnectStopped = "stopped"
+	// EventStreamDisconnectFailed is used when the stream failed.
+	EventStreamDisconnectFailed = "failed"
+	// EventStreamDisconnectClosed is used when the node closed the stream.
+	EventStreamDisconnectClosed = "closed"
+)
+
+// EventStreamDisconnectedEvent is emitted when a stream of upstream events ends.
+type EventStreamDisconnectedEvent struct {
+	EventMeta
+
+	// Stream is the stream that disconnected, EventStreamDefault, EventStreamRaw or an EventStreamGroup.
+	Stream string
+	Topics []string
+	// Reason describes why the stream ended.
+	Reason string
+	// Category is the EventStreamDisconnect constant that Reason falls under.
+	Category string
+	// ConnectedFor is how long the stream was connected.
+	ConnectedFor time.Duration
+}
+
+// DataIntegrityEvent is emitted when data fetched from the node fails verification, e.g. when a block's hash
+// tree root does not match the requested root or a state's root does not match its block header.
+type DataIntegrityEvent struct {
+	EventMeta
+
+	Type DataIntegrityType
+	// ID is the block or state id that was fetched.
+	ID       string
+	Slot     phase0.Slot
+	Expected phase0.Root
+	Actual   phase0.Root
+}
+
+// BlockGraffitiEvent is emitted for every head block when graffiti extraction is enabled.
+type BlockGraffitiEvent struct {
+	EventMeta
+
+	Root          phase0.Root
+	Slot          phase0.Slot
+	ProposerIndex phase0.ValidatorIndex
+	// Graffiti is the decoded graffiti with trailing null bytes removed.
+	Graffiti string
+	// Prefix is the normalized graffiti prefix, see GraffitiPrefix.
+	Prefix string
+}
+
+// DepositDivergenceEvent is emitted when the deposit root tracked from blocks does not match the deposit root
+// of the node's deposit snapshot.
+type DepositDivergenceEvent struct {
+	EventMeta
+
+	// DepositCount is the deposit count of the snapshot, at which the roots were compared.
+	DepositCount uint64
+	SnapshotRoot phase0.Root
+	TrackedRoot  phase0.Root
+	// TrackedCount is the number of deposits tracked from blocks.
+	TrackedCount uint64
+	// SnapshotBlock is the hash of the execution block the snapshot was taken at.
+	SnapshotBlock phase0.Root
+}
+
+// WithdrawalEvent is emitted for every withdrawal in a new canonical block when withdrawal extraction is
+// enabled, and again as a reverted withdrawal if the block is reorged out.
+type WithdrawalEvent struct {
+	EventMeta
+
+	BlockRoot phase0.Root
+	Slot      phase0.Slot
+	// Index is the index of the withdrawal, which increases monotonically across all withdrawals.
+	Index          capella.WithdrawalIndex
+	ValidatorIndex phase0.ValidatorIndex
+	Address        bellatrix.ExecutionAddress
+	Amount         phase0.Gwei
+}
+
+// SyncStateChangedEvent is emitted when the node starts or stops syncing, or its head slot jumps by more than
+// Options.SyncChangeThreshold between two polls of the sync state.
+type SyncStateChangedEvent struct {
+	EventMeta
+
+	// Previous is the sync state of the previous poll, or nil for the first sync state fetched.
+	Previous *v1.SyncState
+	Current  *v1.SyncState
+}
+
+// ELOfflineEvent is emitted when the node starts reporting that its execution client is offline.
+type ELOfflineEvent struct {
+	EventMeta
+
+	State *v1.SyncState
+}
+
+// ForkImminentEvent is emitted once per fork when the next scheduled fork is within Options.ForkImminentEpochs
+// epochs of activating.
+type ForkImminentEvent struct {
+	EventMeta
+
+	Fork *state.ForkEpoch
+	// Epoch is the current epoch.
+	Epoch       phase0.Epoch
+	EpochsUntil phase0.Epoch
+	ActivatesAt time.Time
+}
+
+// ForkActivatedEvent is emitted at the epoch transition where a scheduled fork activates, according to the
+// wallclock and the spec.
+type ForkActivatedEvent struct {
+	EventMeta
+
+	Fork  *state.ForkEpoch
+	Epoch phase0.Epoch
+}
+
+// BlobLimitChangedEvent is emitted at the epoch transition where an entry of the spec's BLOB_SCHEDULE changes the
+// maximum number of blobs per block. These changes happen without a named fork.
+type BlobLimitChangedEvent struct {
+	EventMeta
+
+	Epoch phase0.Epoch
+	// Previous is the maximum number of blobs per block of the previous blob schedule entry, or zero if there is
+	// none.
+	Previous         uint64
+	MaxBlobsPerBlock uint64
+}
+
+// BootstrapFailedEvent is emitted when an attempt to bootstrap the node, i.e. to load its spec and genesis and
+// subscribe to its upstream events, fails.
+type BootstrapFailedEvent struct {
+	EventMeta
+
+	Err error
+	// Attempt is the attempt that failed, starting at 1.
+	Attempt int
+	// WillRetry is false if Start gives up because Options.StartupRetryWindow has elapsed.
+	WillRetry bool
+	// RetryIn is how long until the next attempt.
+	RetryIn time.Duration
 }
@@ -0,0 +1,240 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	eapi "github.com/attestantio/go-eth2-client/api"
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
+)
+
+// fakeClient is a configurable eth2 client for tests that serves the responses in its fields. Responses that
+// are requested by id are looked up by the id as requested, ids without a response are not found.
+type fakeClient struct {
+	// err fails every request.
+	err error
+	// failing is an id whose requests fail with a 500.
+	failing string
+
+	// spec is the raw spec, nil is not found.
+	spec map[string]any
+	// genesis is the genesis, nil is not found.
+	genesis *v1.Genesis
+	// syncState is the sync state, served by NodeSyncing.
+	syncState *v1.SyncState
+
+	// finalized is the epoch of the finalized checkpoint, see setFinalized.
+	finalized   phase0.Epoch
+	finalizedMu sync.Mutex
+
+	duties     map[phase0.Epoch][]*v1.ProposerDuty
+	committees map[phase0.Epoch][]*v1.BeaconCommittee
+	blocks     map[string]*spec.VersionedSignedBeaconBlock
+	headers    map[string]*v1.BeaconBlockHeader
+	roots      map[string]phase0.Root
+	sidecars   map[string][]*deneb.BlobSidecar
+
+	// blockedCommittees holds back the committee fetches of an epoch until its channel is closed. Every held
+	// back fetch signals committeesStarted first.
+	blockedCommittees map[phase0.Epoch]chan struct{}
+	committeesStarted chan struct{}
+}
+
+func (c *fakeClient) Name() string    { return "fake" }
+func (c *fakeClient) Address() string { return "" }
+func (c *fakeClient) IsActive() bool  { return true }
+func (c *fakeClient) IsSynced() bool  { return true }
+
+func (c *fakeClient) setFinalized(epoch phase0.Epoch) {
+	c.finalizedMu.Lock()
+	defer c.finalizedMu.Unlock()
+
+	c.finalized = epoch
+}
+
+// fail returns the error a request for the given id fails with, if any.
+func (c *fakeClient) fail(id string) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	if c.failing != "" && id == c.failing {
+		return &eapi.Error{StatusCode: http.StatusInternalServerError}
+	}
+
+	return nil
+}
+
+// serve returns the response for the given id from responses.
+func serve[T any](c *fakeClient, responses map[string]T, id string) (*eapi.Response[T], error) {
+	if err := c.fail(id); err != nil {
+		return nil, err
+	}
+
+	data, exists := responses[id]
+	if !exists {
+		return nil, &eapi.Error{StatusCode: http.StatusNotFound}
+	}
+
+	return &eapi.Response[T]{Data: data, Metadata: map[string]any{}}, nil
+}
+
+func (c *fakeClient) Spec(ctx context.Context, opts *eapi.SpecOpts) (*eapi.Response[map[string]any], error) {
+	if err := c.fail(""); err != nil {
+		return nil, err
+	}
+
+	if c.spec == nil {
+		return nil, &eapi.Error{StatusCode: http.StatusNotFound}
+	}
+
+	return &eapi.Response[map[string]any]{Data: c.spec}, nil
+}
+
+func (c *fakeClient) Genesis(ctx context.Context, opts *eapi.GenesisOpts) (*eapi.Response[*v1.Genesis], error) {
+	if err := c.fail(""); err != nil {
+		return nil, err
+	}
+
+	if c.genesis == nil {
+		return nil, &eapi.Error{StatusCode: http.StatusNotFound}
+	}
+
+	return &eapi.Response[*v1.Genesis]{Data: c.genesis}, nil
+}
+
+func (c *fakeClient) NodeSyncing(ctx context.Context, opts *eapi.NodeSyncingOpts) (*eapi.Response[*v1.SyncState], error) {
+	if err := c.fail(""); err != nil {
+		return nil, err
+	}
+
+	return &eapi.Response[*v1.SyncState]{Data: c.syncState}, nil
+}
+
+func (c *fakeClient) Finality(ctx context.Context, opts *eapi.FinalityOpts) (*eapi.Response[*v1.Finality], error) {
+	if err := c.fail(opts.State); err != nil {
+		return nil, err
+	}
+
+	c.finalizedMu.Lock()
+	defer c.finalizedMu.Unlock()
+
+	return &eapi.Response[*v1.Finality]{Data: &v1.Finality{
+		Finalized:         &phase0.Checkpoint{Epoch: c.finalized},
+		Justified:         &phase0.Checkpoint{},
+		PreviousJustified: &phase0.Checkpoint{},
+	}}, nil
+}
+
+func (c *fakeClient) ProposerDuties(ctx context.Context, opts *eapi.ProposerDutiesOpts) (*eapi.Response[[]*v1.ProposerDuty], error) {
+	if err := c.fail(""); err != nil {
+		return nil, err
+	}
+
+	return &eapi.Response[[]*v1.ProposerDuty]{Data: c.duties[opts.Epoch]}, nil
+}
+
+func (c *fakeClient) BeaconCommittees(ctx context.Context, opts *eapi.BeaconCommitteesOpts) (*eapi.Response[[]*v1.BeaconCommittee], error) {
+	if blocked, exists := c.blockedCommittees[*opts.Epoch]; exists {
+		c.committeesStarted <- struct{}{}
+		<-blocked
+	}
+
+	if err := c.fail(""); err != nil {
+		return nil, err
+	}
+
+	return &eapi.Response[[]*v1.BeaconCommittee]{Data: c.committees[*opts.Epoch]}, nil
+}
+
+func (c *fakeClient) SignedBeaconBlock(ctx context.Context, opts *eapi.SignedBeaconBlockOpts) (*eapi.Response[*spec.VersionedSignedBeaconBlock], error) {
+	return serve(c, c.blocks, opts.Block)
+}
+
+func (c *fakeClient) BeaconBlockHeader(ctx context.Context, opts *eapi.BeaconBlockHeaderOpts) (*eapi.Response[*v1.BeaconBlockHeader], error) {
+	return serve(c, c.headers, opts.Block)
+}
+
+func (c *fakeClient) BeaconBlockRoot(ctx context.Context, opts *eapi.BeaconBlockRootOpts) (*eapi.Response[*phase0.Root], error) {
+	rsp, err := serve(c, c.roots, opts.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eapi.Response[*phase0.Root]{Data: &rsp.Data}, nil
+}
+
+func (c *fakeClient) BlobSidecars(ctx context.Context, opts *eapi.BlobSidecarsOpts) (*eapi.Response[[]*deneb.BlobSidecar], error) {
+	return serve(c, c.sidecars, opts.Block)
+}
+
+// fakeAPI is a configurable raw API client for tests. Methods without a response in its fields panic.
+type fakeAPI struct {
+	api.ConsensusClient
+
+	peers     types.Peers
+	syncState types.SyncState
+}
+
+func (c *fakeAPI) NodePeers(ctx context.Context) (types.Peers, error) {
+	return c.peers, nil
+}
+
+func (c *fakeAPI) NodeSyncing(ctx context.Context) (*types.SyncState, error) {
+	state := c.syncState
+
+	return &state, nil
+}
+
+// byRoot keys the given responses by the block id of their root.
+func byRoot[T any](responses map[phase0.Root]T) map[string]T {
+	keyed := make(map[string]T, len(responses))
+	for root, response := range responses {
+		keyed[fmt.Sprintf("%#x", root)] = response
+	}
+
+	return keyed
+}
+
+// phase0Block returns an empty phase0 block at the given slot.
+func phase0Block(slot phase0.Slot) *spec.VersionedSignedBeaconBlock {
+	return &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionPhase0,
+		Phase0:  &phase0.SignedBeaconBlock{Message: &phase0.BeaconBlock{Slot: slot}},
+	}
+}
+
+// capellaBlock returns a capella block at the given slot with a withdrawal for each of the given indices.
+func capellaBlock(slot phase0.Slot, parent phase0.Root, indices ...capella.WithdrawalIndex) *spec.VersionedSignedBeaconBlock {
+	withdrawals := make([]*capella.Withdrawal, 0, len(indices))
+	for _, index := range indices {
+		withdrawals = append(withdrawals, &capella.Withdrawal{
+			Index:          index,
+			ValidatorIndex: phase0.ValidatorIndex(index),
+			Address:        bellatrix.ExecutionAddress{byte(index)},
+			Amount:         phase0.Gwei(index) * 100,
+		})
+	}
+
+	return &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionCapella,
+		Capella: &capella.SignedBeaconBlock{
+			Message: &capella.BeaconBlock{
+				Slot:       slot,
+				ParentRoot: parent,
+				Body: &capella.BeaconBlockBody{
+					ExecutionPayload: &capella.ExecutionPayload{Withdrawals: withdrawals},
+				},
+			},
+		},
+	}
+}
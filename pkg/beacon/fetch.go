@@ -3,180 +3,448 @@ package beacon
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/deneb"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
 	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/ethpandaops/beacon/pkg/beacon/stateutil"
 )
 
 func (n *node) FetchSyncStatus(ctx context.Context) (*v1.SyncState, error) {
-	provider, isProvider := n.client.(eth2client.NodeSyncingProvider)
-	if !isProvider {
-		return nil, errors.New("client does not implement eth2client.NodeSyncingProvider")
-	}
+	return singleFlight(ctx, &n.inflight, "sync_status", func(ctx context.Context) (*v1.SyncState, error) {
+		// The raw API client is used as the go-eth2-client sync state does not include el_offline.
+		status, err := n.currentAPI().NodeSyncing(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	status, err := provider.NodeSyncing(ctx, &api.NodeSyncingOpts{})
-	if err != nil {
-		return nil, err
-	}
+		state := &v1.SyncState{
+			HeadSlot:     status.HeadSlot,
+			SyncDistance: status.SyncDistance,
+			IsOptimistic: status.IsOptimistic,
+			IsSyncing:    status.IsSyncing,
+		}
 
-	n.stat.UpdateSyncState(status.Data)
+		wasELOffline := n.stat.ELOffline()
 
-	n.publishSyncStatus(ctx, status.Data)
+		n.stat.UpdateSyncState(state)
+		n.stat.UpdateELOffline(status.ELOffline)
+		n.setHeadSlot(state.HeadSlot)
 
-	return status.Data, nil
+		n.publishSyncStatus(ctx, state, status.ELOffline)
+		n.checkSyncStateChanged(ctx, state)
+
+		if status.ELOffline && !wasELOffline {
+			n.publishELOffline(ctx, state)
+		}
+
+		return state, nil
+	})
 }
 
 func (n *node) FetchPeers(ctx context.Context) (*types.Peers, error) {
-	peers, err := n.api.NodePeers(ctx)
-	if err != nil {
-		return nil, err
-	}
+	return singleFlight(ctx, &n.inflight, "peers", func(ctx context.Context) (*types.Peers, error) {
+		peers, err := n.currentAPI().NodePeers(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		n.peersMu.Lock()
+		previous := n.peers
+		n.peers = peers
+		n.peersFetchedAt = time.Now()
+		n.peersMu.Unlock()
+
+		if peers.Equal(previous) {
+			return &peers, nil
+		}
 
-	n.peers = peers
+		added, removed := peers.Diff(previous)
 
-	n.publishPeersUpdated(ctx, peers)
+		n.publishPeersUpdated(ctx, peers, added, removed)
 
-	return &peers, nil
+		return &peers, nil
+	})
 }
 
 func (n *node) FetchNodeVersion(ctx context.Context) (string, error) {
-	provider, isProvider := n.client.(eth2client.NodeVersionProvider)
-	if !isProvider {
-		return "", errors.New("client does not implement eth2client.NodeVersionProvider")
+	return singleFlight(ctx, &n.inflight, "node_version", func(ctx context.Context) (string, error) {
+		provider, isProvider := n.currentClient().(eth2client.NodeVersionProvider)
+		if !isProvider {
+			return "", errors.New("client does not implement eth2client.NodeVersionProvider")
+		}
+
+		rsp, err := retryThrottled(ctx, n, provider.NodeVersion, &api.NodeVersionOpts{})
+		if err != nil {
+			return "", err
+		}
+
+		info := types.ParseNodeVersion(rsp.Data)
+
+		n.nodeVersionMu.Lock()
+		changed := n.nodeVersion != info
+		n.nodeVersion = info
+		n.nodeVersionMu.Unlock()
+
+		if changed {
+			n.publishNodeVersionUpdated(ctx, info)
+		}
+
+		return rsp.Data, nil
+	})
+}
+
+func (n *node) FetchBlock(ctx context.Context, stateID string) (*spec.VersionedSignedBeaconBlock, error) {
+	if n.blocks != nil {
+		if block, exists := n.blocks.get(stateID); exists {
+			return block, nil
+		}
 	}
 
-	rsp, err := provider.NodeVersion(ctx, &api.NodeVersionOpts{})
-	if err != nil {
-		return "", err
+	return singleFlight(ctx, &n.inflight, "block:"+stateID, func(ctx context.Context) (*spec.VersionedSignedBeaconBlock, error) {
+		block, err := n.fetchBlockThroughCache(ctx, stateID)
+		if err != nil {
+			return nil, err
+		}
+
+		if n.blocks != nil {
+			if err := n.blocks.store(stateID, block); err != nil {
+				n.log.WithError(err).Debug("Failed to add block to the block cache")
+			}
+		}
+
+		return block, nil
+	})
+}
+
+// fetchBlockThroughCache fetches a block that is not in the block cache from Options.Cache, or from the node
+// on a miss. Blocks are only shared through Options.Cache by root, as the block at a slot can change with a
+// reorg. Without an Options.Cache the block cache already holds the blocks in memory, so they are not cached
+// a second time.
+func (n *node) fetchBlockThroughCache(ctx context.Context, stateID string) (*spec.VersionedSignedBeaconBlock, error) {
+	root, byRoot := parseBlockIDRoot(stateID)
+	shared := n.blocks != nil && byRoot && n.currentOptions().Cache != nil
+
+	if shared {
+		if block, exists := n.cachedBlock(ctx, root); exists {
+			return block, nil
+		}
 	}
 
-	n.nodeVersion = rsp.Data
+	block, err := withFetchHooks(ctx, n, &FetchRequest{Kind: FetchKindBlock, ID: stateID}, func() (*spec.VersionedSignedBeaconBlock, error) {
+		return n.getBlock(ctx, stateID)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	n.publishNodeVersionUpdated(ctx, rsp.Data)
+	if shared {
+		n.cacheBlock(ctx, root, block)
+	}
 
-	return rsp.Data, nil
+	return block, nil
 }
 
-func (n *node) FetchBlock(ctx context.Context, stateID string) (*spec.VersionedSignedBeaconBlock, error) {
-	return n.getBlock(ctx, stateID)
+// FetchBlockWithMetadata fetches the block for the given state id together with the execution_optimistic and
+// finalized flags of the response.
+func (n *node) FetchBlockWithMetadata(ctx context.Context, stateID string) (*FetchResult[*spec.VersionedSignedBeaconBlock], error) {
+	return singleFlight(ctx, &n.inflight, "block_with_metadata:"+stateID, func(ctx context.Context) (*FetchResult[*spec.VersionedSignedBeaconBlock], error) {
+		return n.getBlockWithMetadata(ctx, stateID)
+	})
 }
 
 func (n *node) FetchRawBlock(ctx context.Context, stateID string, contentType string) ([]byte, error) {
-	return n.api.RawBlock(ctx, stateID, contentType)
+	data, err := n.currentAPI().RawBlock(ctx, stateID, contentType)
+	if err != nil {
+		return nil, wrapNotFound(err, ErrBlockNotFound)
+	}
+
+	return data, nil
+}
+
+func (n *node) FetchRawBlockInto(ctx context.Context, stateID string, contentType string, dst []byte) ([]byte, error) {
+	data, err := n.currentAPI().RawBlockInto(ctx, stateID, contentType, dst)
+	if err != nil {
+		return nil, wrapNotFound(err, ErrBlockNotFound)
+	}
+
+	return data, nil
 }
 
 func (n *node) FetchBlockRoot(ctx context.Context, stateID string) (*phase0.Root, error) {
-	return n.getBlockRoot(ctx, stateID)
+	slot, err := strconv.ParseUint(stateID, 10, 64)
+	cacheable := n.blockRoots != nil && err == nil
+
+	if cacheable {
+		if root, exists := n.blockRoots.get(phase0.Slot(slot)); exists {
+			return &root, nil
+		}
+	}
+
+	return singleFlight(ctx, &n.inflight, "block_root:"+stateID, func(ctx context.Context) (*phase0.Root, error) {
+		root, err := n.getBlockRoot(ctx, stateID)
+		if err != nil {
+			return nil, err
+		}
+
+		if cacheable && root != nil {
+			n.blockRoots.set(phase0.Slot(slot), *root)
+		}
+
+		return root, nil
+	})
 }
 
 func (n *node) FetchBeaconState(ctx context.Context, stateID string) (*spec.VersionedBeaconState, error) {
-	provider, isProvider := n.client.(eth2client.BeaconStateProvider)
+	return singleFlight(ctx, &n.inflight, "state:"+stateID, func(ctx context.Context) (*spec.VersionedBeaconState, error) {
+		return withFetchHooks(ctx, n, &FetchRequest{Kind: FetchKindState, ID: stateID}, func() (*spec.VersionedBeaconState, error) {
+			result, err := n.getBeaconState(ctx, stateID)
+			if err != nil {
+				return nil, err
+			}
+
+			return result.Data, nil
+		})
+	})
+}
+
+// FetchBeaconStateWithMetadata fetches the beacon state for the given state id together with the
+// execution_optimistic and finalized flags of the response.
+func (n *node) FetchBeaconStateWithMetadata(ctx context.Context, stateID string) (*FetchResult[*spec.VersionedBeaconState], error) {
+	return singleFlight(ctx, &n.inflight, "state_with_metadata:"+stateID, func(ctx context.Context) (*FetchResult[*spec.VersionedBeaconState], error) {
+		return n.getBeaconState(ctx, stateID)
+	})
+}
+
+func (n *node) getBeaconState(ctx context.Context, stateID string) (*FetchResult[*spec.VersionedBeaconState], error) {
+	provider, isProvider := n.currentClient().(eth2client.BeaconStateProvider)
 	if !isProvider {
 		return nil, errors.New("client does not implement eth2client.NodeVersionProvider")
 	}
 
-	rsp, err := provider.BeaconState(ctx, &api.BeaconStateOpts{
+	rsp, err := retryThrottled(ctx, n, provider.BeaconState, &api.BeaconStateOpts{
 		State: stateID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, wrapNotFound(err, ErrStateNotFound)
 	}
 
-	return rsp.Data, nil
+	if n.currentOptions().VerifyStateRoots {
+		if err := n.verifyStateRoot(ctx, stateID, rsp.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	return &FetchResult[*spec.VersionedBeaconState]{
+		ResponseMetadata: parseResponseMetadata(rsp.Metadata),
+		Data:             rsp.Data,
+	}, nil
 }
 
 func (n *node) FetchRawBeaconState(ctx context.Context, stateID string, contentType string) ([]byte, error) {
-	return n.api.RawDebugBeaconState(ctx, stateID, contentType)
+	if !n.Quirks().SupportsRawStateContentType(contentType) {
+		return nil, fmt.Errorf("%w: raw beacon state as %s on %s", ErrNotSupported, contentType, n.ClientType())
+	}
+
+	data, err := n.currentAPI().RawDebugBeaconState(ctx, stateID, contentType)
+	if err != nil {
+		return nil, wrapNotFound(err, ErrStateNotFound)
+	}
+
+	return data, nil
 }
 
-func (n *node) FetchFinality(ctx context.Context, stateID string) (*v1.Finality, error) {
-	provider, isProvider := n.client.(eth2client.FinalityProvider)
-	if !isProvider {
-		return nil, errors.New("client does not implement eth2client.FinalityProvider")
+func (n *node) FetchRawBeaconStateInto(ctx context.Context, stateID string, contentType string, dst []byte) ([]byte, error) {
+	if !n.Quirks().SupportsRawStateContentType(contentType) {
+		return nil, fmt.Errorf("%w: raw beacon state as %s on %s", ErrNotSupported, contentType, n.ClientType())
 	}
 
-	rsp, err := provider.Finality(ctx, &api.FinalityOpts{
-		State: stateID,
+	data, err := n.currentAPI().RawDebugBeaconStateInto(ctx, stateID, contentType, dst)
+	if err != nil {
+		return nil, wrapNotFound(err, ErrStateNotFound)
+	}
+
+	return data, nil
+}
+
+// FetchBeaconStateBalances streams the SSZ encoded beacon state for the given state id and extracts the
+// validator balances, without holding the state in memory.
+func (n *node) FetchBeaconStateBalances(ctx context.Context, stateID string) ([]phase0.Gwei, error) {
+	var balances []phase0.Gwei
+
+	err := n.streamRawBeaconState(ctx, stateID, func(r io.Reader, layout stateutil.Layout) error {
+		var err error
+
+		balances, err = stateutil.ReadBalances(r, layout)
+
+		return err
 	})
+
+	return balances, err
+}
+
+// FetchBeaconStateValidators streams the SSZ encoded beacon state for the given state id and extracts the
+// validators, without holding the state in memory.
+func (n *node) FetchBeaconStateValidators(ctx context.Context, stateID string) ([]*phase0.Validator, error) {
+	var validators []*phase0.Validator
+
+	err := n.streamRawBeaconState(ctx, stateID, func(r io.Reader, layout stateutil.Layout) error {
+		var err error
+
+		validators, err = stateutil.ReadValidators(r, layout)
+
+		return err
+	})
+
+	return validators, err
+}
+
+func (n *node) streamRawBeaconState(ctx context.Context, stateID string, read func(r io.Reader, layout stateutil.Layout) error) error {
+	if !n.Quirks().SupportsRawStateContentType(ContentTypeSSZ) {
+		return fmt.Errorf("%w: raw beacon state as %s on %s", ErrNotSupported, ContentTypeSSZ, n.ClientType())
+	}
+
+	rawSpec, err := n.RawSpec()
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	layout, err := stateutil.LayoutFromSpec(rawSpec)
+	if err != nil {
+		return err
+	}
+
+	body, err := n.currentAPI().RawDebugBeaconStateReader(ctx, stateID)
+	if err != nil {
+		return wrapNotFound(err, ErrStateNotFound)
 	}
 
-	finality := rsp.Data
+	defer body.Close()
+
+	return read(body, layout)
+}
 
-	if stateID == "head" {
-		changed := false
-		if n.finality == nil ||
-			finality.Finalized.Root != n.finality.Finalized.Root ||
-			finality.Finalized.Epoch != n.finality.Finalized.Epoch ||
-			finality.Justified.Root != n.finality.Justified.Root ||
-			finality.Justified.Epoch != n.finality.Justified.Epoch ||
-			finality.PreviousJustified.Epoch != n.finality.PreviousJustified.Epoch ||
-			finality.PreviousJustified.Root != n.finality.PreviousJustified.Root {
-			changed = true
+func (n *node) FetchFinality(ctx context.Context, stateID string) (*v1.Finality, error) {
+	return singleFlight(ctx, &n.inflight, "finality:"+stateID, func(ctx context.Context) (*v1.Finality, error) {
+		provider, isProvider := n.currentClient().(eth2client.FinalityProvider)
+		if !isProvider {
+			return nil, errors.New("client does not implement eth2client.FinalityProvider")
 		}
 
-		n.finality = finality
+		rsp, err := retryThrottled(ctx, n, provider.Finality, &api.FinalityOpts{
+			State: stateID,
+		})
+		if err != nil {
+			return nil, wrapNotFound(err, ErrStateNotFound)
+		}
 
-		if changed {
-			n.publishFinalityCheckpointUpdated(ctx, finality)
+		finality := rsp.Data
+
+		if stateID == "head" {
+			n.finalityMu.Lock()
+
+			previous := n.finality
+
+			changed := false
+			if n.finality == nil ||
+				finality.Finalized.Root != n.finality.Finalized.Root ||
+				finality.Finalized.Epoch != n.finality.Finalized.Epoch ||
+				finality.Justified.Root != n.finality.Justified.Root ||
+				finality.Justified.Epoch != n.finality.Justified.Epoch ||
+				finality.PreviousJustified.Epoch != n.finality.PreviousJustified.Epoch ||
+				finality.PreviousJustified.Root != n.finality.PreviousJustified.Root {
+				changed = true
+			}
+
+			n.finality = copyFinality(finality)
+
+			n.finalityMu.Unlock()
+
+			if changed {
+				n.publishFinalityCheckpointUpdated(ctx, previous, finality)
+			}
 		}
-	}
 
-	return finality, nil
+		return finality, nil
+	})
 }
 
 func (n *node) FetchRawSpec(ctx context.Context) (map[string]any, error) {
-	provider, isProvider := n.client.(eth2client.SpecProvider)
+	provider, isProvider := n.currentClient().(eth2client.SpecProvider)
 	if !isProvider {
 		return nil, errors.New("client does not implement eth2client.SpecProvider")
 	}
 
-	rsp, err := provider.Spec(ctx, &api.SpecOpts{})
+	rsp, err := retryThrottled(ctx, n, provider.Spec, &api.SpecOpts{})
 	if err != nil {
 		return nil, err
 	}
 
+	// Refresh the typed spec too so that both stay in sync. An invalid spec replaces neither.
+	if len(rsp.Data) > 0 {
+		sp := state.NewSpec(rsp.Data)
+		if err := sp.Validate(); err != nil {
+			return nil, fmt.Errorf("fetched spec is invalid: %w", err)
+		}
+
+		n.setSpec(rsp.Data, &sp)
+
+		n.publishSpecUpdated(ctx, &sp)
+	}
+
 	return rsp.Data, nil
 }
 
 func (n *node) FetchSpec(ctx context.Context) (*state.Spec, error) {
-	provider, isProvider := n.client.(eth2client.SpecProvider)
-	if !isProvider {
-		return nil, errors.New("client does not implement eth2client.SpecProvider")
-	}
+	return singleFlight(ctx, &n.inflight, "spec", func(ctx context.Context) (*state.Spec, error) {
+		provider, isProvider := n.currentClient().(eth2client.SpecProvider)
+		if !isProvider {
+			return nil, errors.New("client does not implement eth2client.SpecProvider")
+		}
 
-	rsp, err := provider.Spec(ctx, &api.SpecOpts{})
-	if err != nil {
-		return nil, err
-	}
+		rsp, err := retryThrottled(ctx, n, provider.Spec, &api.SpecOpts{})
+		if err != nil {
+			return nil, err
+		}
 
-	sp := state.NewSpec(rsp.Data)
+		sp := state.NewSpec(rsp.Data)
+		if err := sp.Validate(); err != nil {
+			return nil, fmt.Errorf("fetched spec is invalid: %w", err)
+		}
 
-	n.spec = &sp
+		n.setSpec(rsp.Data, &sp)
 
-	n.publishSpecUpdated(ctx, &sp)
+		n.publishSpecUpdated(ctx, &sp)
 
-	return &sp, nil
+		return &sp, nil
+	})
 }
 
 func (n *node) FetchBeaconBlockBlobs(ctx context.Context, blockID string) ([]*deneb.BlobSidecar, error) {
-	provider, isProvider := n.client.(eth2client.BlobSidecarsProvider)
+	if err := n.requireEndpoint(EndpointBlobSidecars); err != nil {
+		return nil, err
+	}
+
+	provider, isProvider := n.currentClient().(eth2client.BlobSidecarsProvider)
 	if !isProvider {
 		return nil, errors.New("client does not implement eth2client.BlobSidecarsProvider")
 	}
 
-	rsp, err := provider.BlobSidecars(ctx, &api.BlobSidecarsOpts{
+	rsp, err := retryThrottled(ctx, n, provider.BlobSidecars, &api.BlobSidecarsOpts{
 		Block: blockID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, wrapNotFound(n.wrapNotSupported(EndpointBlobSidecars, err), ErrBlockNotFound)
 	}
 
 	return rsp.Data, nil
@@ -185,12 +453,12 @@ func (n *node) FetchBeaconBlockBlobs(ctx context.Context, blockID string) ([]*de
 func (n *node) FetchProposerDuties(ctx context.Context, epoch phase0.Epoch) ([]*v1.ProposerDuty, error) {
 	n.log.WithField("epoch", epoch).Debug("Fetching proposer duties")
 
-	provider, isProvider := n.client.(eth2client.ProposerDutiesProvider)
+	provider, isProvider := n.currentClient().(eth2client.ProposerDutiesProvider)
 	if !isProvider {
 		return nil, errors.New("client does not implement eth2client.ProposerDutiesProvider")
 	}
 
-	rsp, err := provider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+	rsp, err := retryThrottled(ctx, n, provider.ProposerDuties, &api.ProposerDutiesOpts{
 		Epoch: epoch,
 	})
 	if err != nil {
@@ -201,63 +469,133 @@ func (n *node) FetchProposerDuties(ctx context.Context, epoch phase0.Epoch) ([]*
 }
 
 func (n *node) FetchForkChoice(ctx context.Context) (*v1.ForkChoice, error) {
-	provider, isProvider := n.client.(eth2client.ForkChoiceProvider)
+	if err := n.requireEndpoint(EndpointForkChoice); err != nil {
+		return nil, err
+	}
+
+	provider, isProvider := n.currentClient().(eth2client.ForkChoiceProvider)
 	if !isProvider {
 		return nil, errors.New("client does not implement eth2client.ForkChoiceProvider")
 	}
 
-	rsp, err := provider.ForkChoice(ctx, &api.ForkChoiceOpts{})
+	rsp, err := retryThrottled(ctx, n, provider.ForkChoice, &api.ForkChoiceOpts{})
 	if err != nil {
-		return nil, err
+		return nil, n.wrapNotSupported(EndpointForkChoice, err)
 	}
 
 	return rsp.Data, nil
 }
 
 func (n *node) FetchDepositSnapshot(ctx context.Context) (*types.DepositSnapshot, error) {
-	return n.api.DepositSnapshot(ctx)
+	if err := n.requireEndpoint(EndpointDepositSnapshot); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := n.currentAPI().DepositSnapshot(ctx)
+	if err != nil {
+		return nil, n.wrapNotSupported(EndpointDepositSnapshot, err)
+	}
+
+	return snapshot, nil
 }
 
 func (n *node) FetchNodeIdentity(ctx context.Context) (*types.Identity, error) {
-	return n.api.NodeIdentity(ctx)
+	if err := n.requireEndpoint(EndpointNodeIdentity); err != nil {
+		return nil, err
+	}
+
+	return singleFlight(ctx, &n.inflight, "node_identity", func(ctx context.Context) (*types.Identity, error) {
+		identity, err := n.currentAPI().NodeIdentity(ctx)
+		if err != nil {
+			return nil, n.wrapNotSupported(EndpointNodeIdentity, err)
+		}
+
+		n.identityMu.Lock()
+		changed := n.identity == nil ||
+			n.identity.ENR != identity.ENR ||
+			n.identity.Metadata.SeqNumber != identity.Metadata.SeqNumber
+		n.identity = identity
+		n.identityMu.Unlock()
+
+		if changed {
+			n.publishIdentityUpdated(ctx, identity)
+		}
+
+		return identity, nil
+	})
 }
 
 func (n *node) FetchBeaconStateRoot(ctx context.Context, state string) (phase0.Root, error) {
-	provider, isProvider := n.client.(eth2client.BeaconStateRootProvider)
-	if !isProvider {
-		return phase0.Root{}, errors.New("client does not implement eth2client.StateRootProvider")
-	}
+	return singleFlight(ctx, &n.inflight, "state_root:"+state, func(ctx context.Context) (phase0.Root, error) {
+		provider, isProvider := n.currentClient().(eth2client.BeaconStateRootProvider)
+		if !isProvider {
+			return phase0.Root{}, errors.New("client does not implement eth2client.StateRootProvider")
+		}
 
-	rsp, err := provider.BeaconStateRoot(ctx, &api.BeaconStateRootOpts{
-		State: state,
-	})
-	if err != nil {
-		return phase0.Root{}, err
-	}
+		rsp, err := retryThrottled(ctx, n, provider.BeaconStateRoot, &api.BeaconStateRootOpts{
+			State: state,
+		})
+		if err != nil {
+			return phase0.Root{}, wrapNotFound(err, ErrStateNotFound)
+		}
 
-	return *rsp.Data, nil
+		return *rsp.Data, nil
+	})
 }
 
 func (n *node) FetchValidators(ctx context.Context, state string, indices []phase0.ValidatorIndex, pubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*v1.Validator, error) {
-	provider, isProvider := n.client.(eth2client.ValidatorsProvider)
-	if !isProvider {
-		return nil, errors.New("client does not implement eth2client.ValidatorsProvider")
+	// Only complete validator sets of states requested by root are shared through the cache, as the state of
+	// any other state id changes over time.
+	stateRoot, byRoot := parseBlockIDRoot(state)
+	shared := n.currentOptions().ValidatorCache.Enabled && byRoot && len(indices) == 0 && len(pubKeys) == 0
+
+	if shared {
+		if validators, exists := n.cachedValidators(ctx, stateRoot); exists {
+			return validators, nil
+		}
 	}
 
-	rsp, err := provider.Validators(ctx, &api.ValidatorsOpts{
-		State:   state,
-		Indices: indices,
-		PubKeys: pubKeys,
+	request := &FetchRequest{Kind: FetchKindValidators, ID: state, Indices: indices, PubKeys: pubKeys}
+
+	validators, err := withFetchHooks(ctx, n, request, func() (map[phase0.ValidatorIndex]*v1.Validator, error) {
+		provider, isProvider := n.currentClient().(eth2client.ValidatorsProvider)
+		if !isProvider {
+			return nil, errors.New("client does not implement eth2client.ValidatorsProvider")
+		}
+
+		rsp, err := retryThrottled(ctx, n, provider.Validators, &api.ValidatorsOpts{
+			State:   state,
+			Indices: indices,
+			PubKeys: pubKeys,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return rsp.Data, nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return rsp.Data, nil
+	if shared {
+		n.cacheValidators(ctx, stateRoot, validators)
+	}
+
+	return validators, nil
+}
+
+func (n *node) FetchValidator(ctx context.Context, stateID string, idOrPubkey string) (*v1.Validator, error) {
+	validator, err := n.currentAPI().StateValidator(ctx, stateID, idOrPubkey)
+	if err != nil {
+		return nil, wrapNotFound(err, ErrValidatorNotFound)
+	}
+
+	return validator, nil
 }
 
 func (n *node) FetchBeaconCommittees(ctx context.Context, state string, epoch *phase0.Epoch) ([]*v1.BeaconCommittee, error) {
-	provider, isProvider := n.client.(eth2client.BeaconCommitteesProvider)
+	provider, isProvider := n.currentClient().(eth2client.BeaconCommitteesProvider)
 	if !isProvider {
 		return nil, errors.New("client does not implement eth2client.BeaconCommitteesProvider")
 	}
@@ -270,7 +608,7 @@ func (n *node) FetchBeaconCommittees(ctx context.Context, state string, epoch *p
 		opts.Epoch = epoch
 	}
 
-	rsp, err := provider.BeaconCommittees(ctx, opts)
+	rsp, err := retryThrottled(ctx, n, provider.BeaconCommittees, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -279,14 +617,47 @@ func (n *node) FetchBeaconCommittees(ctx context.Context, state string, epoch *p
 }
 
 func (n *node) FetchAttestationData(ctx context.Context, slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, error) {
-	provider, isProvider := n.client.(eth2client.AttestationDataProvider)
+	if data, exists := n.cachedAttestationData(ctx, slot, committeeIndex); exists {
+		return data, nil
+	}
+
+	key := fmt.Sprintf("attestation_data_%d_%d", slot, committeeIndex)
+
+	return singleFlight(ctx, &n.inflight, key, func(ctx context.Context) (*phase0.AttestationData, error) {
+		provider, isProvider := n.currentClient().(eth2client.AttestationDataProvider)
+		if !isProvider {
+			return nil, errors.New("client does not implement eth2client.AttestationDataProvider")
+		}
+
+		rsp, err := retryThrottled(ctx, n, provider.AttestationData, &api.AttestationDataOpts{
+			Slot:           slot,
+			CommitteeIndex: committeeIndex,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Attestation data is only valid for the duration of its slot.
+		if wallclock := n.Wallclock(); wallclock != nil {
+			s := wallclock.Slots().FromNumber(uint64(slot))
+
+			n.cacheAttestationData(ctx, slot, committeeIndex, rsp.Data, s.TimeWindow().End())
+		}
+
+		return rsp.Data, nil
+	})
+}
+
+func (n *node) FetchSyncCommitteeContribution(ctx context.Context, slot phase0.Slot, subcommitteeIndex uint64, beaconBlockRoot phase0.Root) (*altair.SyncCommitteeContribution, error) {
+	provider, isProvider := n.currentClient().(eth2client.SyncCommitteeContributionProvider)
 	if !isProvider {
-		return nil, errors.New("client does not implement eth2client.AttestationDataProvider")
+		return nil, errors.New("client does not implement eth2client.SyncCommitteeContributionProvider")
 	}
 
-	rsp, err := provider.AttestationData(ctx, &api.AttestationDataOpts{
-		Slot:           slot,
-		CommitteeIndex: committeeIndex,
+	rsp, err := retryThrottled(ctx, n, provider.SyncCommitteeContribution, &api.SyncCommitteeContributionOpts{
+		Slot:              slot,
+		SubcommitteeIndex: subcommitteeIndex,
+		BeaconBlockRoot:   beaconBlockRoot,
 	})
 	if err != nil {
 		return nil, err
@@ -296,15 +667,29 @@ func (n *node) FetchAttestationData(ctx context.Context, slot phase0.Slot, commi
 }
 
 func (n *node) FetchBeaconBlockHeader(ctx context.Context, opts *api.BeaconBlockHeaderOpts) (*v1.BeaconBlockHeader, error) {
-	provider, isProvider := n.client.(eth2client.BeaconBlockHeadersProvider)
+	result, err := n.FetchBeaconBlockHeaderWithMetadata(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// FetchBeaconBlockHeaderWithMetadata fetches a beacon block header together with the execution_optimistic and
+// finalized flags of the response.
+func (n *node) FetchBeaconBlockHeaderWithMetadata(ctx context.Context, opts *api.BeaconBlockHeaderOpts) (*FetchResult[*v1.BeaconBlockHeader], error) {
+	provider, isProvider := n.currentClient().(eth2client.BeaconBlockHeadersProvider)
 	if !isProvider {
 		return nil, errors.New("client does not implement eth2client.BeaconBlockHeadersProvider")
 	}
 
-	rsp, err := provider.BeaconBlockHeader(ctx, opts)
+	rsp, err := retryThrottled(ctx, n, provider.BeaconBlockHeader, opts)
 	if err != nil {
-		return nil, err
+		return nil, wrapNotFound(err, ErrBlockNotFound)
 	}
 
-	return rsp.Data, nil
+	return &FetchResult[*v1.BeaconBlockHeader]{
+		ResponseMetadata: parseResponseMetadata(rsp.Metadata),
+		Data:             rsp.Data,
+	}, nil
 }
@@ -0,0 +1,173 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// BlockResult is the result of fetching a single block as part of a batch.
+type BlockResult struct {
+	Block *spec.VersionedSignedBeaconBlock
+	Err   error
+}
+
+// FetchBlocks fetches the blocks for the given block ids using a pool of concurrent workers.
+// The returned map is keyed by block id and each result carries its own error.
+func (n *node) FetchBlocks(ctx context.Context, blockIDs []string, concurrency int) (map[string]*BlockResult, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make(map[string]*BlockResult, len(blockIDs))
+	if len(blockIDs) == 0 {
+		return results, nil
+	}
+
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	ids := make(chan string)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for id := range ids {
+				block, err := n.FetchBlock(ctx, id)
+
+				mu.Lock()
+				results[id] = &BlockResult{
+					Block: block,
+					Err:   err,
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seen := make(map[string]struct{}, len(blockIDs))
+
+	func() {
+		defer close(ids)
+
+		for _, id := range blockIDs {
+			if _, exists := seen[id]; exists {
+				continue
+			}
+
+			seen[id] = struct{}{}
+
+			select {
+			case <-ctx.Done():
+				return
+			case ids <- id:
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// FetchBlobSidecarsRange fetches the blob sidecars for every slot in the inclusive range [fromSlot, toSlot]
+// using a pool of concurrent workers. Slots without a block are skipped, and slots with a block that contains
+// no blobs are omitted from the result.
+func (n *node) FetchBlobSidecarsRange(ctx context.Context, fromSlot, toSlot phase0.Slot, concurrency int) (map[phase0.Slot][]*deneb.BlobSidecar, error) {
+	if toSlot < fromSlot {
+		return nil, fmt.Errorf("invalid slot range: from slot %d is after to slot %d", fromSlot, toSlot)
+	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(map[phase0.Slot][]*deneb.BlobSidecar)
+
+	var firstErr error
+
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	slots := make(chan phase0.Slot)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for slot := range slots {
+				sidecars, err := n.FetchBeaconBlockBlobs(ctx, fmt.Sprintf("%d", slot))
+				if err != nil {
+					if errors.Is(err, ErrBlockNotFound) {
+						// Empty slot.
+						continue
+					}
+
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("failed to fetch blob sidecars for slot %d: %w", slot, err)
+					}
+					mu.Unlock()
+
+					cancel()
+
+					continue
+				}
+
+				if len(sidecars) == 0 {
+					continue
+				}
+
+				mu.Lock()
+				results[slot] = sidecars
+				mu.Unlock()
+			}
+		}()
+	}
+
+	func() {
+		defer close(slots)
+
+		for slot := fromSlot; ; slot++ {
+			select {
+			case <-ctx.Done():
+				return
+			case slots <- slot:
+			}
+
+			// Stop before incrementing, as the slot would wrap around if toSlot is the highest slot.
+			if slot == toSlot {
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,168 @@
+package beacon
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchBlocks(t *testing.T) {
+	n := &node{
+		log:     logging.New(nil),
+		options: DefaultOptions(),
+		client: &fakeClient{blocks: byRoot(map[phase0.Root]*spec.VersionedSignedBeaconBlock{
+			{0x01}: capellaBlock(1, phase0.Root{}),
+			{0x02}: capellaBlock(2, phase0.Root{0x01}),
+		})},
+	}
+
+	const (
+		first   = "0x0100000000000000000000000000000000000000000000000000000000000000"
+		second  = "0x0200000000000000000000000000000000000000000000000000000000000000"
+		missing = "0x0300000000000000000000000000000000000000000000000000000000000000"
+	)
+
+	tests := []struct {
+		name        string
+		blockIDs    []string
+		concurrency int
+		slots       map[string]phase0.Slot
+		failed      []string
+	}{
+		{
+			name:        "no block ids",
+			concurrency: 2,
+			slots:       map[string]phase0.Slot{},
+		},
+		{
+			name:        "all found",
+			blockIDs:    []string{first, second},
+			concurrency: 2,
+			slots:       map[string]phase0.Slot{first: 1, second: 2},
+		},
+		{
+			name:        "per id errors",
+			blockIDs:    []string{first, missing},
+			concurrency: 2,
+			slots:       map[string]phase0.Slot{first: 1},
+			failed:      []string{missing},
+		},
+		{
+			name:        "duplicate ids share a result",
+			blockIDs:    []string{first, first, second},
+			concurrency: 1,
+			slots:       map[string]phase0.Slot{first: 1, second: 2},
+		},
+		{
+			name:        "concurrency below one",
+			blockIDs:    []string{second},
+			concurrency: 0,
+			slots:       map[string]phase0.Slot{second: 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results, err := n.FetchBlocks(context.Background(), test.blockIDs, test.concurrency)
+			require.NoError(t, err)
+			assert.Len(t, results, len(test.slots)+len(test.failed))
+
+			for id, slot := range test.slots {
+				require.Contains(t, results, id)
+				require.NoError(t, results[id].Err)
+				assert.Equal(t, slot, results[id].Block.Capella.Message.Slot)
+			}
+
+			for _, id := range test.failed {
+				require.Contains(t, results, id)
+				assert.Error(t, results[id].Err)
+				assert.Nil(t, results[id].Block)
+			}
+		})
+	}
+}
+
+func TestFetchBlobSidecarsRange(t *testing.T) {
+	sidecar := &deneb.BlobSidecar{Index: 0}
+
+	tests := []struct {
+		name     string
+		from     phase0.Slot
+		to       phase0.Slot
+		failing  string
+		expected []phase0.Slot
+		err      bool
+	}{
+		{
+			name:     "single slot",
+			from:     10,
+			to:       10,
+			expected: []phase0.Slot{10},
+		},
+		{
+			name:     "empty slots and blocks without blobs are skipped",
+			from:     10,
+			to:       13,
+			expected: []phase0.Slot{10, 12},
+		},
+		{
+			name: "inverted range",
+			from: 13,
+			to:   10,
+			err:  true,
+		},
+		{
+			name:    "failed fetch",
+			from:    10,
+			to:      13,
+			failing: "12",
+			err:     true,
+		},
+		{
+			name:     "range ending at the highest slot",
+			from:     math.MaxUint64 - 1,
+			to:       math.MaxUint64,
+			expected: []phase0.Slot{math.MaxUint64},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := &node{
+				log:          logging.New(nil),
+				options:      DefaultOptions(),
+				capabilities: newCapabilities(),
+				client: &fakeClient{
+					sidecars: map[string][]*deneb.BlobSidecar{
+						"10":                   {sidecar},
+						"11":                   {},
+						"12":                   {sidecar, sidecar},
+						"18446744073709551615": {sidecar},
+					},
+					failing: test.failing,
+				},
+			}
+
+			results, err := n.FetchBlobSidecarsRange(context.Background(), test.from, test.to, 2)
+			if test.err {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, results, len(test.expected))
+
+			for _, slot := range test.expected {
+				assert.Contains(t, results, slot)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// FetchKind is the kind of data a FetchRequest is for.
+type FetchKind string
+
+const (
+	// FetchKindBlock is a request made by FetchBlock. Its result is a *spec.VersionedSignedBeaconBlock.
+	FetchKindBlock FetchKind = "block"
+	// FetchKindState is a request made by FetchBeaconState. Its result is a *spec.VersionedBeaconState.
+	FetchKindState FetchKind = "state"
+	// FetchKindValidators is a request made by FetchValidators. Its result is a
+	// map[phase0.ValidatorIndex]*v1.Validator.
+	FetchKindValidators FetchKind = "validators"
+)
+
+// FetchRequest describes a fetch passed to the fetch hooks.
+type FetchRequest struct {
+	Kind FetchKind
+	// ID is the block or state id the data is fetched for, as passed by the caller. Ids other than roots, such
+	// as head, finalized or a slot, refer to different data as the chain progresses or reorgs, so results for
+	// them must not be cached beyond the current head, let alone shared with other processes.
+	ID string
+	// Indices and PubKeys are the validators requested by FetchValidators.
+	Indices []phase0.ValidatorIndex
+	PubKeys []phase0.BLSPubKey
+}
+
+// BeforeFetchHook is called before the node is queried. Returning a non-nil result, of the type documented on
+// the request's FetchKind, skips the node and returns the result instead, e.g. on a hit in an external cache.
+// Returning an error fails the fetch, as does returning a nil pointer or map of the documented type, e.g.
+// (*spec.VersionedSignedBeaconBlock)(nil). A hook without a result returns an untyped nil.
+type BeforeFetchHook func(ctx context.Context, request *FetchRequest) (any, error)
+
+// AfterFetchHook is called once the node has been queried, with the result or the error of the fetch, e.g. to
+// populate an external cache. It is not called for results returned by a BeforeFetchHook.
+type AfterFetchHook func(ctx context.Context, request *FetchRequest, result any, err error)
+
+func (n *node) OnBeforeFetch(hook BeforeFetchHook) {
+	n.fetchHooksMu.Lock()
+	defer n.fetchHooksMu.Unlock()
+
+	n.beforeFetch = append(n.beforeFetch, hook)
+}
+
+func (n *node) OnAfterFetch(hook AfterFetchHook) {
+	n.fetchHooksMu.Lock()
+	defer n.fetchHooksMu.Unlock()
+
+	n.afterFetch = append(n.afterFetch, hook)
+}
+
+// withFetchHooks runs fetch surrounded by the registered fetch hooks. The before hooks are called in the order
+// they were registered until one of them returns a result.
+func withFetchHooks[T any](ctx context.Context, n *node, request *FetchRequest, fetch func() (T, error)) (T, error) {
+	n.fetchHooksMu.RLock()
+	before := n.beforeFetch
+	after := n.afterFetch
+	n.fetchHooksMu.RUnlock()
+
+	var zero T
+
+	for _, hook := range before {
+		result, err := hook(ctx, request)
+		if err != nil {
+			return zero, err
+		}
+
+		if result == nil {
+			continue
+		}
+
+		typed, valid := result.(T)
+		if !valid {
+			return zero, fmt.Errorf("before fetch hook returned %T for a %s fetch, expected %T", result, request.Kind, zero)
+		}
+
+		// A nil pointer or map in the interface passes the nil check above, but would be returned to the
+		// caller as a successful fetch.
+		if value := reflect.ValueOf(result); (value.Kind() == reflect.Pointer || value.Kind() == reflect.Map) && value.IsNil() {
+			return zero, fmt.Errorf("before fetch hook returned a nil %T for a %s fetch", result, request.Kind)
+		}
+
+		return typed, nil
+	}
+
+	result, err := fetch()
+
+	for _, hook := range after {
+		hook(ctx, request, result, err)
+	}
+
+	return result, err
+}
@@ -0,0 +1,97 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchHooks(t *testing.T) {
+	// The node has no client, so every fetch that reaches it fails.
+	n := &node{log: logging.New(nil), options: DefaultOptions()}
+
+	cached := &spec.VersionedSignedBeaconBlock{Version: spec.DataVersionDeneb}
+	fetched := []*FetchRequest{}
+
+	n.OnBeforeFetch(func(ctx context.Context, request *FetchRequest) (any, error) {
+		if request.Kind == FetchKindBlock && request.ID == "head" {
+			return cached, nil
+		}
+
+		return nil, nil
+	})
+	n.OnAfterFetch(func(ctx context.Context, request *FetchRequest, result any, err error) {
+		assert.Error(t, err)
+
+		fetched = append(fetched, request)
+	})
+
+	block, err := n.FetchBlock(context.Background(), "head")
+	require.NoError(t, err)
+	assert.Same(t, cached, block)
+
+	_, err = n.FetchBlock(context.Background(), "finalized")
+	require.Error(t, err)
+
+	_, err = n.FetchValidators(context.Background(), "head", []phase0.ValidatorIndex{1}, nil)
+	require.Error(t, err)
+
+	// The after hooks are only called for fetches that reached the node.
+	require.Len(t, fetched, 2)
+	assert.Equal(t, &FetchRequest{Kind: FetchKindBlock, ID: "finalized"}, fetched[0])
+	assert.Equal(t, &FetchRequest{Kind: FetchKindValidators, ID: "head", Indices: []phase0.ValidatorIndex{1}}, fetched[1])
+}
+
+func TestBeforeFetchHookErrors(t *testing.T) {
+	n := &node{log: logging.New(nil), options: DefaultOptions()}
+
+	errUnavailable := errors.New("cache unavailable")
+
+	n.OnBeforeFetch(func(ctx context.Context, request *FetchRequest) (any, error) {
+		switch request.Kind {
+		case FetchKindState:
+			return nil, errUnavailable
+		default:
+			return map[phase0.ValidatorIndex]*v1.Validator{}, nil
+		}
+	})
+
+	_, err := n.FetchBeaconState(context.Background(), "head")
+	require.ErrorIs(t, err, errUnavailable)
+
+	// A result of the wrong type fails the fetch.
+	_, err = n.FetchBlock(context.Background(), "head")
+	require.ErrorContains(t, err, "expected *spec.VersionedSignedBeaconBlock")
+
+	validators, err := n.FetchValidators(context.Background(), "head", nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, validators)
+}
+
+func TestBeforeFetchHookTypedNil(t *testing.T) {
+	n := &node{log: logging.New(nil), options: DefaultOptions()}
+
+	n.OnBeforeFetch(func(ctx context.Context, request *FetchRequest) (any, error) {
+		switch request.Kind {
+		case FetchKindBlock:
+			return (*spec.VersionedSignedBeaconBlock)(nil), nil
+		default:
+			return map[phase0.ValidatorIndex]*v1.Validator(nil), nil
+		}
+	})
+
+	// A typed nil is not a result, so it fails the fetch rather than being returned as a nil block.
+	block, err := n.FetchBlock(context.Background(), "head")
+	require.ErrorContains(t, err, "nil *spec.VersionedSignedBeaconBlock")
+	assert.Nil(t, block)
+
+	_, err = n.FetchValidators(context.Background(), "head", nil, nil)
+	require.ErrorContains(t, err, "nil map")
+}
@@ -0,0 +1,168 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/ethpandaops/ethwallclock"
+)
+
+func (n *node) CurrentFork() (*state.ForkEpoch, error) {
+	sp, epoch, err := n.specAndCurrentEpoch()
+	if err != nil {
+		return nil, err
+	}
+
+	return sp.ForkEpochs.CurrentFork(epoch)
+}
+
+func (n *node) NextScheduledFork() (*state.ForkEpoch, error) {
+	sp, epoch, err := n.specAndCurrentEpoch()
+	if err != nil {
+		return nil, err
+	}
+
+	return sp.ForkEpochs.NextScheduledFork(epoch)
+}
+
+// specAndCurrentEpoch returns the cached spec and the current wallclock epoch.
+func (n *node) specAndCurrentEpoch() (*state.Spec, phase0.Epoch, error) {
+	sp, err := n.Spec()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	wallclock := n.Wallclock()
+	if wallclock == nil {
+		return nil, 0, errors.New("wallclock is not available")
+	}
+
+	epoch := wallclock.Epochs().Current()
+
+	return sp, phase0.Epoch(epoch.Number()), nil
+}
+
+// subscribeForkImminent emits a ForkImminentEvent once the next scheduled fork is within
+// Options.ForkImminentEpochs epochs of activating.
+func (n *node) subscribeForkImminent(ctx context.Context) {
+	n.Wallclock().OnEpochChanged(func(epoch ethwallclock.Epoch) {
+		n.checkForkImminent(ctx, phase0.Epoch(epoch.Number()))
+	})
+}
+
+// checkForkImminent publishes a ForkImminentEvent for the next scheduled fork if it activates within
+// Options.ForkImminentEpochs epochs of the given epoch. Each fork is only published once.
+func (n *node) checkForkImminent(ctx context.Context, epoch phase0.Epoch) {
+	sp, err := n.Spec()
+	if err != nil {
+		return
+	}
+
+	next, err := sp.ForkEpochs.NextScheduledFork(epoch)
+	if err != nil {
+		return
+	}
+
+	until := next.Epoch - epoch
+	if until > n.currentOptions().ForkImminentEpochs {
+		return
+	}
+
+	n.forkImminentMu.Lock()
+
+	if n.forkImminent == nil {
+		n.forkImminent = make(map[spec.DataVersion]struct{})
+	}
+
+	if _, published := n.forkImminent[next.Name]; published {
+		n.forkImminentMu.Unlock()
+
+		return
+	}
+
+	n.forkImminent[next.Name] = struct{}{}
+
+	n.forkImminentMu.Unlock()
+
+	var activatesAt time.Time
+	if wallclock := n.Wallclock(); wallclock != nil {
+		activation := wallclock.Epochs().FromNumber(uint64(next.Epoch))
+		activatesAt = activation.TimeWindow().Start()
+	}
+
+	n.log.WithField("fork", next.Name.String()).WithField("epochs_until", until).Info("Fork is imminent")
+
+	n.publishForkImminent(ctx, &ForkImminentEvent{
+		Fork:        next,
+		Epoch:       epoch,
+		EpochsUntil: until,
+		ActivatesAt: activatesAt,
+	})
+}
+
+// subscribeForkActivation emits a ForkActivatedEvent at the epoch transition where a scheduled fork activates.
+func (n *node) subscribeForkActivation(ctx context.Context) {
+	n.Wallclock().OnEpochChanged(func(epoch ethwallclock.Epoch) {
+		n.checkForkActivated(ctx, phase0.Epoch(epoch.Number()))
+		n.checkBlobLimitChanged(ctx, phase0.Epoch(epoch.Number()))
+	})
+}
+
+// checkForkActivated publishes a ForkActivatedEvent for every fork that activates at the given epoch, in fork
+// order.
+func (n *node) checkForkActivated(ctx context.Context, epoch phase0.Epoch) {
+	sp, err := n.Spec()
+	if err != nil {
+		return
+	}
+
+	for _, fork := range sp.ForkEpochs.Active(epoch) {
+		if fork.Epoch != epoch {
+			continue
+		}
+
+		n.log.WithField("fork", fork.Name.String()).WithField("epoch", epoch).Info("Fork activated")
+
+		n.publishForkActivated(ctx, &ForkActivatedEvent{
+			Fork:  fork,
+			Epoch: epoch,
+		})
+	}
+}
+
+// checkBlobLimitChanged publishes a BlobLimitChangedEvent if a blob schedule entry that changes the maximum number
+// of blobs per block activates at the given epoch.
+func (n *node) checkBlobLimitChanged(ctx context.Context, epoch phase0.Epoch) {
+	sp, err := n.Spec()
+	if err != nil {
+		return
+	}
+
+	current := sp.BlobSchedule.Active(epoch)
+	if current == nil || current.Epoch != epoch {
+		return
+	}
+
+	var previous uint64
+	if epoch > 0 {
+		if entry := sp.BlobSchedule.Active(epoch - 1); entry != nil {
+			previous = entry.MaxBlobsPerBlock
+		}
+	}
+
+	if previous == current.MaxBlobsPerBlock {
+		return
+	}
+
+	n.log.WithField("epoch", epoch).WithField("max_blobs_per_block", current.MaxBlobsPerBlock).Info("Blob limit changed")
+
+	n.publishBlobLimitChanged(ctx, &BlobLimitChangedEvent{
+		Epoch:            epoch,
+		Previous:         previous,
+		MaxBlobsPerBlock: current.MaxBlobsPerBlock,
+	})
+}
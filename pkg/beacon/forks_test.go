@@ -0,0 +1,170 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/ethpandaops/ethwallclock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newForkTestNode() *node {
+	options := DefaultOptions()
+	options.ForkImminentEpochs = 2
+
+	genesis := time.Now().Add(-10 * 32 * 12 * time.Second)
+
+	return &node{
+		log:       logging.New(nil),
+		broker:    emission.NewEmitter(),
+		options:   options,
+		wallclock: ethwallclock.NewEthereumBeaconChain(genesis, 12*time.Second, 32),
+		spec: &state.Spec{
+			SlotsPerEpoch: 32,
+			ForkEpochs: state.ForkEpochs{
+				{Epoch: 0, Name: spec.DataVersionPhase0},
+				{Epoch: 12, Name: spec.DataVersionAltair},
+			},
+		},
+	}
+}
+
+func TestCheckForkImminent(t *testing.T) {
+	n := newForkTestNode()
+
+	events := make(chan *ForkImminentEvent, 4)
+
+	n.OnForkImminent(context.Background(), func(ctx context.Context, event *ForkImminentEvent) error {
+		events <- event
+
+		return nil
+	})
+
+	// Too early, then within the window twice.
+	n.checkForkImminent(context.Background(), 9)
+	n.checkForkImminent(context.Background(), 10)
+	n.checkForkImminent(context.Background(), 11)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, spec.DataVersionAltair, event.Fork.Name)
+		assert.EqualValues(t, 10, event.Epoch)
+		assert.EqualValues(t, 2, event.EpochsUntil)
+		assert.WithinDuration(t, time.Now().Add(2*32*12*time.Second), event.ActivatesAt, 13*time.Second)
+	case <-time.After(time.Second):
+		require.Fail(t, "expected a fork imminent event")
+	}
+
+	select {
+	case event := <-events:
+		require.Failf(t, "unexpected fork imminent event", "%+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCheckForkActivated(t *testing.T) {
+	n := newForkTestNode()
+	n.spec.ForkEpochs = append(n.spec.ForkEpochs, &state.ForkEpoch{Epoch: 12, Name: spec.DataVersionBellatrix})
+
+	events := make(chan *ForkActivatedEvent, 4)
+
+	n.OnForkActivated(context.Background(), func(ctx context.Context, event *ForkActivatedEvent) error {
+		events <- event
+
+		return nil
+	})
+
+	n.checkForkActivated(context.Background(), 11)
+	n.checkForkActivated(context.Background(), 12)
+	n.checkForkActivated(context.Background(), 13)
+
+	activated := []spec.DataVersion{}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-events:
+			assert.EqualValues(t, 12, event.Epoch)
+
+			activated = append(activated, event.Fork.Name)
+		case <-time.After(time.Second):
+			require.Fail(t, "expected a fork activated event")
+		}
+	}
+
+	assert.ElementsMatch(t, []spec.DataVersion{spec.DataVersionAltair, spec.DataVersionBellatrix}, activated)
+
+	select {
+	case event := <-events:
+		require.Failf(t, "unexpected fork activated event", "%+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCurrentAndNextScheduledFork(t *testing.T) {
+	n := newForkTestNode()
+
+	current, err := n.CurrentFork()
+	require.NoError(t, err)
+	assert.Equal(t, spec.DataVersionPhase0, current.Name)
+
+	next, err := n.NextScheduledFork()
+	require.NoError(t, err)
+	assert.Equal(t, spec.DataVersionAltair, next.Name)
+
+	n.spec.ForkEpochs[1].Epoch = 5
+
+	current, err = n.CurrentFork()
+	require.NoError(t, err)
+	assert.Equal(t, spec.DataVersionAltair, current.Name)
+
+	_, err = n.NextScheduledFork()
+	assert.Error(t, err)
+}
+
+func TestCheckBlobLimitChanged(t *testing.T) {
+	n := newForkTestNode()
+	n.spec.BlobSchedule = state.BlobSchedule{
+		{Epoch: 10, MaxBlobsPerBlock: 9},
+		{Epoch: 20, MaxBlobsPerBlock: 9},
+		{Epoch: 30, MaxBlobsPerBlock: 12},
+	}
+
+	events := make(chan *BlobLimitChangedEvent, 4)
+
+	n.OnBlobLimitChanged(context.Background(), func(ctx context.Context, event *BlobLimitChangedEvent) error {
+		events <- event
+
+		return nil
+	})
+
+	for _, epoch := range []phase0.Epoch{9, 10, 11, 20, 30} {
+		n.checkBlobLimitChanged(context.Background(), epoch)
+	}
+
+	for _, expected := range []*BlobLimitChangedEvent{
+		{Epoch: 10, Previous: 0, MaxBlobsPerBlock: 9},
+		{Epoch: 30, Previous: 9, MaxBlobsPerBlock: 12},
+	} {
+		select {
+		case event := <-events:
+			assert.Equal(t, expected.Epoch, event.Epoch)
+			assert.Equal(t, expected.Previous, event.Previous)
+			assert.Equal(t, expected.MaxBlobsPerBlock, event.MaxBlobsPerBlock)
+		case <-time.After(time.Second):
+			require.Fail(t, "expected a blob limit changed event")
+		}
+	}
+
+	select {
+	case event := <-events:
+		require.Failf(t, "unexpected blob limit changed event", "%+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
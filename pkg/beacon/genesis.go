@@ -3,24 +3,73 @@ package beacon
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
 func (n *node) FetchGenesis(ctx context.Context) (*v1.Genesis, error) {
-	provider, isProvider := n.client.(eth2client.GenesisProvider)
-	if !isProvider {
-		return nil, errors.New("client does not implement eth2client.GenesisProvider")
+	return singleFlight(ctx, &n.inflight, "genesis", func(ctx context.Context) (*v1.Genesis, error) {
+		provider, isProvider := n.currentClient().(eth2client.GenesisProvider)
+		if !isProvider {
+			return nil, errors.New("client does not implement eth2client.GenesisProvider")
+		}
+
+		rsp, err := retryThrottled(ctx, n, provider.Genesis, &api.GenesisOpts{})
+		if err != nil {
+			return nil, wrapNotFound(err, ErrGenesisNotAvailable)
+		}
+
+		n.genesisMu.Lock()
+		n.genesis = rsp.Data
+		n.genesisMu.Unlock()
+
+		return rsp.Data, nil
+	})
+}
+
+func (n *node) Genesis() (*v1.Genesis, error) {
+	n.genesisMu.RLock()
+	genesis := n.genesis
+	n.genesisMu.RUnlock()
+
+	if genesis != nil {
+		return genesis, nil
+	}
+
+	if n.currentClient() == nil {
+		return nil, ErrGenesisNotAvailable
 	}
 
-	rsp, err := provider.Genesis(ctx, &api.GenesisOpts{})
+	ctx := n.currentCtx()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	genesis, err := n.FetchGenesis(ctx)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrGenesisNotAvailable, err)
+	}
+
+	if genesis == nil {
+		return nil, ErrGenesisNotAvailable
 	}
 
-	n.genesis = rsp.Data
+	return genesis, nil
+}
+
+func (n *node) GenesisValidatorsRoot() (phase0.Root, error) {
+	genesis, err := n.Genesis()
+	if err != nil {
+		return phase0.Root{}, err
+	}
 
-	return rsp.Data, nil
+	return genesis.GenesisValidatorsRoot, nil
 }
@@ -0,0 +1,89 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
+	"github.com/ethpandaops/beacon/pkg/beacon/blockutil"
+)
+
+const (
+	// graffitiPrefixNone is the prefix of blocks without graffiti.
+	graffitiPrefixNone = "none"
+	// graffitiPrefixOther is the prefix of blocks whose graffiti does not start with a known client.
+	graffitiPrefixOther = "other"
+)
+
+// graffitiPrefixes are the prefixes GraffitiPrefix reports. Graffiti is chosen by proposers, so only known
+// client names are reported to keep the cardinality of the graffiti metrics bounded.
+var graffitiPrefixes = map[string]bool{
+	string(types.AgentLighthouse): true,
+	string(types.AgentNimbus):     true,
+	string(types.AgentTeku):       true,
+	string(types.AgentPrysm):      true,
+	string(types.AgentLodestar):   true,
+	string(types.AgentGrandine):   true,
+	"geth":                        true,
+	"nethermind":                  true,
+	"besu":                        true,
+	"erigon":                      true,
+	"reth":                        true,
+}
+
+// GraffitiPrefix normalizes the graffiti to the client it starts with, e.g. "Lighthouse/v5.1.0" becomes
+// "lighthouse". The leading run of letters is lowercased and reported if it is the name of a known consensus
+// or execution client, graffiti starting with anything else is reported as "other" and empty graffiti as
+// "none".
+func GraffitiPrefix(graffiti string) string {
+	graffiti = strings.TrimSpace(graffiti)
+	if graffiti == "" {
+		return graffitiPrefixNone
+	}
+
+	end := strings.IndexFunc(graffiti, func(r rune) bool {
+		return r > unicode.MaxASCII || !unicode.IsLetter(r)
+	})
+	if end == -1 {
+		end = len(graffiti)
+	}
+
+	prefix := strings.ToLower(graffiti[:end])
+	if !graffitiPrefixes[prefix] {
+		return graffitiPrefixOther
+	}
+
+	return prefix
+}
+
+func (n *node) subscribeGraffitiExtraction(ctx context.Context) {
+	n.OnHead(ctx, func(ctx context.Context, event *v1.HeadEvent) error {
+		block, err := n.FetchBlock(ctx, fmt.Sprintf("%#x", event.Block))
+		if err != nil {
+			return fmt.Errorf("failed to fetch head block to extract graffiti: %w", err)
+		}
+
+		graffiti, err := blockutil.Graffiti(block)
+		if err != nil {
+			return err
+		}
+
+		proposer, err := block.ProposerIndex()
+		if err != nil {
+			return err
+		}
+
+		n.publishBlockGraffiti(ctx, &BlockGraffitiEvent{
+			Root:          event.Block,
+			Slot:          event.Slot,
+			ProposerIndex: proposer,
+			Graffiti:      graffiti,
+			Prefix:        GraffitiPrefix(graffiti),
+		})
+
+		return nil
+	})
+}
@@ -0,0 +1,32 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraffitiPrefix(t *testing.T) {
+	tests := []struct {
+		graffiti string
+		expected string
+	}{
+		{graffiti: "", expected: graffitiPrefixNone},
+		{graffiti: "   ", expected: graffitiPrefixNone},
+		{graffiti: "Lighthouse/v5.1.0-abc", expected: "lighthouse"},
+		{graffiti: "teku", expected: "teku"},
+		{graffiti: "RP-N nimbus", expected: graffitiPrefixOther},
+		{graffiti: "  Nethermind Lodestar", expected: "nethermind"},
+		{graffiti: "GETH", expected: "geth"},
+		{graffiti: "🦏 rocket", expected: graffitiPrefixOther},
+		{graffiti: "0xdeadbeef", expected: graffitiPrefixOther},
+		{graffiti: "abcdefghijklmnopqrstuvwxyz", expected: graffitiPrefixOther},
+		{graffiti: "lighthousekeeper", expected: graffitiPrefixOther},
+	}
+
+	for _, test := range tests {
+		t.Run(test.graffiti, func(t *testing.T) {
+			assert.Equal(t, test.expected, GraffitiPrefix(test.graffiti))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// checkHeadLag emits a HeadLagEvent if the node's head is further behind the wallclock slot than allowed.
+func (n *node) checkHeadLag(ctx context.Context, wallclockSlot phase0.Slot) {
+	if n.stat.Syncing() {
+		return
+	}
+
+	headSlot, err := n.HeadSlot()
+	if err != nil {
+		return
+	}
+
+	if wallclockSlot <= headSlot || wallclockSlot-headSlot <= n.currentOptions().HeadLag.Threshold {
+		return
+	}
+
+	n.log.
+		WithField("head_slot", headSlot).
+		WithField("wallclock_slot", wallclockSlot).
+		Debug("Head is lagging behind the wallclock")
+
+	n.publishHeadLag(ctx, headSlot, wallclockSlot)
+}
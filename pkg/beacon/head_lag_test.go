@@ -0,0 +1,114 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func slotPtr(slot phase0.Slot) *phase0.Slot {
+	return &slot
+}
+
+func TestCheckHeadLag(t *testing.T) {
+	tests := []struct {
+		name     string
+		syncing  bool
+		headSlot *phase0.Slot
+		slot     phase0.Slot
+		expected bool
+	}{
+		{
+			name:     "head unknown",
+			slot:     100,
+			expected: false,
+		},
+		{
+			name:     "within threshold",
+			headSlot: slotPtr(96),
+			slot:     100,
+			expected: false,
+		},
+		{
+			name:     "lagging",
+			headSlot: slotPtr(95),
+			slot:     100,
+			expected: true,
+		},
+		{
+			name:     "lagging while syncing",
+			syncing:  true,
+			headSlot: slotPtr(50),
+			slot:     100,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := DefaultOptions().EnableHeadLagDetection()
+
+			n := &node{
+				log:      logging.New(nil),
+				config:   &Config{},
+				options:  options,
+				broker:   emission.NewEmitter(),
+				stat:     NewStatus(1, 1),
+				headSlot: test.headSlot,
+			}
+
+			n.stat.UpdateSyncState(&v1.SyncState{IsSyncing: test.syncing})
+
+			var events []*HeadLagEvent
+
+			n.OnHeadLag(context.Background(), func(ctx context.Context, event *HeadLagEvent) error {
+				events = append(events, event)
+
+				return nil
+			})
+
+			n.checkHeadLag(context.Background(), test.slot)
+
+			if !test.expected {
+				assert.Empty(t, events)
+
+				return
+			}
+
+			assert.Len(t, events, 1)
+			assert.Equal(t, test.slot-*test.headSlot, events[0].Lag)
+		})
+	}
+}
+
+func TestHeadSlotOnlyMovesForward(t *testing.T) {
+	n := &node{
+		log:     logging.New(nil),
+		options: DefaultOptions(),
+		broker:  emission.NewEmitter(),
+	}
+
+	n.setHeadSlot(100)
+
+	// A head event arriving late doesn't take the head slot back.
+	n.setHeadSlot(99)
+
+	slot, err := n.HeadSlot()
+	assert.NoError(t, err)
+	assert.Equal(t, phase0.Slot(100), slot)
+
+	// A reorg onto a lower slot does.
+	assert.NoError(t, n.handleChainReorg(context.Background(), &v1.Event{
+		Topic: topicChainReorg,
+		Data:  &v1.ChainReorgEvent{Slot: 98, Depth: 2},
+	}))
+
+	slot, err = n.HeadSlot()
+	assert.NoError(t, err)
+	assert.Equal(t, phase0.Slot(98), slot)
+}
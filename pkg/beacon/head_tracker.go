@@ -0,0 +1,101 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+
+	eapi "github.com/attestantio/go-eth2-client/api"
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/headtracker"
+)
+
+func (n *node) subscribeHeadTracker(ctx context.Context) {
+	n.headTrackerMu.Lock()
+	n.headTracker = headtracker.New(n.currentOptions().HeadTracker.Slots)
+	n.headTrackerMu.Unlock()
+
+	n.OnBlock(ctx, func(ctx context.Context, event *v1.BlockEvent) error {
+		return n.trackBlock(ctx, event.Block)
+	})
+
+	n.OnHead(ctx, func(ctx context.Context, event *v1.HeadEvent) error {
+		return n.trackHead(ctx, event.Block)
+	})
+
+	n.OnChainReOrg(ctx, func(ctx context.Context, event *v1.ChainReorgEvent) error {
+		return n.trackHead(ctx, event.NewHeadBlock)
+	})
+}
+
+// currentHeadTracker returns the head tracker, or nil if the head tracker is disabled.
+func (n *node) currentHeadTracker() *headtracker.Tracker {
+	n.headTrackerMu.RLock()
+	defer n.headTrackerMu.RUnlock()
+
+	return n.headTracker
+}
+
+// trackHead sets the head of the head tracker, fetching the block and its missing ancestors first if needed.
+func (n *node) trackHead(ctx context.Context, root phase0.Root) error {
+	if err := n.trackBlock(ctx, root); err != nil {
+		return err
+	}
+
+	return n.currentHeadTracker().SetHead(root)
+}
+
+// trackBlock adds the block to the head tracker, followed by any ancestors that are missing from the tracked
+// window.
+func (n *node) trackBlock(ctx context.Context, root phase0.Root) error {
+	tracker := n.currentHeadTracker()
+
+	for i := uint64(0); i <= n.currentOptions().HeadTracker.Slots; i++ {
+		if tracker.Contains(root) {
+			return nil
+		}
+
+		header, err := n.FetchBeaconBlockHeader(ctx, &eapi.BeaconBlockHeaderOpts{
+			Block: fmt.Sprintf("%#x", root),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch block header %#x: %w", root, err)
+		}
+
+		if header == nil || header.Header == nil || header.Header.Message == nil {
+			return fmt.Errorf("block header %#x is empty", root)
+		}
+
+		message := header.Header.Message
+
+		if !tracker.Add(root, message.Slot, message.ParentRoot) || message.Slot == 0 {
+			return nil
+		}
+
+		root = message.ParentRoot
+	}
+
+	return nil
+}
+
+// CanonicalRootAtSlot returns the root of the canonical block at the given slot from the head tracker. It
+// returns false if the slot is empty, outside of the tracked window or the head tracker is disabled.
+func (n *node) CanonicalRootAtSlot(slot phase0.Slot) (phase0.Root, bool) {
+	tracker := n.currentHeadTracker()
+	if tracker == nil {
+		return phase0.Root{}, false
+	}
+
+	return tracker.CanonicalRootAtSlot(slot)
+}
+
+// IsCanonical returns true if the block is part of the canonical chain tracked by the head tracker. It
+// returns false if the block is outside of the tracked window or the head tracker is disabled.
+func (n *node) IsCanonical(root phase0.Root) bool {
+	tracker := n.currentHeadTracker()
+	if tracker == nil {
+		return false
+	}
+
+	return tracker.IsCanonical(root)
+}
@@ -0,0 +1,169 @@
+// Package headtracker maintains an in-memory view of the recent canonical chain.
+package headtracker
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+type block struct {
+	slot   phase0.Slot
+	parent phase0.Root
+}
+
+// Tracker tracks the blocks of the last N slots along with their parent links, and the current head. The
+// canonical chain is the chain of parents walked back from the head.
+type Tracker struct {
+	mu     sync.RWMutex
+	slots  phase0.Slot
+	blocks map[phase0.Root]block
+	head   phase0.Root
+	latest phase0.Slot
+}
+
+// New returns a tracker that keeps the blocks of the given number of slots behind the most recent block.
+func New(slots uint64) *Tracker {
+	return &Tracker{
+		slots:  phase0.Slot(slots),
+		blocks: make(map[phase0.Root]block),
+	}
+}
+
+// cutoff returns the lowest slot that is tracked.
+func (t *Tracker) cutoff() phase0.Slot {
+	if t.latest < t.slots {
+		return 0
+	}
+
+	return t.latest - t.slots
+}
+
+// Add adds a block to the tracker. It returns false if the block is older than the tracked window.
+func (t *Tracker) Add(root phase0.Root, slot phase0.Slot, parent phase0.Root) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if slot > t.latest {
+		t.latest = slot
+
+		cutoff := t.cutoff()
+
+		for r, b := range t.blocks {
+			if b.slot < cutoff {
+				delete(t.blocks, r)
+			}
+		}
+	}
+
+	if slot < t.cutoff() {
+		return false
+	}
+
+	t.blocks[root] = block{slot: slot, parent: parent}
+
+	return true
+}
+
+// Contains returns true if the block is tracked.
+func (t *Tracker) Contains(root phase0.Root) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	_, exists := t.blocks[root]
+
+	return exists
+}
+
+// SetHead sets the head of the canonical chain. The block must have been added first.
+func (t *Tracker) SetHead(root phase0.Root) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, exists := t.blocks[root]; !exists {
+		return fmt.Errorf("block %#x is not tracked", root)
+	}
+
+	t.head = root
+
+	return nil
+}
+
+// Head returns the head of the canonical chain and its slot. It returns false if no head has been set.
+func (t *Tracker) Head() (phase0.Root, phase0.Slot, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	head, exists := t.blocks[t.head]
+	if !exists {
+		return phase0.Root{}, 0, false
+	}
+
+	return t.head, head.slot, true
+}
+
+// walk calls fn for every block of the canonical chain, from the head back to the oldest tracked ancestor,
+// until fn returns false.
+func (t *Tracker) walk(fn func(root phase0.Root, slot phase0.Slot) bool) {
+	root := t.head
+
+	for {
+		b, exists := t.blocks[root]
+		if !exists {
+			return
+		}
+
+		if !fn(root, b.slot) {
+			return
+		}
+
+		root = b.parent
+	}
+}
+
+// CanonicalRootAtSlot returns the root of the canonical block at the given slot. It returns false if the
+// slot is empty on the canonical chain, or is outside of the tracked chain.
+func (t *Tracker) CanonicalRootAtSlot(slot phase0.Slot) (phase0.Root, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var (
+		canonical phase0.Root
+		found     bool
+	)
+
+	t.walk(func(root phase0.Root, s phase0.Slot) bool {
+		if s == slot {
+			canonical = root
+			found = true
+		}
+
+		return s > slot
+	})
+
+	return canonical, found
+}
+
+// IsCanonical returns true if the block is part of the tracked canonical chain.
+func (t *Tracker) IsCanonical(root phase0.Root) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	target, exists := t.blocks[root]
+	if !exists {
+		return false
+	}
+
+	found := false
+
+	t.walk(func(r phase0.Root, s phase0.Slot) bool {
+		if r == root {
+			found = true
+		}
+
+		return s > target.slot
+	})
+
+	return found
+}
@@ -0,0 +1,73 @@
+package headtracker_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/headtracker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func root(b byte) phase0.Root {
+	return phase0.Root{b}
+}
+
+func TestTracker(t *testing.T) {
+	tracker := headtracker.New(64)
+
+	_, _, ok := tracker.Head()
+	assert.False(t, ok)
+
+	// 1 <- 2 <- 4 (slot 3 is empty), with a fork 2 <- 3b.
+	tracker.Add(root(1), 1, root(0))
+	tracker.Add(root(2), 2, root(1))
+	tracker.Add(root(0x3b), 3, root(2))
+	tracker.Add(root(4), 4, root(2))
+
+	require.Error(t, tracker.SetHead(root(5)))
+	require.NoError(t, tracker.SetHead(root(0x3b)))
+
+	canonical, ok := tracker.CanonicalRootAtSlot(3)
+	assert.True(t, ok)
+	assert.Equal(t, root(0x3b), canonical)
+	assert.True(t, tracker.IsCanonical(root(0x3b)))
+	assert.False(t, tracker.IsCanonical(root(4)))
+
+	// Reorg to 4.
+	require.NoError(t, tracker.SetHead(root(4)))
+
+	head, slot, ok := tracker.Head()
+	assert.True(t, ok)
+	assert.Equal(t, root(4), head)
+	assert.Equal(t, phase0.Slot(4), slot)
+
+	_, ok = tracker.CanonicalRootAtSlot(3)
+	assert.False(t, ok)
+
+	canonical, ok = tracker.CanonicalRootAtSlot(1)
+	assert.True(t, ok)
+	assert.Equal(t, root(1), canonical)
+
+	assert.True(t, tracker.IsCanonical(root(4)))
+	assert.True(t, tracker.IsCanonical(root(2)))
+	assert.False(t, tracker.IsCanonical(root(0x3b)))
+	assert.False(t, tracker.IsCanonical(root(0xff)))
+
+	_, ok = tracker.CanonicalRootAtSlot(0)
+	assert.False(t, ok)
+}
+
+func TestTrackerPrunes(t *testing.T) {
+	tracker := headtracker.New(2)
+
+	assert.True(t, tracker.Add(root(1), 1, root(0)))
+	assert.True(t, tracker.Add(root(2), 2, root(1)))
+	assert.True(t, tracker.Add(root(5), 5, root(2)))
+
+	assert.False(t, tracker.Contains(root(1)))
+	assert.False(t, tracker.Contains(root(2)))
+	assert.True(t, tracker.Contains(root(5)))
+
+	assert.False(t, tracker.Add(root(2), 2, root(1)))
+}
@@ -65,6 +65,12 @@ func (n Health) Healthy() bool {
 	return n.healthy
 }
 
+// Unhealthy returns true if the node has failed enough consecutive health checks to be considered unhealthy.
+// Unlike !Healthy, it is false before the first health checks complete and again after the first successful one.
+func (n Health) Unhealthy() bool {
+	return n.failures >= n.failThreshold
+}
+
 // FailedTotal returns the total number of failures.
 func (n Health) FailedTotal() uint64 {
 	return n.failTotal
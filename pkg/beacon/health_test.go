@@ -0,0 +1,112 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchIsHealthyOptimistic(t *testing.T) {
+	n := &node{
+		options: DefaultOptions(),
+		client:  &fakeClient{syncState: &v1.SyncState{IsOptimistic: true}},
+	}
+
+	require.NoError(t, n.fetchIsHealthy(context.Background()))
+
+	n.options.HealthCheck.OptimisticIsUnhealthy = true
+
+	require.ErrorIs(t, n.fetchIsHealthy(context.Background()), ErrNodeOptimistic)
+
+	n.client = &fakeClient{syncState: &v1.SyncState{}}
+
+	require.NoError(t, n.fetchIsHealthy(context.Background()))
+}
+
+func TestStatusOptimistic(t *testing.T) {
+	s := NewStatus(1, 1)
+
+	assert.False(t, s.Optimistic())
+
+	s.UpdateSyncState(&v1.SyncState{IsOptimistic: true})
+
+	assert.True(t, s.Optimistic())
+}
+
+func TestHealthMetricsReadiness(t *testing.T) {
+	n := &node{
+		log:     logging.New(nil),
+		config:  &Config{Name: "node-a"},
+		options: DefaultOptions(),
+		broker:  emission.NewEmitter(),
+		stat:    NewStatus(1, 1),
+	}
+
+	h := NewHealthMetrics(n, n.log, "test_readiness", map[string]string{})
+	require.NoError(t, h.Start(context.Background()))
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(h.Ready))
+	assert.Equal(t, float64(0), testutil.ToFloat64(h.Bootstrapped))
+
+	n.bootstrapped = true
+	n.markReady(context.Background())
+
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(h.Ready) == 1 && testutil.ToFloat64(h.Bootstrapped) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// Readiness is independent of the liveness of the node.
+	assert.Equal(t, float64(0), testutil.ToFloat64(h.Up))
+}
+
+func TestPausePollingWhileUnhealthy(t *testing.T) {
+	client := &fakeClient{err: errors.New("connection refused")}
+
+	n := &node{
+		log:     logging.New(nil),
+		options: DefaultOptions(),
+		broker:  emission.NewEmitter(),
+		client:  client,
+		stat:    NewStatus(1, 2),
+	}
+
+	polls := 0
+	poll := n.poll(func() { polls++ })
+
+	// Polling is not paused before the node has been found unhealthy.
+	poll()
+	assert.Equal(t, 1, polls)
+
+	n.runHealthcheck(context.Background())
+	assert.False(t, n.PollingPaused())
+
+	n.runHealthcheck(context.Background())
+	assert.True(t, n.PollingPaused())
+
+	poll()
+	assert.Equal(t, 1, polls)
+
+	client.err = nil
+
+	n.runHealthcheck(context.Background())
+	assert.False(t, n.PollingPaused())
+
+	poll()
+	assert.Equal(t, 2, polls)
+
+	// Polling is never paused when PausePolling is disabled.
+	n.options.HealthCheck.PausePolling = false
+	client.err = errors.New("connection refused")
+
+	n.runHealthcheck(context.Background())
+	n.runHealthcheck(context.Background())
+	assert.False(t, n.PollingPaused())
+}
@@ -0,0 +1,266 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	eapi "github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// DataIntegrityType is the type of check that failed in a DataIntegrityEvent.
+type DataIntegrityType string
+
+const (
+	// DataIntegrityTypeBlockRoot is a fetched block whose hash tree root does not match the requested root, or
+	// the root the requested id resolved to.
+	DataIntegrityTypeBlockRoot DataIntegrityType = "block_root"
+	// DataIntegrityTypeStateRoot is a fetched state whose hash tree root does not match the requested root, or
+	// the state root of the block in its latest block header.
+	DataIntegrityTypeStateRoot DataIntegrityType = "state_root"
+)
+
+// RootMismatchError is returned when a root computed from fetched data does not match the expected root.
+type RootMismatchError struct {
+	Type DataIntegrityType
+	// ID is the block or state id that was fetched.
+	ID       string
+	Expected phase0.Root
+	Actual   phase0.Root
+}
+
+func (e *RootMismatchError) Error() string {
+	return fmt.Sprintf("%s: %s for %s: expected %#x, got %#x", ErrRootMismatch, e.Type, e.ID, e.Expected, e.Actual)
+}
+
+// Unwrap allows errors.Is to match ErrRootMismatch.
+func (e *RootMismatchError) Unwrap() error {
+	return ErrRootMismatch
+}
+
+// resolveBlockRoot returns the root of the block with the given id. Ids such as head can move on between
+// requests, so a block that is verified is fetched by the root its id resolved to.
+func (n *node) resolveBlockRoot(ctx context.Context, blockID string) (phase0.Root, error) {
+	if root, isRoot := parseBlockIDRoot(blockID); isRoot {
+		return root, nil
+	}
+
+	root, err := n.getBlockRoot(ctx, blockID)
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("failed to resolve block root to verify block: %w", err)
+	}
+
+	return *root, nil
+}
+
+// verifyBlockRoot checks that the hash tree root of the block, requested with the given id, matches the
+// expected root.
+func (n *node) verifyBlockRoot(ctx context.Context, blockID string, expected phase0.Root, block *spec.VersionedSignedBeaconBlock) error {
+	actual, err := block.Root()
+	if err != nil {
+		return fmt.Errorf("failed to compute block root: %w", err)
+	}
+
+	slot, err := block.Slot()
+	if err != nil {
+		return err
+	}
+
+	if expected == actual {
+		return nil
+	}
+
+	n.publishDataIntegrity(ctx, &DataIntegrityEvent{
+		Type:     DataIntegrityTypeBlockRoot,
+		ID:       blockID,
+		Slot:     slot,
+		Expected: expected,
+		Actual:   actual,
+	})
+
+	return &RootMismatchError{
+		Type:     DataIntegrityTypeBlockRoot,
+		ID:       blockID,
+		Expected: expected,
+		Actual:   actual,
+	}
+}
+
+// verifyStateRoot checks the hash tree root of the state, requested with the given id. A state requested by its
+// root must hash to that root. Any other state must be the post-state of the block in its latest block header,
+// which is looked up by root rather than by slot so that states on other forks are not mistaken for corrupt ones.
+// States at empty slots have been advanced past the latest block and states of blocks the node does not serve
+// cannot be verified, they are accepted as is.
+func (n *node) verifyStateRoot(ctx context.Context, stateID string, state *spec.VersionedBeaconState) error {
+	slot, err := state.Slot()
+	if err != nil {
+		return err
+	}
+
+	actual, err := stateRoot(state)
+	if err != nil {
+		return fmt.Errorf("failed to compute state root: %w", err)
+	}
+
+	expected, verifiable, err := n.expectedStateRoot(ctx, stateID, slot, actual, state)
+	if err != nil {
+		return err
+	}
+
+	if !verifiable || expected == actual {
+		return nil
+	}
+
+	n.publishDataIntegrity(ctx, &DataIntegrityEvent{
+		Type:     DataIntegrityTypeStateRoot,
+		ID:       stateID,
+		Slot:     slot,
+		Expected: expected,
+		Actual:   actual,
+	})
+
+	return &RootMismatchError{
+		Type:     DataIntegrityTypeStateRoot,
+		ID:       stateID,
+		Expected: expected,
+		Actual:   actual,
+	}
+}
+
+// expectedStateRoot returns the root the state should hash to, and false if it cannot be verified.
+func (n *node) expectedStateRoot(ctx context.Context, stateID string, slot phase0.Slot, actual phase0.Root, state *spec.VersionedBeaconState) (phase0.Root, bool, error) {
+	if root, isRoot := parseBlockIDRoot(stateID); isRoot {
+		return root, true, nil
+	}
+
+	latest, err := latestBlockHeader(state)
+	if err != nil {
+		return phase0.Root{}, false, err
+	}
+
+	if latest.Slot != slot {
+		return phase0.Root{}, false, nil
+	}
+
+	// The state root of the latest block header is only filled in at the next slot, so it is the root of this
+	// state. If the state is intact, the header hashes to the root of its block.
+	filled := *latest
+	filled.StateRoot = actual
+
+	blockRoot, err := filled.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, false, fmt.Errorf("failed to compute block root: %w", err)
+	}
+
+	_, err = n.FetchBeaconBlockHeader(ctx, &eapi.BeaconBlockHeaderOpts{
+		Block: fmt.Sprintf("%#x", phase0.Root(blockRoot)),
+	})
+	if err == nil {
+		return actual, true, nil
+	}
+
+	if !errors.Is(err, ErrBlockNotFound) {
+		return phase0.Root{}, false, fmt.Errorf("failed to fetch block header to verify state root: %w", err)
+	}
+
+	// No block hashes to the state, so either the state is corrupt or its block is one the node does not serve.
+	// The canonical header at the slot tells the two apart if it is the state's block.
+	header, err := n.FetchBeaconBlockHeader(ctx, &eapi.BeaconBlockHeaderOpts{
+		Block: strconv.FormatUint(uint64(slot), 10),
+	})
+	if err != nil {
+		if errors.Is(err, ErrBlockNotFound) {
+			return phase0.Root{}, false, nil
+		}
+
+		return phase0.Root{}, false, fmt.Errorf("failed to fetch block header to verify state root: %w", err)
+	}
+
+	if header.Header == nil || header.Header.Message == nil {
+		return phase0.Root{}, false, errors.New("block header is empty")
+	}
+
+	canonical := header.Header.Message
+	if canonical.ParentRoot != latest.ParentRoot || canonical.BodyRoot != latest.BodyRoot || canonical.ProposerIndex != latest.ProposerIndex {
+		return phase0.Root{}, false, nil
+	}
+
+	return canonical.StateRoot, true, nil
+}
+
+// latestBlockHeader returns the latest block header of the state.
+func latestBlockHeader(state *spec.VersionedBeaconState) (*phase0.BeaconBlockHeader, error) {
+	var header *phase0.BeaconBlockHeader
+
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		if state.Phase0 != nil {
+			header = state.Phase0.LatestBlockHeader
+		}
+	case spec.DataVersionAltair:
+		if state.Altair != nil {
+			header = state.Altair.LatestBlockHeader
+		}
+	case spec.DataVersionBellatrix:
+		if state.Bellatrix != nil {
+			header = state.Bellatrix.LatestBlockHeader
+		}
+	case spec.DataVersionCapella:
+		if state.Capella != nil {
+			header = state.Capella.LatestBlockHeader
+		}
+	case spec.DataVersionDeneb:
+		if state.Deneb != nil {
+			header = state.Deneb.LatestBlockHeader
+		}
+	default:
+		return nil, fmt.Errorf("unsupported state version %s", state.Version)
+	}
+
+	if header == nil {
+		return nil, fmt.Errorf("no latest block header in %s state", state.Version)
+	}
+
+	return header, nil
+}
+
+// stateRoot returns the hash tree root of the state.
+func stateRoot(state *spec.VersionedBeaconState) (phase0.Root, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		if state.Phase0 == nil {
+			return phase0.Root{}, errors.New("no phase0 state")
+		}
+
+		return state.Phase0.HashTreeRoot()
+	case spec.DataVersionAltair:
+		if state.Altair == nil {
+			return phase0.Root{}, errors.New("no altair state")
+		}
+
+		return state.Altair.HashTreeRoot()
+	case spec.DataVersionBellatrix:
+		if state.Bellatrix == nil {
+			return phase0.Root{}, errors.New("no bellatrix state")
+		}
+
+		return state.Bellatrix.HashTreeRoot()
+	case spec.DataVersionCapella:
+		if state.Capella == nil {
+			return phase0.Root{}, errors.New("no capella state")
+		}
+
+		return state.Capella.HashTreeRoot()
+	case spec.DataVersionDeneb:
+		if state.Deneb == nil {
+			return phase0.Root{}, errors.New("no deneb state")
+		}
+
+		return state.Deneb.HashTreeRoot()
+	default:
+		return phase0.Root{}, fmt.Errorf("unsupported state version %s", state.Version)
+	}
+}
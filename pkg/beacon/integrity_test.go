@@ -0,0 +1,215 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBlockRoot(t *testing.T) {
+	n := &node{
+		log:     logging.New(nil),
+		broker:  emission.NewEmitter(),
+		options: DefaultOptions().EnableBlockRootVerification(),
+	}
+
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.SignedBeaconBlock{
+			Message: &phase0.BeaconBlock{
+				Slot: 5,
+				Body: &phase0.BeaconBlockBody{
+					ETH1Data: &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+				},
+			},
+		},
+	}
+
+	root, err := block.Root()
+	require.NoError(t, err)
+
+	events := make(chan *DataIntegrityEvent, 1)
+
+	n.OnDataIntegrity(context.Background(), func(ctx context.Context, event *DataIntegrityEvent) error {
+		events <- event
+
+		return nil
+	})
+
+	require.NoError(t, n.verifyBlockRoot(context.Background(), fmt.Sprintf("%#x", root), root, block))
+
+	requested := phase0.Root{0x01}
+
+	err = n.verifyBlockRoot(context.Background(), fmt.Sprintf("%#x", requested), requested, block)
+	require.ErrorIs(t, err, ErrRootMismatch)
+
+	var mismatch *RootMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, requested, mismatch.Expected)
+	assert.Equal(t, root, mismatch.Actual)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, DataIntegrityTypeBlockRoot, event.Type)
+		assert.Equal(t, phase0.Slot(5), event.Slot)
+		assert.Equal(t, requested, event.Expected)
+		assert.Equal(t, root, event.Actual)
+	case <-time.After(time.Second):
+		t.Fatal("expected a data integrity event")
+	}
+}
+
+func phase0State(slot phase0.Slot) *spec.VersionedBeaconState {
+	return &spec.VersionedBeaconState{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.BeaconState{
+			Slot:                        slot,
+			Fork:                        &phase0.Fork{},
+			LatestBlockHeader:           &phase0.BeaconBlockHeader{Slot: slot, ProposerIndex: 3, ParentRoot: phase0.Root{0x0a}},
+			BlockRoots:                  make([]phase0.Root, 8192),
+			StateRoots:                  make([]phase0.Root, 8192),
+			ETH1Data:                    &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+			RANDAOMixes:                 make([]phase0.Root, 65536),
+			Slashings:                   make([]phase0.Gwei, 8192),
+			JustificationBits:           bitfield.NewBitvector4(),
+			PreviousJustifiedCheckpoint: &phase0.Checkpoint{},
+			CurrentJustifiedCheckpoint:  &phase0.Checkpoint{},
+			FinalizedCheckpoint:         &phase0.Checkpoint{},
+		},
+	}
+}
+
+func TestVerifyStateRoot(t *testing.T) {
+	state := phase0State(10)
+
+	root, err := stateRoot(state)
+	require.NoError(t, err)
+
+	// The state's block, with the state root filled in.
+	block := *state.Phase0.LatestBlockHeader
+	block.StateRoot = root
+
+	blockRoot, err := block.HashTreeRoot()
+	require.NoError(t, err)
+
+	header := func(message phase0.BeaconBlockHeader) *v1.BeaconBlockHeader {
+		return &v1.BeaconBlockHeader{Header: &phase0.SignedBeaconBlockHeader{Message: &message}}
+	}
+
+	// The canonical block at the state's slot is another block.
+	fork := block
+	fork.ParentRoot = phase0.Root{0x0b}
+	fork.StateRoot = phase0.Root{0x02}
+
+	// The canonical block at the state's slot is the state's block, with another state root.
+	corrupt := block
+	corrupt.StateRoot = phase0.Root{0x01}
+
+	emptySlot := phase0State(11)
+	emptySlot.Phase0.LatestBlockHeader.Slot = 10
+
+	tests := []struct {
+		name     string
+		stateID  string
+		state    *spec.VersionedBeaconState
+		headers  map[string]*v1.BeaconBlockHeader
+		expected *phase0.Root
+	}{
+		{
+			name:    "block found by root",
+			stateID: "head",
+			state:   state,
+			headers: map[string]*v1.BeaconBlockHeader{fmt.Sprintf("%#x", phase0.Root(blockRoot)): header(block)},
+		},
+		{
+			name:    "empty slot",
+			stateID: "head",
+			state:   emptySlot,
+		},
+		{
+			name:    "block on another fork",
+			stateID: "head",
+			state:   state,
+			headers: map[string]*v1.BeaconBlockHeader{"10": header(fork)},
+		},
+		{
+			name:     "block with another state root",
+			stateID:  "head",
+			state:    state,
+			headers:  map[string]*v1.BeaconBlockHeader{"10": header(corrupt)},
+			expected: &phase0.Root{0x01},
+		},
+		{
+			name:    "requested by root",
+			stateID: fmt.Sprintf("%#x", root),
+			state:   state,
+		},
+		{
+			name:     "requested by another root",
+			stateID:  fmt.Sprintf("%#x", phase0.Root{0x03}),
+			state:    state,
+			expected: &phase0.Root{0x03},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := &node{
+				log:     logging.New(nil),
+				broker:  emission.NewEmitter(),
+				options: DefaultOptions().EnableStateRootVerification(),
+				client:  &fakeClient{headers: test.headers},
+			}
+
+			err := n.verifyStateRoot(context.Background(), test.stateID, test.state)
+
+			if test.expected == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrRootMismatch)
+
+			var mismatch *RootMismatchError
+			require.ErrorAs(t, err, &mismatch)
+			assert.Equal(t, DataIntegrityTypeStateRoot, mismatch.Type)
+			assert.Equal(t, *test.expected, mismatch.Expected)
+			assert.Equal(t, root, mismatch.Actual)
+		})
+	}
+}
+
+func TestResolveBlockRoot(t *testing.T) {
+	head := phase0.Root{0x02}
+
+	n := &node{
+		log:     logging.New(nil),
+		options: DefaultOptions(),
+		client:  &fakeClient{roots: map[string]phase0.Root{"head": head}},
+	}
+
+	root, err := n.resolveBlockRoot(context.Background(), "head")
+	require.NoError(t, err)
+	assert.Equal(t, head, root)
+
+	// Roots are used as is, without a request to the node.
+	requested := phase0.Root{0x01}
+
+	root, err = n.resolveBlockRoot(context.Background(), fmt.Sprintf("%#x", requested))
+	require.NoError(t, err)
+	assert.Equal(t, requested, root)
+
+	_, err = n.resolveBlockRoot(context.Background(), "finalized")
+	require.ErrorIs(t, err, ErrBlockNotFound)
+}
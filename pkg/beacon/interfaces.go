@@ -0,0 +1,239 @@
+package beacon
+
+import (
+	"context"
+	"time"
+
+	eapi "github.com/attestantio/go-eth2-client/api"
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+)
+
+// Lifecycle controls the starting and stopping of a node.
+type Lifecycle interface {
+	// Start starts the node. Starting a started node does nothing, any other state than NodeStateNotStarted
+	// returns a StateTransitionError.
+	Start(ctx context.Context) error
+	// StartAsync starts the node asynchronously.
+	StartAsync(ctx context.Context)
+	// Stop stops the node. Stopping a stopped node does nothing and stopping a node Start was never called on
+	// returns a StateTransitionError. Stopping a starting node cancels the start, and stopping a node that
+	// failed to start releases its metrics and handlers.
+	Stop(ctx context.Context) error
+	// State returns the lifecycle state of the node.
+	State() NodeState
+	// ReloadConfig applies a new configuration and, if non-nil, the reloadable subset of options to a
+	// running node.
+	ReloadConfig(ctx context.Context, config *Config, options *Options) error
+}
+
+// HealthReporter reports the health of a node.
+type HealthReporter interface {
+	// Healthy returns true if the node is healthy, i.e. its recent health checks are passing.
+	Healthy() bool
+	// IsReady returns true once the node is ready. With LenientStartup the node additionally has to have been
+	// healthy once. ReadyEvent is published asynchronously, so it may not have been delivered yet when IsReady
+	// first returns true.
+	IsReady() bool
+	// Bootstrapped returns true once the node has loaded its spec and genesis and subscribed to its upstream
+	// events. Unlike Healthy it does not change afterwards.
+	Bootstrapped() bool
+	// Synced returns true if the node is healthy, neither syncing nor optimistic, and its head slot is within
+	// toleranceSlots of the wallclock slot.
+	Synced(toleranceSlots uint64) bool
+	// PollingPaused returns true while the periodic fetches are paused because the node is unhealthy, see
+	// HealthCheckOptions.PausePolling.
+	PollingPaused() bool
+	// Status returns the status of the ndoe.
+	Status() *Status
+	// LastEventTime returns the time the last event was received from the upstream event stream.
+	// The zero time is returned if no event has been received yet.
+	LastEventTime() time.Time
+}
+
+// BlockReader fetches blocks and their sidecars from a node.
+type BlockReader interface {
+	// FetchBlock fetches the block for the given state id. Returns ErrBlockNotFound if the block does not exist.
+	FetchBlock(ctx context.Context, stateID string) (*spec.VersionedSignedBeaconBlock, error)
+	// FetchBlockWithMetadata fetches the block for the given state id together with the execution_optimistic
+	// and finalized flags of the response.
+	FetchBlockWithMetadata(ctx context.Context, stateID string) (*FetchResult[*spec.VersionedSignedBeaconBlock], error)
+	// FetchBlocks fetches the blocks for the given block ids concurrently, returning a result per block id.
+	FetchBlocks(ctx context.Context, blockIDs []string, concurrency int) (map[string]*BlockResult, error)
+	// FetchRawBlock fetches the raw, unparsed block for the given state id. Use FetchRawBlockResponse to fall
+	// back to JSON if the node cannot serve SSZ.
+	FetchRawBlock(ctx context.Context, stateID string, contentType string) ([]byte, error)
+	// FetchRawBlockInto fetches the raw, unparsed block for the given state id into dst, reusing its capacity.
+	// The returned slice shares dst's backing array unless the block did not fit.
+	FetchRawBlockInto(ctx context.Context, stateID string, contentType string, dst []byte) ([]byte, error)
+	// FetchRawBlockResponse fetches the raw, unparsed block for the given state id together with its fork
+	// version and the content type it was served as, so it can be decoded without guessing the fork. If dst is
+	// non-nil the block is read into it.
+	FetchRawBlockResponse(ctx context.Context, stateID string, contentType string, dst []byte) (*api.RawResponse, error)
+	// FetchBlockRoot fetches the block root for the given state id.
+	FetchBlockRoot(ctx context.Context, stateID string) (*phase0.Root, error)
+	// FetchBeaconBlockHeader fetches beacon block headers.
+	FetchBeaconBlockHeader(ctx context.Context, opts *eapi.BeaconBlockHeaderOpts) (*v1.BeaconBlockHeader, error)
+	// FetchBeaconBlockHeaderWithMetadata fetches a beacon block header together with the execution_optimistic
+	// and finalized flags of the response.
+	FetchBeaconBlockHeaderWithMetadata(ctx context.Context, opts *eapi.BeaconBlockHeaderOpts) (*FetchResult[*v1.BeaconBlockHeader], error)
+	// FetchBeaconBlockBlobs fetches blob sidecars for the given block id.
+	FetchBeaconBlockBlobs(ctx context.Context, blockID string) ([]*deneb.BlobSidecar, error)
+	// FetchBlobSidecarsRange fetches blob sidecars for every slot in the given range, grouped by slot.
+	FetchBlobSidecarsRange(ctx context.Context, fromSlot, toSlot phase0.Slot, concurrency int) (map[phase0.Slot][]*deneb.BlobSidecar, error)
+}
+
+// StateReader fetches beacon states and values derived from them from a node.
+type StateReader interface {
+	// FetchBeaconState fetches the beacon state for the given state id. Returns ErrStateNotFound if the state does not exist.
+	FetchBeaconState(ctx context.Context, stateID string) (*spec.VersionedBeaconState, error)
+	// FetchBeaconStateWithMetadata fetches the beacon state for the given state id together with the
+	// execution_optimistic and finalized flags of the response.
+	FetchBeaconStateWithMetadata(ctx context.Context, stateID string) (*FetchResult[*spec.VersionedBeaconState], error)
+	// FetchBeaconStateRoot fetches the state root for the given state id.
+	FetchBeaconStateRoot(ctx context.Context, stateID string) (phase0.Root, error)
+	// FetchRawBeaconState fetches the raw, unparsed beacon state for the given state id. Use
+	// FetchRawBeaconStateResponse to fall back to JSON if the node cannot serve SSZ.
+	FetchRawBeaconState(ctx context.Context, stateID string, contentType string) ([]byte, error)
+	// FetchRawBeaconStateInto fetches the raw, unparsed beacon state for the given state id into dst, reusing
+	// its capacity. The returned slice shares dst's backing array unless the state did not fit.
+	FetchRawBeaconStateInto(ctx context.Context, stateID string, contentType string, dst []byte) ([]byte, error)
+	// FetchRawBeaconStateResponse fetches the raw, unparsed beacon state for the given state id together with its
+	// fork version and the content type it was served as. If dst is non-nil the state is read into it.
+	FetchRawBeaconStateResponse(ctx context.Context, stateID string, contentType string, dst []byte) (*api.RawResponse, error)
+	// FetchBeaconStateBalances fetches the validator balances from the beacon state for the given state id,
+	// streaming the state instead of loading it into memory.
+	FetchBeaconStateBalances(ctx context.Context, stateID string) ([]phase0.Gwei, error)
+	// FetchBeaconStateValidators fetches the validators from the beacon state for the given state id,
+	// streaming the state instead of loading it into memory.
+	FetchBeaconStateValidators(ctx context.Context, stateID string) ([]*phase0.Validator, error)
+	// FetchValidators fetches the validators for the given state id and validator ids.
+	FetchValidators(ctx context.Context, state string, indices []phase0.ValidatorIndex, pubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*v1.Validator, error)
+	// FetchValidator fetches a single validator for the given state id. The id is either the validator index
+	// or the hex encoded public key. ErrValidatorNotFound is returned if the validator does not exist.
+	FetchValidator(ctx context.Context, stateID string, idOrPubkey string) (*v1.Validator, error)
+	// FetchFinality fetches the finality checkpoint for the state id.
+	FetchFinality(ctx context.Context, stateID string) (*v1.Finality, error)
+	// FetchBeaconCommittees fetches the committees for the given epoch at the given state.
+	FetchBeaconCommittees(ctx context.Context, state string, epoch *phase0.Epoch) ([]*v1.BeaconCommittee, error)
+	// FetchWeakSubjectivityCheckpoint computes the current weak subjectivity checkpoint from the head state.
+	FetchWeakSubjectivityCheckpoint(ctx context.Context) (*WeakSubjectivityCheckpoint, error)
+}
+
+// EventSubscriber registers handlers for the beacon events proxied by a node and the custom events it derives.
+type EventSubscriber interface {
+	// Proxied beacon events
+	// OnEvent is called when a beacon event is received. The data of events on topics this library does not
+	// decode, see SupportedTopics, is the raw JSON payload as json.RawMessage.
+	OnEvent(ctx context.Context, handler func(ctx context.Context, ev *v1.Event) error)
+	// OnBlock is called when a block is received.
+	OnBlock(ctx context.Context, handler func(ctx context.Context, ev *v1.BlockEvent) error)
+	// OnAttestation is called when an attestation is received.
+	OnAttestation(ctx context.Context, handler func(ctx context.Context, ev *phase0.Attestation) error)
+	// OnFinalizedCheckpoint is called when a finalized checkpoint is received.
+	OnFinalizedCheckpoint(ctx context.Context, handler func(ctx context.Context, ev *v1.FinalizedCheckpointEvent) error)
+	// OnHead is called when the head is received.
+	OnHead(ctx context.Context, handler func(ctx context.Context, ev *v1.HeadEvent) error)
+	// OnChainReOrg is called when a chain reorg is received.
+	OnChainReOrg(ctx context.Context, handler func(ctx context.Context, ev *v1.ChainReorgEvent) error)
+	// OnVoluntaryExit is called when a voluntary exit is received.
+	OnVoluntaryExit(ctx context.Context, handler func(ctx context.Context, ev *phase0.SignedVoluntaryExit) error)
+	// OnContributionAndProof is called when a contribution and proof is received.
+	OnContributionAndProof(ctx context.Context, handler func(ctx context.Context, ev *altair.SignedContributionAndProof) error)
+	// OnBlobSidecar is called when a blob sidecar is received.
+	OnBlobSidecar(ctx context.Context, handler func(ctx context.Context, ev *v1.BlobSidecarEvent) error)
+	// OnDataColumnSidecar is called when a data column sidecar is received.
+	OnDataColumnSidecar(ctx context.Context, handler func(ctx context.Context, ev *DataColumnSidecarEvent) error)
+
+	// Custom events
+	// OnReady is called when the node is ready.
+	OnReady(ctx context.Context, handler func(ctx context.Context, event *ReadyEvent) error)
+	// OnSyncStatus is called when the sync status changes.
+	OnSyncStatus(ctx context.Context, handler func(ctx context.Context, event *SyncStatusEvent) error)
+	// OnNodeVersionUpdated is called when the node version is updated.
+	OnNodeVersionUpdated(ctx context.Context, handler func(ctx context.Context, event *NodeVersionUpdatedEvent) error)
+	// OnIdentityUpdated is called when the node's ENR or metadata sequence number changes.
+	OnIdentityUpdated(ctx context.Context, handler func(ctx context.Context, event *IdentityUpdatedEvent) error)
+	// OnPeersUpdated is called when the peers are updated.
+	OnPeersUpdated(ctx context.Context, handler func(ctx context.Context, event *PeersUpdatedEvent) error)
+	// OnSpecUpdated is called when the spec is updated.
+	OnSpecUpdated(ctx context.Context, handler func(ctx context.Context, event *SpecUpdatedEvent) error)
+	// OnEmptySlot is called when an empty slot is detected, Options.EmptySlotGrace slots after the slot.
+	OnEmptySlot(ctx context.Context, handler func(ctx context.Context, event *EmptySlotEvent) error)
+	// OnHealthCheckFailed is called when a health check fails.
+	OnHealthCheckFailed(ctx context.Context, handler func(ctx context.Context, event *HealthCheckFailedEvent) error)
+	// OnHealthCheckSucceeded is called when a health check succeeds.
+	OnHealthCheckSucceeded(ctx context.Context, handler func(ctx context.Context, event *HealthCheckSucceededEvent) error)
+	// OnFinalityCheckpointUpdated is called when a the head finality checkpoint is updated.
+	OnFinalityCheckpointUpdated(ctx context.Context, handler func(ctx context.Context, event *FinalityCheckpointUpdated) error)
+	// OnFirstTimeHealthy is called when the node is healthy for the first time.
+	OnFirstTimeHealthy(ctx context.Context, handler func(ctx context.Context, event *FirstTimeHealthyEvent) error)
+	// OnBlockOrphaned is called when a previously seen block is no longer canonical.
+	OnBlockOrphaned(ctx context.Context, handler func(ctx context.Context, event *BlockOrphanedEvent) error)
+	// OnHeadLag is called when the node's head falls too far behind the wallclock while not syncing.
+	OnHeadLag(ctx context.Context, handler func(ctx context.Context, event *HeadLagEvent) error)
+	// OnClockSkewExceeded is called when the skew between the local clock and the node's clock exceeds the
+	// configured threshold.
+	OnClockSkewExceeded(ctx context.Context, handler func(ctx context.Context, event *ClockSkewExceededEvent) error)
+	// OnAnyEvent is called for every event published by the node, wrapped in an envelope with metadata.
+	OnAnyEvent(ctx context.Context, handler func(ctx context.Context, event *EventEnvelope) error)
+	// OnAnyCustomEvent is called for every custom event published by the node, i.e. every event that is not
+	// proxied from the upstream node, wrapped in an envelope with its topic.
+	OnAnyCustomEvent(ctx context.Context, handler func(ctx context.Context, event *EventEnvelope) error)
+	// OnHandlerError is called when an event handler returns an error.
+	OnHandlerError(ctx context.Context, handler func(ctx context.Context, event *HandlerErrorEvent) error)
+	// OnPossibleSlashing is called when a double proposal, double vote or surround vote is detected.
+	OnPossibleSlashing(ctx context.Context, handler func(ctx context.Context, event *PossibleSlashingEvent) error)
+	// OnConfigReloaded is called when the node's configuration is reloaded.
+	OnConfigReloaded(ctx context.Context, handler func(ctx context.Context, event *ConfigReloadedEvent) error)
+	// OnEventStreamConnected is called when a stream of upstream events is started.
+	OnEventStreamConnected(ctx context.Context, handler func(ctx context.Context, event *EventStreamConnectedEvent) error)
+	// OnEventStreamDisconnected is called when a stream of upstream events ends.
+	OnEventStreamDisconnected(ctx context.Context, handler func(ctx context.Context, event *EventStreamDisconnectedEvent) error)
+	// OnDataIntegrity is called when data fetched from the node fails verification.
+	OnDataIntegrity(ctx context.Context, handler func(ctx context.Context, event *DataIntegrityEvent) error)
+	// OnBlockGraffiti is called with the decoded graffiti of every head block when graffiti extraction is
+	// enabled.
+	OnBlockGraffiti(ctx context.Context, handler func(ctx context.Context, event *BlockGraffitiEvent) error)
+	// OnDepositDivergence is called when the deposits tracked from blocks diverge from the deposit snapshot.
+	OnDepositDivergence(ctx context.Context, handler func(ctx context.Context, event *DepositDivergenceEvent) error)
+	// OnWithdrawal is called for every withdrawal in a new canonical block when withdrawal extraction is
+	// enabled.
+	OnWithdrawal(ctx context.Context, handler func(ctx context.Context, event *WithdrawalEvent) error)
+	// OnWithdrawalReverted is called for every withdrawal passed to OnWithdrawal handlers whose block is no
+	// longer canonical after a reorg.
+	OnWithdrawalReverted(ctx context.Context, handler func(ctx context.Context, event *WithdrawalEvent) error)
+	// OnSyncStateChanged is called when the node starts or stops syncing, or its head slot moves by more than
+	// Options.SyncChangeThreshold. Unlike OnSyncStatus it is not called for every sync status poll.
+	OnSyncStateChanged(ctx context.Context, handler func(ctx context.Context, event *SyncStateChangedEvent) error)
+	// OnELOffline is called when the node starts reporting that its execution client is offline.
+	OnELOffline(ctx context.Context, handler func(ctx context.Context, event *ELOfflineEvent) error)
+	// OnForkImminent is called once per fork when the next scheduled fork is within Options.ForkImminentEpochs
+	// epochs of activating.
+	OnForkImminent(ctx context.Context, handler func(ctx context.Context, event *ForkImminentEvent) error)
+	// OnForkActivated is called at the epoch transition where a scheduled fork activates.
+	OnForkActivated(ctx context.Context, handler func(ctx context.Context, event *ForkActivatedEvent) error)
+	// OnBlobLimitChanged is called at the epoch transition where the spec's blob schedule changes the maximum
+	// number of blobs per block.
+	OnBlobLimitChanged(ctx context.Context, handler func(ctx context.Context, event *BlobLimitChangedEvent) error)
+	// OnBootstrapFailed is called when an attempt to bootstrap the node fails.
+	OnBootstrapFailed(ctx context.Context, handler func(ctx context.Context, event *BootstrapFailedEvent) error)
+	// SubscribeTopic registers a handler for the given topic and returns a handle to unsubscribe it. The handler
+	// is called with the events of the topic as is, use Subscribe for a handler that takes the event type.
+	SubscribeTopic(topic string, handler func(ctx context.Context, event any) error) (Subscription, error)
+}
+
+// FetchHooks lets embedders intercept the fetches of blocks, states and validators from the node, e.g. to serve
+// them from and populate a cache shared between processes.
+type FetchHooks interface {
+	// OnBeforeFetch registers a hook that is called before FetchBlock, FetchBeaconState and FetchValidators query
+	// the node, and can return the result instead.
+	OnBeforeFetch(hook BeforeFetchHook)
+	// OnAfterFetch registers a hook that is called after FetchBlock, FetchBeaconState and FetchValidators have
+	// queried the node.
+	OnAfterFetch(hook AfterFetchHook)
+}
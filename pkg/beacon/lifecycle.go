@@ -0,0 +1,191 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+)
+
+// NodeState is the lifecycle state of a node.
+type NodeState int
+
+const (
+	// NodeStateNotStarted is the state of a node that Start has not been called on.
+	NodeStateNotStarted NodeState = iota
+	// NodeStateStarting is the state of a node while Start is running. A node that fails to start returns to
+	// NodeStateNotStarted, so Start can be retried.
+	NodeStateStarting
+	// NodeStateStarted is the state of a node once Start has returned successfully.
+	NodeStateStarted
+	// NodeStateStopping is the state of a node while Stop is running, or when it was stopped while starting
+	// and Start has not returned yet.
+	NodeStateStopping
+	// NodeStateStopped is the state of a node that was stopped. A stopped node cannot be started again.
+	NodeStateStopped
+)
+
+func (s NodeState) String() string {
+	switch s {
+	case NodeStateNotStarted:
+		return "not_started"
+	case NodeStateStarting:
+		return "starting"
+	case NodeStateStarted:
+		return "started"
+	case NodeStateStopping:
+		return "stopping"
+	case NodeStateStopped:
+		return "stopped"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// StateTransitionError is returned by Start and Stop when the node is in a state they cannot be called in.
+type StateTransitionError struct {
+	From NodeState
+	To   NodeState
+}
+
+func (e *StateTransitionError) Error() string {
+	return fmt.Sprintf("%s: %s to %s", ErrInvalidStateTransition, e.From, e.To)
+}
+
+// Unwrap allows errors.Is to match ErrInvalidStateTransition.
+func (e *StateTransitionError) Unwrap() error {
+	return ErrInvalidStateTransition
+}
+
+func (n *node) State() NodeState {
+	n.stateMu.Lock()
+	defer n.stateMu.Unlock()
+
+	return n.state
+}
+
+func (n *node) Start(ctx context.Context) error {
+	n.stateMu.Lock()
+
+	switch n.state {
+	case NodeStateStarted:
+		n.stateMu.Unlock()
+
+		return nil
+	case NodeStateNotStarted:
+	default:
+		from := n.state
+		n.stateMu.Unlock()
+
+		return &StateTransitionError{From: from, To: NodeStateStarting}
+	}
+
+	if n.handlersCtx == nil {
+		n.handlersCtx, n.handlersCancel = context.WithCancel(context.WithoutCancel(ctx))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	n.ctx = ctx
+	n.cancel = cancel
+	n.state = NodeStateStarting
+	n.stateMu.Unlock()
+
+	if err := n.start(ctx); err != nil {
+		// Undo whatever start got to, so that Start can be called again.
+		if stopErr := n.teardown(); stopErr != nil {
+			n.log.WithError(stopErr).Warn("Failed to tear down beacon node after failing to start")
+		}
+
+		n.stateMu.Lock()
+		if n.state == NodeStateStopping {
+			// Stop was called while starting, so the node stays stopped.
+			if stopErr := n.shutdown(); stopErr != nil {
+				n.log.WithError(stopErr).Warn("Failed to stop beacon node")
+			}
+
+			n.state = NodeStateStopped
+		} else {
+			n.state = NodeStateNotStarted
+		}
+		n.stateMu.Unlock()
+
+		return err
+	}
+
+	n.stateMu.Lock()
+	defer n.stateMu.Unlock()
+
+	// Stop was called while starting. It only cancelled the context, so the node is torn down here.
+	if n.state == NodeStateStopping {
+		if err := n.shutdown(); err != nil {
+			n.log.WithError(err).Warn("Failed to stop beacon node")
+		}
+
+		n.state = NodeStateStopped
+
+		return &StateTransitionError{From: NodeStateStopping, To: NodeStateStarted}
+	}
+
+	n.state = NodeStateStarted
+
+	return nil
+}
+
+func (n *node) StartAsync(ctx context.Context) {
+	go func() {
+		if err := n.Start(ctx); err != nil {
+			n.log.WithError(err).Error("Failed to start beacon node")
+		}
+	}()
+}
+
+func (n *node) Stop(ctx context.Context) error {
+	n.stateMu.Lock()
+
+	switch n.state {
+	case NodeStateStopping, NodeStateStopped:
+		n.stateMu.Unlock()
+
+		return nil
+	case NodeStateNotStarted:
+		if n.handlersCtx != nil {
+			// A node that failed to start still has its metrics and handlers registered, so it can be stopped.
+			break
+		}
+
+		n.stateMu.Unlock()
+
+		return &StateTransitionError{From: NodeStateNotStarted, To: NodeStateStopping}
+	case NodeStateStarting:
+		// Start finishes tearing down the node once it returns.
+		n.state = NodeStateStopping
+		cancel := n.cancel
+		n.stateMu.Unlock()
+
+		cancel()
+
+		return nil
+	}
+
+	n.state = NodeStateStopping
+	n.stateMu.Unlock()
+
+	err := n.shutdown()
+
+	n.setState(NodeStateStopped)
+
+	return err
+}
+
+// currentCtx returns the context of the current start, or nil if the node has not been started.
+func (n *node) currentCtx() context.Context {
+	n.stateMu.Lock()
+	defer n.stateMu.Unlock()
+
+	return n.ctx
+}
+
+func (n *node) setState(state NodeState) {
+	n.stateMu.Lock()
+	defer n.stateMu.Unlock()
+
+	n.state = state
+}
@@ -0,0 +1,185 @@
+package beacon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/ethpandaops/beacon/pkg/beacon/simulator"
+	"github.com/ethpandaops/beacon/pkg/human"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLifecycleTestNode(t *testing.T) Node {
+	t.Helper()
+
+	server := httptest.NewServer(simulator.New(simulator.DefaultOptions()).Handler())
+	t.Cleanup(server.Close)
+
+	options := DefaultOptions().DisablePrometheusMetrics()
+
+	return NewNode(logging.New(nil), &Config{Name: "lifecycle", Addr: server.URL}, "lifecycle", *options)
+}
+
+func TestNodeLifecycle(t *testing.T) {
+	n := newLifecycleTestNode(t)
+	assert.Equal(t, NodeStateNotStarted, n.State())
+
+	// Stopping a node that was never started is invalid.
+	err := n.Stop(context.Background())
+	assert.ErrorIs(t, err, ErrInvalidStateTransition)
+	assert.Equal(t, NodeStateNotStarted, n.State())
+
+	require.NoError(t, n.Start(context.Background()))
+	assert.Equal(t, NodeStateStarted, n.State())
+
+	// Starting twice is a no-op.
+	require.NoError(t, n.Start(context.Background()))
+	assert.Equal(t, NodeStateStarted, n.State())
+
+	require.NoError(t, n.Stop(context.Background()))
+	assert.Equal(t, NodeStateStopped, n.State())
+
+	// Stopping twice is a no-op.
+	require.NoError(t, n.Stop(context.Background()))
+
+	// A stopped node cannot be started again.
+	err = n.Start(context.Background())
+
+	var transitionErr *StateTransitionError
+
+	require.ErrorAs(t, err, &transitionErr)
+	assert.Equal(t, NodeStateStopped, transitionErr.From)
+	assert.Equal(t, NodeStateStarting, transitionErr.To)
+}
+
+func TestNodeLifecycleStartFailure(t *testing.T) {
+	n := newLifecycleTestNode(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.ErrorIs(t, n.Start(ctx), context.Canceled)
+	assert.Equal(t, NodeStateNotStarted, n.State())
+
+	// A node that failed to start can be started again.
+	require.NoError(t, n.Start(context.Background()))
+	assert.Equal(t, NodeStateStarted, n.State())
+
+	require.NoError(t, n.Stop(context.Background()))
+
+	t.Run("after bootstrap", func(t *testing.T) {
+		handler := simulator.New(simulator.DefaultOptions()).Handler()
+
+		var bootstrapping, failing atomic.Bool
+
+		failing.Store(true)
+
+		// The node bootstraps, then fails to start as the sync status is unavailable. The eth2 client checks the
+		// sync status when it is created, before bootstrap fetches the spec.
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/eth/v1/config/spec" {
+				bootstrapping.Store(true)
+			}
+
+			if r.URL.Path == "/eth/v1/node/syncing" && bootstrapping.Load() && failing.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+
+				return
+			}
+
+			handler.ServeHTTP(w, r)
+		}))
+		t.Cleanup(server.Close)
+
+		options := DefaultOptions()
+		options.StartupRetryWindow = human.Duration{}
+
+		n, ok := NewNode(logging.New(nil), &Config{Name: "lifecycle_failure", Addr: server.URL}, "lifecycle_failure", *options).(*node)
+		require.True(t, ok)
+
+		require.Error(t, n.Start(context.Background()))
+		assert.Equal(t, NodeStateNotStarted, n.State())
+		require.True(t, n.Bootstrapped())
+
+		handlers := n.broker.GetListenerCount(topicFinalizedCheckpoint)
+		jobs := n.metrics.Events().crons.Len()
+
+		failing.Store(false)
+
+		require.NoError(t, n.Start(context.Background()))
+		assert.Equal(t, NodeStateStarted, n.State())
+
+		// The retried start does not register the handlers and metrics again.
+		assert.Equal(t, handlers, n.broker.GetListenerCount(topicFinalizedCheckpoint))
+		assert.Equal(t, jobs, n.metrics.Events().crons.Len())
+
+		require.NoError(t, n.Stop(context.Background()))
+	})
+
+	t.Run("stop after failing", func(t *testing.T) {
+		n := newLifecycleTestNode(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		require.Error(t, n.Start(ctx))
+
+		// A node that failed to start can be stopped instead of retried.
+		require.NoError(t, n.Stop(context.Background()))
+		assert.Equal(t, NodeStateStopped, n.State())
+	})
+}
+
+func TestNodeContextReadWhileStarting(t *testing.T) {
+	n, ok := newLifecycleTestNode(t).(*node)
+	require.True(t, ok)
+
+	events := make(chan struct{}, 1)
+
+	_, err := n.SubscribeTopic(topicHead, func(ctx context.Context, event any) error {
+		select {
+		case events <- struct{}{}:
+		default:
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+
+	// Start sets the node's context while handlers and Genesis read it, which the race detector checks.
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 100; i++ {
+			n.broker.Emit(topicHead, &v1.HeadEvent{})
+
+			_, _ = n.Genesis()
+		}
+	}()
+
+	require.NoError(t, n.Start(context.Background()))
+	t.Cleanup(func() { _ = n.Stop(context.Background()) })
+
+	<-done
+
+	select {
+	case <-events:
+	case <-time.After(time.Second):
+		t.Fatal("handler was not called")
+	}
+}
+
+func TestNodeStateString(t *testing.T) {
+	assert.Equal(t, "not_started", NodeStateNotStarted.String())
+	assert.Equal(t, "stopping", NodeStateStopping.String())
+	assert.Equal(t, "unknown(9)", NodeState(9).String())
+}
@@ -1,8 +1,8 @@
 package beacon
 
 import (
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
 	"github.com/rs/zerolog"
-	"github.com/sirupsen/logrus"
 )
 
 func (n *node) GetZeroLogLevel() zerolog.Level {
@@ -10,34 +10,17 @@ func (n *node) GetZeroLogLevel() zerolog.Level {
 		return zerolog.NoLevel
 	}
 
-	var logLevel logrus.Level
-
-	// Handle both Logger and Entry types
-	switch v := n.log.(type) {
-	case *logrus.Logger:
-		logLevel = v.GetLevel()
-	case *logrus.Entry:
-		logLevel = v.Logger.GetLevel()
+	// Use the most verbose level that is enabled on the logger.
+	switch {
+	case n.log.Enabled(logging.LevelDebug):
+		return zerolog.DebugLevel
+	case n.log.Enabled(logging.LevelInfo):
+		return zerolog.InfoLevel
+	case n.log.Enabled(logging.LevelWarn):
+		return zerolog.WarnLevel
+	case n.log.Enabled(logging.LevelError):
+		return zerolog.ErrorLevel
 	default:
-		return zerolog.NoLevel
-	}
-
-	zerologLevel := zerolog.NoLevel
-
-	switch logLevel {
-	case logrus.DebugLevel:
-		zerologLevel = zerolog.DebugLevel
-	case logrus.InfoLevel:
-		zerologLevel = zerolog.InfoLevel
-	case logrus.WarnLevel:
-		zerologLevel = zerolog.WarnLevel
-	case logrus.ErrorLevel:
-		zerologLevel = zerolog.ErrorLevel
-	case logrus.FatalLevel:
-		zerologLevel = zerolog.FatalLevel
-	case logrus.PanicLevel:
-		zerologLevel = zerolog.PanicLevel
+		return zerolog.Disabled
 	}
-
-	return zerologLevel
 }
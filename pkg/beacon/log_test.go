@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/ethpandaops/beacon/pkg/beacon"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging/logrusadapter"
 	"github.com/rs/zerolog"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -38,12 +39,12 @@ func TestGetZeroLogLevel(t *testing.T) {
 		{
 			name:     "fatal level",
 			logLevel: logrus.FatalLevel,
-			want:     zerolog.FatalLevel,
+			want:     zerolog.Disabled,
 		},
 		{
 			name:     "panic level",
 			logLevel: logrus.PanicLevel,
-			want:     zerolog.PanicLevel,
+			want:     zerolog.Disabled,
 		},
 	}
 
@@ -52,7 +53,7 @@ func TestGetZeroLogLevel(t *testing.T) {
 			logger := logrus.New()
 			logger.SetLevel(tt.logLevel)
 
-			node := beacon.NewNode(logger, &beacon.Config{}, "", beacon.Options{})
+			node := beacon.NewNode(logrusadapter.FromLogrus(logger), &beacon.Config{}, "", beacon.Options{})
 			got := node.GetZeroLogLevel()
 
 			assert.Equal(t, tt.want, got)
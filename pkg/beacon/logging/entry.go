@@ -0,0 +1,123 @@
+package logging
+
+import "fmt"
+
+// Entry is a Logger with fields that are added to every message it logs. It has the logrus style helpers
+// that the beacon node logs with, so that call sites can build up fields one at a time.
+type Entry struct {
+	logger Logger
+	fields map[string]any
+}
+
+// New returns an Entry without fields that logs to the logger. A nil logger discards every message.
+func New(logger Logger) *Entry {
+	if entry, ok := logger.(*Entry); ok {
+		return entry
+	}
+
+	if logger == nil {
+		logger = discard{}
+	}
+
+	return &Entry{logger: logger}
+}
+
+// WithField returns a copy of the entry with the field added.
+func (e *Entry) WithField(key string, value any) *Entry {
+	fields := make(map[string]any, len(e.fields)+1)
+	for k, v := range e.fields {
+		fields[k] = v
+	}
+
+	fields[key] = value
+
+	return &Entry{logger: e.logger, fields: fields}
+}
+
+// WithError returns a copy of the entry with the error added as the error field.
+func (e *Entry) WithError(err error) *Entry {
+	return e.WithField("error", err)
+}
+
+// Log logs the message with the entry's fields and the given fields at the given level. The given fields take
+// precedence over the entry's fields.
+func (e *Entry) Log(level Level, msg string, fields map[string]any) {
+	merged := make(map[string]any, len(e.fields)+len(fields))
+	for k, v := range e.fields {
+		merged[k] = v
+	}
+
+	for k, v := range fields {
+		merged[k] = v
+	}
+
+	e.logger.Log(level, msg, merged)
+}
+
+// Enabled returns true if messages at the given level are logged.
+func (e *Entry) Enabled(level Level) bool {
+	return e.logger.Enabled(level)
+}
+
+// Debug logs the message at the debug level.
+func (e *Entry) Debug(msg string) {
+	e.log(LevelDebug, msg)
+}
+
+// Debugf formats and logs the message at the debug level.
+func (e *Entry) Debugf(format string, args ...any) {
+	e.logf(LevelDebug, format, args...)
+}
+
+// Info logs the message at the info level.
+func (e *Entry) Info(msg string) {
+	e.log(LevelInfo, msg)
+}
+
+// Infof formats and logs the message at the info level.
+func (e *Entry) Infof(format string, args ...any) {
+	e.logf(LevelInfo, format, args...)
+}
+
+// Warn logs the message at the warn level.
+func (e *Entry) Warn(msg string) {
+	e.log(LevelWarn, msg)
+}
+
+// Warnf formats and logs the message at the warn level.
+func (e *Entry) Warnf(format string, args ...any) {
+	e.logf(LevelWarn, format, args...)
+}
+
+// Error logs the message at the error level.
+func (e *Entry) Error(msg string) {
+	e.log(LevelError, msg)
+}
+
+// Errorf formats and logs the message at the error level.
+func (e *Entry) Errorf(format string, args ...any) {
+	e.logf(LevelError, format, args...)
+}
+
+func (e *Entry) log(level Level, msg string) {
+	if !e.logger.Enabled(level) {
+		return
+	}
+
+	e.logger.Log(level, msg, e.fields)
+}
+
+func (e *Entry) logf(level Level, format string, args ...any) {
+	if !e.logger.Enabled(level) {
+		return
+	}
+
+	e.logger.Log(level, fmt.Sprintf(format, args...), e.fields)
+}
+
+// discard is a Logger that discards every message.
+type discard struct{}
+
+func (discard) Log(level Level, msg string, fields map[string]any) {}
+
+func (discard) Enabled(level Level) bool { return false }
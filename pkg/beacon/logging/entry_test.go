@@ -0,0 +1,63 @@
+package logging_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntrySlog(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	log := logging.New(logging.FromSlog(logger))
+
+	log.WithField("slot", 1).Debug("hidden")
+	log.WithError(errors.New("boom")).WithField("slot", 2).Warn("visible")
+
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), `level=WARN msg=visible error=boom slot=2`)
+}
+
+func TestEntryZerolog(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := logging.New(logging.FromZerolog(zerolog.New(&buf).Level(zerolog.WarnLevel)))
+
+	assert.False(t, log.Enabled(logging.LevelInfo))
+	assert.True(t, log.Enabled(logging.LevelWarn))
+
+	log.Info("hidden")
+	log.WithField("slot", 3).Errorf("visible %d", 4)
+
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), `"level":"error"`)
+	assert.Contains(t, buf.String(), `"slot":3`)
+	assert.Contains(t, buf.String(), `"message":"visible 4"`)
+}
+
+func TestEntryFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	log := logging.New(logging.FromSlog(logger)).WithField("module", "test")
+
+	log.WithField("slot", 1).Info("first")
+	log.Log(logging.LevelInfo, "second", map[string]any{"module": "override"})
+
+	assert.Contains(t, buf.String(), `msg=first module=test slot=1`)
+	assert.Contains(t, buf.String(), `msg=second module=override`)
+	assert.NotContains(t, buf.String(), `msg=second module=override slot`)
+}
+
+func TestEntryDiscard(t *testing.T) {
+	log := logging.New(nil)
+
+	assert.False(t, log.Enabled(logging.LevelError))
+	log.WithField("slot", 1).Error("discarded")
+}
@@ -0,0 +1,42 @@
+// Package logging provides a small logging interface so that embedders can use their own logger with the
+// beacon node, along with adapters for zerolog and log/slog and Entry, which adds fields to the messages of a
+// Logger. The logrus adapter is in the logrusadapter package, so that this package does not depend on logrus.
+package logging
+
+// Level is the severity of a log message.
+type Level int
+
+const (
+	// LevelDebug is for messages that are useful when debugging.
+	LevelDebug Level = iota
+	// LevelInfo is for general operational messages.
+	LevelInfo
+	// LevelWarn is for messages about unexpected but recoverable conditions.
+	LevelWarn
+	// LevelError is for messages about failures.
+	LevelError
+)
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Logger is the interface a logger has to implement to be used by the beacon node.
+type Logger interface {
+	// Log logs the message with the given fields at the given level.
+	Log(level Level, msg string, fields map[string]any)
+	// Enabled returns true if messages at the given level are logged.
+	Enabled(level Level) bool
+}
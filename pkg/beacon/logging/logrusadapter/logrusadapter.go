@@ -0,0 +1,55 @@
+// Package logrusadapter adapts logrus loggers to logging.Logger.
+package logrusadapter
+
+import (
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/sirupsen/logrus"
+)
+
+type logrusAdapter struct {
+	log logrus.FieldLogger
+}
+
+// FromLogrus adapts a logrus logger to a logging.Logger.
+func FromLogrus(log logrus.FieldLogger) logging.Logger {
+	return &logrusAdapter{log: log}
+}
+
+func (a *logrusAdapter) Log(level logging.Level, msg string, fields map[string]any) {
+	entry := a.log.WithFields(fields)
+
+	switch level {
+	case logging.LevelDebug:
+		entry.Debug(msg)
+	case logging.LevelInfo:
+		entry.Info(msg)
+	case logging.LevelWarn:
+		entry.Warn(msg)
+	default:
+		entry.Error(msg)
+	}
+}
+
+func (a *logrusAdapter) Enabled(level logging.Level) bool {
+	switch v := a.log.(type) {
+	case *logrus.Logger:
+		return v.IsLevelEnabled(toLogrusLevel(level))
+	case *logrus.Entry:
+		return v.Logger.IsLevelEnabled(toLogrusLevel(level))
+	default:
+		return true
+	}
+}
+
+func toLogrusLevel(level logging.Level) logrus.Level {
+	switch level {
+	case logging.LevelDebug:
+		return logrus.DebugLevel
+	case logging.LevelInfo:
+		return logrus.InfoLevel
+	case logging.LevelWarn:
+		return logrus.WarnLevel
+	default:
+		return logrus.ErrorLevel
+	}
+}
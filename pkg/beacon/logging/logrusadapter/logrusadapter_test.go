@@ -0,0 +1,32 @@
+package logrusadapter_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging/logrusadapter"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromLogrus(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
+	logger.SetLevel(logrus.InfoLevel)
+
+	log := logging.New(logrusadapter.FromLogrus(logger.WithField("module", "test")))
+
+	assert.False(t, log.Enabled(logging.LevelDebug))
+	assert.True(t, log.Enabled(logging.LevelInfo))
+
+	log.WithField("slot", 1).Debug("hidden")
+	log.WithError(errors.New("boom")).WithField("slot", 2).Warn("visible")
+
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), `level=warning msg=visible error=boom module=test slot=2`)
+}
@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+)
+
+type slogAdapter struct {
+	log *slog.Logger
+}
+
+// FromSlog adapts a log/slog logger to a Logger.
+func FromSlog(log *slog.Logger) Logger {
+	return &slogAdapter{log: log}
+}
+
+func (a *slogAdapter) Log(level Level, msg string, fields map[string]any) {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+
+	// Sort the keys so that the attributes are in a stable order.
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, slog.Any(k, fields[k]))
+	}
+
+	a.log.LogAttrs(context.Background(), toSlogLevel(level), msg, attrs...)
+}
+
+func (a *slogAdapter) Enabled(level Level) bool {
+	return a.log.Enabled(context.Background(), toSlogLevel(level))
+}
+
+func toSlogLevel(level Level) slog.Level {
+	switch level {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"github.com/rs/zerolog"
+)
+
+type zerologAdapter struct {
+	log zerolog.Logger
+}
+
+// FromZerolog adapts a zerolog logger to a Logger.
+func FromZerolog(log zerolog.Logger) Logger {
+	return &zerologAdapter{log: log}
+}
+
+func (a *zerologAdapter) Log(level Level, msg string, fields map[string]any) {
+	a.log.WithLevel(toZerologLevel(level)).Fields(fields).Msg(msg)
+}
+
+func (a *zerologAdapter) Enabled(level Level) bool {
+	return a.log.GetLevel() <= toZerologLevel(level)
+}
+
+func toZerologLevel(level Level) zerolog.Level {
+	switch level {
+	case LevelDebug:
+		return zerolog.DebugLevel
+	case LevelInfo:
+		return zerolog.InfoLevel
+	case LevelWarn:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.ErrorLevel
+	}
+}
@@ -2,16 +2,19 @@ package beacon
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/sirupsen/logrus"
 )
 
 // Metrics contains all the metrics jobs.
 type Metrics struct {
 	jobs map[string]MetricsJob
-	log  logrus.FieldLogger
+	log  *logging.Entry
 }
 
 // MetricsJob is a job that reports metrics.
@@ -21,10 +24,64 @@ type MetricsJob interface {
 	Name() string
 }
 
-// NewMetrics returns a new Metrics instance.
-func NewMetrics(log logrus.FieldLogger, namespace, nodeName string, beacon Node) *Metrics {
-	constLabels := prometheus.Labels{
-		"node": nodeName,
+const (
+	defaultMetricsNodeLabelName = "node"
+	metricsModuleLabelName      = "module"
+)
+
+// metricsVariableLabelNames are the variable labels of the metrics jobs. Const labels can't use them since
+// registering a metric with a duplicate label panics.
+var metricsVariableLabelNames = map[string]struct{}{
+	"block_hash":    {},
+	"block_id":      {},
+	"checkpoint":    {},
+	"custody":       {},
+	"deposit_count": {},
+	"deposit_root":  {},
+	"direction":     {},
+	"event":         {},
+	"fork":          {},
+	"name":          {},
+	"prefix":        {},
+	"preset":        {},
+	"reason":        {},
+	"result":        {},
+	"state":         {},
+	"state_id":      {},
+	"stream":        {},
+	"topic":         {},
+	"type":          {},
+	"version":       {},
+}
+
+var metricsLabelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// NewMetrics returns a new Metrics instance. It panics if MetricsOptions.ConstLabels are invalid, use
+// NewMetricsWithLabels to get an error instead.
+func NewMetrics(log logging.Logger, namespace, nodeName string, beacon Node) *Metrics {
+	m, err := NewMetricsWithLabels(log, namespace, nodeName, nil, beacon)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// NewMetricsWithLabels returns a new Metrics instance. The labels are added to the const labels of every
+// metric, with MetricsOptions.ConstLabels taking precedence. An error is returned if a label name is invalid or
+// clashes with the node label or a label of the metrics themselves.
+func NewMetricsWithLabels(log logging.Logger, namespace, nodeName string, labels map[string]string, beacon Node) (*Metrics, error) {
+	opts := MetricsOptions{}
+	extractGraffiti := false
+
+	if o := beacon.Options(); o != nil {
+		opts = o.Metrics
+		extractGraffiti = o.ExtractGraffiti
+	}
+
+	constLabels, err := buildMetricsConstLabels(nodeName, labels, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	beac := NewBeaconMetrics(beacon, log, namespace, constLabels)
@@ -34,23 +91,47 @@ func NewMetrics(log logrus.FieldLogger, namespace, nodeName string, beacon Node)
 	spec := NewSpecJob(beacon, log, namespace, constLabels)
 	sync := NewSyncMetrics(beacon, log, namespace, constLabels)
 	health := NewHealthMetrics(beacon, log, namespace, constLabels)
+	wallclock := NewWallclockMetrics(beacon, log, namespace, constLabels)
+	dataColumn := NewDataColumnMetrics(beacon, log, namespace, constLabels)
+	stream := NewStreamMetrics(beacon, log, namespace, constLabels)
 
 	jobs := map[string]MetricsJob{
-		sync.Name():    sync,
-		general.Name(): general,
-		event.Name():   event,
-		forks.Name():   forks,
-		spec.Name():    spec,
-		health.Name():  health,
-		beac.Name():    beac,
+		sync.Name():       sync,
+		general.Name():    general,
+		event.Name():      event,
+		forks.Name():      forks,
+		spec.Name():       spec,
+		health.Name():     health,
+		beac.Name():       beac,
+		wallclock.Name():  wallclock,
+		dataColumn.Name(): dataColumn,
+		stream.Name():     stream,
+	}
+
+	if opts.ForkChoice.Enabled {
+		forkChoice := NewForkChoiceMetrics(beacon, log, namespace, constLabels, opts.ForkChoice)
+
+		jobs[forkChoice.Name()] = forkChoice
+	}
+
+	if opts.PayloadSizes {
+		payloadSize := NewPayloadSizeMetrics(beacon, log, namespace, constLabels)
+
+		jobs[payloadSize.Name()] = payloadSize
+	}
+
+	if extractGraffiti {
+		graffiti := NewGraffitiMetrics(beacon, log, namespace, constLabels)
+
+		jobs[graffiti.Name()] = graffiti
 	}
 
 	m := &Metrics{
 		jobs,
-		log,
+		logging.New(log),
 	}
 
-	return m
+	return m, nil
 }
 
 // Start starts all the jobs.
@@ -64,15 +145,17 @@ func (m *Metrics) Start(ctx context.Context) error {
 	return nil
 }
 
-// Stop stops all the metrics jobs.
+// Stop stops all the metrics jobs, even if stopping one of them fails.
 func (m *Metrics) Stop() error {
+	var errs []error
+
 	for _, job := range m.jobs {
 		if err := job.Stop(); err != nil {
-			return fmt.Errorf("failed to stop job %s: %v", job.Name(), err)
+			errs = append(errs, fmt.Errorf("failed to stop job %s: %v", job.Name(), err))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // General returns the general metrics job.
@@ -109,3 +192,58 @@ func (m *Metrics) Health() *HealthMetrics {
 func (m *Metrics) Beacon() *BeaconMetrics {
 	return m.jobs[metricsJobNameBeacon].(*BeaconMetrics)
 }
+
+// DataColumn returns the data column metrics job.
+func (m *Metrics) DataColumn() *DataColumnMetrics {
+	return m.jobs[metricsJobNameDataColumn].(*DataColumnMetrics)
+}
+
+func buildMetricsConstLabels(nodeName string, labels map[string]string, opts MetricsOptions) (prometheus.Labels, error) {
+	nodeLabel := opts.NodeLabelName
+	if nodeLabel == "" {
+		nodeLabel = defaultMetricsNodeLabelName
+	}
+
+	if err := validateMetricsLabelName(nodeLabel); err != nil {
+		return nil, fmt.Errorf("invalid metrics node label: %w", err)
+	}
+
+	constLabels := prometheus.Labels{
+		nodeLabel: nodeName,
+	}
+
+	for _, extra := range []map[string]string{labels, opts.ConstLabels} {
+		for k, v := range extra {
+			if err := validateMetricsLabelName(k); err != nil {
+				return nil, fmt.Errorf("invalid metrics const label: %w", err)
+			}
+
+			if k == nodeLabel {
+				return nil, fmt.Errorf("invalid metrics const label: %q clashes with the node label", k)
+			}
+
+			constLabels[k] = v
+		}
+	}
+
+	return constLabels, nil
+}
+
+// validateMetricsLabelName returns an error if name is not a valid Prometheus label name or is used by the
+// metrics themselves.
+func validateMetricsLabelName(name string) error {
+	if !metricsLabelNameRegex.MatchString(name) || strings.HasPrefix(name, "__") {
+		return fmt.Errorf("%q is not a valid label name", name)
+	}
+
+	if _, exists := metricsVariableLabelNames[name]; exists || name == metricsModuleLabelName {
+		return fmt.Errorf("%q is reserved", name)
+	}
+
+	return nil
+}
+
+// Stream returns the event stream metrics job.
+func (m *Metrics) Stream() *StreamMetrics {
+	return m.jobs[metricsJobNameStream].(*StreamMetrics)
+}
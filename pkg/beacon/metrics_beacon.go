@@ -9,14 +9,15 @@ import (
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
 	"github.com/go-co-op/gocron"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/sirupsen/logrus"
 )
 
 // Beacon reports Beacon information about the beacon chain.
 type BeaconMetrics struct {
-	log                 logrus.FieldLogger
+	log                 *logging.Entry
 	beaconNode          Node
 	Slot                prometheus.GaugeVec
 	Transactions        prometheus.GaugeVec
@@ -28,12 +29,21 @@ type BeaconMetrics struct {
 	ReOrgs              prometheus.Counter
 	ReOrgDepth          prometheus.Counter
 	EmptySlots          prometheus.Counter
+	OrphanedBlocks      prometheus.Counter
 	ProposerDelay       prometheus.Histogram
+	AttestationDelay    prometheus.HistogramVec
 	Withdrawals         prometheus.GaugeVec
 	WithdrawalsAmount   prometheus.GaugeVec
 	WithdrawalsIndexMax prometheus.GaugeVec
 	WithdrawalsIndexMin prometheus.GaugeVec
 	BlobKZGCommitments  prometheus.GaugeVec
+	ExecutionRequests   prometheus.GaugeVec
+	Eth1DataVotes       prometheus.GaugeVec
+	Eth1DataCandidates  prometheus.Gauge
+	Eth1DataLeaderShare prometheus.Gauge
+	Eth1VotingPeriod    prometheus.Gauge
+
+	eth1Votes *eth1VoteTracker
 
 	currentVersionHead      string
 	currentVersionFinalized string
@@ -46,14 +56,15 @@ const (
 )
 
 // NewBeaconMetrics creates a new BeaconMetrics instance.
-func NewBeaconMetrics(beac Node, log logrus.FieldLogger, namespace string, constLabels map[string]string) *BeaconMetrics {
-	constLabels["module"] = metricsJobNameBeacon
+func NewBeaconMetrics(beac Node, log logging.Logger, namespace string, constLabels map[string]string) *BeaconMetrics {
+	constLabels[metricsModuleLabelName] = metricsJobNameBeacon
 	namespace += "_beacon"
 
 	b := &BeaconMetrics{
 		beaconNode: beac,
-		log:        log,
+		log:        logging.New(log),
 		crons:      gocron.NewScheduler(time.Local),
+		eth1Votes:  newEth1VoteTracker(),
 		Slot: *prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace:   namespace,
@@ -164,6 +175,18 @@ func NewBeaconMetrics(beac Node, log logrus.FieldLogger, namespace string, const
 				Buckets:     prometheus.LinearBuckets(0, 1000, 13),
 			},
 		),
+		AttestationDelay: *prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace:   namespace,
+				Name:        "attestation_delay",
+				Help:        "The delay between the start of the slot and the arrival of an attestation for that slot (in milliseconds).",
+				ConstLabels: constLabels,
+				Buckets:     prometheus.LinearBuckets(0, 250, 49),
+			},
+			[]string{
+				"version",
+			},
+		),
 		EmptySlots: prometheus.NewCounter(
 			prometheus.CounterOpts{
 				Namespace:   namespace,
@@ -172,6 +195,14 @@ func NewBeaconMetrics(beac Node, log logrus.FieldLogger, namespace string, const
 				ConstLabels: constLabels,
 			},
 		),
+		OrphanedBlocks: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace:   namespace,
+				Name:        "orphaned_blocks_count",
+				Help:        "The number of previously seen blocks that are no longer canonical.",
+				ConstLabels: constLabels,
+			},
+		),
 		Withdrawals: *prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace:   namespace,
@@ -232,6 +263,56 @@ func NewBeaconMetrics(beac Node, log logrus.FieldLogger, namespace string, const
 				"version",
 			},
 		),
+		ExecutionRequests: *prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "execution_requests",
+				Help:        "The amount of execution requests in the block.",
+				ConstLabels: constLabels,
+			},
+			[]string{
+				"block_id",
+				"version",
+				"type",
+			},
+		),
+		Eth1DataVotes: *prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "eth1_data_votes",
+				Help:        "The amount of blocks voting for each eth1 data candidate in the current voting period.",
+				ConstLabels: constLabels,
+			},
+			[]string{
+				"deposit_root",
+				"deposit_count",
+				"block_hash",
+			},
+		),
+		Eth1DataCandidates: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "eth1_data_vote_candidates",
+				Help:        "The amount of distinct eth1 data candidates voted for in the current voting period.",
+				ConstLabels: constLabels,
+			},
+		),
+		Eth1DataLeaderShare: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "eth1_data_vote_leader_share",
+				Help:        "The share of votes held by the leading eth1 data candidate in the current voting period.",
+				ConstLabels: constLabels,
+			},
+		),
+		Eth1VotingPeriod: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "eth1_voting_period",
+				Help:        "The current eth1 data voting period.",
+				ConstLabels: constLabels,
+			},
+		),
 	}
 
 	prometheus.MustRegister(b.Attestations)
@@ -244,12 +325,19 @@ func NewBeaconMetrics(beac Node, log logrus.FieldLogger, namespace string, const
 	prometheus.MustRegister(b.ReOrgs)
 	prometheus.MustRegister(b.ReOrgDepth)
 	prometheus.MustRegister(b.ProposerDelay)
+	prometheus.MustRegister(b.AttestationDelay)
 	prometheus.MustRegister(b.EmptySlots)
+	prometheus.MustRegister(b.OrphanedBlocks)
 	prometheus.MustRegister(b.Withdrawals)
 	prometheus.MustRegister(b.WithdrawalsAmount)
 	prometheus.MustRegister(b.WithdrawalsIndexMax)
 	prometheus.MustRegister(b.WithdrawalsIndexMin)
 	prometheus.MustRegister(b.BlobKZGCommitments)
+	prometheus.MustRegister(b.ExecutionRequests)
+	prometheus.MustRegister(b.Eth1DataVotes)
+	prometheus.MustRegister(b.Eth1DataCandidates)
+	prometheus.MustRegister(b.Eth1DataLeaderShare)
+	prometheus.MustRegister(b.Eth1VotingPeriod)
 
 	return b
 }
@@ -297,6 +385,9 @@ func (b *BeaconMetrics) setupSubscriptions(ctx context.Context) error {
 		}
 
 		block, err := b.beaconNode.FetchBlock(ctx, fmt.Sprintf("%#x", event.Block))
+
+		b.recordExecutionRequests(ctx, "head", fmt.Sprintf("%#x", event.Block), block)
+
 		if err != nil {
 			return err
 		}
@@ -305,13 +396,17 @@ func (b *BeaconMetrics) setupSubscriptions(ctx context.Context) error {
 			return err
 		}
 
-		return nil
+		return b.recordEth1DataVote(block)
 	})
 
+	b.beaconNode.OnAttestation(ctx, b.handleAttestation)
+
 	b.beaconNode.OnChainReOrg(ctx, b.handleChainReorg)
 
 	b.beaconNode.OnEmptySlot(ctx, b.handleEmptySlot)
 
+	b.beaconNode.OnBlockOrphaned(ctx, b.handleBlockOrphaned)
+
 	b.beaconNode.OnFinalityCheckpointUpdated(ctx, func(ctx context.Context, ev *FinalityCheckpointUpdated) error {
 		return b.updateFinality(ctx)
 	})
@@ -336,6 +431,17 @@ func (b *BeaconMetrics) handleEmptySlot(ctx context.Context, event *EmptySlotEve
 	return nil
 }
 
+func (b *BeaconMetrics) handleBlockOrphaned(ctx context.Context, event *BlockOrphanedEvent) error {
+	b.log.
+		WithField("slot", event.Slot).
+		WithField("root", fmt.Sprintf("%#x", event.Root)).
+		Debug("Orphaned block detected")
+
+	b.OrphanedBlocks.Inc()
+
+	return nil
+}
+
 func (b *BeaconMetrics) handleBlock(ctx context.Context, event *v1.BlockEvent) error {
 	syncState, err := b.beaconNode.SyncState()
 	if err != nil {
@@ -365,6 +471,44 @@ func (b *BeaconMetrics) handleBlock(ctx context.Context, event *v1.BlockEvent) e
 	return nil
 }
 
+func (b *BeaconMetrics) handleAttestation(ctx context.Context, attestation *phase0.Attestation) error {
+	if attestation.Data == nil {
+		return nil
+	}
+
+	syncState, err := b.beaconNode.SyncState()
+	if err != nil {
+		return nil
+	}
+
+	if syncState == nil || syncState.IsSyncing {
+		return nil
+	}
+
+	sp, err := b.beaconNode.Spec()
+	if err != nil {
+		return err
+	}
+
+	slot := b.beaconNode.Wallclock().Slots().FromNumber(uint64(attestation.Data.Slot))
+
+	delay := time.Since(slot.TimeWindow().Start())
+	if delay < 0 {
+		return nil
+	}
+
+	version := "unknown"
+
+	fork, err := sp.ForkEpochs.CurrentFork(phase0.Epoch(attestation.Data.Slot / sp.SlotsPerEpoch))
+	if err == nil {
+		version = fork.Name.String()
+	}
+
+	b.AttestationDelay.WithLabelValues(version).Observe(float64(delay.Milliseconds()))
+
+	return nil
+}
+
 func (b *BeaconMetrics) handleChainReorg(ctx context.Context, event *v1.ChainReorgEvent) error {
 	b.ReOrgs.Inc()
 	b.ReOrgDepth.Add(float64(event.Depth))
@@ -374,6 +518,9 @@ func (b *BeaconMetrics) handleChainReorg(ctx context.Context, event *v1.ChainReo
 
 func (b *BeaconMetrics) GetSignedBeaconBlock(ctx context.Context, blockID string) error {
 	block, err := b.beaconNode.FetchBlock(ctx, blockID)
+
+	b.recordExecutionRequests(ctx, blockID, blockID, block)
+
 	if err != nil {
 		return err
 	}
@@ -519,3 +666,82 @@ func (b *BeaconMetrics) recordNewBeaconBlock(blockID string, block *spec.Version
 		b.BlobKZGCommitments.WithLabelValues(blockID, version).Set(float64(len(blobs)))
 	}
 }
+
+// recordExecutionRequests records the execution request counts of Electra+ blocks. The raw block is used since
+// execution requests are not available on the versioned block. It is only fetched when block is nil, because it
+// could not be fetched or decoded, or is from a fork after Deneb. Earlier forks have no execution requests.
+func (b *BeaconMetrics) recordExecutionRequests(ctx context.Context, label, blockID string, block *spec.VersionedSignedBeaconBlock) {
+	if block != nil && block.Version <= spec.DataVersionDeneb {
+		b.ExecutionRequests.DeletePartialMatch(prometheus.Labels{"block_id": label})
+
+		return
+	}
+
+	data, err := b.beaconNode.FetchRawBlock(ctx, blockID, "application/json")
+	if err != nil {
+		b.log.WithError(err).WithField("block_id", blockID).Debug("Failed to fetch raw block for execution requests")
+
+		return
+	}
+
+	version, counts, err := GetExecutionRequestCountsFromRawBlock(data)
+	if err != nil {
+		b.log.WithError(err).WithField("block_id", blockID).Error("Failed to get execution requests from block")
+
+		return
+	}
+
+	b.ExecutionRequests.DeletePartialMatch(prometheus.Labels{"block_id": label})
+
+	if counts == nil {
+		return
+	}
+
+	b.ExecutionRequests.WithLabelValues(label, version, "deposit").Set(float64(counts.Deposits))
+	b.ExecutionRequests.WithLabelValues(label, version, "withdrawal").Set(float64(counts.Withdrawals))
+	b.ExecutionRequests.WithLabelValues(label, version, "consolidation").Set(float64(counts.Consolidations))
+}
+
+// recordEth1DataVote records the eth1 data vote of the block and updates the vote divergence metrics for the
+// current voting period.
+func (b *BeaconMetrics) recordEth1DataVote(block *spec.VersionedSignedBeaconBlock) error {
+	sp, err := b.beaconNode.Spec()
+	if err != nil {
+		return err
+	}
+
+	slotsPerPeriod := uint64(sp.SlotsPerEpoch) * uint64(sp.EpochsPerEth1VotingPeriod)
+	if slotsPerPeriod == 0 {
+		return nil
+	}
+
+	slot, err := block.Slot()
+	if err != nil {
+		return err
+	}
+
+	data, err := block.ETH1Data()
+	if err != nil {
+		return err
+	}
+
+	period := uint64(slot) / slotsPerPeriod
+
+	votes := b.eth1Votes.add(period, slot, data)
+
+	b.Eth1DataVotes.Reset()
+
+	for vote, count := range votes.Votes {
+		b.Eth1DataVotes.
+			WithLabelValues(vote.DepositRoot, fmt.Sprintf("%d", vote.DepositCount), vote.BlockHash).
+			Set(float64(count))
+	}
+
+	_, share := votes.Leader()
+
+	b.Eth1DataCandidates.Set(float64(len(votes.Votes)))
+	b.Eth1DataLeaderShare.Set(share)
+	b.Eth1VotingPeriod.Set(float64(votes.Period))
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/ethpandaops/ethwallclock"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DataColumnMetrics reports metrics on the data column sidecars received by the node.
+type DataColumnMetrics struct {
+	beacon         Node
+	log            *logging.Entry
+	Sidecars       *prometheus.CounterVec
+	CustodyColumns prometheus.Gauge
+	CustodySeen    prometheus.Gauge
+	CustodyRatio   prometheus.Gauge
+
+	coverage *dataColumnCoverage
+}
+
+const (
+	metricsJobNameDataColumn = "data_column"
+)
+
+// NewDataColumnMetrics returns a new DataColumnMetrics instance.
+func NewDataColumnMetrics(beac Node, log logging.Logger, namespace string, constLabels map[string]string) *DataColumnMetrics {
+	constLabels[metricsModuleLabelName] = metricsJobNameDataColumn
+
+	namespace += "_data_column"
+
+	d := &DataColumnMetrics{
+		beacon:   beac,
+		log:      logging.New(log),
+		coverage: newDataColumnCoverage(),
+		Sidecars: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   namespace,
+				Name:        "sidecars_total",
+				Help:        "Total of data column sidecars received, by whether the node custodies the column.",
+				ConstLabels: constLabels,
+			},
+			[]string{"custody"},
+		),
+		CustodyColumns: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "custody_columns",
+				Help:        "The number of columns the node custodies.",
+				ConstLabels: constLabels,
+			},
+		),
+		CustodySeen: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "custody_columns_seen",
+				Help:        "The number of custody columns received for the previous slot.",
+				ConstLabels: constLabels,
+			},
+		),
+		CustodyRatio: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "custody_coverage_ratio",
+				Help:        "The share of custody columns received for the previous slot.",
+				ConstLabels: constLabels,
+			},
+		),
+	}
+
+	prometheus.MustRegister(d.Sidecars)
+	prometheus.MustRegister(d.CustodyColumns)
+	prometheus.MustRegister(d.CustodySeen)
+	prometheus.MustRegister(d.CustodyRatio)
+
+	return d
+}
+
+// Name returns the name of the job.
+func (d *DataColumnMetrics) Name() string {
+	return metricsJobNameDataColumn
+}
+
+// Start starts the job.
+func (d *DataColumnMetrics) Start(ctx context.Context) error {
+	d.beacon.OnDataColumnSidecar(ctx, d.handleDataColumnSidecar)
+
+	d.beacon.OnReady(ctx, func(ctx context.Context, event *ReadyEvent) error {
+		d.beacon.Wallclock().OnSlotChanged(func(slot ethwallclock.Slot) {
+			if slot.Number() == 0 {
+				return
+			}
+
+			d.observeCoverage(phase0.Slot(slot.Number() - 1))
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+// Stop stops the job.
+func (d *DataColumnMetrics) Stop() error {
+	return nil
+}
+
+func (d *DataColumnMetrics) handleDataColumnSidecar(ctx context.Context, event *DataColumnSidecarEvent) error {
+	d.coverage.add(event.Slot, event.Index)
+
+	custody, err := d.beacon.IsCustodyColumn(event.Index)
+	if err != nil {
+		d.Sidecars.WithLabelValues("unknown").Inc()
+
+		return nil
+	}
+
+	if !custody {
+		d.Sidecars.WithLabelValues("false").Inc()
+
+		return nil
+	}
+
+	d.Sidecars.WithLabelValues("true").Inc()
+
+	return nil
+}
+
+// observeCoverage reports the share of custody columns received for the slot. Slots without any data column
+// sidecars (e.g. blocks without blobs) are skipped.
+func (d *DataColumnMetrics) observeCoverage(slot phase0.Slot) {
+	seen := d.coverage.take(slot)
+	if len(seen) == 0 {
+		return
+	}
+
+	columns, err := d.beacon.CustodyColumns()
+	if err != nil || len(columns) == 0 {
+		return
+	}
+
+	custodySeen := 0
+
+	for _, column := range columns {
+		if _, exists := seen[column]; exists {
+			custodySeen++
+		}
+	}
+
+	d.CustodyColumns.Set(float64(len(columns)))
+	d.CustodySeen.Set(float64(custodySeen))
+	d.CustodyRatio.Set(float64(custodySeen) / float64(len(columns)))
+}
@@ -5,16 +5,21 @@ import (
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
 	"github.com/go-co-op/gocron"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/sirupsen/logrus"
 )
 
 // EventMetrics reports event counts.
 type EventMetrics struct {
-	log                logrus.FieldLogger
+	log                *logging.Entry
 	Count              prometheus.CounterVec
 	TimeSinceLastEvent prometheus.Gauge
+	HandlerErrors      prometheus.CounterVec
+	HandlerRetries     prometheus.CounterVec
+	DeadLetters        prometheus.CounterVec
+	UnknownTopics      prometheus.CounterVec
+	SampledOut         prometheus.CounterFunc
 
 	beacon Node
 
@@ -28,12 +33,12 @@ const (
 )
 
 // NewEvent creates a new Event instance.
-func NewEventJob(bc Node, log logrus.FieldLogger, namespace string, constLabels map[string]string) *EventMetrics {
-	constLabels["module"] = metricsJobNameEvent
+func NewEventJob(bc Node, log logging.Logger, namespace string, constLabels map[string]string) *EventMetrics {
+	constLabels[metricsModuleLabelName] = metricsJobNameEvent
 	namespace += "_event"
 
 	e := &EventMetrics{
-		log:    log,
+		log:    logging.New(log),
 		beacon: bc,
 		crons:  gocron.NewScheduler(time.Local),
 		Count: *prometheus.NewCounterVec(
@@ -55,11 +60,72 @@ func NewEventJob(bc Node, log logrus.FieldLogger, namespace string, constLabels
 				ConstLabels: constLabels,
 			},
 		),
+		HandlerErrors: *prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   namespace,
+				Name:        "handler_errors_total",
+				Help:        "The count of errors returned by event handlers.",
+				ConstLabels: constLabels,
+			},
+			[]string{
+				"topic",
+			},
+		),
+		HandlerRetries: *prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   namespace,
+				Name:        "handler_retries_total",
+				Help:        "The count of event handler retries.",
+				ConstLabels: constLabels,
+			},
+			[]string{
+				"topic",
+			},
+		),
+		DeadLetters: *prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   namespace,
+				Name:        "dead_letters_total",
+				Help:        "The count of events forwarded to the dead letter channel.",
+				ConstLabels: constLabels,
+			},
+			[]string{
+				"topic",
+			},
+		),
+		UnknownTopics: *prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   namespace,
+				Name:        "unknown_topics_total",
+				Help:        "The count of beacon events on topics that are passed through without being decoded.",
+				ConstLabels: constLabels,
+			},
+			[]string{
+				"topic",
+			},
+		),
 		LastEventTime: time.Now(),
 	}
 
+	e.SampledOut = prometheus.NewCounterFunc(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        "sampled_out_attestations_total",
+			Help:        "The count of attestation events dropped by attestation sampling.",
+			ConstLabels: constLabels,
+		},
+		func() float64 {
+			return float64(bc.SampledOutAttestations())
+		},
+	)
+
 	prometheus.MustRegister(&e.Count)
 	prometheus.MustRegister(e.TimeSinceLastEvent)
+	prometheus.MustRegister(&e.HandlerErrors)
+	prometheus.MustRegister(&e.HandlerRetries)
+	prometheus.MustRegister(&e.DeadLetters)
+	prometheus.MustRegister(&e.UnknownTopics)
+	prometheus.MustRegister(e.SampledOut)
 
 	return e
 }
@@ -72,6 +138,7 @@ func (e *EventMetrics) Name() string {
 // Start starts the job.
 func (e *EventMetrics) Start(ctx context.Context) error {
 	e.beacon.OnEvent(ctx, e.HandleEvent)
+	e.beacon.OnHandlerError(ctx, e.HandleHandlerError)
 
 	if _, err := e.crons.Every("1s").Do(e.tick, ctx); err != nil {
 		return err
@@ -95,8 +162,28 @@ func (e *EventMetrics) tick(ctx context.Context) {
 // HandleEvent handles all beacon events
 func (e *EventMetrics) HandleEvent(ctx context.Context, event *v1.Event) error {
 	e.Count.WithLabelValues(event.Topic).Inc()
+
+	if !supportedEventTopics.Exists(event.Topic) {
+		e.UnknownTopics.WithLabelValues(event.Topic).Inc()
+	}
+
 	e.LastEventTime = time.Now()
 	e.TimeSinceLastEvent.Set(0)
 
 	return nil
 }
+
+// HandleHandlerError records errors returned by event handlers.
+func (e *EventMetrics) HandleHandlerError(ctx context.Context, event *HandlerErrorEvent) error {
+	e.HandlerErrors.WithLabelValues(event.Topic).Inc()
+
+	if event.WillRetry {
+		e.HandlerRetries.WithLabelValues(event.Topic).Inc()
+	}
+
+	if event.DeadLettered {
+		e.DeadLetters.WithLabelValues(event.Topic).Inc()
+	}
+
+	return nil
+}
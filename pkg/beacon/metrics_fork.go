@@ -2,11 +2,12 @@ package beacon
 
 import (
 	"context"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
 	"github.com/ethpandaops/ethwallclock"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/sirupsen/logrus"
 )
 
 // ForkMetrics reports the state of any forks (previous, active or upcoming).
@@ -14,8 +15,13 @@ type ForkMetrics struct {
 	Epochs    prometheus.GaugeVec
 	Activated prometheus.GaugeVec
 	Current   prometheus.GaugeVec
-	beacon    Node
-	log       logrus.FieldLogger
+	// EpochsUntilNext and SecondsUntilNext count down to the next scheduled fork.
+	EpochsUntilNext  prometheus.GaugeVec
+	SecondsUntilNext prometheus.GaugeVec
+	// MaxBlobsPerBlock is the maximum number of blobs per block according to the spec's blob schedule.
+	MaxBlobsPerBlock prometheus.Gauge
+	beacon           Node
+	log              *logging.Entry
 }
 
 const (
@@ -23,14 +29,14 @@ const (
 )
 
 // NewForksJob returns a new Forks instance.
-func NewForksJob(beac Node, log logrus.FieldLogger, namespace string, constLabels map[string]string) *ForkMetrics {
-	constLabels["module"] = metricsJobNameFork
+func NewForksJob(beac Node, log logging.Logger, namespace string, constLabels map[string]string) *ForkMetrics {
+	constLabels[metricsModuleLabelName] = metricsJobNameFork
 
 	namespace += "_fork"
 
 	f := &ForkMetrics{
 		beacon: beac,
-		log:    log,
+		log:    logging.New(log),
 		Epochs: *prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace:   namespace,
@@ -64,11 +70,44 @@ func NewForksJob(beac Node, log logrus.FieldLogger, namespace string, constLabel
 				"fork",
 			},
 		),
+		EpochsUntilNext: *prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "epochs_until_next",
+				Help:        "The number of epochs until the next scheduled fork activates.",
+				ConstLabels: constLabels,
+			},
+			[]string{
+				"fork",
+			},
+		),
+		SecondsUntilNext: *prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "seconds_until_next",
+				Help:        "The number of seconds until the next scheduled fork activates.",
+				ConstLabels: constLabels,
+			},
+			[]string{
+				"fork",
+			},
+		),
+		MaxBlobsPerBlock: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "max_blobs_per_block",
+				Help:        "The maximum number of blobs per block according to the blob schedule.",
+				ConstLabels: constLabels,
+			},
+		),
 	}
 
 	prometheus.MustRegister(f.Epochs)
 	prometheus.MustRegister(f.Activated)
 	prometheus.MustRegister(f.Current)
+	prometheus.MustRegister(f.EpochsUntilNext)
+	prometheus.MustRegister(f.SecondsUntilNext)
+	prometheus.MustRegister(f.MaxBlobsPerBlock)
 
 	return f
 }
@@ -87,6 +126,12 @@ func (f *ForkMetrics) Start(ctx context.Context) error {
 			}
 		})
 
+		f.beacon.Wallclock().OnSlotChanged(func(slot ethwallclock.Slot) {
+			if err := f.calculateCountdown(); err != nil {
+				f.log.WithError(err).Debug("Failed to calculate next fork countdown")
+			}
+		})
+
 		return nil
 	})
 
@@ -120,6 +165,10 @@ func (f *ForkMetrics) calculateCurrent(ctx context.Context) error {
 		}
 	}
 
+	if entry := spec.BlobSchedule.Active(phase0.Epoch(phase0.Slot(slot.Number()) / slotsPerEpoch)); entry != nil {
+		f.MaxBlobsPerBlock.Set(float64(entry.MaxBlobsPerBlock))
+	}
+
 	current, err := spec.ForkEpochs.CurrentFork(phase0.Epoch(phase0.Slot(slot.Number()) / slotsPerEpoch))
 	if err != nil {
 		f.log.WithError(err).Error("Failed to set current fork")
@@ -131,3 +180,29 @@ func (f *ForkMetrics) calculateCurrent(ctx context.Context) error {
 
 	return nil
 }
+
+// calculateCountdown sets the countdown to the next scheduled fork, if there is one.
+func (f *ForkMetrics) calculateCountdown() error {
+	if _, err := f.beacon.Spec(); err != nil {
+		return err
+	}
+
+	wallclock := f.beacon.Wallclock()
+	epoch := wallclock.Epochs().Current()
+
+	f.EpochsUntilNext.Reset()
+	f.SecondsUntilNext.Reset()
+
+	next, err := f.beacon.NextScheduledFork()
+	if err != nil {
+		// No fork is scheduled.
+		return nil
+	}
+
+	activation := wallclock.Epochs().FromNumber(uint64(next.Epoch))
+
+	f.EpochsUntilNext.WithLabelValues(next.Name.String()).Set(float64(next.Epoch) - float64(epoch.Number()))
+	f.SecondsUntilNext.WithLabelValues(next.Name.String()).Set(time.Until(activation.TimeWindow().Start()).Seconds())
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package beacon
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/go-co-op/gocron"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ForkChoiceMetrics reports metrics derived from periodically polling the node's fork choice.
+type ForkChoiceMetrics struct {
+	log         *logging.Entry
+	beacon      Node
+	interval    string
+	Nodes       prometheus.Gauge
+	ViableHeads prometheus.Gauge
+	Checkpoints prometheus.GaugeVec
+
+	crons *gocron.Scheduler
+}
+
+const (
+	metricsJobNameForkChoice = "fork_choice"
+)
+
+// NewForkChoiceMetrics returns a new ForkChoice metrics instance.
+func NewForkChoiceMetrics(beac Node, log logging.Logger, namespace string, constLabels map[string]string, opts ForkChoiceMetricsOptions) *ForkChoiceMetrics {
+	constLabels[metricsModuleLabelName] = metricsJobNameForkChoice
+	namespace += "_fork_choice"
+
+	f := &ForkChoiceMetrics{
+		log:      logging.New(log),
+		beacon:   beac,
+		interval: opts.Interval.String(),
+		crons:    gocron.NewScheduler(time.Local),
+		Nodes: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "nodes",
+				Help:        "The amount of nodes in the fork choice.",
+				ConstLabels: constLabels,
+			},
+		),
+		ViableHeads: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "viable_heads",
+				Help:        "The amount of fork choice leaves that have not been marked as invalid.",
+				ConstLabels: constLabels,
+			},
+		),
+		Checkpoints: *prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "checkpoint_epoch",
+				Help:        "The epochs of the fork choice checkpoints.",
+				ConstLabels: constLabels,
+			},
+			[]string{
+				"checkpoint",
+			},
+		),
+	}
+
+	prometheus.MustRegister(f.Nodes)
+	prometheus.MustRegister(f.ViableHeads)
+	prometheus.MustRegister(f.Checkpoints)
+
+	return f
+}
+
+// Name returns the name of the job.
+func (f *ForkChoiceMetrics) Name() string {
+	return metricsJobNameForkChoice
+}
+
+// Start starts the job.
+func (f *ForkChoiceMetrics) Start(ctx context.Context) error {
+	if _, err := f.crons.Every(f.interval).Do(f.tick, ctx); err != nil {
+		return err
+	}
+
+	f.crons.StartAsync()
+
+	return nil
+}
+
+// Stop stops the job.
+func (f *ForkChoiceMetrics) Stop() error {
+	f.crons.Stop()
+
+	return nil
+}
+
+func (f *ForkChoiceMetrics) tick(ctx context.Context) {
+	if f.beacon.PollingPaused() || !f.beacon.Healthy() {
+		return
+	}
+
+	forkChoice, err := f.beacon.FetchForkChoice(ctx)
+	if err != nil {
+		f.log.WithError(err).Debug("Failed to fetch fork choice")
+
+		return
+	}
+
+	f.Nodes.Set(float64(len(forkChoice.ForkChoiceNodes)))
+	f.ViableHeads.Set(float64(countViableHeads(forkChoice.ForkChoiceNodes)))
+
+	f.Checkpoints.WithLabelValues("justified").Set(float64(forkChoice.JustifiedCheckpoint.Epoch))
+	f.Checkpoints.WithLabelValues("finalized").Set(float64(forkChoice.FinalizedCheckpoint.Epoch))
+}
+
+// countViableHeads returns the number of fork choice leaves that have not been marked as invalid.
+func countViableHeads(nodes []*v1.ForkChoiceNode) int {
+	parents := make(map[phase0.Root]struct{}, len(nodes))
+
+	for _, node := range nodes {
+		parents[node.ParentRoot] = struct{}{}
+	}
+
+	heads := 0
+
+	for _, node := range nodes {
+		if _, isParent := parents[node.BlockRoot]; isParent {
+			continue
+		}
+
+		if node.Validity == v1.ForkChoiceNodeValidityInvalid {
+			continue
+		}
+
+		heads++
+	}
+
+	return heads
+}
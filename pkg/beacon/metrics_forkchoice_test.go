@@ -0,0 +1,22 @@
+package beacon
+
+import (
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountViableHeads(t *testing.T) {
+	nodes := []*v1.ForkChoiceNode{
+		{BlockRoot: phase0.Root{0x01}, Validity: v1.ForkChoiceNodeValidityValid},
+		{BlockRoot: phase0.Root{0x02}, ParentRoot: phase0.Root{0x01}, Validity: v1.ForkChoiceNodeValidityValid},
+		{BlockRoot: phase0.Root{0x03}, ParentRoot: phase0.Root{0x02}, Validity: v1.ForkChoiceNodeValidityValid},
+		{BlockRoot: phase0.Root{0x04}, ParentRoot: phase0.Root{0x02}, Validity: v1.ForkChoiceNodeValidityOptimistic},
+		{BlockRoot: phase0.Root{0x05}, ParentRoot: phase0.Root{0x01}, Validity: v1.ForkChoiceNodeValidityInvalid},
+	}
+
+	assert.Equal(t, 2, countViableHeads(nodes))
+	assert.Equal(t, 0, countViableHeads(nil))
+}
@@ -4,17 +4,19 @@ import (
 	"context"
 
 	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/sirupsen/logrus"
 )
 
 // GeneralMetrics reports general information about the node.
 type GeneralMetrics struct {
 	beacon      Node
-	log         logrus.FieldLogger
+	log         *logging.Entry
 	NodeVersion prometheus.GaugeVec
 	ClientName  prometheus.GaugeVec
 	Peers       prometheus.GaugeVec
+	Throttled   prometheus.CounterFunc
+	ClockSkew   prometheus.GaugeFunc
 }
 
 const (
@@ -22,12 +24,12 @@ const (
 )
 
 // NewGeneral creates a new General instance.
-func NewGeneralJob(beac Node, log logrus.FieldLogger, namespace string, constLabels map[string]string) *GeneralMetrics {
-	constLabels["module"] = metricsJobNameGeneral
+func NewGeneralJob(beac Node, log logging.Logger, namespace string, constLabels map[string]string) *GeneralMetrics {
+	constLabels[metricsModuleLabelName] = metricsJobNameGeneral
 
 	g := &GeneralMetrics{
 		beacon: beac,
-		log:    log,
+		log:    logging.New(log),
 		NodeVersion: *prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace:   namespace,
@@ -53,8 +55,39 @@ func NewGeneralJob(beac Node, log logrus.FieldLogger, namespace string, constLab
 		),
 	}
 
+	g.Throttled = prometheus.NewCounterFunc(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        "throttled_requests_total",
+			Help:        "The count of requests that the beacon node responded to with a 429.",
+			ConstLabels: constLabels,
+		},
+		func() float64 {
+			return float64(beac.ThrottledRequests())
+		},
+	)
+
+	g.ClockSkew = prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Name:        "clock_skew_seconds",
+			Help:        "The skew between the beacon node's clock and the local clock, positive if the node is ahead.",
+			ConstLabels: constLabels,
+		},
+		func() float64 {
+			skew, err := beac.ClockSkew()
+			if err != nil {
+				return 0
+			}
+
+			return skew.Seconds()
+		},
+	)
+
 	prometheus.MustRegister(&g.NodeVersion)
 	prometheus.MustRegister(&g.Peers)
+	prometheus.MustRegister(g.Throttled)
+	prometheus.MustRegister(g.ClockSkew)
 
 	return g
 }
@@ -0,0 +1,64 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// GraffitiMetrics reports the graffiti of head blocks.
+type GraffitiMetrics struct {
+	log    *logging.Entry
+	beacon Node
+	Blocks *prometheus.CounterVec
+}
+
+const (
+	metricsJobNameGraffiti = "graffiti"
+)
+
+// NewGraffitiMetrics returns a new GraffitiMetrics instance.
+func NewGraffitiMetrics(beac Node, log logging.Logger, namespace string, constLabels map[string]string) *GraffitiMetrics {
+	constLabels[metricsModuleLabelName] = metricsJobNameGraffiti
+	namespace += "_graffiti"
+
+	g := &GraffitiMetrics{
+		log:    logging.New(log),
+		beacon: beac,
+		Blocks: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   namespace,
+				Name:        "blocks_total",
+				Help:        "The number of head blocks by normalized graffiti prefix.",
+				ConstLabels: constLabels,
+			},
+			[]string{"prefix"},
+		),
+	}
+
+	prometheus.MustRegister(g.Blocks)
+
+	return g
+}
+
+// Name returns the name of the job.
+func (g *GraffitiMetrics) Name() string {
+	return metricsJobNameGraffiti
+}
+
+// Start starts the job.
+func (g *GraffitiMetrics) Start(ctx context.Context) error {
+	g.beacon.OnBlockGraffiti(ctx, func(ctx context.Context, event *BlockGraffitiEvent) error {
+		g.Blocks.WithLabelValues(event.Prefix).Inc()
+
+		return nil
+	})
+
+	return nil
+}
+
+// Stop stops the job.
+func (g *GraffitiMetrics) Stop() error {
+	return nil
+}
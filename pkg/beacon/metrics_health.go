@@ -3,16 +3,18 @@ package beacon
 import (
 	"context"
 
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/sirupsen/logrus"
 )
 
 // HealthMetrics reports metrics on the health status of the node.
 type HealthMetrics struct {
 	beacon            Node
-	log               logrus.FieldLogger
+	log               *logging.Entry
 	CheckResultsTotal *prometheus.CounterVec
 	Up                prometheus.Gauge
+	Ready             prometheus.Gauge
+	Bootstrapped      prometheus.Gauge
 }
 
 const (
@@ -20,14 +22,14 @@ const (
 )
 
 // NewHealthMetrics returns a new HealthMetrics instance.
-func NewHealthMetrics(beac Node, log logrus.FieldLogger, namespace string, constLabels map[string]string) *HealthMetrics {
-	constLabels["module"] = metricsJobNameHealth
+func NewHealthMetrics(beac Node, log logging.Logger, namespace string, constLabels map[string]string) *HealthMetrics {
+	constLabels[metricsModuleLabelName] = metricsJobNameHealth
 
 	namespace += "_health"
 
 	h := &HealthMetrics{
 		beacon: beac,
-		log:    log,
+		log:    logging.New(log),
 		CheckResultsTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace:   namespace,
@@ -45,10 +47,28 @@ func NewHealthMetrics(beac Node, log logrus.FieldLogger, namespace string, const
 				ConstLabels: constLabels,
 			},
 		),
+		Ready: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "ready",
+				Help:        "Whether the node is ready to be used. Unlike up it does not change once set.",
+				ConstLabels: constLabels,
+			},
+		),
+		Bootstrapped: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "bootstrapped",
+				Help:        "Whether the node has loaded its spec and genesis and subscribed to its events.",
+				ConstLabels: constLabels,
+			},
+		),
 	}
 
 	prometheus.MustRegister(h.CheckResultsTotal)
 	prometheus.MustRegister(h.Up)
+	prometheus.MustRegister(h.Ready)
+	prometheus.MustRegister(h.Bootstrapped)
 
 	return h
 }
@@ -74,6 +94,12 @@ func (h *HealthMetrics) Start(ctx context.Context) error {
 		return nil
 	})
 
+	h.beacon.OnReady(ctx, func(ctx context.Context, event *ReadyEvent) error {
+		h.checkReadiness()
+
+		return nil
+	})
+
 	return nil
 }
 
@@ -98,4 +124,20 @@ func (h *HealthMetrics) checkUp(ctx context.Context) {
 	} else {
 		h.Up.Set(0)
 	}
+
+	h.checkReadiness()
+}
+
+func (h *HealthMetrics) checkReadiness() {
+	if h.beacon.IsReady() {
+		h.Ready.Set(1)
+	} else {
+		h.Ready.Set(0)
+	}
+
+	if h.beacon.Bootstrapped() {
+		h.Bootstrapped.Set(1)
+	} else {
+		h.Bootstrapped.Set(0)
+	}
 }
@@ -0,0 +1,81 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PayloadSizeMetrics reports the size of the events received from the node.
+type PayloadSizeMetrics struct {
+	log         *logging.Entry
+	beacon      Node
+	PayloadSize *prometheus.HistogramVec
+}
+
+const (
+	metricsJobNamePayloadSize = "payload_size"
+)
+
+// NewPayloadSizeMetrics returns a new PayloadSizeMetrics instance.
+func NewPayloadSizeMetrics(beac Node, log logging.Logger, namespace string, constLabels map[string]string) *PayloadSizeMetrics {
+	constLabels[metricsModuleLabelName] = metricsJobNamePayloadSize
+	namespace += "_event"
+
+	p := &PayloadSizeMetrics{
+		log:    logging.New(log),
+		beacon: beac,
+		PayloadSize: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace:   namespace,
+				Name:        "payload_size_bytes",
+				Help:        "The size of the JSON encoded payload of beacon events.",
+				ConstLabels: constLabels,
+				// 128B to 8MB.
+				Buckets: prometheus.ExponentialBuckets(128, 4, 9),
+			},
+			[]string{"topic"},
+		),
+	}
+
+	prometheus.MustRegister(p.PayloadSize)
+
+	return p
+}
+
+// Name returns the name of the job.
+func (p *PayloadSizeMetrics) Name() string {
+	return metricsJobNamePayloadSize
+}
+
+// Start starts the job.
+func (p *PayloadSizeMetrics) Start(ctx context.Context) error {
+	p.beacon.OnEvent(ctx, func(ctx context.Context, event *v1.Event) error {
+		p.observe(event)
+
+		return nil
+	})
+
+	return nil
+}
+
+// Stop stops the job.
+func (p *PayloadSizeMetrics) Stop() error {
+	return nil
+}
+
+// observe records the size of the event's payload. go-eth2-client only hands over decoded events, so the
+// payload is encoded again to measure it.
+func (p *PayloadSizeMetrics) observe(event *v1.Event) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		p.log.WithError(err).WithField("topic", event.Topic).Debug("Failed to encode event to measure its size")
+
+		return
+	}
+
+	p.PayloadSize.WithLabelValues(event.Topic).Observe(float64(len(data)))
+}
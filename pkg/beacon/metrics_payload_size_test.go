@@ -0,0 +1,28 @@
+package beacon
+
+import (
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadSizeMetrics(t *testing.T) {
+	p := NewPayloadSizeMetrics(nil, logging.New(nil), "test", map[string]string{})
+
+	p.observe(&v1.Event{Topic: topicHead, Data: &v1.HeadEvent{Slot: 1}})
+	p.observe(&v1.Event{Topic: topicHead, Data: &v1.HeadEvent{Slot: 2}})
+
+	assert.Equal(t, 1, testutil.CollectAndCount(p.PayloadSize))
+
+	p.observe(&v1.Event{Topic: topicBlock, Data: &v1.BlockEvent{Slot: 1}})
+
+	assert.Equal(t, 2, testutil.CollectAndCount(p.PayloadSize))
+
+	// Events that cannot be encoded are skipped.
+	p.observe(&v1.Event{Topic: topicChainReorg, Data: make(chan int)})
+
+	assert.Equal(t, 2, testutil.CollectAndCount(p.PayloadSize))
+}
@@ -4,15 +4,15 @@ import (
 	"context"
 	"math/big"
 
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
 	"github.com/ethpandaops/beacon/pkg/beacon/state"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/sirupsen/logrus"
 )
 
 // SpecMetrics reports metrics about the configured consensus spec.
 type SpecMetrics struct {
 	beacon                           Node
-	log                              logrus.FieldLogger
+	log                              *logging.Entry
 	SafeSlotsToUpdateJustified       prometheus.Gauge
 	DepositChainID                   prometheus.Gauge
 	ConfigName                       prometheus.GaugeVec
@@ -44,13 +44,13 @@ const (
 )
 
 // NewSpecJob returns a new Spec instance.
-func NewSpecJob(bc Node, log logrus.FieldLogger, namespace string, constLabels map[string]string) *SpecMetrics {
-	constLabels["module"] = metricsJobNameSpec
+func NewSpecJob(bc Node, log logging.Logger, namespace string, constLabels map[string]string) *SpecMetrics {
+	constLabels[metricsModuleLabelName] = metricsJobNameSpec
 
 	namespace += "_spec"
 
 	s := &SpecMetrics{
-		log:    log,
+		log:    logging.New(log),
 		beacon: bc,
 		SafeSlotsToUpdateJustified: prometheus.NewGauge(
 			prometheus.GaugeOpts{
@@ -0,0 +1,224 @@
+package beacon
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/go-co-op/gocron"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StreamMetrics reports metrics on the upstream event streams, as opposed to the health of the node itself.
+type StreamMetrics struct {
+	beacon Node
+	log    *logging.Entry
+
+	Connects       *prometheus.CounterVec
+	Reconnects     *prometheus.CounterVec
+	Connected      *prometheus.GaugeVec
+	ConnectedFor   *prometheus.GaugeVec
+	EventsPerSec   *prometheus.GaugeVec
+	LastDisconnect *prometheus.GaugeVec
+
+	mu          sync.Mutex
+	connectedAt map[string]time.Time
+	open        map[string]int
+	counts      map[string]uint64
+	lastTick    time.Time
+
+	crons *gocron.Scheduler
+}
+
+const (
+	metricsJobNameStream = "stream"
+)
+
+// NewStreamMetrics returns a new StreamMetrics instance.
+func NewStreamMetrics(beac Node, log logging.Logger, namespace string, constLabels map[string]string) *StreamMetrics {
+	constLabels[metricsModuleLabelName] = metricsJobNameStream
+
+	namespace += "_event_stream"
+
+	s := &StreamMetrics{
+		beacon:      beac,
+		log:         logging.New(log),
+		connectedAt: make(map[string]time.Time),
+		open:        make(map[string]int),
+		counts:      make(map[string]uint64),
+		lastTick:    time.Now(),
+		crons:       gocron.NewScheduler(time.Local),
+		Connects: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   namespace,
+				Name:        "connects_total",
+				Help:        "Total of times the upstream event stream was started.",
+				ConstLabels: constLabels,
+			},
+			[]string{"stream"},
+		),
+		Reconnects: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   namespace,
+				Name:        "reconnects_total",
+				Help:        "Total of times the upstream event stream was started again after disconnecting.",
+				ConstLabels: constLabels,
+			},
+			[]string{"stream"},
+		),
+		Connected: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "connected",
+				Help:        "Whether the upstream event stream is connected or not.",
+				ConstLabels: constLabels,
+			},
+			[]string{"stream"},
+		),
+		ConnectedFor: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "connected_seconds",
+				Help:        "The amount of time the upstream event stream has been connected for (in seconds).",
+				ConstLabels: constLabels,
+			},
+			[]string{"stream"},
+		),
+		EventsPerSec: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "events_per_second",
+				Help:        "The rate of events received from the upstream event stream, by topic.",
+				ConstLabels: constLabels,
+			},
+			[]string{"topic"},
+		),
+		LastDisconnect: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "last_disconnect_timestamp_seconds",
+				Help:        "The time of the last disconnect of the upstream event stream, labelled with its reason category.",
+				ConstLabels: constLabels,
+			},
+			[]string{"stream", "reason"},
+		),
+	}
+
+	prometheus.MustRegister(s.Connects)
+	prometheus.MustRegister(s.Reconnects)
+	prometheus.MustRegister(s.Connected)
+	prometheus.MustRegister(s.ConnectedFor)
+	prometheus.MustRegister(s.EventsPerSec)
+	prometheus.MustRegister(s.LastDisconnect)
+
+	return s
+}
+
+// Name returns the name of the job.
+func (s *StreamMetrics) Name() string {
+	return metricsJobNameStream
+}
+
+// Start starts the job.
+func (s *StreamMetrics) Start(ctx context.Context) error {
+	s.beacon.OnEventStreamConnected(ctx, func(ctx context.Context, event *EventStreamConnectedEvent) error {
+		s.observeConnected(event.Stream, event.Reconnect, event.At)
+
+		return nil
+	})
+
+	s.beacon.OnEventStreamDisconnected(ctx, func(ctx context.Context, event *EventStreamDisconnectedEvent) error {
+		s.observeDisconnected(event.Stream, event.Category, event.At)
+
+		return nil
+	})
+
+	s.beacon.OnEvent(ctx, func(ctx context.Context, event *v1.Event) error {
+		s.mu.Lock()
+		s.counts[event.Topic]++
+		s.mu.Unlock()
+
+		return nil
+	})
+
+	if _, err := s.crons.Every("10s").Do(func() {
+		s.tick(time.Now())
+	}); err != nil {
+		return err
+	}
+
+	s.crons.StartAsync()
+
+	return nil
+}
+
+// Stop stops the job.
+func (s *StreamMetrics) Stop() error {
+	s.crons.Stop()
+
+	return nil
+}
+
+func (s *StreamMetrics) observeConnected(stream string, reconnect bool, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Connects.WithLabelValues(stream).Inc()
+
+	if reconnect {
+		s.Reconnects.WithLabelValues(stream).Inc()
+	}
+
+	s.open[stream]++
+	s.connectedAt[stream] = at
+
+	s.Connected.WithLabelValues(stream).Set(1)
+	s.ConnectedFor.WithLabelValues(stream).Set(0)
+}
+
+// observeDisconnected records the end of a stream. A restarted stream may connect again before the previous
+// connection reports its disconnect, so the stream is only marked as disconnected once every connection ended.
+func (s *StreamMetrics) observeDisconnected(stream, category string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.open[stream] > 0 {
+		s.open[stream]--
+	}
+
+	if s.open[stream] == 0 {
+		delete(s.connectedAt, stream)
+
+		s.Connected.WithLabelValues(stream).Set(0)
+		s.ConnectedFor.WithLabelValues(stream).Set(0)
+	}
+
+	// Only the most recent reason is kept for each stream.
+	s.LastDisconnect.DeletePartialMatch(prometheus.Labels{"stream": stream})
+	s.LastDisconnect.WithLabelValues(stream, category).Set(float64(at.Unix()))
+}
+
+// tick updates the connection durations and the per topic event rates since the previous tick.
+func (s *StreamMetrics) tick(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for stream, at := range s.connectedAt {
+		s.ConnectedFor.WithLabelValues(stream).Set(now.Sub(at).Seconds())
+	}
+
+	elapsed := now.Sub(s.lastTick).Seconds()
+	s.lastTick = now
+
+	if elapsed <= 0 {
+		return
+	}
+
+	for topic, count := range s.counts {
+		s.EventsPerSec.WithLabelValues(topic).Set(float64(count) / elapsed)
+
+		s.counts[topic] = 0
+	}
+}
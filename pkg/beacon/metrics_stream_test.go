@@ -0,0 +1,69 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamMetrics(t *testing.T) {
+	s := NewStreamMetrics(nil, logging.New(nil), "test", map[string]string{})
+
+	start := time.Now()
+
+	s.observeConnected(EventStreamDefault, false, start)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.Connects.WithLabelValues(EventStreamDefault)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(s.Reconnects.WithLabelValues(EventStreamDefault)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.Connected.WithLabelValues(EventStreamDefault)))
+
+	s.counts["head"] = 20
+	s.lastTick = start
+	s.tick(start.Add(10 * time.Second))
+
+	assert.Equal(t, float64(10), testutil.ToFloat64(s.ConnectedFor.WithLabelValues(EventStreamDefault)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(s.EventsPerSec.WithLabelValues("head")))
+
+	// A restarted stream can connect again before the previous connection reports its disconnect.
+	s.observeConnected(EventStreamDefault, true, start.Add(20*time.Second))
+	s.observeDisconnected(EventStreamDefault, EventStreamDisconnectRestarted, start.Add(20*time.Second))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.Reconnects.WithLabelValues(EventStreamDefault)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.Connected.WithLabelValues(EventStreamDefault)))
+
+	s.observeDisconnected(EventStreamDefault, EventStreamDisconnectStopped, start.Add(30*time.Second))
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(s.Connected.WithLabelValues(EventStreamDefault)))
+	assert.Equal(t, 1, testutil.CollectAndCount(s.LastDisconnect))
+	assert.Equal(t, float64(start.Add(30*time.Second).Unix()), testutil.ToFloat64(s.LastDisconnect.WithLabelValues(EventStreamDefault, EventStreamDisconnectStopped)))
+
+	s.observeConnected(EventStreamDefault, true, start.Add(40*time.Second))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(s.Reconnects.WithLabelValues(EventStreamDefault)))
+}
+
+func TestEventStreamDisconnectCategory(t *testing.T) {
+	restarted, restart := context.WithCancelCause(context.Background())
+	restart(errEventStreamRestarted)
+
+	stopped, stop := context.WithCancel(context.Background())
+	stop()
+
+	assert.Equal(t, EventStreamDisconnectRestarted, eventStreamDisconnectCategory(restarted, nil))
+	assert.Equal(t, EventStreamDisconnectStopped, eventStreamDisconnectCategory(stopped, errors.New("read: connection reset")))
+	assert.Equal(t, EventStreamDisconnectFailed, eventStreamDisconnectCategory(context.Background(), errors.New("read: connection reset")))
+	assert.Equal(t, EventStreamDisconnectClosed, eventStreamDisconnectCategory(context.Background(), nil))
+}
+
+func TestMarkStreamConnected(t *testing.T) {
+	n := &node{}
+
+	assert.False(t, n.markStreamConnected(EventStreamDefault))
+	assert.True(t, n.markStreamConnected(EventStreamDefault))
+	assert.False(t, n.markStreamConnected(EventStreamRaw))
+}
@@ -3,19 +3,20 @@ package beacon
 import (
 	"context"
 
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/sirupsen/logrus"
 )
 
 // SyncMetrics reports metrics on the sync status of the node.
 type SyncMetrics struct {
 	beacon               Node
-	log                  logrus.FieldLogger
+	log                  *logging.Entry
 	Percentage           prometheus.Gauge
 	EstimatedHighestSlot prometheus.Gauge
 	HeadSlot             prometheus.Gauge
 	Distance             prometheus.Gauge
 	IsSyncing            prometheus.Gauge
+	IsOptimistic         prometheus.Gauge
 }
 
 const (
@@ -23,14 +24,14 @@ const (
 )
 
 // NewSyncMetrics returns a new Sync metrics instance.
-func NewSyncMetrics(beac Node, log logrus.FieldLogger, namespace string, constLabels map[string]string) *SyncMetrics {
-	constLabels["module"] = metricsJobNameSync
+func NewSyncMetrics(beac Node, log logging.Logger, namespace string, constLabels map[string]string) *SyncMetrics {
+	constLabels[metricsModuleLabelName] = metricsJobNameSync
 
 	namespace += "_sync"
 
 	s := &SyncMetrics{
 		beacon: beac,
-		log:    log,
+		log:    logging.New(log),
 		Percentage: prometheus.NewGauge(
 			prometheus.GaugeOpts{
 				Namespace:   namespace,
@@ -71,6 +72,14 @@ func NewSyncMetrics(beac Node, log logrus.FieldLogger, namespace string, constLa
 				ConstLabels: constLabels,
 			},
 		),
+		IsOptimistic: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "is_optimistic",
+				Help:        "1 if the node is optimistically synced.",
+				ConstLabels: constLabels,
+			},
+		),
 	}
 
 	prometheus.MustRegister(s.Percentage)
@@ -78,6 +87,7 @@ func NewSyncMetrics(beac Node, log logrus.FieldLogger, namespace string, constLa
 	prometheus.MustRegister(s.HeadSlot)
 	prometheus.MustRegister(s.Distance)
 	prometheus.MustRegister(s.IsSyncing)
+	prometheus.MustRegister(s.IsOptimistic)
 
 	return s
 }
@@ -95,6 +105,7 @@ func (s *SyncMetrics) Start(ctx context.Context) error {
 		s.Distance.Set(float64(status.SyncDistance))
 		s.HeadSlot.Set(float64(status.HeadSlot))
 		s.observeSyncIsSyncing(status.IsSyncing)
+		s.observeSyncIsOptimistic(status.IsOptimistic)
 
 		estimatedHighestHeadSlot := status.SyncDistance + status.HeadSlot
 		s.EstimatedHighestSlot.Set(float64(estimatedHighestHeadSlot))
@@ -125,3 +136,12 @@ func (s *SyncMetrics) observeSyncIsSyncing(syncing bool) {
 
 	s.IsSyncing.Set(0)
 }
+
+func (s *SyncMetrics) observeSyncIsOptimistic(optimistic bool) {
+	if optimistic {
+		s.IsOptimistic.Set(1)
+		return
+	}
+
+	s.IsOptimistic.Set(0)
+}
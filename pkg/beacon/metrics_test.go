@@ -0,0 +1,128 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMetricsConstLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		opts     MetricsOptions
+		expected prometheus.Labels
+		err      string
+	}{
+		{
+			name: "defaults",
+			opts: MetricsOptions{},
+			expected: prometheus.Labels{
+				"node": "beacon-1",
+			},
+		},
+		{
+			name: "extra labels",
+			opts: MetricsOptions{
+				ConstLabels: map[string]string{
+					"network": "mainnet",
+					"region":  "eu",
+				},
+			},
+			expected: prometheus.Labels{
+				"node":    "beacon-1",
+				"network": "mainnet",
+				"region":  "eu",
+			},
+		},
+		{
+			name: "config labels",
+			labels: map[string]string{
+				"datacenter": "dc1",
+				"network":    "holesky",
+			},
+			opts: MetricsOptions{
+				ConstLabels: map[string]string{
+					"network": "mainnet",
+				},
+			},
+			expected: prometheus.Labels{
+				"node":       "beacon-1",
+				"datacenter": "dc1",
+				"network":    "mainnet",
+			},
+		},
+		{
+			name: "node label override",
+			opts: MetricsOptions{
+				NodeLabelName: "instance",
+			},
+			expected: prometheus.Labels{
+				"instance": "beacon-1",
+			},
+		},
+		{
+			name: "node label clash",
+			opts: MetricsOptions{
+				ConstLabels: map[string]string{
+					"node": "other",
+				},
+			},
+			err: `"node" clashes with the node label`,
+		},
+		{
+			name: "module label clash",
+			labels: map[string]string{
+				"module": "other",
+			},
+			err: `"module" is reserved`,
+		},
+		{
+			name: "variable label clash",
+			opts: MetricsOptions{
+				ConstLabels: map[string]string{
+					"topic": "head",
+				},
+			},
+			err: `"topic" is reserved`,
+		},
+		{
+			name: "invalid label name",
+			labels: map[string]string{
+				"data-center": "dc1",
+			},
+			err: `"data-center" is not a valid label name`,
+		},
+		{
+			name: "internal label name",
+			labels: map[string]string{
+				"__name__": "other",
+			},
+			err: `"__name__" is not a valid label name`,
+		},
+		{
+			name: "invalid node label override",
+			opts: MetricsOptions{
+				NodeLabelName: "version",
+			},
+			err: `"version" is reserved`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			labels, err := buildMetricsConstLabels("beacon-1", test.labels, test.opts)
+			if test.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, labels)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package beacon
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/go-co-op/gocron"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// WallclockMetrics reports the current position of the wallclock.
+type WallclockMetrics struct {
+	log               *logging.Entry
+	beacon            Node
+	Slot              prometheus.Gauge
+	Epoch             prometheus.Gauge
+	SlotTimeRemaining prometheus.Gauge
+	HeadDrift         prometheus.Gauge
+
+	crons *gocron.Scheduler
+}
+
+const (
+	metricsJobNameWallclock = "wallclock"
+)
+
+// NewWallclockMetrics returns a new Wallclock metrics instance.
+func NewWallclockMetrics(beac Node, log logging.Logger, namespace string, constLabels map[string]string) *WallclockMetrics {
+	constLabels[metricsModuleLabelName] = metricsJobNameWallclock
+	namespace += "_wallclock"
+
+	w := &WallclockMetrics{
+		log:    logging.New(log),
+		beacon: beac,
+		crons:  gocron.NewScheduler(time.Local),
+		Slot: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "slot",
+				Help:        "The current wallclock slot.",
+				ConstLabels: constLabels,
+			},
+		),
+		Epoch: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "epoch",
+				Help:        "The current wallclock epoch.",
+				ConstLabels: constLabels,
+			},
+		),
+		SlotTimeRemaining: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "slot_time_remaining_ms",
+				Help:        "The amount of time remaining in the current wallclock slot (in milliseconds).",
+				ConstLabels: constLabels,
+			},
+		),
+		HeadDrift: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "head_drift_slots",
+				Help:        "The amount of slots the node's head is behind the wallclock slot.",
+				ConstLabels: constLabels,
+			},
+		),
+	}
+
+	prometheus.MustRegister(w.Slot)
+	prometheus.MustRegister(w.Epoch)
+	prometheus.MustRegister(w.SlotTimeRemaining)
+	prometheus.MustRegister(w.HeadDrift)
+
+	return w
+}
+
+// Name returns the name of the job.
+func (w *WallclockMetrics) Name() string {
+	return metricsJobNameWallclock
+}
+
+// Start starts the job.
+func (w *WallclockMetrics) Start(ctx context.Context) error {
+	if _, err := w.crons.Every("1s").Do(w.tick, ctx); err != nil {
+		return err
+	}
+
+	w.crons.StartAsync()
+
+	return nil
+}
+
+// Stop stops the job.
+func (w *WallclockMetrics) Stop() error {
+	w.crons.Stop()
+
+	return nil
+}
+
+//nolint:unparam // ctx will probably be used in the future
+func (w *WallclockMetrics) tick(ctx context.Context) {
+	wallclock := w.beacon.Wallclock()
+	if wallclock == nil {
+		// The wallclock is only available once the node has bootstrapped.
+		return
+	}
+
+	slot, epoch, err := wallclock.Now()
+	if err != nil {
+		w.log.WithError(err).Debug("Failed to get wallclock position")
+
+		return
+	}
+
+	w.Slot.Set(float64(slot.Number()))
+	w.Epoch.Set(float64(epoch.Number()))
+	w.SlotTimeRemaining.Set(float64(time.Until(slot.TimeWindow().End()).Milliseconds()))
+
+	headSlot, err := w.beacon.HeadSlot()
+	if err != nil {
+		return
+	}
+
+	w.HeadDrift.Set(float64(slot.Number()) - float64(headSlot))
+}
@@ -3,6 +3,7 @@ package beacon
 import (
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/beacon/pkg/human"
 )
 
@@ -10,8 +11,91 @@ import (
 type Options struct {
 	BeaconSubscription BeaconSubscriptionOptions
 	HealthCheck        HealthCheckOptions
+	Polling            PollingOptions
 	PrometheusMetrics  bool
+	Metrics            MetricsOptions
 	DetectEmptySlots   bool
+	// EmptySlotGrace is the number of slots that pass before a slot is checked for a block. Defaults to 1,
+	// which checks the previous slot at the start of every slot.
+	EmptySlotGrace human.Slots
+	// DetectOrphanedBlocks enables orphaned block detection. Requires the block and
+	// chain_reorg topics to be enabled in the beacon subscription.
+	DetectOrphanedBlocks bool
+	// DetectEquivocations enables detection of double proposals and double/surround votes. Requires
+	// the block and attestation topics to be enabled in the beacon subscription.
+	DetectEquivocations bool
+	HeadLag             HeadLagOptions
+	ClockSkew           ClockSkewOptions
+	EventDeduplication  EventDeduplicationOptions
+	Handlers            HandlerOptions
+	// HeadTracker enables tracking the recent canonical chain. Requires the block, head and chain_reorg
+	// topics to be enabled in the beacon subscription.
+	HeadTracker HeadTrackerOptions
+	// BlockCache enables caching recently seen blocks. Blocks announced by block events are fetched into the
+	// cache, so the block topic should be enabled in the beacon subscription.
+	BlockCache BlockCacheOptions
+	// BlockRootCache enables caching block roots fetched by slot. Cached roots are invalidated by chain
+	// reorgs, so the chain_reorg topic should be enabled in the beacon subscription.
+	BlockRootCache BlockRootCacheOptions
+	// AttestationSampling reduces the attestation topic to a sample before it is dispatched to handlers.
+	AttestationSampling AttestationSamplingOptions
+	// LenientStartup stops Start from failing when the node cannot be bootstrapped. Failures are logged and
+	// retried in the background, and the node only becomes ready once it is bootstrapped and healthy.
+	LenientStartup bool
+	// WaitForGenesis stops Start from failing when the network has no genesis yet. The spec and genesis are
+	// polled in the background and bootstrap completes once they are available.
+	WaitForGenesis bool
+	// MaxResponseSize is the maximum size in bytes of a response read by the raw API client. Larger responses
+	// fail with ErrResponseTooLarge. Zero disables the limit.
+	MaxResponseSize int64
+	// PropagateRequestID sends the ID generated for every request to the node in the X-Request-Id header, for
+	// both the raw API client and go-eth2-client. The ID of raw API client requests is always included in debug
+	// logs and errors, that of go-eth2-client requests only when propagation is enabled.
+	PropagateRequestID bool
+	// VerifyBlockRoots hash tree roots every fetched block and compares it against the requested root. Blocks
+	// requested by another id, such as head or a slot, are resolved to their root first and fetched by that
+	// root. Mismatches fail the fetch with a RootMismatchError and emit a DataIntegrityEvent.
+	VerifyBlockRoots bool
+	// VerifyStateRoots hash tree roots every fetched state and compares it against the requested root, or the
+	// state root of the block in the state's latest block header. Mismatches fail the fetch with a
+	// RootMismatchError and emit a DataIntegrityEvent. States at empty slots cannot be verified and are accepted.
+	VerifyStateRoots bool
+	// ExtractGraffiti fetches every head block to emit its graffiti as a BlockGraffitiEvent and count head
+	// blocks by graffiti prefix. Requires the head topic to be enabled in the beacon subscription.
+	ExtractGraffiti bool
+	// TrackDeposits maintains the deposit count and root from the deposits in blocks, seeded from and
+	// cross-checked against the node's deposit snapshot. Requires the block topic to be enabled in the beacon
+	// subscription and the node to serve deposit snapshots.
+	TrackDeposits bool
+	// ExtractWithdrawals fetches every new canonical block to emit a WithdrawalEvent for each of its
+	// withdrawals, starting from the first head after the node is started. The withdrawals of blocks that are
+	// reorged out are emitted again as reverted. Requires the head topic to be enabled in the beacon
+	// subscription.
+	ExtractWithdrawals bool
+	// EpochIterator holds the options for ForEachEpoch.
+	EpochIterator EpochIteratorOptions
+	// SyncChangeThreshold is the number of slots the head slot has to jump by between two polls of the sync
+	// state for a SyncStateChangedEvent to be emitted. Changes to whether the node is syncing are always
+	// emitted.
+	SyncChangeThreshold phase0.Slot
+	// ForkImminentEpochs is the number of epochs before the next scheduled fork activates that a
+	// ForkImminentEvent is emitted. Zero disables the event.
+	ForkImminentEpochs phase0.Epoch
+	// RecordEventsTo is the path of a file that every upstream event is appended to as a RecordedEvent, for
+	// replaying it later with ReplayEvents. Empty disables recording.
+	RecordEventsTo string
+	// StartupRetryWindow is how long Start keeps retrying to bootstrap the node, e.g. while it returns 503s
+	// during its own startup, before failing. A BootstrapFailedEvent is emitted for every failed attempt. Zero
+	// fails Start on the first error. It does not apply with LenientStartup or WaitForGenesis, which retry in
+	// the background indefinitely.
+	StartupRetryWindow human.Duration
+	// ValidatorCache enables caching the validator sets fetched by state root in Cache.
+	ValidatorCache ValidatorCacheOptions
+	// Cache backs the attestation data and validator caches, and shares the blocks of the block cache that
+	// are fetched by root. Supplying a Cache backed by an external store lets several processes share the
+	// cached data. Defaults to an in-memory cache of 256 MiB per node when nil, in which case blocks are only
+	// held by the block cache.
+	Cache Cache
 }
 
 // EnablePrometheusMetrics enables Prometheus metrics.
@@ -28,6 +112,58 @@ func (o *Options) DisablePrometheusMetrics() *Options {
 	return o
 }
 
+// WithMetricsConstLabels adds the given constant labels to every Prometheus metric.
+func (o *Options) WithMetricsConstLabels(labels map[string]string) *Options {
+	if o.Metrics.ConstLabels == nil {
+		o.Metrics.ConstLabels = make(map[string]string, len(labels))
+	}
+
+	for k, v := range labels {
+		o.Metrics.ConstLabels[k] = v
+	}
+
+	return o
+}
+
+// WithMetricsNodeLabelName overrides the name of the label that holds the node name.
+func (o *Options) WithMetricsNodeLabelName(name string) *Options {
+	o.Metrics.NodeLabelName = name
+
+	return o
+}
+
+// EnableForkChoiceMetrics enables the fork choice polling metrics job.
+func (o *Options) EnableForkChoiceMetrics() *Options {
+	o.Metrics.ForkChoice.Enabled = true
+
+	if o.Metrics.ForkChoice.Interval.Duration == 0 {
+		o.Metrics.ForkChoice.Interval = human.Duration{Duration: 12 * time.Second}
+	}
+
+	return o
+}
+
+// DisableForkChoiceMetrics disables the fork choice polling metrics job.
+func (o *Options) DisableForkChoiceMetrics() *Options {
+	o.Metrics.ForkChoice.Enabled = false
+
+	return o
+}
+
+// EnablePayloadSizeMetrics enables the event payload size metrics.
+func (o *Options) EnablePayloadSizeMetrics() *Options {
+	o.Metrics.PayloadSizes = true
+
+	return o
+}
+
+// DisablePayloadSizeMetrics disables the event payload size metrics.
+func (o *Options) DisablePayloadSizeMetrics() *Options {
+	o.Metrics.PayloadSizes = false
+
+	return o
+}
+
 // EnableEmptySlotDetection enables empty slot detection.
 func (o *Options) EnableEmptySlotDetection() *Options {
 	o.DetectEmptySlots = true
@@ -35,6 +171,13 @@ func (o *Options) EnableEmptySlotDetection() *Options {
 	return o
 }
 
+// WithEmptySlotGrace sets the number of slots that pass before a slot is checked for a block.
+func (o *Options) WithEmptySlotGrace(grace human.Slots) *Options {
+	o.EmptySlotGrace = grace
+
+	return o
+}
+
 // DisableEmptySlotDetection disables empty slot detection.
 func (o *Options) DisableEmptySlotDetection() *Options {
 	o.DetectEmptySlots = false
@@ -42,13 +185,300 @@ func (o *Options) DisableEmptySlotDetection() *Options {
 	return o
 }
 
+// EnableOrphanedBlockDetection enables orphaned block detection.
+func (o *Options) EnableOrphanedBlockDetection() *Options {
+	o.DetectOrphanedBlocks = true
+
+	return o
+}
+
+// DisableOrphanedBlockDetection disables orphaned block detection.
+func (o *Options) DisableOrphanedBlockDetection() *Options {
+	o.DetectOrphanedBlocks = false
+
+	return o
+}
+
+// EnableEquivocationDetection enables equivocation detection.
+func (o *Options) EnableEquivocationDetection() *Options {
+	o.DetectEquivocations = true
+
+	return o
+}
+
+// DisableEquivocationDetection disables equivocation detection.
+func (o *Options) DisableEquivocationDetection() *Options {
+	o.DetectEquivocations = false
+
+	return o
+}
+
+// EnableHeadLagDetection enables head lag detection.
+func (o *Options) EnableHeadLagDetection() *Options {
+	o.HeadLag.Enabled = true
+
+	return o
+}
+
+// DisableHeadLagDetection disables head lag detection.
+func (o *Options) DisableHeadLagDetection() *Options {
+	o.HeadLag.Enabled = false
+
+	return o
+}
+
+// EnableClockSkewMeasurement enables measuring the clock skew between the local clock and the node.
+func (o *Options) EnableClockSkewMeasurement() *Options {
+	o.ClockSkew.Enabled = true
+
+	return o
+}
+
+// DisableClockSkewMeasurement disables measuring the clock skew between the local clock and the node.
+func (o *Options) DisableClockSkewMeasurement() *Options {
+	o.ClockSkew.Enabled = false
+
+	return o
+}
+
+// EnableEventDeduplication enables dropping duplicate head and block events.
+func (o *Options) EnableEventDeduplication() *Options {
+	o.EventDeduplication.Enabled = true
+
+	return o
+}
+
+// DisableEventDeduplication disables dropping duplicate head and block events.
+func (o *Options) DisableEventDeduplication() *Options {
+	o.EventDeduplication.Enabled = false
+
+	return o
+}
+
+// EnableHeadTracker enables tracking the recent canonical chain.
+func (o *Options) EnableHeadTracker() *Options {
+	o.HeadTracker.Enabled = true
+
+	return o
+}
+
+// DisableHeadTracker disables tracking the recent canonical chain.
+func (o *Options) DisableHeadTracker() *Options {
+	o.HeadTracker.Enabled = false
+
+	return o
+}
+
+// EnableBlockCache enables caching recently seen blocks.
+func (o *Options) EnableBlockCache() *Options {
+	o.BlockCache.Enabled = true
+
+	return o
+}
+
+// DisableBlockCache disables caching recently seen blocks.
+func (o *Options) DisableBlockCache() *Options {
+	o.BlockCache.Enabled = false
+
+	return o
+}
+
+// WithCache backs the caches of the node with the given cache.
+func (o *Options) WithCache(cache Cache) *Options {
+	o.Cache = cache
+
+	return o
+}
+
+// EnableBlockRootCache enables caching block roots fetched by slot.
+func (o *Options) EnableBlockRootCache() *Options {
+	o.BlockRootCache.Enabled = true
+
+	return o
+}
+
+// DisableBlockRootCache disables caching block roots fetched by slot.
+func (o *Options) DisableBlockRootCache() *Options {
+	o.BlockRootCache.Enabled = false
+
+	return o
+}
+
+// WithAttestationSampling processes 1 in every N attestation events, where N is every, and at most perSecond
+// attestation events per second. Zero disables the respective limit.
+func (o *Options) WithAttestationSampling(every uint64, perSecond int) *Options {
+	o.AttestationSampling = AttestationSamplingOptions{
+		Enabled:   true,
+		Every:     every,
+		PerSecond: perSecond,
+	}
+
+	return o
+}
+
+// DisableAttestationSampling disables attestation sampling.
+func (o *Options) DisableAttestationSampling() *Options {
+	o.AttestationSampling.Enabled = false
+
+	return o
+}
+
+// EnableLenientStartup enables lenient startup.
+func (o *Options) EnableLenientStartup() *Options {
+	o.LenientStartup = true
+
+	return o
+}
+
+// DisableLenientStartup disables lenient startup.
+func (o *Options) DisableLenientStartup() *Options {
+	o.LenientStartup = false
+
+	return o
+}
+
+// EnableWaitForGenesis enables waiting for genesis in the background.
+func (o *Options) EnableWaitForGenesis() *Options {
+	o.WaitForGenesis = true
+
+	return o
+}
+
+// DisableWaitForGenesis disables waiting for genesis in the background.
+func (o *Options) DisableWaitForGenesis() *Options {
+	o.WaitForGenesis = false
+
+	return o
+}
+
+// WithMaxResponseSize sets the maximum size in bytes of a response read by the raw API client. Zero disables
+// the limit.
+func (o *Options) WithMaxResponseSize(limit int64) *Options {
+	o.MaxResponseSize = limit
+
+	return o
+}
+
+// EnableRequestIDPropagation enables sending request IDs to the node.
+func (o *Options) EnableRequestIDPropagation() *Options {
+	o.PropagateRequestID = true
+
+	return o
+}
+
+// DisableRequestIDPropagation disables sending request IDs to the node.
+func (o *Options) DisableRequestIDPropagation() *Options {
+	o.PropagateRequestID = false
+
+	return o
+}
+
+// EnableBlockRootVerification enables verifying the roots of fetched blocks.
+func (o *Options) EnableBlockRootVerification() *Options {
+	o.VerifyBlockRoots = true
+
+	return o
+}
+
+// DisableBlockRootVerification disables verifying the roots of fetched blocks.
+func (o *Options) DisableBlockRootVerification() *Options {
+	o.VerifyBlockRoots = false
+
+	return o
+}
+
+// EnableStateRootVerification enables verifying the roots of fetched states.
+func (o *Options) EnableStateRootVerification() *Options {
+	o.VerifyStateRoots = true
+
+	return o
+}
+
+// DisableStateRootVerification disables verifying the roots of fetched states.
+func (o *Options) DisableStateRootVerification() *Options {
+	o.VerifyStateRoots = false
+
+	return o
+}
+
+// EnableGraffitiExtraction enables extracting the graffiti of head blocks.
+func (o *Options) EnableGraffitiExtraction() *Options {
+	o.ExtractGraffiti = true
+
+	return o
+}
+
+// DisableGraffitiExtraction disables extracting the graffiti of head blocks.
+func (o *Options) DisableGraffitiExtraction() *Options {
+	o.ExtractGraffiti = false
+
+	return o
+}
+
+// EnableDepositTracking enables tracking deposits from blocks.
+func (o *Options) EnableDepositTracking() *Options {
+	o.TrackDeposits = true
+
+	return o
+}
+
+// DisableDepositTracking disables tracking deposits from blocks.
+func (o *Options) DisableDepositTracking() *Options {
+	o.TrackDeposits = false
+
+	return o
+}
+
+// EnableWithdrawalExtraction enables emitting the withdrawals of canonical blocks.
+func (o *Options) EnableWithdrawalExtraction() *Options {
+	o.ExtractWithdrawals = true
+
+	return o
+}
+
+// DisableWithdrawalExtraction disables emitting the withdrawals of canonical blocks.
+func (o *Options) DisableWithdrawalExtraction() *Options {
+	o.ExtractWithdrawals = false
+
+	return o
+}
+
 // DefaultOptions returns the default options.
 func DefaultOptions() *Options {
 	return &Options{
-		BeaconSubscription: DefaultDisabledBeaconSubscriptionOptions(),
-		HealthCheck:        DefaultHealthCheckOptions(),
-		PrometheusMetrics:  true,
-		DetectEmptySlots:   false,
+		BeaconSubscription:   DefaultDisabledBeaconSubscriptionOptions(),
+		HealthCheck:          DefaultHealthCheckOptions(),
+		Polling:              DefaultPollingOptions(),
+		PrometheusMetrics:    true,
+		Metrics:              DefaultMetricsOptions(),
+		DetectEmptySlots:     false,
+		EmptySlotGrace:       1,
+		DetectOrphanedBlocks: false,
+		DetectEquivocations:  false,
+		HeadLag:              DefaultHeadLagOptions(),
+		ClockSkew:            DefaultClockSkewOptions(),
+		EventDeduplication:   DefaultEventDeduplicationOptions(),
+		HeadTracker:          DefaultHeadTrackerOptions(),
+		BlockCache:           DefaultBlockCacheOptions(),
+		BlockRootCache:       DefaultBlockRootCacheOptions(),
+		AttestationSampling:  DefaultAttestationSamplingOptions(),
+		Handlers:             DefaultHandlerOptions(),
+		LenientStartup:       false,
+		WaitForGenesis:       false,
+		MaxResponseSize:      0,
+		PropagateRequestID:   false,
+		VerifyBlockRoots:     false,
+		VerifyStateRoots:     false,
+		ExtractGraffiti:      false,
+		TrackDeposits:        false,
+		ExtractWithdrawals:   false,
+		EpochIterator:        DefaultEpochIteratorOptions(),
+		SyncChangeThreshold:  32,
+		ForkImminentEpochs:   0,
+		RecordEventsTo:       "",
+		StartupRetryWindow:   human.Duration{Duration: time.Minute},
+		ValidatorCache:       DefaultValidatorCacheOptions(),
+		Cache:                nil,
 	}
 }
 
@@ -56,6 +486,21 @@ func DefaultOptions() *Options {
 type BeaconSubscriptionOptions struct {
 	Enabled bool
 	Topics  EventTopics
+	// Groups splits the topics across separate upstream event streams, one per group, so a busy topic (e.g.
+	// attestation) does not hold up the others. Topics that are not part of any group share a single stream.
+	// By default all topics share a single stream, which keeps the number of connections to the node low.
+	Groups []EventTopics
+}
+
+// SeparateTopics subscribes to each topic on its own upstream event stream.
+func (b *BeaconSubscriptionOptions) SeparateTopics() *BeaconSubscriptionOptions {
+	b.Groups = make([]EventTopics, 0, len(b.Topics))
+
+	for _, topic := range b.Topics {
+		b.Groups = append(b.Groups, EventTopics{topic})
+	}
+
+	return b
 }
 
 // Disable disables the beacon subscription.
@@ -112,13 +557,291 @@ type HealthCheckOptions struct {
 	SuccessfulResponses int
 	// FailureThreshold is the number of consecutive failed health checks required before the node is considered unhealthy.
 	FailedResponses int
+	// OptimisticIsUnhealthy fails health checks while the node is optimistically synced.
+	OptimisticIsUnhealthy bool
+	// PausePolling skips the periodic sync status, node version, peers, node identity, clock skew and fork
+	// choice metrics fetches while the node is unhealthy, and resumes them once a health check succeeds again.
+	// It is enabled by default.
+	PausePolling bool
 }
 
 // DefaultHealthCheckOptions returns the default health check options.
 func DefaultHealthCheckOptions() HealthCheckOptions {
 	return HealthCheckOptions{
-		Interval:            human.Duration{Duration: 15 * time.Second},
-		SuccessfulResponses: 3,
-		FailedResponses:     3,
+		Interval:              human.Duration{Duration: 15 * time.Second},
+		SuccessfulResponses:   3,
+		FailedResponses:       3,
+		OptimisticIsUnhealthy: false,
+		PausePolling:          true,
 	}
 }
+
+// PollingOptions holds the intervals of the periodic fetches of the node's state.
+type PollingOptions struct {
+	// SyncStatus is the interval at which the sync status is fetched.
+	SyncStatus human.Duration
+	// NodeVersion is the interval at which the node version is fetched.
+	NodeVersion human.Duration
+	// Peers is the interval at which the peers are fetched.
+	Peers human.Duration
+	// NodeIdentity is the interval at which the node identity is fetched.
+	NodeIdentity human.Duration
+}
+
+// DefaultPollingOptions returns the default polling options.
+func DefaultPollingOptions() PollingOptions {
+	return PollingOptions{
+		SyncStatus:   human.Duration{Duration: 15 * time.Second},
+		NodeVersion:  human.Duration{Duration: 15 * time.Minute},
+		Peers:        human.Duration{Duration: time.Minute},
+		NodeIdentity: human.Duration{Duration: 5 * time.Minute},
+	}
+}
+
+// MetricsOptions holds the options for the Prometheus metrics.
+type MetricsOptions struct {
+	// ConstLabels are additional constant labels (e.g. network, region) that are added to every metric.
+	ConstLabels map[string]string
+	// NodeLabelName is the name of the label that holds the node name. Defaults to "node".
+	NodeLabelName string
+	// ForkChoice holds the options for the opt-in fork choice polling job.
+	ForkChoice ForkChoiceMetricsOptions
+	// PayloadSizes enables the opt-in histogram of event payload sizes per topic. Every event is encoded
+	// again to measure it, which is costly on busy topics such as attestations.
+	PayloadSizes bool
+}
+
+// ForkChoiceMetricsOptions holds the options for the fork choice metrics job.
+type ForkChoiceMetricsOptions struct {
+	Enabled bool
+	// Interval is the interval at which the fork choice is polled.
+	Interval human.Duration
+}
+
+// DefaultMetricsOptions returns the default metrics options.
+func DefaultMetricsOptions() MetricsOptions {
+	return MetricsOptions{
+		ConstLabels:   map[string]string{},
+		NodeLabelName: defaultMetricsNodeLabelName,
+		ForkChoice: ForkChoiceMetricsOptions{
+			Enabled:  false,
+			Interval: human.Duration{Duration: 12 * time.Second},
+		},
+		PayloadSizes: false,
+	}
+}
+
+// HeadLagOptions holds the options for head lag detection.
+type HeadLagOptions struct {
+	Enabled bool
+	// Threshold is the number of slots the head can be behind the wallclock before a HeadLagEvent is emitted.
+	Threshold phase0.Slot
+}
+
+// DefaultHeadLagOptions returns the default head lag options.
+func DefaultHeadLagOptions() HeadLagOptions {
+	return HeadLagOptions{
+		Enabled:   false,
+		Threshold: 4,
+	}
+}
+
+// ClockSkewOptions holds the options for clock skew measurement.
+type ClockSkewOptions struct {
+	Enabled bool
+	// Interval is the interval at which the clock skew is measured.
+	Interval human.Duration
+	// Threshold is the skew, in either direction, above which a ClockSkewExceededEvent is emitted. The Date
+	// header used for the measurement only has second precision, so thresholds below a second are not useful.
+	Threshold human.Duration
+}
+
+// DefaultClockSkewOptions returns the default clock skew options.
+func DefaultClockSkewOptions() ClockSkewOptions {
+	return ClockSkewOptions{
+		Enabled:   false,
+		Interval:  human.Duration{Duration: time.Minute},
+		Threshold: human.Duration{Duration: 2 * time.Second},
+	}
+}
+
+// EventDeduplicationOptions holds the options for head and block event deduplication.
+type EventDeduplicationOptions struct {
+	Enabled bool
+	// Window is how long a head or block event is remembered. Events with the same slot and root that are
+	// received within the window are dropped instead of being published.
+	Window human.Duration
+}
+
+// DefaultEventDeduplicationOptions returns the default event deduplication options.
+func DefaultEventDeduplicationOptions() EventDeduplicationOptions {
+	return EventDeduplicationOptions{
+		Enabled: false,
+		Window:  human.Duration{Duration: time.Minute},
+	}
+}
+
+// HeadTrackerOptions holds the options for the head tracker.
+type HeadTrackerOptions struct {
+	Enabled bool
+	// Slots is the number of slots behind the most recent block that are tracked.
+	Slots uint64
+}
+
+// DefaultHeadTrackerOptions returns the default head tracker options.
+func DefaultHeadTrackerOptions() HeadTrackerOptions {
+	return HeadTrackerOptions{
+		Enabled: false,
+		Slots:   64,
+	}
+}
+
+// BlockCacheOptions holds the options for the block cache.
+type BlockCacheOptions struct {
+	Enabled bool
+	// Slots is the number of slots behind the most recent block that blocks are cached for.
+	Slots uint64
+	// TTL is how long blocks fetched by root are kept in Options.Cache.
+	TTL human.Duration
+}
+
+// DefaultBlockCacheOptions returns the default block cache options.
+func DefaultBlockCacheOptions() BlockCacheOptions {
+	return BlockCacheOptions{
+		Enabled: false,
+		Slots:   32,
+		TTL:     human.Duration{Duration: 32 * 12 * time.Second},
+	}
+}
+
+// ValidatorCacheOptions holds the options for the validator cache.
+type ValidatorCacheOptions struct {
+	Enabled bool
+	// TTL is how long validator sets are kept in Options.Cache.
+	TTL human.Duration
+}
+
+// DefaultValidatorCacheOptions returns the default validator cache options.
+func DefaultValidatorCacheOptions() ValidatorCacheOptions {
+	return ValidatorCacheOptions{
+		Enabled: false,
+		TTL:     human.Duration{Duration: 32 * 12 * time.Second},
+	}
+}
+
+// BlockRootCacheOptions holds the options for the block root cache.
+type BlockRootCacheOptions struct {
+	Enabled bool
+	// Slots is the number of slots behind the most recent cached slot that block roots are cached for.
+	Slots uint64
+}
+
+// DefaultBlockRootCacheOptions returns the default block root cache options.
+func DefaultBlockRootCacheOptions() BlockRootCacheOptions {
+	return BlockRootCacheOptions{
+		Enabled: false,
+		Slots:   64,
+	}
+}
+
+// EpochIteratorOptions holds the options for ForEachEpoch.
+type EpochIteratorOptions struct {
+	// NonFinalized controls how epochs that are not finalized yet are handled.
+	NonFinalized NonFinalizedEpochPolicy
+	// PollInterval is the interval at which finality is polled when waiting for an epoch to be finalized.
+	PollInterval human.Duration
+	// Concurrency is the number of blocks of an epoch that are fetched concurrently.
+	Concurrency int
+}
+
+// DefaultEpochIteratorOptions returns the default epoch iterator options.
+func DefaultEpochIteratorOptions() EpochIteratorOptions {
+	return EpochIteratorOptions{
+		NonFinalized: NonFinalizedEpochPolicySkip,
+		PollInterval: human.Duration{Duration: 12 * time.Second},
+		Concurrency:  4,
+	}
+}
+
+// AttestationSamplingOptions holds the options for attestation sampling.
+type AttestationSamplingOptions struct {
+	Enabled bool
+	// Every processes 1 in every N attestation events. Zero or one processes every event.
+	Every uint64
+	// PerSecond is the maximum number of attestation events processed per second. Zero disables the limit.
+	PerSecond int
+}
+
+// DefaultAttestationSamplingOptions returns the default attestation sampling options.
+func DefaultAttestationSamplingOptions() AttestationSamplingOptions {
+	return AttestationSamplingOptions{
+		Enabled:   false,
+		Every:     1,
+		PerSecond: 0,
+	}
+}
+
+// HandlerErrorPolicy controls what happens when an event handler returns an error.
+type HandlerErrorPolicy string
+
+const (
+	// HandlerErrorPolicyLog only logs the error.
+	HandlerErrorPolicyLog HandlerErrorPolicy = "log"
+	// HandlerErrorPolicyRetry retries the handler up to MaxRetries times with exponential backoff.
+	HandlerErrorPolicyRetry HandlerErrorPolicy = "retry"
+	// HandlerErrorPolicyDeadLetter retries the handler up to MaxRetries times and then forwards the event
+	// to the DeadLetters channel.
+	HandlerErrorPolicyDeadLetter HandlerErrorPolicy = "dead_letter"
+)
+
+// HandlerOptions holds the options for event handlers.
+type HandlerOptions struct {
+	// Timeout is how long a handler can take to process a single event before its context is cancelled
+	// and dispatch moves on. Zero disables the timeout.
+	Timeout human.Duration
+	// ErrorPolicy is the policy applied when a handler returns an error.
+	ErrorPolicy HandlerErrorPolicy
+	// MaxRetries is the number of times a failed handler is retried. Retries are made from a queue per
+	// handler, so later events are dispatched without waiting for them.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry. It doubles on every subsequent retry.
+	RetryBackoff human.Duration
+	// DeadLetters receives events that failed with the dead letter policy. Events are dropped if the
+	// channel is full.
+	DeadLetters chan<- *DeadLetter
+}
+
+// DefaultHandlerOptions returns the default handler options.
+func DefaultHandlerOptions() HandlerOptions {
+	return HandlerOptions{
+		Timeout:      human.Duration{Duration: time.Minute},
+		ErrorPolicy:  HandlerErrorPolicyLog,
+		MaxRetries:   3,
+		RetryBackoff: human.Duration{Duration: 100 * time.Millisecond},
+	}
+}
+
+// WithHandlerRetries retries failed handlers up to maxRetries times, starting with the given backoff.
+func (o *Options) WithHandlerRetries(maxRetries int, backoff time.Duration) *Options {
+	o.Handlers.ErrorPolicy = HandlerErrorPolicyRetry
+	o.Handlers.MaxRetries = maxRetries
+	o.Handlers.RetryBackoff = human.Duration{Duration: backoff}
+
+	return o
+}
+
+// WithHandlerDeadLetters forwards events whose handlers still fail after the configured retries to the
+// given channel.
+func (o *Options) WithHandlerDeadLetters(deadLetters chan<- *DeadLetter) *Options {
+	o.Handlers.ErrorPolicy = HandlerErrorPolicyDeadLetter
+	o.Handlers.DeadLetters = deadLetters
+
+	return o
+}
+
+// WithHandlerTimeout sets the per-event handler timeout. Zero disables the timeout.
+func (o *Options) WithHandlerTimeout(timeout time.Duration) *Options {
+	o.Handlers.Timeout = human.Duration{Duration: timeout}
+
+	return o
+}
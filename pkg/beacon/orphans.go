@@ -0,0 +1,124 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	eapi "github.com/attestantio/go-eth2-client/api"
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	// orphanTrackerSlotWindow is the number of slots behind the most recent block that are tracked.
+	orphanTrackerSlotWindow = 64
+)
+
+// orphanTracker keeps track of recently seen blocks so that they can be checked for canonicality after a reorg.
+type orphanTracker struct {
+	mu     sync.Mutex
+	blocks map[phase0.Root]phase0.Slot
+	latest phase0.Slot
+}
+
+func newOrphanTracker() *orphanTracker {
+	return &orphanTracker{
+		blocks: make(map[phase0.Root]phase0.Slot),
+	}
+}
+
+func (o *orphanTracker) add(root phase0.Root, slot phase0.Slot) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.blocks[root] = slot
+
+	if slot <= o.latest {
+		return
+	}
+
+	o.latest = slot
+
+	if o.latest < orphanTrackerSlotWindow {
+		return
+	}
+
+	cutoff := o.latest - orphanTrackerSlotWindow
+
+	for r, s := range o.blocks {
+		if s < cutoff {
+			delete(o.blocks, r)
+		}
+	}
+}
+
+func (o *orphanTracker) remove(root phase0.Root) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	delete(o.blocks, root)
+}
+
+// between returns the tracked blocks with a slot in the inclusive range [from, to].
+func (o *orphanTracker) between(from, to phase0.Slot) map[phase0.Root]phase0.Slot {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	blocks := make(map[phase0.Root]phase0.Slot)
+
+	for root, slot := range o.blocks {
+		if slot >= from && slot <= to {
+			blocks[root] = slot
+		}
+	}
+
+	return blocks
+}
+
+func (n *node) subscribeOrphanedBlockDetection(ctx context.Context) {
+	n.OnBlock(ctx, func(ctx context.Context, event *v1.BlockEvent) error {
+		n.orphans.add(event.Block, event.Slot)
+
+		return nil
+	})
+
+	n.OnChainReOrg(ctx, n.detectOrphanedBlocks)
+}
+
+func (n *node) detectOrphanedBlocks(ctx context.Context, event *v1.ChainReorgEvent) error {
+	from := phase0.Slot(0)
+	if uint64(event.Slot) > event.Depth {
+		from = event.Slot - phase0.Slot(event.Depth)
+	}
+
+	for root, slot := range n.orphans.between(from, event.Slot) {
+		result, err := n.FetchBeaconBlockHeaderWithMetadata(ctx, &eapi.BeaconBlockHeaderOpts{
+			Block: fmt.Sprintf("%#x", root),
+		})
+		if err != nil {
+			n.log.
+				WithError(err).
+				WithField("root", fmt.Sprintf("%#x", root)).
+				Debug("Failed to fetch block header while checking for orphaned blocks")
+
+			continue
+		}
+
+		header := result.Data
+		if header == nil || header.Canonical {
+			continue
+		}
+
+		n.orphans.remove(root)
+
+		proposer := phase0.ValidatorIndex(0)
+		if header.Header != nil && header.Header.Message != nil {
+			proposer = header.Header.Message.ProposerIndex
+		}
+
+		n.publishBlockOrphaned(ctx, root, slot, proposer, result.ExecutionOptimistic)
+	}
+
+	return nil
+}
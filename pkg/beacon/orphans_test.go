@@ -0,0 +1,136 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func orphanHeader(root phase0.Root, slot phase0.Slot, proposer phase0.ValidatorIndex, canonical bool) *v1.BeaconBlockHeader {
+	return &v1.BeaconBlockHeader{
+		Root:      root,
+		Canonical: canonical,
+		Header: &phase0.SignedBeaconBlockHeader{
+			Message: &phase0.BeaconBlockHeader{Slot: slot, ProposerIndex: proposer},
+		},
+	}
+}
+
+type orphanTestBlock struct {
+	root phase0.Root
+	slot phase0.Slot
+}
+
+func TestOrphanTracker(t *testing.T) {
+	tests := []struct {
+		name     string
+		blocks   []orphanTestBlock
+		from     phase0.Slot
+		to       phase0.Slot
+		expected map[phase0.Root]phase0.Slot
+	}{
+		{
+			name:     "empty",
+			from:     0,
+			to:       10,
+			expected: map[phase0.Root]phase0.Slot{},
+		},
+		{
+			name:     "inclusive range",
+			blocks:   []orphanTestBlock{{phase0.Root{0x01}, 9}, {phase0.Root{0x02}, 10}, {phase0.Root{0x03}, 11}, {phase0.Root{0x04}, 12}},
+			from:     10,
+			to:       11,
+			expected: map[phase0.Root]phase0.Slot{{0x02}: 10, {0x03}: 11},
+		},
+		{
+			name:     "blocks behind the window are dropped",
+			blocks:   []orphanTestBlock{{phase0.Root{0x01}, 10}, {phase0.Root{0x02}, 10 + orphanTrackerSlotWindow + 1}},
+			from:     0,
+			to:       100,
+			expected: map[phase0.Root]phase0.Slot{{0x02}: 10 + orphanTrackerSlotWindow + 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := newOrphanTracker()
+
+			for _, block := range test.blocks {
+				tracker.add(block.root, block.slot)
+			}
+
+			assert.Equal(t, test.expected, tracker.between(test.from, test.to))
+		})
+	}
+}
+
+func TestDetectOrphanedBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		reorg    *v1.ChainReorgEvent
+		expected []phase0.Root
+	}{
+		{
+			name:     "non canonical blocks within the depth are orphaned",
+			reorg:    &v1.ChainReorgEvent{Slot: 12, Depth: 2},
+			expected: []phase0.Root{{0x02}},
+		},
+		{
+			name:     "blocks before the depth are not checked",
+			reorg:    &v1.ChainReorgEvent{Slot: 12, Depth: 1},
+			expected: nil,
+		},
+		{
+			name:     "depth beyond genesis",
+			reorg:    &v1.ChainReorgEvent{Slot: 12, Depth: 100},
+			expected: []phase0.Root{{0x01}, {0x02}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := &node{
+				log:     logging.New(nil),
+				options: DefaultOptions(),
+				broker:  emission.NewEmitter(),
+				orphans: newOrphanTracker(),
+				client: &fakeClient{headers: byRoot(map[phase0.Root]*v1.BeaconBlockHeader{
+					{0x01}: orphanHeader(phase0.Root{0x01}, 5, 1, false),
+					{0x02}: orphanHeader(phase0.Root{0x02}, 10, 2, false),
+					{0x03}: orphanHeader(phase0.Root{0x03}, 12, 3, true),
+				})},
+			}
+
+			n.orphans.add(phase0.Root{0x01}, 5)
+			n.orphans.add(phase0.Root{0x02}, 10)
+			n.orphans.add(phase0.Root{0x03}, 12)
+			// Blocks that can't be fetched are skipped.
+			n.orphans.add(phase0.Root{0x04}, 11)
+
+			var orphaned []phase0.Root
+
+			n.OnBlockOrphaned(context.Background(), func(ctx context.Context, event *BlockOrphanedEvent) error {
+				orphaned = append(orphaned, event.Root)
+
+				assert.Equal(t, phase0.ValidatorIndex(event.Root[0]), event.ProposerIndex)
+
+				return nil
+			})
+
+			require.NoError(t, n.detectOrphanedBlocks(context.Background(), test.reorg))
+			assert.ElementsMatch(t, test.expected, orphaned)
+
+			// Orphaned blocks are only reported once.
+			orphaned = nil
+
+			require.NoError(t, n.detectOrphanedBlocks(context.Background(), test.reorg))
+			assert.Empty(t, orphaned)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package beacon
+
+func (n *node) PollingPaused() bool {
+	return n.pollingPaused.Load()
+}
+
+// updatePolling pauses or resumes the periodic fetches according to the latest health check.
+func (n *node) updatePolling() {
+	paused := n.currentOptions().HealthCheck.PausePolling && n.stat.Health().Unhealthy()
+
+	if n.pollingPaused.Swap(paused) == paused {
+		return
+	}
+
+	if paused {
+		n.log.Warn("Beacon node is unhealthy, pausing polling until it recovers")
+	} else {
+		n.log.Info("Beacon node recovered, resuming polling")
+	}
+}
+
+// poll wraps a periodic fetch so it is skipped while polling is paused.
+func (n *node) poll(fetch func()) func() {
+	return func() {
+		if n.pollingPaused.Load() {
+			return
+		}
+
+		fetch()
+	}
+}
@@ -0,0 +1,274 @@
+package beacon
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/ethpandaops/beacon/pkg/human"
+)
+
+const topicNodesDiverged = "nodes_diverged"
+
+// NodePool groups beacon nodes that follow the same network and reports on their aggregated status.
+type NodePool struct {
+	log    *logging.Entry
+	nodes  map[string]Node
+	broker *emission.Emitter
+	opts   NodePoolOptions
+
+	mu           sync.Mutex
+	diverged     bool
+	laggingSince time.Time
+}
+
+// NodePoolOptions holds the options for a NodePool.
+type NodePoolOptions struct {
+	// CheckInterval is the interval at which the nodes are checked for divergence.
+	CheckInterval human.Duration
+	// LagGracePeriod is how long nodes may report different finalized epochs before they are considered
+	// diverged. Nodes that report different roots for the same finalized epoch are diverged straight away.
+	LagGracePeriod human.Duration
+}
+
+// DefaultNodePoolOptions returns the default node pool options.
+func DefaultNodePoolOptions() NodePoolOptions {
+	return NodePoolOptions{
+		CheckInterval: human.Duration{Duration: 12 * time.Second},
+		// Two epochs on mainnet.
+		LagGracePeriod: human.Duration{Duration: 2 * 32 * 12 * time.Second},
+	}
+}
+
+// PoolStatus is the aggregated status of the nodes in a NodePool.
+type PoolStatus struct {
+	// Nodes holds the status of every node, by name.
+	Nodes map[string]*PoolNodeStatus
+	// Healthy is the number of healthy nodes.
+	Healthy int
+	// Quorum is true if more than half of the nodes are healthy.
+	Quorum bool
+	// MinSyncDistance and MaxSyncDistance are the smallest and largest sync distances reported by the nodes.
+	MinSyncDistance phase0.Slot
+	MaxSyncDistance phase0.Slot
+	// SyncDistanceSpread is the difference between MaxSyncDistance and MinSyncDistance.
+	SyncDistanceSpread phase0.Slot
+	// Finalized is the finalized checkpoint reported by most nodes. It is nil if no node reported finality.
+	Finalized *phase0.Checkpoint
+	// FinalityConsensus is true if every node that reported finality agrees on Finalized.
+	FinalityConsensus bool
+}
+
+// PoolNodeStatus is the status of a single node in a NodePool.
+type PoolNodeStatus struct {
+	Healthy bool
+	// SyncDistance is nil if the node's sync state is not known yet.
+	SyncDistance *phase0.Slot
+	// Finalized is nil if the node's finality is not known yet.
+	Finalized *phase0.Checkpoint
+}
+
+// NodesDivergedEvent is emitted by a NodePool when its nodes report different roots for the same finalized
+// epoch, or keep reporting different finalized epochs for longer than the lag grace period.
+type NodesDivergedEvent struct {
+	EventMeta
+
+	// Checkpoints holds the finalized checkpoint reported by every node that reported finality.
+	Checkpoints map[string]*phase0.Checkpoint
+	// ConflictingRoots is true if nodes reported different roots for the same finalized epoch, as opposed to
+	// some nodes lagging behind.
+	ConflictingRoots bool
+}
+
+// NewNodePool creates a pool of the given nodes, keyed by name.
+func NewNodePool(log logging.Logger, nodes map[string]Node, opts NodePoolOptions) *NodePool {
+	members := make(map[string]Node, len(nodes))
+	for name, node := range nodes {
+		members[name] = node
+	}
+
+	return &NodePool{
+		log:    logging.New(log).WithField("module", "consensus/beacon/pool"),
+		nodes:  members,
+		broker: emission.NewEmitter(),
+		opts:   opts,
+	}
+}
+
+// Nodes returns the nodes in the pool, by name.
+func (p *NodePool) Nodes() map[string]Node {
+	nodes := make(map[string]Node, len(p.nodes))
+	for name, node := range p.nodes {
+		nodes[name] = node
+	}
+
+	return nodes
+}
+
+// Start checks the nodes for divergence every CheckInterval until the context is done, emitting a
+// NodesDivergedEvent when they diverge.
+func (p *NodePool) Start(ctx context.Context) {
+	interval := p.opts.CheckInterval.Duration
+	if interval <= 0 {
+		interval = DefaultNodePoolOptions().CheckInterval.Duration
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.checkDivergence(time.Now())
+			}
+		}
+	}()
+}
+
+// OnNodesDiverged is called when the nodes in the pool diverge, see NodesDivergedEvent.
+func (p *NodePool) OnNodesDiverged(ctx context.Context, handler func(ctx context.Context, event *NodesDivergedEvent) error) {
+	p.broker.On(topicNodesDiverged, func(event *NodesDivergedEvent) {
+		if err := handler(ctx, event); err != nil {
+			p.log.WithError(err).WithField("topic", topicNodesDiverged).Error("Subscriber error")
+		}
+	})
+}
+
+// Status returns the aggregated status of the nodes in the pool from their cached state.
+func (p *NodePool) Status() *PoolStatus {
+	status := &PoolStatus{
+		Nodes: make(map[string]*PoolNodeStatus, len(p.nodes)),
+	}
+
+	checkpoints := make(map[string]*phase0.Checkpoint)
+	distances := make([]phase0.Slot, 0, len(p.nodes))
+
+	for name, node := range p.nodes {
+		nodeStatus := &PoolNodeStatus{
+			Healthy: node.Healthy(),
+		}
+
+		if nodeStatus.Healthy {
+			status.Healthy++
+		}
+
+		if syncState, err := node.SyncState(); err == nil && syncState != nil {
+			distance := syncState.SyncDistance
+			nodeStatus.SyncDistance = &distance
+
+			distances = append(distances, distance)
+		}
+
+		if finality, err := node.Finality(); err == nil && finality != nil && finality.Finalized != nil {
+			nodeStatus.Finalized = finality.Finalized
+			checkpoints[name] = finality.Finalized
+		}
+
+		status.Nodes[name] = nodeStatus
+	}
+
+	status.Quorum = status.Healthy*2 > len(p.nodes)
+
+	if len(distances) > 0 {
+		sort.Slice(distances, func(i, j int) bool { return distances[i] < distances[j] })
+
+		status.MinSyncDistance = distances[0]
+		status.MaxSyncDistance = distances[len(distances)-1]
+		status.SyncDistanceSpread = status.MaxSyncDistance - status.MinSyncDistance
+	}
+
+	status.Finalized, status.FinalityConsensus = majorityCheckpoint(checkpoints)
+
+	return status
+}
+
+// checkDivergence emits a NodesDivergedEvent if the nodes diverged at the given time while they did not at
+// the previous check. Nodes that only report different finalized epochs are lagging rather than diverged
+// until they have done so for longer than the lag grace period.
+func (p *NodePool) checkDivergence(now time.Time) {
+	checkpoints := make(map[string]*phase0.Checkpoint)
+
+	for name, node := range p.nodes {
+		if finality, err := node.Finality(); err == nil && finality != nil && finality.Finalized != nil {
+			checkpoints[name] = finality.Finalized
+		}
+	}
+
+	roots := make(map[phase0.Epoch]phase0.Root)
+	conflicting := false
+
+	for _, checkpoint := range checkpoints {
+		if root, exists := roots[checkpoint.Epoch]; exists && root != checkpoint.Root {
+			conflicting = true
+		}
+
+		roots[checkpoint.Epoch] = checkpoint.Root
+	}
+
+	lagging := len(roots) > 1
+
+	p.mu.Lock()
+
+	switch {
+	case !lagging:
+		p.laggingSince = time.Time{}
+	case p.laggingSince.IsZero():
+		p.laggingSince = now
+	}
+
+	diverged := conflicting || (lagging && now.Sub(p.laggingSince) >= p.opts.LagGracePeriod.Duration)
+
+	wasDiverged := p.diverged
+	p.diverged = diverged
+
+	p.mu.Unlock()
+
+	if !diverged || wasDiverged {
+		return
+	}
+
+	p.log.WithField("conflicting_roots", conflicting).Warn("Nodes disagree on the finalized checkpoint")
+
+	event := &NodesDivergedEvent{
+		Checkpoints:      checkpoints,
+		ConflictingRoots: conflicting,
+	}
+	event.setEventMeta("", time.Now())
+
+	p.broker.Emit(topicNodesDiverged, event)
+}
+
+// majorityCheckpoint returns the checkpoint reported by most nodes, preferring the higher epoch on a tie, and
+// whether every node reported it.
+func majorityCheckpoint(checkpoints map[string]*phase0.Checkpoint) (*phase0.Checkpoint, bool) {
+	if len(checkpoints) == 0 {
+		return nil, true
+	}
+
+	votes := make(map[phase0.Checkpoint]int)
+
+	for _, checkpoint := range checkpoints {
+		votes[*checkpoint]++
+	}
+
+	var (
+		majority phase0.Checkpoint
+		count    int
+	)
+
+	for checkpoint, n := range votes {
+		if n > count || (n == count && checkpoint.Epoch > majority.Epoch) {
+			majority = checkpoint
+			count = n
+		}
+	}
+
+	return &majority, len(votes) == 1
+}
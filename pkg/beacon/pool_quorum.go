@@ -0,0 +1,135 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+)
+
+// QuorumResult is the answer most nodes in a NodePool agreed on, along with the answers of the nodes that
+// disagreed.
+type QuorumResult[T any] struct {
+	// Data is the majority answer. It is the zero value if no node answered.
+	Data T
+	// Agreeing holds the names of the nodes that returned the majority answer.
+	Agreeing []string
+	// Disagreements holds the answers of the nodes that returned a different answer, by name.
+	Disagreements map[string]T
+	// Errors holds the errors of the nodes that failed to answer, by name.
+	Errors map[string]error
+}
+
+// FetchBlockQuorum fetches the block from every node in the pool and returns the block most nodes agree on,
+// compared by block root. ErrNoQuorum is returned along with the result if fewer than a majority of the
+// nodes in the pool agree.
+func (p *NodePool) FetchBlockQuorum(ctx context.Context, blockID string) (*QuorumResult[*spec.VersionedSignedBeaconBlock], error) {
+	return fetchQuorum(ctx, p, func(ctx context.Context, node Node) (*spec.VersionedSignedBeaconBlock, error) {
+		return node.FetchBlock(ctx, blockID)
+	}, func(block *spec.VersionedSignedBeaconBlock) (string, error) {
+		root, err := block.Root()
+		if err != nil {
+			return "", err
+		}
+
+		return root.String(), nil
+	})
+}
+
+// FetchFinalityQuorum fetches the finality checkpoints from every node in the pool and returns the
+// checkpoints most nodes agree on. ErrNoQuorum is returned along with the result if fewer than a majority of
+// the nodes in the pool agree.
+func (p *NodePool) FetchFinalityQuorum(ctx context.Context, stateID string) (*QuorumResult[*v1.Finality], error) {
+	return fetchQuorum(ctx, p, func(ctx context.Context, node Node) (*v1.Finality, error) {
+		return node.FetchFinality(ctx, stateID)
+	}, func(finality *v1.Finality) (string, error) {
+		return fmt.Sprintf("%v/%v/%v", finality.Finalized, finality.Justified, finality.PreviousJustified), nil
+	})
+}
+
+type quorumAnswer[T any] struct {
+	data T
+	key  string
+	err  error
+}
+
+// fetchQuorum calls fetch on every node concurrently and groups the answers by key.
+func fetchQuorum[T any](ctx context.Context, p *NodePool, fetch func(ctx context.Context, node Node) (T, error), key func(T) (string, error)) (*QuorumResult[T], error) {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		answers = make(map[string]quorumAnswer[T], len(p.nodes))
+	)
+
+	for name, node := range p.nodes {
+		wg.Add(1)
+
+		go func(name string, node Node) {
+			defer wg.Done()
+
+			answer := quorumAnswer[T]{}
+
+			answer.data, answer.err = fetch(ctx, node)
+			if answer.err == nil {
+				answer.key, answer.err = key(answer.data)
+			}
+
+			mu.Lock()
+			answers[name] = answer
+			mu.Unlock()
+		}(name, node)
+	}
+
+	wg.Wait()
+
+	result := &QuorumResult[T]{
+		Disagreements: make(map[string]T),
+		Errors:        make(map[string]error),
+	}
+
+	votes := make(map[string]int)
+
+	for name, answer := range answers {
+		if answer.err != nil {
+			result.Errors[name] = answer.err
+
+			continue
+		}
+
+		votes[answer.key]++
+	}
+
+	majority := ""
+	for k, count := range votes {
+		// Ties are broken by key so that the result does not depend on map iteration order.
+		if count > votes[majority] || (count == votes[majority] && k < majority) {
+			majority = k
+		}
+	}
+
+	for name, answer := range answers {
+		if answer.err != nil {
+			continue
+		}
+
+		if answer.key == majority {
+			result.Data = answer.data
+			result.Agreeing = append(result.Agreeing, name)
+
+			continue
+		}
+
+		result.Disagreements[name] = answer.data
+	}
+
+	sort.Strings(result.Agreeing)
+
+	if len(result.Agreeing)*2 <= len(p.nodes) {
+		return result, fmt.Errorf("%w: %d of %d nodes agree", ErrNoQuorum, len(result.Agreeing), len(p.nodes))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,53 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchQuorum(t *testing.T) {
+	a, b, c := &node{}, &node{}, &node{}
+
+	pool := NewNodePool(logging.New(nil), map[string]Node{"a": a, "b": b, "c": c}, DefaultNodePoolOptions())
+
+	key := func(answer int) (string, error) {
+		return strconv.Itoa(answer), nil
+	}
+
+	t.Run("majority", func(t *testing.T) {
+		answers := map[Node]int{a: 1, b: 1, c: 2}
+
+		result, err := fetchQuorum(context.Background(), pool, func(ctx context.Context, n Node) (int, error) {
+			return answers[n], nil
+		}, key)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.Data)
+		assert.Equal(t, []string{"a", "b"}, result.Agreeing)
+		assert.Equal(t, map[string]int{"c": 2}, result.Disagreements)
+		assert.Empty(t, result.Errors)
+	})
+
+	t.Run("no quorum", func(t *testing.T) {
+		answers := map[Node]int{a: 1, b: 2}
+
+		result, err := fetchQuorum(context.Background(), pool, func(ctx context.Context, n Node) (int, error) {
+			if n == c {
+				return 0, errors.New("unavailable")
+			}
+
+			return answers[n], nil
+		}, key)
+		require.ErrorIs(t, err, ErrNoQuorum)
+
+		assert.Len(t, result.Agreeing, 1)
+		assert.Len(t, result.Disagreements, 1)
+		assert.Contains(t, result.Errors, "c")
+	})
+}
@@ -0,0 +1,130 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/ethpandaops/beacon/pkg/human"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPoolTestNode(healthy bool, syncDistance phase0.Slot, finalized *phase0.Checkpoint) *node {
+	n := &node{
+		log:     logging.New(nil),
+		broker:  emission.NewEmitter(),
+		options: DefaultOptions(),
+		stat:    NewStatus(1, 1),
+	}
+
+	if healthy {
+		n.stat.Health().RecordSuccess()
+	}
+
+	n.stat.UpdateSyncState(&v1.SyncState{SyncDistance: syncDistance})
+
+	if finalized != nil {
+		n.finality = &v1.Finality{Finalized: finalized}
+	}
+
+	return n
+}
+
+func TestNodePoolStatus(t *testing.T) {
+	checkpoint := &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x01}}
+
+	pool := NewNodePool(logging.New(nil), map[string]Node{
+		"a": newPoolTestNode(true, 0, checkpoint),
+		"b": newPoolTestNode(true, 2, checkpoint),
+		"c": newPoolTestNode(false, 5, nil),
+	}, DefaultNodePoolOptions())
+
+	status := pool.Status()
+
+	assert.Equal(t, 2, status.Healthy)
+	assert.True(t, status.Quorum)
+	assert.Equal(t, phase0.Slot(0), status.MinSyncDistance)
+	assert.Equal(t, phase0.Slot(5), status.MaxSyncDistance)
+	assert.Equal(t, phase0.Slot(5), status.SyncDistanceSpread)
+	assert.Equal(t, checkpoint, status.Finalized)
+	assert.True(t, status.FinalityConsensus)
+	assert.Nil(t, status.Nodes["c"].Finalized)
+}
+
+func TestNodePoolDiverged(t *testing.T) {
+	a := newPoolTestNode(true, 0, &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x01}})
+	b := newPoolTestNode(true, 0, &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x01}})
+	c := newPoolTestNode(true, 0, &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x02}})
+
+	pool := NewNodePool(logging.New(nil), map[string]Node{"a": a, "b": b, "c": c}, DefaultNodePoolOptions())
+
+	events := make(chan *NodesDivergedEvent, 2)
+
+	pool.OnNodesDiverged(context.Background(), func(ctx context.Context, event *NodesDivergedEvent) error {
+		events <- event
+
+		return nil
+	})
+
+	// Status only reports the disagreement, the divergence check emits the event.
+	status := pool.Status()
+
+	assert.False(t, status.FinalityConsensus)
+	assert.Equal(t, phase0.Root{0x01}, status.Finalized.Root)
+	assert.Empty(t, events)
+
+	pool.checkDivergence(time.Now())
+
+	require.Len(t, events, 1)
+
+	event := <-events
+	assert.True(t, event.ConflictingRoots)
+	assert.Len(t, event.Checkpoints, 3)
+
+	// The event is only emitted again after the nodes agreed in between.
+	pool.checkDivergence(time.Now())
+	assert.Empty(t, events)
+}
+
+func TestNodePoolLagging(t *testing.T) {
+	a := newPoolTestNode(true, 0, &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x01}})
+	b := newPoolTestNode(true, 0, &phase0.Checkpoint{Epoch: 9, Root: phase0.Root{0x02}})
+
+	opts := DefaultNodePoolOptions()
+	opts.LagGracePeriod = human.Duration{Duration: time.Minute}
+
+	pool := NewNodePool(logging.New(nil), map[string]Node{"a": a, "b": b}, opts)
+
+	events := make(chan *NodesDivergedEvent, 1)
+
+	pool.OnNodesDiverged(context.Background(), func(ctx context.Context, event *NodesDivergedEvent) error {
+		events <- event
+
+		return nil
+	})
+
+	now := time.Now()
+
+	// A node that is behind on finality is not diverged until it stays behind past the grace period.
+	pool.checkDivergence(now)
+	pool.checkDivergence(now.Add(30 * time.Second))
+	assert.Empty(t, events)
+
+	pool.checkDivergence(now.Add(time.Minute))
+
+	require.Len(t, events, 1)
+	assert.False(t, (<-events).ConflictingRoots)
+
+	// Catching up resets the grace period.
+	b.finality = &v1.Finality{Finalized: &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x01}}}
+	pool.checkDivergence(now.Add(2 * time.Minute))
+
+	a.finality = &v1.Finality{Finalized: &phase0.Checkpoint{Epoch: 11, Root: phase0.Root{0x03}}}
+	pool.checkDivergence(now.Add(3 * time.Minute))
+	assert.Empty(t, events)
+}
@@ -11,96 +11,288 @@ import (
 	"github.com/ethpandaops/beacon/pkg/beacon/state"
 )
 
+// receivedAtKey is the context key for the time an upstream event was read from the event stream.
+type receivedAtKey struct{}
+
+// withReceivedAt returns a context recording when the upstream event handled with it was read from the event
+// stream, so that every event published for it is stamped with that time.
+func withReceivedAt(ctx context.Context, at time.Time) context.Context {
+	return context.WithValue(ctx, receivedAtKey{}, at)
+}
+
+// receivedAt returns the time recorded with withReceivedAt, or the current time for events that do not
+// originate from the event stream.
+func receivedAt(ctx context.Context) time.Time {
+	if at, ok := ctx.Value(receivedAtKey{}).(time.Time); ok {
+		return at
+	}
+
+	return time.Now()
+}
+
+// emit publishes the event on its topic and wraps it in an envelope for OnAnyEvent subscribers.
+func (n *node) emit(ctx context.Context, topic string, event interface{}) {
+	config := n.currentConfig()
+	at := receivedAt(ctx)
+
+	if meta, ok := event.(eventMetaSetter); ok {
+		meta.setEventMeta(config.Name, at)
+	}
+
+	n.broker.Emit(topic, event)
+
+	n.emitEnvelope(config, topic, at, event, !proxiedTopics.Exists(topic))
+}
+
+// emitEnvelope wraps the event in an envelope for OnAnyEvent subscribers, and for OnAnyCustomEvent subscribers
+// if it is a custom event.
+func (n *node) emitEnvelope(config *Config, topic string, at time.Time, event interface{}, custom bool) {
+	envelope := &EventEnvelope{
+		Topic:      topic,
+		Node:       config.Name,
+		Labels:     config.Labels,
+		ReceivedAt: at,
+		Event:      event,
+	}
+
+	n.broker.Emit(topicAnyEvent, envelope)
+
+	if custom {
+		n.broker.Emit(topicAnyCustomEvent, envelope)
+	}
+}
+
 // Official beacon events that are proxied
 func (n *node) publishBlock(ctx context.Context, event *v1.BlockEvent) {
-	n.broker.Emit(topicBlock, event)
+	n.emit(ctx, topicBlock, event)
 }
 
 func (n *node) publishAttestation(ctx context.Context, event *phase0.Attestation) {
-	n.broker.Emit(topicAttestation, event)
+	n.emit(ctx, topicAttestation, event)
 }
 
 func (n *node) publishChainReOrg(ctx context.Context, event *v1.ChainReorgEvent) {
-	n.broker.Emit(topicChainReorg, event)
+	n.emit(ctx, topicChainReorg, event)
 }
 
 func (n *node) publishFinalizedCheckpoint(ctx context.Context, event *v1.FinalizedCheckpointEvent) {
-	n.broker.Emit(topicFinalizedCheckpoint, event)
+	n.emit(ctx, topicFinalizedCheckpoint, event)
 }
 
 func (n *node) publishHead(ctx context.Context, event *v1.HeadEvent) {
-	n.broker.Emit(topicHead, event)
+	n.emit(ctx, topicHead, event)
 }
 
 func (n *node) publishVoluntaryExit(ctx context.Context, event *phase0.SignedVoluntaryExit) {
-	n.broker.Emit(topicVoluntaryExit, event)
+	n.emit(ctx, topicVoluntaryExit, event)
 }
 
 func (n *node) publishContributionAndProof(ctx context.Context, event *altair.SignedContributionAndProof) {
-	n.broker.Emit(topicContributionAndProof, event)
+	n.emit(ctx, topicContributionAndProof, event)
 }
 
 func (n *node) publishBlobSidecar(ctx context.Context, event *v1.BlobSidecarEvent) {
-	n.broker.Emit(topicBlobSidecar, event)
+	n.emit(ctx, topicBlobSidecar, event)
 }
 
+// publishEvent publishes the upstream event to OnEvent subscribers. It is not wrapped in an envelope, as
+// OnAnyEvent subscribers receive the event from its own topic, or from publishUnhandledEvent.
 func (n *node) publishEvent(ctx context.Context, event *v1.Event) {
 	n.broker.Emit(topicEvent, event)
 }
 
+// publishUnhandledEvent wraps an upstream event whose topic has no handler of its own in an envelope, so that
+// OnAnyEvent subscribers receive every upstream event exactly once.
+func (n *node) publishUnhandledEvent(ctx context.Context, event *v1.Event) {
+	n.emitEnvelope(n.currentConfig(), event.Topic, receivedAt(ctx), event.Data, false)
+}
+
 // Custom Events derived from our pseudo beacon node
 func (n *node) publishReady(ctx context.Context) {
-	n.broker.Emit(topicReady, nil)
+	n.emit(ctx, topicReady, &ReadyEvent{})
 }
 
-func (n *node) publishSyncStatus(ctx context.Context, st *v1.SyncState) {
-	n.broker.Emit(topicSyncStatus, &SyncStatusEvent{
-		State: st,
+func (n *node) publishSyncStatus(ctx context.Context, st *v1.SyncState, elOffline bool) {
+	n.emit(ctx, topicSyncStatus, &SyncStatusEvent{
+		State:     st,
+		ELOffline: elOffline,
 	})
 }
 
-func (n *node) publishNodeVersionUpdated(ctx context.Context, version string) {
-	n.broker.Emit(topicNodeVersionUpdated, &NodeVersionUpdatedEvent{
-		Version: version,
+func (n *node) publishNodeVersionUpdated(ctx context.Context, info types.NodeVersion) {
+	n.emit(ctx, topicNodeVersionUpdated, &NodeVersionUpdatedEvent{
+		Version: info.Raw,
+		Info:    info,
 	})
 }
 
-func (n *node) publishPeersUpdated(ctx context.Context, peers types.Peers) {
-	n.broker.Emit(topicPeersUpdated, &PeersUpdatedEvent{
-		Peers: peers,
+func (n *node) publishIdentityUpdated(ctx context.Context, identity *types.Identity) {
+	n.emit(ctx, topicIdentityUpdated, &IdentityUpdatedEvent{
+		Identity: identity,
+	})
+}
+
+func (n *node) publishPeersUpdated(ctx context.Context, peers, added, removed types.Peers) {
+	n.emit(ctx, topicPeersUpdated, &PeersUpdatedEvent{
+		Peers:   peers,
+		Added:   added,
+		Removed: removed,
 	})
 }
 
 func (n *node) publishSpecUpdated(ctx context.Context, spec *state.Spec) {
-	n.broker.Emit(topicSpecUpdated, &SpecUpdatedEvent{
+	n.emit(ctx, topicSpecUpdated, &SpecUpdatedEvent{
 		Spec: spec,
 	})
 }
 
 func (n *node) publishEmptySlot(ctx context.Context, slot phase0.Slot) {
-	n.broker.Emit(topicEmptySlot, &EmptySlotEvent{
+	n.emit(ctx, topicEmptySlot, &EmptySlotEvent{
 		Slot: slot,
 	})
 }
 
 func (n *node) publishHealthCheckSucceeded(ctx context.Context, duration time.Duration) {
-	n.broker.Emit(topicHealthCheckSucceeded, &HealthCheckSucceededEvent{
+	n.emit(ctx, topicHealthCheckSucceeded, &HealthCheckSucceededEvent{
 		Duration: duration,
 	})
 }
 
 func (n *node) publishHealthCheckFailed(ctx context.Context, duration time.Duration) {
-	n.broker.Emit(topicHealthCheckFailed, &HealthCheckFailedEvent{
+	n.emit(ctx, topicHealthCheckFailed, &HealthCheckFailedEvent{
 		Duration: duration,
 	})
 }
 
-func (n *node) publishFinalityCheckpointUpdated(ctx context.Context, finality *v1.Finality) {
-	n.broker.Emit(topicFinalityCheckpointUpdated, &FinalityCheckpointUpdated{
+func (n *node) publishFinalityCheckpointUpdated(ctx context.Context, previous, finality *v1.Finality) {
+	event := &FinalityCheckpointUpdated{
 		Finality: finality,
-	})
+		Previous: previous,
+	}
+
+	if previous != nil && previous.Finalized != nil && finality.Finalized != nil {
+		event.EpochsAdvanced = int64(finality.Finalized.Epoch) - int64(previous.Finalized.Epoch)
+	}
+
+	n.emit(ctx, topicFinalityCheckpointUpdated, event)
 }
 
 func (n *node) publishFirstTimeHealthy(ctx context.Context) {
-	n.broker.Emit(topicFirstTimeHealthy, &FirstTimeHealthyEvent{})
+	n.emit(ctx, topicFirstTimeHealthy, &FirstTimeHealthyEvent{})
+}
+
+func (n *node) publishBlockOrphaned(ctx context.Context, root phase0.Root, slot phase0.Slot, proposer phase0.ValidatorIndex, executionOptimistic bool) {
+	n.emit(ctx, topicBlockOrphaned, &BlockOrphanedEvent{
+		Root:                root,
+		Slot:                slot,
+		ProposerIndex:       proposer,
+		ExecutionOptimistic: executionOptimistic,
+	})
+}
+
+func (n *node) publishHeadLag(ctx context.Context, headSlot, wallclockSlot phase0.Slot) {
+	n.emit(ctx, topicHeadLag, &HeadLagEvent{
+		HeadSlot:      headSlot,
+		WallclockSlot: wallclockSlot,
+		Lag:           wallclockSlot - headSlot,
+	})
+}
+
+// publishHandlerError is not wrapped in an envelope as failing any_event handlers would otherwise
+// feed back into themselves.
+func (n *node) publishHandlerError(ctx context.Context, event *HandlerErrorEvent) {
+	event.setEventMeta(n.currentConfig().Name, time.Now())
+
+	n.broker.Emit(topicHandlerError, event)
+}
+
+func (n *node) publishPossibleSlashing(ctx context.Context, event *PossibleSlashingEvent) {
+	n.emit(ctx, topicPossibleSlashing, event)
+}
+
+func (n *node) publishConfigReloaded(ctx context.Context, event *ConfigReloadedEvent) {
+	n.emit(ctx, topicConfigReloaded, event)
+}
+
+func (n *node) publishEventStreamConnected(ctx context.Context, stream string, topics []string, reconnect bool) {
+	n.emit(ctx, topicEventStreamConnected, &EventStreamConnectedEvent{
+		Stream:    stream,
+		Topics:    topics,
+		Reconnect: reconnect,
+	})
+}
+
+func (n *node) publishEventStreamDisconnected(ctx context.Context, stream string, topics []string, reason, category string, connectedFor time.Duration) {
+	n.emit(ctx, topicEventStreamDisconnected, &EventStreamDisconnectedEvent{
+		Stream:       stream,
+		Topics:       topics,
+		Reason:       reason,
+		Category:     category,
+		ConnectedFor: connectedFor,
+	})
+}
+
+func (n *node) publishDataColumnSidecar(ctx context.Context, event *DataColumnSidecarEvent) {
+	n.emit(ctx, topicDataColumnSidecar, event)
+}
+
+func (n *node) publishClockSkewExceeded(ctx context.Context, skew time.Duration) {
+	n.emit(ctx, topicClockSkewExceeded, &ClockSkewExceededEvent{
+		Skew:      skew,
+		Threshold: n.currentOptions().ClockSkew.Threshold.Duration,
+	})
+}
+
+func (n *node) publishDataIntegrity(ctx context.Context, event *DataIntegrityEvent) {
+	n.emit(ctx, topicDataIntegrity, event)
+}
+
+func (n *node) publishBlockGraffiti(ctx context.Context, event *BlockGraffitiEvent) {
+	n.emit(ctx, topicBlockGraffiti, event)
+}
+
+func (n *node) publishDepositDivergence(ctx context.Context, event *DepositDivergenceEvent) {
+	n.emit(ctx, topicDepositDivergence, event)
+}
+
+func (n *node) publishWithdrawal(ctx context.Context, event *WithdrawalEvent) {
+	n.emit(ctx, topicWithdrawal, event)
+}
+
+func (n *node) publishWithdrawalReverted(ctx context.Context, event *WithdrawalEvent) {
+	n.emit(ctx, topicWithdrawalReverted, event)
+}
+
+func (n *node) publishELOffline(ctx context.Context, st *v1.SyncState) {
+	n.emit(ctx, topicELOffline, &ELOfflineEvent{
+		State: st,
+	})
+}
+
+func (n *node) publishForkImminent(ctx context.Context, event *ForkImminentEvent) {
+	n.emit(ctx, topicForkImminent, event)
+}
+
+func (n *node) publishForkActivated(ctx context.Context, event *ForkActivatedEvent) {
+	n.emit(ctx, topicForkActivated, event)
+}
+
+func (n *node) publishBlobLimitChanged(ctx context.Context, event *BlobLimitChangedEvent) {
+	n.emit(ctx, topicBlobLimitChanged, event)
+}
+
+func (n *node) publishBootstrapFailed(ctx context.Context, err error, attempt int, willRetry bool, retryIn time.Duration) {
+	n.emit(ctx, topicBootstrapFailed, &BootstrapFailedEvent{
+		Err:       err,
+		Attempt:   attempt,
+		WillRetry: willRetry,
+		RetryIn:   retryIn,
+	})
+}
+
+func (n *node) publishSyncStateChanged(ctx context.Context, previous, current *v1.SyncState) {
+	n.emit(ctx, topicSyncStateChanged, &SyncStateChangedEvent{
+		Previous: previous,
+		Current:  current,
+	})
 }
@@ -0,0 +1,172 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmitWrapsEventsInEnvelope(t *testing.T) {
+	n := &node{
+		log:    logging.New(nil),
+		config: &Config{Name: "node-a", Labels: map[string]string{"network": "mainnet"}},
+		broker: emission.NewEmitter(),
+	}
+
+	ctx := context.Background()
+
+	var envelopes []*EventEnvelope
+
+	n.OnAnyEvent(ctx, func(ctx context.Context, event *EventEnvelope) error {
+		envelopes = append(envelopes, event)
+
+		return nil
+	})
+
+	var emptySlots []*EmptySlotEvent
+
+	n.OnEmptySlot(ctx, func(ctx context.Context, event *EmptySlotEvent) error {
+		emptySlots = append(emptySlots, event)
+
+		return nil
+	})
+
+	n.publishEmptySlot(ctx, phase0.Slot(10))
+
+	require.Len(t, emptySlots, 1)
+	require.Len(t, envelopes, 1)
+
+	assert.Equal(t, topicEmptySlot, envelopes[0].Topic)
+	assert.Equal(t, "node-a", envelopes[0].Node)
+	assert.Equal(t, map[string]string{"network": "mainnet"}, envelopes[0].Labels)
+	assert.False(t, envelopes[0].ReceivedAt.IsZero())
+	assert.Equal(t, emptySlots[0], envelopes[0].Event)
+
+	assert.Equal(t, "node-a", emptySlots[0].Node)
+	assert.Equal(t, envelopes[0].ReceivedAt, emptySlots[0].At)
+}
+
+func TestPublishFinalityCheckpointUpdated(t *testing.T) {
+	n := &node{
+		log:    logging.New(nil),
+		config: &Config{Name: "node-a"},
+		broker: emission.NewEmitter(),
+	}
+
+	var events []*FinalityCheckpointUpdated
+
+	n.OnFinalityCheckpointUpdated(context.Background(), func(ctx context.Context, event *FinalityCheckpointUpdated) error {
+		events = append(events, event)
+
+		return nil
+	})
+
+	finality := func(epoch phase0.Epoch) *v1.Finality {
+		return &v1.Finality{
+			Finalized:         &phase0.Checkpoint{Epoch: epoch},
+			Justified:         &phase0.Checkpoint{Epoch: epoch + 1},
+			PreviousJustified: &phase0.Checkpoint{Epoch: epoch},
+		}
+	}
+
+	n.publishFinalityCheckpointUpdated(context.Background(), nil, finality(10))
+	n.publishFinalityCheckpointUpdated(context.Background(), finality(10), finality(13))
+
+	require.Len(t, events, 2)
+
+	assert.Nil(t, events[0].Previous)
+	assert.Equal(t, int64(0), events[0].EpochsAdvanced)
+
+	assert.Equal(t, finality(10), events[1].Previous)
+	assert.Equal(t, finality(13), events[1].Finality)
+	assert.Equal(t, int64(3), events[1].EpochsAdvanced)
+}
+
+func TestOnAnyCustomEvent(t *testing.T) {
+	n := &node{
+		log:    logging.New(nil),
+		config: &Config{Name: "node-a"},
+		broker: emission.NewEmitter(),
+	}
+
+	var envelopes []*EventEnvelope
+
+	n.OnAnyCustomEvent(context.Background(), func(ctx context.Context, event *EventEnvelope) error {
+		envelopes = append(envelopes, event)
+
+		return nil
+	})
+
+	n.publishEmptySlot(context.Background(), phase0.Slot(10))
+	n.publishHead(context.Background(), &v1.HeadEvent{Slot: 11})
+	n.publishFirstTimeHealthy(context.Background())
+
+	require.Len(t, envelopes, 2)
+	assert.Equal(t, topicEmptySlot, envelopes[0].Topic)
+	assert.Equal(t, topicFirstTimeHealthy, envelopes[1].Topic)
+}
+
+func TestPublishReady(t *testing.T) {
+	n := &node{
+		log:    logging.New(nil),
+		config: &Config{Name: "node-a"},
+		broker: emission.NewEmitter(),
+	}
+
+	var events []*ReadyEvent
+
+	n.OnReady(context.Background(), func(ctx context.Context, event *ReadyEvent) error {
+		events = append(events, event)
+
+		return nil
+	})
+
+	n.publishReady(context.Background())
+
+	require.Len(t, events, 1)
+	require.NotNil(t, events[0])
+	assert.Equal(t, "node-a", events[0].Node)
+}
+
+func TestUpstreamEventsAreEnvelopedOnce(t *testing.T) {
+	n := &node{
+		log:    logging.New(nil),
+		config: &Config{Name: "node-a"},
+		broker: emission.NewEmitter(),
+	}
+
+	var envelopes []*EventEnvelope
+
+	n.OnAnyEvent(context.Background(), func(ctx context.Context, event *EventEnvelope) error {
+		envelopes = append(envelopes, event)
+
+		return nil
+	})
+
+	receivedAt := time.Now().Add(-time.Second)
+	ctx := withReceivedAt(context.Background(), receivedAt)
+
+	attestation := &phase0.Attestation{}
+	attributes := &v1.PayloadAttributesEvent{}
+
+	require.NoError(t, n.handleEvent(ctx, &v1.Event{Topic: topicAttestation, Data: attestation}))
+	require.NoError(t, n.handleEvent(ctx, &v1.Event{Topic: "payload_attributes", Data: attributes}))
+
+	// Every upstream event is enveloped once, stamped with the time it was read from the event stream.
+	require.Len(t, envelopes, 2)
+
+	assert.Equal(t, topicAttestation, envelopes[0].Topic)
+	assert.Same(t, attestation, envelopes[0].Event)
+	assert.Equal(t, receivedAt, envelopes[0].ReceivedAt)
+
+	assert.Equal(t, "payload_attributes", envelopes[1].Topic)
+	assert.Same(t, attributes, envelopes[1].Event)
+	assert.Equal(t, receivedAt, envelopes[1].ReceivedAt)
+}
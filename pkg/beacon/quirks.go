@@ -0,0 +1,94 @@
+package beacon
+
+import (
+	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
+)
+
+const (
+	// ContentTypeJSON is the JSON content type.
+	ContentTypeJSON = "application/json"
+	// ContentTypeSSZ is the SSZ content type.
+	ContentTypeSSZ = "application/octet-stream"
+)
+
+// ClientQuirks describes the known behavioral differences of a consensus client so that workarounds
+// can live in this package instead of in every consumer.
+type ClientQuirks struct {
+	// RawStateContentTypes are the content types the client can serve the raw beacon state in.
+	RawStateContentTypes []string
+	// UnsupportedTopics are event stream topics that the client does not serve by default. They are left out
+	// of the upstream subscription with a warning and reported in SubscribedTopics().Unsupported, so handlers
+	// for them are never called.
+	UnsupportedTopics EventTopics
+}
+
+// SupportsTopic returns true if the client serves the given event stream topic.
+func (q ClientQuirks) SupportsTopic(topic string) bool {
+	return !q.UnsupportedTopics.Exists(topic)
+}
+
+// SupportsRawStateContentType returns true if the client can serve the raw beacon state in the given
+// content type. An empty content type defaults to JSON.
+func (q ClientQuirks) SupportsRawStateContentType(contentType string) bool {
+	if contentType == "" {
+		contentType = ContentTypeJSON
+	}
+
+	for _, ct := range q.RawStateContentTypes {
+		if ct == contentType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FilterTopics returns the topics that the client supports and the ones it does not.
+func (q ClientQuirks) FilterTopics(topics EventTopics) (supported, unsupported EventTopics) {
+	supported = EventTopics{}
+	unsupported = EventTopics{}
+
+	for _, topic := range topics {
+		if q.SupportsTopic(topic) {
+			supported = append(supported, topic)
+		} else {
+			unsupported = append(unsupported, topic)
+		}
+	}
+
+	return supported, unsupported
+}
+
+// QuirksForClient returns the known quirks of the given client.
+func QuirksForClient(client types.Agent) ClientQuirks {
+	quirks := ClientQuirks{
+		RawStateContentTypes: []string{ContentTypeJSON, ContentTypeSSZ},
+		UnsupportedTopics:    EventTopics{},
+	}
+
+	switch client {
+	case types.AgentLighthouse, types.AgentPrysm:
+		// The light client server has to be explicitly enabled on these clients.
+		quirks.UnsupportedTopics = EventTopics{
+			"light_client_finality_update",
+			"light_client_optimistic_update",
+		}
+	}
+
+	return quirks
+}
+
+func (n *node) ClientType() types.Agent {
+	n.nodeVersionMu.RLock()
+	defer n.nodeVersionMu.RUnlock()
+
+	if n.nodeVersion.Client == "" {
+		return types.AgentUnknown
+	}
+
+	return n.nodeVersion.Client
+}
+
+func (n *node) Quirks() ClientQuirks {
+	return QuirksForClient(n.ClientType())
+}
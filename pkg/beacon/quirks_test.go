@@ -0,0 +1,73 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuirksForClient(t *testing.T) {
+	topics := EventTopics{topicHead, topicBlock, "light_client_finality_update"}
+
+	tests := []struct {
+		client      types.Agent
+		supported   EventTopics
+		unsupported EventTopics
+	}{
+		{
+			client:      types.AgentLighthouse,
+			supported:   EventTopics{topicHead, topicBlock},
+			unsupported: EventTopics{"light_client_finality_update"},
+		},
+		{
+			client:      types.AgentTeku,
+			supported:   topics,
+			unsupported: EventTopics{},
+		},
+		{
+			client:      types.AgentUnknown,
+			supported:   topics,
+			unsupported: EventTopics{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.client), func(t *testing.T) {
+			quirks := QuirksForClient(test.client)
+
+			supported, unsupported := quirks.FilterTopics(topics)
+
+			assert.Equal(t, test.supported, supported)
+			assert.Equal(t, test.unsupported, unsupported)
+			assert.True(t, quirks.SupportsRawStateContentType(""))
+			assert.True(t, quirks.SupportsRawStateContentType(ContentTypeSSZ))
+			assert.False(t, quirks.SupportsRawStateContentType("text/plain"))
+		})
+	}
+}
+
+func TestClientType(t *testing.T) {
+	n := &node{}
+
+	assert.Equal(t, types.AgentUnknown, n.ClientType())
+
+	n.nodeVersion = types.ParseNodeVersion("Lighthouse/v4.5.0-441fc16/x86_64-linux")
+
+	assert.Equal(t, types.AgentLighthouse, n.ClientType())
+}
+
+func TestFilterSupportedTopics(t *testing.T) {
+	n := &node{
+		log:         logging.New(nil),
+		options:     DefaultOptions(),
+		nodeVersion: types.NodeVersion{Client: types.AgentLighthouse},
+	}
+
+	topics := n.filterSupportedTopics(EventTopics{topicHead, "light_client_finality_update"})
+
+	// The dropped topics are reported instead of silently disappearing.
+	assert.Equal(t, EventTopics{topicHead}, topics)
+	assert.Equal(t, EventTopics{"light_client_finality_update"}, n.SubscribedTopics().Unsupported)
+}
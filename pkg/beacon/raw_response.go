@@ -0,0 +1,30 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+)
+
+func (n *node) FetchRawBlockResponse(ctx context.Context, stateID string, contentType string, dst []byte) (*api.RawResponse, error) {
+	rsp, err := n.currentAPI().RawBlockResponse(ctx, stateID, contentType, dst)
+	if err != nil {
+		return nil, wrapNotFound(err, ErrBlockNotFound)
+	}
+
+	return rsp, nil
+}
+
+func (n *node) FetchRawBeaconStateResponse(ctx context.Context, stateID string, contentType string, dst []byte) (*api.RawResponse, error) {
+	if !n.Quirks().SupportsRawStateContentType(contentType) {
+		return nil, fmt.Errorf("%w: raw beacon state as %s on %s", ErrNotSupported, contentType, n.ClientType())
+	}
+
+	rsp, err := n.currentAPI().RawDebugBeaconStateResponse(ctx, stateID, contentType, dst)
+	if err != nil {
+		return nil, wrapNotFound(err, ErrStateNotFound)
+	}
+
+	return rsp, nil
+}
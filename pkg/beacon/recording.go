@@ -0,0 +1,196 @@
+package beacon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+)
+
+// maxRecordedEventSize is the maximum size of a single line of an event recording.
+const maxRecordedEventSize = 10 * 1024 * 1024
+
+// RecordedEvent is an upstream event as written by Options.RecordEventsTo, one JSON object per line. Data
+// holds the event as the beacon API encodes it.
+type RecordedEvent struct {
+	Topic      string          `json:"topic"`
+	ReceivedAt time.Time       `json:"received_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// eventRecorder appends upstream events to a file.
+type eventRecorder struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func newEventRecorder(path string) (*eventRecorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event recording: %w", err)
+	}
+
+	return &eventRecorder{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+func (r *eventRecorder) record(event *RecordedEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.encoder.Encode(event)
+}
+
+func (r *eventRecorder) close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.file.Close()
+}
+
+// recordEvent records an event received from the upstream event stream, if recording is enabled.
+func (n *node) recordEvent(topic string, data any) {
+	n.recorderMu.Lock()
+	recorder := n.recorder
+	n.recorderMu.Unlock()
+
+	if recorder == nil {
+		return
+	}
+
+	raw, isRaw := data.([]byte)
+	if !isRaw {
+		var err error
+
+		raw, err = json.Marshal(data)
+		if err != nil {
+			n.log.WithError(err).WithField("topic", topic).Warn("Failed to encode event for recording")
+
+			return
+		}
+	}
+
+	if err := recorder.record(&RecordedEvent{
+		Topic:      topic,
+		ReceivedAt: time.Now(),
+		Data:       raw,
+	}); err != nil {
+		n.log.WithError(err).WithField("topic", topic).Warn("Failed to record event")
+	}
+}
+
+// startRecording opens the event recording if Options.RecordEventsTo is set.
+func (n *node) startRecording() error {
+	if n.currentOptions().RecordEventsTo == "" {
+		return nil
+	}
+
+	recorder, err := newEventRecorder(n.currentOptions().RecordEventsTo)
+	if err != nil {
+		return err
+	}
+
+	n.recorderMu.Lock()
+	n.recorder = recorder
+	n.recorderMu.Unlock()
+
+	return nil
+}
+
+func (n *node) stopRecording() error {
+	n.recorderMu.Lock()
+	recorder := n.recorder
+	n.recorder = nil
+	n.recorderMu.Unlock()
+
+	if recorder == nil {
+		return nil
+	}
+
+	return recorder.close()
+}
+
+// replayEventTypes creates the go-eth2-client type the data of an event is decoded into, by topic. Other topics
+// are replayed through the raw event handler instead.
+var replayEventTypes = map[string]func() any{
+	topicAttestation:          func() any { return &phase0.Attestation{} },
+	topicBlock:                func() any { return &v1.BlockEvent{} },
+	topicChainReorg:           func() any { return &v1.ChainReorgEvent{} },
+	topicFinalizedCheckpoint:  func() any { return &v1.FinalizedCheckpointEvent{} },
+	topicHead:                 func() any { return &v1.HeadEvent{} },
+	topicVoluntaryExit:        func() any { return &phase0.SignedVoluntaryExit{} },
+	topicContributionAndProof: func() any { return &altair.SignedContributionAndProof{} },
+	topicBlobSidecar:          func() any { return &v1.BlobSidecarEvent{} },
+}
+
+func (n *node) ReplayEvents(ctx context.Context, r io.Reader, speed float64) error {
+	if speed < 0 {
+		return errors.New("replay speed must not be negative")
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordedEventSize)
+
+	var previous time.Time
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		recorded := &RecordedEvent{}
+		if err := json.Unmarshal(scanner.Bytes(), recorded); err != nil {
+			return fmt.Errorf("invalid recorded event on line %d: %w", line, err)
+		}
+
+		if speed > 0 && !previous.IsZero() {
+			wait := time.Duration(float64(recorded.ReceivedAt.Sub(previous)) / speed)
+			if wait > 0 {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(wait):
+				}
+			}
+		}
+
+		previous = recorded.ReceivedAt
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := n.replayEvent(ctx, recorded); err != nil {
+			n.log.WithError(err).WithField("line", line).Error("Failed to replay event")
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (n *node) replayEvent(ctx context.Context, recorded *RecordedEvent) error {
+	newData, ok := replayEventTypes[recorded.Topic]
+	if !ok {
+		return n.handleRawEvent(ctx, &api.Event{Topic: recorded.Topic, Data: recorded.Data})
+	}
+
+	data := newData()
+	if err := json.Unmarshal(recorded.Data, data); err != nil {
+		return fmt.Errorf("failed to unmarshal %s event: %w", recorded.Topic, err)
+	}
+
+	return n.handleEvent(ctx, &v1.Event{Topic: recorded.Topic, Data: data})
+}
@@ -0,0 +1,112 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplayEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	options := DefaultOptions()
+	options.RecordEventsTo = path
+
+	recording := &node{log: logging.New(nil), broker: emission.NewEmitter(), options: options}
+	require.NoError(t, recording.startRecording())
+
+	recording.recordEvent(topicBlock, &v1.BlockEvent{Slot: 1, Block: phase0.Root{0x01}})
+	recording.recordEvent(topicHead, &v1.HeadEvent{Slot: 1, Block: phase0.Root{0x01}})
+	recording.recordEvent(topicDataColumnSidecar, []byte(`{"block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","index":"3","slot":"1","kzg_commitments":[]}`))
+
+	require.NoError(t, recording.stopRecording())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	replay := &node{log: logging.New(nil), broker: emission.NewEmitter(), options: DefaultOptions()}
+
+	blocks := make(chan *v1.BlockEvent, 1)
+	heads := make(chan *v1.HeadEvent, 1)
+	sidecars := make(chan *DataColumnSidecarEvent, 1)
+
+	replay.OnBlock(context.Background(), func(ctx context.Context, event *v1.BlockEvent) error {
+		blocks <- event
+
+		return nil
+	})
+	replay.OnHead(context.Background(), func(ctx context.Context, event *v1.HeadEvent) error {
+		heads <- event
+
+		return nil
+	})
+	replay.OnDataColumnSidecar(context.Background(), func(ctx context.Context, event *DataColumnSidecarEvent) error {
+		sidecars <- event
+
+		return nil
+	})
+
+	require.NoError(t, replay.ReplayEvents(context.Background(), file, 0))
+
+	select {
+	case block := <-blocks:
+		assert.Equal(t, phase0.Slot(1), block.Slot)
+		assert.Equal(t, phase0.Root{0x01}, block.Block)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for block event")
+	}
+
+	select {
+	case head := <-heads:
+		assert.Equal(t, phase0.Root{0x01}, head.Block)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for head event")
+	}
+
+	select {
+	case sidecar := <-sidecars:
+		assert.Equal(t, uint64(3), sidecar.Index)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for data column sidecar event")
+	}
+}
+
+func TestReplayEventsSpeed(t *testing.T) {
+	start := time.Now()
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	require.NoError(t, encoder.Encode(&RecordedEvent{Topic: topicHead, ReceivedAt: start, Data: json.RawMessage(`{"slot":"1","block":"0x0100000000000000000000000000000000000000000000000000000000000000","state":"0x0000000000000000000000000000000000000000000000000000000000000000","epoch_transition":false,"current_duty_dependent_root":"0x0000000000000000000000000000000000000000000000000000000000000000","previous_duty_dependent_root":"0x0000000000000000000000000000000000000000000000000000000000000000"}`)}))
+	require.NoError(t, encoder.Encode(&RecordedEvent{Topic: topicHead, ReceivedAt: start.Add(400 * time.Millisecond), Data: json.RawMessage(`{"slot":"2","block":"0x0200000000000000000000000000000000000000000000000000000000000000","state":"0x0000000000000000000000000000000000000000000000000000000000000000","epoch_transition":false,"current_duty_dependent_root":"0x0000000000000000000000000000000000000000000000000000000000000000","previous_duty_dependent_root":"0x0000000000000000000000000000000000000000000000000000000000000000"}`)}))
+
+	n := &node{log: logging.New(nil), broker: emission.NewEmitter(), options: DefaultOptions()}
+
+	// Replaying at 4x speed compresses the 400ms between the events to 100ms.
+	before := time.Now()
+
+	require.NoError(t, n.ReplayEvents(context.Background(), &buf, 4))
+
+	elapsed := time.Since(before)
+	assert.GreaterOrEqual(t, elapsed, 100*time.Millisecond)
+	assert.Less(t, elapsed, 400*time.Millisecond)
+
+	slot, err := n.HeadSlot()
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Slot(2), slot)
+
+	assert.Error(t, n.ReplayEvents(context.Background(), bytes.NewBufferString("{}\n"), -1))
+}
@@ -0,0 +1,238 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+	"github.com/ethpandaops/beacon/pkg/human"
+	"github.com/go-co-op/gocron"
+)
+
+// currentConfig returns the configuration the node is currently running with.
+func (n *node) currentConfig() *Config {
+	n.configMu.RLock()
+	defer n.configMu.RUnlock()
+
+	if n.config == nil {
+		return &Config{}
+	}
+
+	return n.config
+}
+
+// currentOptions returns the options the node is currently running with. The returned options must not be
+// modified, ReloadConfig replaces them with a copy instead.
+func (n *node) currentOptions() *Options {
+	n.configMu.RLock()
+	defer n.configMu.RUnlock()
+
+	return n.options
+}
+
+// currentClient returns the eth2 client for the node's current config.
+func (n *node) currentClient() eth2client.Service {
+	n.clientsMu.RLock()
+	defer n.clientsMu.RUnlock()
+
+	return n.client
+}
+
+// currentAPI returns the raw API client for the node's current config.
+func (n *node) currentAPI() api.ConsensusClient {
+	n.clientsMu.RLock()
+	defer n.clientsMu.RUnlock()
+
+	return n.api
+}
+
+// setClients replaces both clients at once and closes the previous eth2 client by cancelling its context.
+func (n *node) setClients(client eth2client.Service, apiClient api.ConsensusClient, cancel context.CancelFunc) {
+	n.clientsMu.Lock()
+	previous := n.cancelClients
+	n.client = client
+	n.api = apiClient
+	n.cancelClients = cancel
+	n.clientsMu.Unlock()
+
+	if previous != nil {
+		previous()
+	}
+}
+
+// ReloadConfig applies a new configuration to the node. Changed headers are applied by recreating the
+// clients, while the node is only redialled at a new address if the address changed. Whenever the clients are
+// recreated the previous clients are closed and the node's capabilities are probed again. If options is non-nil
+// the health check and polling intervals and the beacon subscription topics are applied as well; all other
+// options are ignored. Changed labels are added to subsequent event envelopes, but metric const labels are fixed when the
+// node is created. A ConfigReloadedEvent is published once the configuration has been applied.
+func (n *node) ReloadConfig(ctx context.Context, config *Config, options *Options) error {
+	if config == nil {
+		return errors.New("config is nil")
+	}
+
+	if config.Addr == "" {
+		return errors.New("config address is empty")
+	}
+
+	n.reloadMu.Lock()
+	defer n.reloadMu.Unlock()
+
+	previous := n.currentConfig()
+
+	event := &ConfigReloadedEvent{
+		Previous: previous,
+		Current:  config,
+	}
+
+	// go-eth2-client only accepts headers when the client is created, so any change of address or headers
+	// requires new clients.
+	clientsChanged := previous.Addr != config.Addr || !maps.Equal(previous.Headers, config.Headers)
+
+	clientsChanged = clientsChanged && n.currentClient() != nil
+
+	// The clients and the capability probes outlive the call, so they are bound to the node's context rather
+	// than ctx.
+	parent := n.currentCtx()
+	if parent == nil {
+		parent = context.WithoutCancel(ctx)
+	}
+
+	if clientsChanged {
+		client, apiClient, cancel, err := n.newClients(parent, config)
+		if err != nil {
+			return fmt.Errorf("failed to create clients for %s: %w", config.Addr, err)
+		}
+
+		n.setClients(client, apiClient, cancel)
+
+		event.Redialled = previous.Addr != config.Addr
+	}
+
+	current := n.currentOptions()
+	updated := *current
+	resubscribe := clientsChanged
+
+	if options != nil {
+		intervals := jobIntervals(current)
+
+		for name, interval := range jobIntervals(options) {
+			if interval == intervals[name] {
+				continue
+			}
+
+			if err := n.rescheduleJob(name, interval.String()); err != nil {
+				return err
+			}
+		}
+
+		updated.HealthCheck.Interval = options.HealthCheck.Interval
+		updated.Polling = options.Polling
+
+		if options.BeaconSubscription.Enabled != current.BeaconSubscription.Enabled ||
+			!slices.Equal(options.BeaconSubscription.Topics, current.BeaconSubscription.Topics) {
+			updated.BeaconSubscription.Enabled = options.BeaconSubscription.Enabled
+			updated.BeaconSubscription.Topics = options.BeaconSubscription.Topics
+
+			resubscribe = true
+		}
+	}
+
+	n.configMu.Lock()
+	n.config = config
+	n.options = &updated
+	n.configMu.Unlock()
+
+	if clientsChanged {
+		// New clients may reach a different node, or the same node with different credentials, so what the
+		// previous clients learnt about the node no longer applies.
+		n.capabilities.reset()
+		go n.probeCapabilities(parent)
+
+		if _, err := n.FetchNodeVersion(ctx); err != nil {
+			n.log.WithError(err).Warn("Failed to fetch node version after recreating the clients")
+		}
+	}
+
+	if resubscribe {
+		event.Resubscribed = n.restartBeaconSubscription()
+	}
+
+	n.log.WithField("redialled", event.Redialled).WithField("resubscribed", event.Resubscribed).Info("Reloaded config")
+
+	n.publishConfigReloaded(ctx, event)
+
+	return nil
+}
+
+const (
+	jobHealthCheck  = "health_check"
+	jobSyncStatus   = "sync_status"
+	jobNodeVersion  = "node_version"
+	jobPeers        = "peers"
+	jobNodeIdentity = "node_identity"
+)
+
+// cronJob is a job of the node's crons together with its task, so that it can be rescheduled.
+type cronJob struct {
+	job  *gocron.Job
+	task func()
+}
+
+// jobIntervals returns the intervals of the jobs ReloadConfig reschedules, by name.
+func jobIntervals(options *Options) map[string]human.Duration {
+	return map[string]human.Duration{
+		jobHealthCheck:  options.HealthCheck.Interval,
+		jobSyncStatus:   options.Polling.SyncStatus,
+		jobNodeVersion:  options.Polling.NodeVersion,
+		jobPeers:        options.Polling.Peers,
+		jobNodeIdentity: options.Polling.NodeIdentity,
+	}
+}
+
+// rescheduleJob replaces the job with the given name with one that runs its task at the given interval. It is
+// a noop if the node has not been started yet.
+func (n *node) rescheduleJob(name, interval string) error {
+	n.cronsMu.Lock()
+	defer n.cronsMu.Unlock()
+
+	scheduled, exists := n.cronJobs[name]
+	if n.crons == nil || !exists {
+		return nil
+	}
+
+	job, err := n.crons.Every(interval).Do(scheduled.task)
+	if err != nil {
+		return fmt.Errorf("failed to reschedule %s: %w", name, err)
+	}
+
+	n.crons.RemoveByReference(scheduled.job)
+	scheduled.job = job
+
+	return nil
+}
+
+// restartBeaconSubscription stops the upstream event stream and subscribes again with the current options.
+// It returns false if there was no active event stream, in which case the pending subscription loop picks up
+// the current options by itself.
+func (n *node) restartBeaconSubscription() bool {
+	n.cancelEventsMu.Lock()
+	cancel := n.cancelEvents
+	n.cancelEvents = nil
+	n.cancelEventsMu.Unlock()
+
+	if cancel == nil {
+		return false
+	}
+
+	cancel()
+
+	//nolint:errcheck // we dont care if this errors out since it runs indefinitely in a goroutine
+	go n.ensureBeaconSubscription(n.currentCtx())
+
+	return true
+}
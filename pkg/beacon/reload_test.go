@@ -0,0 +1,155 @@
+package beacon
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/ethpandaops/beacon/pkg/beacon/simulator"
+	"github.com/ethpandaops/beacon/pkg/human"
+	"github.com/go-co-op/gocron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadConfig(t *testing.T) {
+	options := DefaultOptions()
+
+	n := &node{
+		log:     logging.New(nil),
+		broker:  emission.NewEmitter(),
+		config:  &Config{Name: "node", Addr: "http://localhost:5052"},
+		options: options,
+	}
+
+	events := make(chan *ConfigReloadedEvent, 1)
+
+	n.OnConfigReloaded(context.Background(), func(ctx context.Context, event *ConfigReloadedEvent) error {
+		events <- event
+
+		return nil
+	})
+
+	assert.Error(t, n.ReloadConfig(context.Background(), nil, nil))
+	assert.Error(t, n.ReloadConfig(context.Background(), &Config{Name: "node"}, nil))
+
+	// The node has not been started, so nothing is dialled and no event stream is restarted.
+	current := &Config{Name: "node", Addr: "http://localhost:5053", Headers: map[string]string{"Authorization": "secret"}}
+
+	reloaded := *options
+	reloaded.HealthCheck.Interval = human.Duration{Duration: time.Minute}
+	reloaded.BeaconSubscription.Topics = EventTopics{topicHead}
+
+	require.NoError(t, n.ReloadConfig(context.Background(), current, &reloaded))
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "http://localhost:5052", event.Previous.Addr)
+		assert.Equal(t, current, event.Current)
+		assert.False(t, event.Redialled)
+		assert.False(t, event.Resubscribed)
+	case <-time.After(time.Second):
+		t.Fatal("config reloaded event was not published")
+	}
+
+	assert.Equal(t, current, n.currentConfig())
+	assert.Equal(t, time.Minute, n.currentOptions().HealthCheck.Interval.Duration)
+	assert.Equal(t, EventTopics{topicHead}, n.currentOptions().BeaconSubscription.Topics)
+
+	// The options are replaced rather than modified, so readers holding the previous options are unaffected.
+	assert.NotEqual(t, time.Minute, options.HealthCheck.Interval.Duration)
+}
+
+func TestReloadConfigRedial(t *testing.T) {
+	first := httptest.NewServer(simulator.New(simulator.DefaultOptions()).Handler())
+	defer first.Close()
+
+	second := httptest.NewServer(simulator.New(simulator.DefaultOptions()).Handler())
+	defer second.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n := &node{
+		log:          logging.New(nil),
+		broker:       emission.NewEmitter(),
+		ctx:          ctx,
+		config:       &Config{Name: "node", Addr: first.URL},
+		options:      DefaultOptions(),
+		capabilities: newCapabilities(),
+	}
+
+	client, apiClient, cancelClients, err := n.newClients(ctx, n.currentConfig())
+	require.NoError(t, err)
+
+	n.setClients(client, apiClient, cancelClients)
+
+	defer cancelClients()
+
+	clientCtx, cancelClientCtx := context.WithCancel(context.Background())
+	n.cancelClients = cancelClientCtx
+
+	n.capabilities.set(EndpointDepositSnapshot, false)
+
+	require.NoError(t, n.ReloadConfig(context.Background(), &Config{Name: "node", Addr: second.URL}, nil))
+
+	// Both clients are replaced, the previous eth2 client is closed and the capabilities are probed again.
+	assert.NotSame(t, client, n.currentClient())
+	assert.NotEqual(t, apiClient, n.currentAPI())
+	assert.Error(t, clientCtx.Err())
+	assert.True(t, n.SupportsEndpoint(EndpointDepositSnapshot))
+}
+
+func TestReloadConfigReschedulesJobs(t *testing.T) {
+	options := DefaultOptions()
+
+	n := &node{
+		log:     logging.New(nil),
+		broker:  emission.NewEmitter(),
+		config:  &Config{Name: "node", Addr: "http://localhost:5052"},
+		options: options,
+		crons:   gocron.NewScheduler(time.Local),
+	}
+
+	n.cronJobs = map[string]*cronJob{}
+
+	for name, interval := range jobIntervals(options) {
+		job, err := n.crons.Every(interval.String()).Do(func() {})
+		require.NoError(t, err)
+
+		n.cronJobs[name] = &cronJob{job: job, task: func() {}}
+	}
+
+	peers := n.cronJobs[jobPeers].job
+	syncStatus := n.cronJobs[jobSyncStatus].job
+
+	reloaded := *options
+	reloaded.Polling.Peers = human.Duration{Duration: 2 * time.Minute}
+
+	require.NoError(t, n.ReloadConfig(context.Background(), n.currentConfig(), &reloaded))
+
+	// Only the job whose interval changed is replaced.
+	assert.NotSame(t, peers, n.cronJobs[jobPeers].job)
+	assert.Same(t, syncStatus, n.cronJobs[jobSyncStatus].job)
+	assert.Equal(t, len(n.cronJobs), n.crons.Len())
+	assert.Equal(t, 2*time.Minute, n.currentOptions().Polling.Peers.Duration)
+}
+
+func TestRestartBeaconSubscription(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	n := &node{ctx: ctx}
+
+	assert.False(t, n.restartBeaconSubscription())
+
+	streamCtx, cancelStream := context.WithCancel(context.Background())
+	n.cancelEvents = cancelStream
+
+	assert.True(t, n.restartBeaconSubscription())
+	assert.Error(t, streamCtx.Err())
+	assert.Nil(t, n.cancelEvents)
+}
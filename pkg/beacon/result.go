@@ -0,0 +1,59 @@
+package beacon
+
+import (
+	"strconv"
+)
+
+// ResponseMetadata holds the flags the beacon node returns alongside block and state data.
+type ResponseMetadata struct {
+	// ExecutionOptimistic is true if the data references an execution payload that has not yet been
+	// fully verified by the execution client.
+	ExecutionOptimistic bool
+	// Finalized is true if the data is from the finalized part of the chain.
+	Finalized bool
+}
+
+// FetchResult wraps fetched data together with the metadata of the response.
+type FetchResult[T any] struct {
+	ResponseMetadata
+
+	Data T
+}
+
+// parseResponseMetadata extracts the execution_optimistic and finalized flags from the metadata of a
+// go-eth2-client response. JSON responses carry the flags in the body, while SSZ responses only carry them
+// in the Eth-Execution-Optimistic and Eth-Finalized headers, which go-eth2-client passes on as metadata
+// keyed by header name. Missing or unparsable flags are reported as false.
+func parseResponseMetadata(metadata map[string]any) ResponseMetadata {
+	return ResponseMetadata{
+		ExecutionOptimistic: metadataBool(metadata, "execution_optimistic", "Eth-Execution-Optimistic"),
+		Finalized:           metadataBool(metadata, "finalized", "Eth-Finalized"),
+	}
+}
+
+// metadataBool returns the flag stored under the first of the keys present in the metadata.
+func metadataBool(metadata map[string]any, keys ...string) bool {
+	for _, key := range keys {
+		if _, exists := metadata[key]; exists {
+			return parseMetadataBool(metadata[key])
+		}
+	}
+
+	return false
+}
+
+func parseMetadataBool(raw any) bool {
+	switch value := raw.(type) {
+	case bool:
+		return value
+	case string:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return false
+		}
+
+		return parsed
+	default:
+		return false
+	}
+}
@@ -0,0 +1,61 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseResponseMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]any
+		expected ResponseMetadata
+	}{
+		{
+			name:     "nil metadata",
+			metadata: nil,
+			expected: ResponseMetadata{},
+		},
+		{
+			name: "bool flags",
+			metadata: map[string]any{
+				"execution_optimistic": true,
+				"finalized":            true,
+			},
+			expected: ResponseMetadata{ExecutionOptimistic: true, Finalized: true},
+		},
+		{
+			name: "string flags",
+			metadata: map[string]any{
+				"execution_optimistic": "true",
+				"finalized":            "false",
+			},
+			expected: ResponseMetadata{ExecutionOptimistic: true},
+		},
+		{
+			name: "ssz header flags",
+			metadata: map[string]any{
+				"Content-Type":             "application/octet-stream",
+				"Eth-Consensus-Version":    "deneb",
+				"Eth-Execution-Optimistic": "true",
+				"Eth-Finalized":            "true",
+			},
+			expected: ResponseMetadata{ExecutionOptimistic: true, Finalized: true},
+		},
+		{
+			name: "invalid flags",
+			metadata: map[string]any{
+				"execution_optimistic": "maybe",
+				"finalized":            1,
+			},
+			expected: ResponseMetadata{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseResponseMetadata(tt.metadata))
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package beacon
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// eventSampler decides which events of a busy topic are processed. Events are first sampled 1-in-N and then
+// rate limited per second.
+type eventSampler struct {
+	mu sync.Mutex
+
+	every     uint64
+	perSecond int
+
+	seen        uint64
+	windowStart time.Time
+	windowCount int
+
+	sampledOut atomic.Uint64
+}
+
+func newEventSampler(every uint64, perSecond int) *eventSampler {
+	return &eventSampler{
+		every:     every,
+		perSecond: perSecond,
+	}
+}
+
+// sample returns true if the event should be processed, and counts it as sampled out otherwise.
+func (s *eventSampler) sample(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seen++
+
+	if s.every > 1 && (s.seen-1)%s.every != 0 {
+		s.sampledOut.Add(1)
+
+		return false
+	}
+
+	if s.perSecond > 0 {
+		if now.Sub(s.windowStart) >= time.Second {
+			s.windowStart = now
+			s.windowCount = 0
+		}
+
+		if s.windowCount >= s.perSecond {
+			s.sampledOut.Add(1)
+
+			return false
+		}
+
+		s.windowCount++
+	}
+
+	return true
+}
+
+// SampledOutAttestations returns the number of attestation events that were dropped by attestation
+// sampling.
+func (n *node) SampledOutAttestations() uint64 {
+	if n.attestationSampler == nil {
+		return 0
+	}
+
+	return n.attestationSampler.sampledOut.Load()
+}
@@ -0,0 +1,65 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSampler(t *testing.T) {
+	now := time.Now()
+
+	t.Run("one in n", func(t *testing.T) {
+		s := newEventSampler(3, 0)
+
+		kept := 0
+
+		for i := 0; i < 9; i++ {
+			if s.sample(now) {
+				kept++
+			}
+		}
+
+		assert.Equal(t, 3, kept)
+		assert.Equal(t, uint64(6), s.sampledOut.Load())
+	})
+
+	t.Run("per second", func(t *testing.T) {
+		s := newEventSampler(0, 2)
+
+		assert.True(t, s.sample(now))
+		assert.True(t, s.sample(now.Add(100*time.Millisecond)))
+		assert.False(t, s.sample(now.Add(200*time.Millisecond)))
+
+		// The limit resets after a second.
+		assert.True(t, s.sample(now.Add(time.Second)))
+		assert.Equal(t, uint64(1), s.sampledOut.Load())
+	})
+}
+
+func TestHandleEventSamplesAttestations(t *testing.T) {
+	options := DefaultOptions().WithAttestationSampling(2, 0)
+
+	n := &node{
+		log:                logging.New(nil),
+		broker:             emission.NewEmitter(),
+		options:            options,
+		attestationSampler: newEventSampler(options.AttestationSampling.Every, options.AttestationSampling.PerSecond),
+	}
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, n.handleEvent(context.Background(), &v1.Event{
+			Topic: topicAttestation,
+			Data:  &phase0.Attestation{},
+		}))
+	}
+
+	assert.Equal(t, uint64(2), n.SampledOutAttestations())
+}
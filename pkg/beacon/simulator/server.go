@@ -0,0 +1,278 @@
+package simulator
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// subscriberBuffer is the number of events buffered for a subscriber before Advance blocks on it.
+const subscriberBuffer = 1024
+
+type subscriber struct {
+	topics map[string]bool
+	events chan *Event
+	done   chan struct{}
+}
+
+// subscribe registers a subscriber for the given topics. The returned function unsubscribes it.
+func (s *Simulator) subscribe(topics []string) (*subscriber, func()) {
+	sub := &subscriber{
+		topics: make(map[string]bool, len(topics)),
+		events: make(chan *Event, subscriberBuffer),
+		done:   make(chan struct{}),
+	}
+
+	for _, topic := range topics {
+		sub.topics[topic] = true
+	}
+
+	s.subscribersMu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.subscribersMu.Unlock()
+
+	return sub, func() {
+		s.subscribersMu.Lock()
+		delete(s.subscribers, sub)
+		s.subscribersMu.Unlock()
+
+		close(sub.done)
+	}
+}
+
+// publish delivers the event to every subscriber of its topic. Subscribers are never skipped, so a slow
+// subscriber slows down the simulation instead of missing events.
+func (s *Simulator) publish(event *Event) {
+	s.subscribersMu.Lock()
+	subscribers := make([]*subscriber, 0, len(s.subscribers))
+
+	for sub := range s.subscribers {
+		if sub.topics[event.Topic] {
+			subscribers = append(subscribers, sub)
+		}
+	}
+	s.subscribersMu.Unlock()
+
+	for _, sub := range subscribers {
+		select {
+		case sub.events <- event:
+		case <-sub.done:
+		}
+	}
+}
+
+// Subscribers returns the number of connected event stream subscribers.
+func (s *Simulator) Subscribers() int {
+	s.subscribersMu.Lock()
+	defer s.subscribersMu.Unlock()
+
+	return len(s.subscribers)
+}
+
+// Run advances the chain every interval until the context is cancelled. An interval shorter than
+// SecondsPerSlot runs the simulation accelerated.
+func (s *Simulator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Advance()
+		}
+	}
+}
+
+// Handler returns an http.Handler that serves the simulated chain over the subset of the beacon API needed
+// to bootstrap a beacon node and follow its event stream.
+func (s *Simulator) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /eth/v1/events", s.handleEvents)
+	mux.HandleFunc("GET /eth/v1/beacon/genesis", s.handleGenesis)
+	mux.HandleFunc("GET /eth/v1/config/spec", s.handleSpec)
+	mux.HandleFunc("GET /eth/v1/node/version", s.handleNodeVersion)
+	mux.HandleFunc("GET /eth/v1/node/syncing", s.handleNodeSyncing)
+	mux.HandleFunc("GET /eth/v1/node/peers", s.handleNodePeers)
+	mux.HandleFunc("GET /eth/v1/beacon/states/{state_id}/finality_checkpoints", s.handleFinality)
+	mux.HandleFunc("GET /eth/v1/beacon/headers/{block_id}", s.handleHeader)
+
+	return mux
+}
+
+func (s *Simulator) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+
+		return
+	}
+
+	var topics []string
+	for _, value := range r.URL.Query()["topics"] {
+		topics = append(topics, strings.Split(value, ",")...)
+	}
+
+	sub, unsubscribe := s.subscribe(topics)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-sub.events:
+			data, err := json.Marshal(event.Data)
+			if err != nil {
+				return
+			}
+
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Topic, data); err != nil {
+				return
+			}
+
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Simulator) handleGenesis(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, map[string]string{
+		"genesis_time":            strconv.FormatInt(s.options.GenesisTime.Unix(), 10),
+		"genesis_validators_root": hexRoot(s.genesisValidatorsRoot()),
+		"genesis_fork_version":    "0x00000000",
+	})
+}
+
+func (s *Simulator) handleSpec(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, map[string]string{
+		"CONFIG_NAME":                      "simulator",
+		"PRESET_BASE":                      "mainnet",
+		"SECONDS_PER_SLOT":                 strconv.FormatUint(s.options.SecondsPerSlot, 10),
+		"SLOTS_PER_EPOCH":                  strconv.FormatUint(s.options.SlotsPerEpoch, 10),
+		"GENESIS_FORK_VERSION":             "0x00000000",
+		"MIN_GENESIS_TIME":                 strconv.FormatInt(s.options.GenesisTime.Unix(), 10),
+		"GENESIS_DELAY":                    "0",
+		"ALTAIR_FORK_EPOCH":                "18446744073709551615",
+		"ALTAIR_FORK_VERSION":              "0x01000000",
+		"BELLATRIX_FORK_EPOCH":             "18446744073709551615",
+		"BELLATRIX_FORK_VERSION":           "0x02000000",
+		"CAPELLA_FORK_EPOCH":               "18446744073709551615",
+		"CAPELLA_FORK_VERSION":             "0x03000000",
+		"DENEB_FORK_EPOCH":                 "18446744073709551615",
+		"DENEB_FORK_VERSION":               "0x04000000",
+		"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": "256",
+	})
+}
+
+func (s *Simulator) handleNodeVersion(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, map[string]string{
+		"version": "Simulator/v0.0.0",
+	})
+}
+
+func (s *Simulator) handleNodeSyncing(w http.ResponseWriter, _ *http.Request) {
+	head := s.Head()
+
+	writeData(w, map[string]any{
+		"head_slot":     strconv.FormatUint(uint64(head.Slot), 10),
+		"sync_distance": "0",
+		"is_syncing":    false,
+		"is_optimistic": false,
+		"el_offline":    false,
+	})
+}
+
+func (s *Simulator) handleNodePeers(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, []any{})
+}
+
+func (s *Simulator) handleFinality(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("state_id") != "head" {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	writeData(w, s.Finality())
+}
+
+func (s *Simulator) handleHeader(w http.ResponseWriter, r *http.Request) {
+	block := s.resolveBlock(r.PathValue("block_id"))
+	if block == nil {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	writeData(w, &v1.BeaconBlockHeader{
+		Root:      block.Root,
+		Canonical: s.CanonicalBlock(block.Slot) == block,
+		Header: &phase0.SignedBeaconBlockHeader{
+			Message: &phase0.BeaconBlockHeader{
+				Slot:          block.Slot,
+				ProposerIndex: block.ProposerIndex,
+				ParentRoot:    block.ParentRoot,
+				StateRoot:     block.StateRoot,
+				BodyRoot:      block.BodyRoot,
+			},
+		},
+	})
+}
+
+// resolveBlock resolves a block id of the beacon API to a block, or nil if there is none.
+func (s *Simulator) resolveBlock(id string) *Block {
+	switch id {
+	case "head":
+		return s.Head()
+	case "genesis":
+		return s.CanonicalBlock(0)
+	case "finalized":
+		return s.Block(s.Finality().Finalized.Root)
+	case "justified":
+		return s.Block(s.Finality().Justified.Root)
+	}
+
+	if strings.HasPrefix(id, "0x") {
+		raw, err := hex.DecodeString(strings.TrimPrefix(id, "0x"))
+		if err != nil || len(raw) != len(phase0.Root{}) {
+			return nil
+		}
+
+		return s.Block(phase0.Root(raw))
+	}
+
+	slot, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return s.CanonicalBlock(phase0.Slot(slot))
+}
+
+func (s *Simulator) genesisValidatorsRoot() phase0.Root {
+	return stateRoot(s.CanonicalBlock(0).Root)
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+func hexRoot(root phase0.Root) string {
+	return fmt.Sprintf("%#x", root)
+}
@@ -0,0 +1,360 @@
+// Package simulator simulates the chain progression of a beacon node and serves it over the beacon API, so
+// consumers of the beacon package can soak-test their event handlers deterministically. Given the same seed,
+// a simulator produces the same sequence of blocks, reorgs and finalized checkpoints.
+package simulator
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Options are the options of a simulator.
+type Options struct {
+	// Seed seeds the random source that decides missed slots and reorgs.
+	Seed int64
+	// GenesisTime is the time of slot 0.
+	GenesisTime time.Time
+	// SecondsPerSlot is the SECONDS_PER_SLOT of the simulated spec.
+	SecondsPerSlot uint64
+	// SlotsPerEpoch is the SLOTS_PER_EPOCH of the simulated spec.
+	SlotsPerEpoch uint64
+	// Validators is the number of validators that take turns proposing blocks.
+	Validators uint64
+	// FinalityEpochs is the number of epochs between finalized checkpoints.
+	FinalityEpochs uint64
+	// MissedSlotRate is the probability that a slot has no block.
+	MissedSlotRate float64
+	// ReorgRate is the probability that a block orphans the previous head instead of building on it.
+	ReorgRate float64
+}
+
+// DefaultOptions returns the default options of a simulator.
+func DefaultOptions() Options {
+	return Options{
+		Seed:           1,
+		GenesisTime:    time.Unix(1606824023, 0),
+		SecondsPerSlot: 12,
+		SlotsPerEpoch:  32,
+		Validators:     64,
+		FinalityEpochs: 2,
+		MissedSlotRate: 0.05,
+		ReorgRate:      0.02,
+	}
+}
+
+// Block is a block produced by the simulator.
+type Block struct {
+	Slot          phase0.Slot
+	ProposerIndex phase0.ValidatorIndex
+	Root          phase0.Root
+	ParentRoot    phase0.Root
+	StateRoot     phase0.Root
+	BodyRoot      phase0.Root
+}
+
+// Event is an event emitted by the simulator. Data is one of the go-eth2-client event types and is encoded as
+// the beacon API would encode it.
+type Event struct {
+	Topic string
+	Data  any
+}
+
+// Topics of the events emitted by the simulator.
+const (
+	TopicBlock               = "block"
+	TopicHead                = "head"
+	TopicChainReorg          = "chain_reorg"
+	TopicFinalizedCheckpoint = "finalized_checkpoint"
+)
+
+// Simulator is a simulated beacon chain. It only progresses when Advance is called, or while Run is running.
+type Simulator struct {
+	options Options
+
+	// advanceMu serializes Advance so subscribers receive the events of every slot in order.
+	advanceMu sync.Mutex
+
+	mu     sync.Mutex
+	rand   *rand.Rand
+	slot   phase0.Slot
+	head   *Block
+	blocks map[phase0.Root]*Block
+	// canonical is the canonical block of every slot that has one.
+	canonical map[phase0.Slot]*Block
+
+	justified *phase0.Checkpoint
+	finalized *phase0.Checkpoint
+
+	subscribersMu sync.Mutex
+	subscribers   map[*subscriber]struct{}
+}
+
+// New creates a new simulator at slot 0 with only the genesis block.
+func New(options Options) *Simulator {
+	genesis := &Block{}
+	genesis.Root = blockRoot(genesis)
+	genesis.StateRoot = stateRoot(genesis.Root)
+
+	checkpoint := &phase0.Checkpoint{Epoch: 0, Root: genesis.Root}
+
+	return &Simulator{
+		options:     options,
+		rand:        rand.New(rand.NewSource(options.Seed)), //nolint:gosec // determinism, not security
+		head:        genesis,
+		blocks:      map[phase0.Root]*Block{genesis.Root: genesis},
+		canonical:   map[phase0.Slot]*Block{0: genesis},
+		justified:   checkpoint,
+		finalized:   checkpoint,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Options returns the options of the simulator.
+func (s *Simulator) Options() Options {
+	return s.options
+}
+
+// Slot returns the current slot.
+func (s *Simulator) Slot() phase0.Slot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.slot
+}
+
+// Head returns the current head block.
+func (s *Simulator) Head() *Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.head
+}
+
+// Block returns the block with the given root, or nil if the simulator did not produce it.
+func (s *Simulator) Block(root phase0.Root) *Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.blocks[root]
+}
+
+// CanonicalBlock returns the canonical block at the given slot, or nil if the slot is empty.
+func (s *Simulator) CanonicalBlock(slot phase0.Slot) *Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.canonical[slot]
+}
+
+// Finality returns the current finality checkpoints.
+func (s *Simulator) Finality() *v1.Finality {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	justified := *s.justified
+	finalized := *s.finalized
+
+	return &v1.Finality{
+		Finalized:         &finalized,
+		Justified:         &justified,
+		PreviousJustified: &justified,
+	}
+}
+
+// Advance moves the chain to the next slot and returns the events of that slot in the order they were
+// emitted to subscribers.
+func (s *Simulator) Advance() []*Event {
+	s.advanceMu.Lock()
+	defer s.advanceMu.Unlock()
+
+	s.mu.Lock()
+	events := s.advance()
+	s.mu.Unlock()
+
+	for _, event := range events {
+		s.publish(event)
+	}
+
+	return events
+}
+
+// AdvanceTo advances the chain until it reaches the given slot and returns all events emitted on the way.
+func (s *Simulator) AdvanceTo(slot phase0.Slot) []*Event {
+	var events []*Event
+
+	for s.Slot() < slot {
+		events = append(events, s.Advance()...)
+	}
+
+	return events
+}
+
+func (s *Simulator) advance() []*Event {
+	s.slot++
+
+	slotsPerEpoch := phase0.Slot(s.options.SlotsPerEpoch)
+	epoch := phase0.Epoch(s.slot / slotsPerEpoch)
+	epochTransition := s.slot%slotsPerEpoch == 0
+
+	var events []*Event
+
+	if epochTransition {
+		events = append(events, s.checkpoint(epoch)...)
+	}
+
+	// Draw both numbers every slot so the sequence does not depend on which branch is taken.
+	missed := s.rand.Float64() < s.options.MissedSlotRate
+	reorg := s.rand.Float64() < s.options.ReorgRate
+
+	if missed {
+		return events
+	}
+
+	parent := s.head
+	oldHead := s.head
+
+	// A reorg orphans the head, as long as the head is newer than the justified checkpoint.
+	if reorg && s.head.Slot > s.startSlot(s.justified.Epoch) {
+		parent = s.blocks[s.head.ParentRoot]
+	}
+
+	block := &Block{
+		Slot:          s.slot,
+		ProposerIndex: phase0.ValidatorIndex(uint64(s.slot) % s.options.Validators),
+		ParentRoot:    parent.Root,
+	}
+	block.Root = blockRoot(block)
+	block.StateRoot = stateRoot(block.Root)
+	block.BodyRoot = bodyRoot(block.Root)
+
+	s.blocks[block.Root] = block
+	s.head = block
+
+	if parent != oldHead {
+		delete(s.canonical, oldHead.Slot)
+	}
+
+	s.canonical[block.Slot] = block
+
+	events = append(events, &Event{
+		Topic: TopicBlock,
+		Data: &v1.BlockEvent{
+			Slot:  block.Slot,
+			Block: block.Root,
+		},
+	})
+
+	if parent != oldHead {
+		events = append(events, &Event{
+			Topic: TopicChainReorg,
+			Data: &v1.ChainReorgEvent{
+				Slot:         block.Slot,
+				Depth:        1,
+				OldHeadBlock: oldHead.Root,
+				NewHeadBlock: block.Root,
+				OldHeadState: oldHead.StateRoot,
+				NewHeadState: block.StateRoot,
+				Epoch:        epoch,
+			},
+		})
+	}
+
+	events = append(events, &Event{
+		Topic: TopicHead,
+		Data: &v1.HeadEvent{
+			Slot:                      block.Slot,
+			Block:                     block.Root,
+			State:                     block.StateRoot,
+			EpochTransition:           epochTransition,
+			CurrentDutyDependentRoot:  s.dependentRoot(epoch),
+			PreviousDutyDependentRoot: s.dependentRoot(epoch - min(epoch, 1)),
+		},
+	})
+
+	return events
+}
+
+// checkpoint justifies the previous epoch at the start of every epoch and finalizes the epoch before that
+// every FinalityEpochs epochs.
+func (s *Simulator) checkpoint(epoch phase0.Epoch) []*Event {
+	if epoch < 2 {
+		return nil
+	}
+
+	s.justified = &phase0.Checkpoint{
+		Epoch: epoch - 1,
+		Root:  s.checkpointRoot(epoch - 1),
+	}
+
+	if s.options.FinalityEpochs == 0 || uint64(epoch)%s.options.FinalityEpochs != 0 {
+		return nil
+	}
+
+	root := s.checkpointRoot(epoch - 2)
+
+	s.finalized = &phase0.Checkpoint{
+		Epoch: epoch - 2,
+		Root:  root,
+	}
+
+	return []*Event{{
+		Topic: TopicFinalizedCheckpoint,
+		Data: &v1.FinalizedCheckpointEvent{
+			Block: root,
+			State: s.blocks[root].StateRoot,
+			Epoch: epoch - 2,
+		},
+	}}
+}
+
+// dependentRoot returns the root of the last canonical block before the start of the given epoch.
+func (s *Simulator) dependentRoot(epoch phase0.Epoch) phase0.Root {
+	start := s.startSlot(epoch)
+	if start == 0 {
+		return s.canonical[0].Root
+	}
+
+	return s.canonicalAtOrBefore(start - 1).Root
+}
+
+// checkpointRoot returns the root of the checkpoint of the given epoch, the last canonical block at or before
+// the start of the epoch.
+func (s *Simulator) checkpointRoot(epoch phase0.Epoch) phase0.Root {
+	return s.canonicalAtOrBefore(s.startSlot(epoch)).Root
+}
+
+func (s *Simulator) canonicalAtOrBefore(slot phase0.Slot) *Block {
+	for ; slot > 0; slot-- {
+		if block, ok := s.canonical[slot]; ok {
+			return block
+		}
+	}
+
+	return s.canonical[0]
+}
+
+func (s *Simulator) startSlot(epoch phase0.Epoch) phase0.Slot {
+	return phase0.Slot(uint64(epoch) * s.options.SlotsPerEpoch)
+}
+
+func blockRoot(block *Block) phase0.Root {
+	buf := make([]byte, 8, 8+len(block.ParentRoot))
+	binary.LittleEndian.PutUint64(buf, uint64(block.Slot))
+	buf = append(buf, block.ParentRoot[:]...)
+
+	return sha256.Sum256(buf)
+}
+
+func stateRoot(root phase0.Root) phase0.Root {
+	return sha256.Sum256(append([]byte("state"), root[:]...))
+}
+
+func bodyRoot(root phase0.Root) phase0.Root {
+	return sha256.Sum256(append([]byte("body"), root[:]...))
+}
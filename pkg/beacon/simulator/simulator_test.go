@@ -0,0 +1,163 @@
+package simulator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulatorDeterministic(t *testing.T) {
+	a := New(DefaultOptions())
+	b := New(DefaultOptions())
+
+	assert.Equal(t, a.AdvanceTo(320), b.AdvanceTo(320))
+	assert.Equal(t, a.Head(), b.Head())
+
+	options := DefaultOptions()
+	options.Seed = 2
+
+	c := New(options)
+	c.AdvanceTo(320)
+
+	assert.NotEqual(t, a.Head(), c.Head())
+}
+
+func TestSimulatorFinality(t *testing.T) {
+	options := DefaultOptions()
+	options.SlotsPerEpoch = 8
+	options.MissedSlotRate = 0
+
+	s := New(options)
+
+	var finalized []phase0.Epoch
+
+	for _, event := range s.AdvanceTo(8 * 8) {
+		if event.Topic == TopicFinalizedCheckpoint {
+			finalized = append(finalized, event.Data.(*v1.FinalizedCheckpointEvent).Epoch)
+		}
+	}
+
+	// Finality advances every 2 epochs.
+	assert.Equal(t, []phase0.Epoch{0, 2, 4, 6}, finalized)
+
+	finality := s.Finality()
+	assert.Equal(t, phase0.Epoch(6), finality.Finalized.Epoch)
+	assert.Equal(t, phase0.Epoch(7), finality.Justified.Epoch)
+	assert.Equal(t, s.CanonicalBlock(6*8).Root, finality.Finalized.Root)
+}
+
+func TestSimulatorReorg(t *testing.T) {
+	options := DefaultOptions()
+	options.MissedSlotRate = 0
+	options.ReorgRate = 1
+
+	s := New(options)
+	s.Advance()
+
+	first := s.Head()
+
+	events := s.Advance()
+	require.Len(t, events, 3)
+	assert.Equal(t, TopicBlock, events[0].Topic)
+	assert.Equal(t, TopicChainReorg, events[1].Topic)
+	assert.Equal(t, TopicHead, events[2].Topic)
+
+	reorg := events[1].Data.(*v1.ChainReorgEvent)
+	assert.Equal(t, first.Root, reorg.OldHeadBlock)
+	assert.Equal(t, s.Head().Root, reorg.NewHeadBlock)
+
+	// The orphaned block is no longer canonical and the new head builds on genesis.
+	assert.Nil(t, s.CanonicalBlock(1))
+	assert.Equal(t, s.CanonicalBlock(0).Root, s.Head().ParentRoot)
+}
+
+func TestSimulatorEventStream(t *testing.T) {
+	s := New(DefaultOptions())
+
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := api.NewConsensusClient(ctx, logging.New(nil), server.URL, http.Client{}, nil)
+
+	received := make(chan *api.Event, 16)
+
+	go func() {
+		_ = client.Events(ctx, []string{TopicHead}, func(event *api.Event) {
+			received <- event
+		})
+	}()
+
+	require.Eventually(t, func() bool { return s.Subscribers() == 1 }, time.Second, 10*time.Millisecond)
+
+	s.Advance()
+
+	select {
+	case event := <-received:
+		assert.Equal(t, TopicHead, event.Topic)
+
+		head := &v1.HeadEvent{}
+		require.NoError(t, head.UnmarshalJSON(event.Data))
+		assert.Equal(t, s.Head().Root, head.Block)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for head event")
+	}
+}
+
+func TestSimulatorDrivesNode(t *testing.T) {
+	options := DefaultOptions()
+	// Keep the simulated wallclock in step with the simulated chain.
+	options.GenesisTime = time.Now()
+
+	s := New(options)
+
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts := *beacon.DefaultOptions()
+	opts.PrometheusMetrics = false
+	opts.BeaconSubscription.Enable()
+	opts.BeaconSubscription.Topics = []string{TopicHead}
+
+	node := beacon.NewNode(logging.New(nil), &beacon.Config{Name: "simulator", Addr: server.URL}, "simulator", opts)
+
+	heads := make(chan *v1.HeadEvent, 16)
+
+	node.OnHead(ctx, func(ctx context.Context, event *v1.HeadEvent) error {
+		heads <- event
+
+		return nil
+	})
+
+	require.NoError(t, node.Start(ctx))
+
+	defer func() {
+		_ = node.Stop(ctx)
+	}()
+
+	require.Eventually(t, func() bool { return s.Subscribers() > 0 }, 10*time.Second, 10*time.Millisecond)
+
+	s.Advance()
+
+	select {
+	case head := <-heads:
+		assert.Equal(t, s.Head().Root, head.Block)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for head event")
+	}
+}
@@ -0,0 +1,30 @@
+package beacon
+
+import (
+	"context"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// singleFlight executes fn for the given key, sharing the result with any concurrent callers
+// that request the same key while the call is still in flight. fn runs with a context that is
+// not cancelled with the caller's, so a caller giving up does not fail the call for the others;
+// each caller stops waiting as soon as its own context is done.
+func singleFlight[T any](ctx context.Context, group *singleflight.Group, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+
+	ch := group.DoChan(key, func() (interface{}, error) {
+		return fn(context.WithoutCancel(ctx))
+	})
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case result := <-ch:
+		if result.Err != nil {
+			return zero, result.Err
+		}
+
+		return result.Val.(T), nil
+	}
+}
@@ -0,0 +1,44 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/singleflight"
+)
+
+func TestSingleFlightCallerCancellation(t *testing.T) {
+	group := &singleflight.Group{}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	callErr := make(chan error, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	result := make(chan error, 1)
+
+	go func() {
+		_, err := singleFlight(ctx, group, "key", func(ctx context.Context) (int, error) {
+			close(started)
+			<-release
+
+			callErr <- ctx.Err()
+
+			return 1, nil
+		})
+
+		result <- err
+	}()
+
+	<-started
+
+	// The caller stops waiting once its context is done, while the shared call carries on.
+	cancel()
+	require.ErrorIs(t, <-result, context.Canceled)
+
+	close(release)
+	assert.NoError(t, <-callErr)
+}
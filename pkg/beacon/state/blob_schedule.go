@@ -0,0 +1,126 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cast"
+)
+
+// BlobScheduleEntry changes the maximum number of blobs per block from an epoch onwards. Entries activate
+// without a named fork (blob parameter only forks).
+type BlobScheduleEntry struct {
+	Epoch            phase0.Epoch `json:"EPOCH,string"`
+	MaxBlobsPerBlock uint64       `json:"MAX_BLOBS_PER_BLOCK,string"`
+}
+
+// BlobSchedule is the BLOB_SCHEDULE of the spec, sorted by epoch.
+type BlobSchedule []*BlobScheduleEntry
+
+// Active returns the entry that is active at the given epoch, or nil if no entry is active yet.
+func (b BlobSchedule) Active(epoch phase0.Epoch) *BlobScheduleEntry {
+	i := sort.Search(len(b), func(i int) bool {
+		return b[i].Epoch > epoch
+	})
+
+	if i == 0 {
+		return nil
+	}
+
+	return b[i-1]
+}
+
+// BlobParameters returns the blob parameters that are active at the given epoch. Before the first blob schedule
+// entry the Electra parameters apply.
+func (s *Spec) BlobParameters(epoch phase0.Epoch) BlobScheduleEntry {
+	if entry := s.BlobSchedule.Active(epoch); entry != nil {
+		return *entry
+	}
+
+	return BlobScheduleEntry{
+		Epoch:            s.ElectraForkEpoch,
+		MaxBlobsPerBlock: s.MaxBlobsPerBlockElectra,
+	}
+}
+
+// NextBlobLimitChange returns the first entry after the given epoch that changes the maximum number of blobs per
+// block, or nil if there is none.
+func (b BlobSchedule) NextBlobLimitChange(epoch phase0.Epoch) *BlobScheduleEntry {
+	current := b.Active(epoch)
+
+	for _, entry := range b {
+		if entry.Epoch <= epoch {
+			continue
+		}
+
+		if current == nil || entry.MaxBlobsPerBlock != current.MaxBlobsPerBlock {
+			return entry
+		}
+	}
+
+	return nil
+}
+
+// parseBlobSchedule parses the BLOB_SCHEDULE of a raw spec, which is either a list of objects or its JSON
+// encoding. The returned schedule is sorted by epoch and an error is returned if two entries share an epoch.
+func parseBlobSchedule(raw any) (BlobSchedule, error) {
+	if encoded, ok := raw.(string); ok {
+		var entries []map[string]any
+		if err := json.Unmarshal([]byte(encoded), &entries); err != nil {
+			return nil, fmt.Errorf("invalid blob schedule: %w", err)
+		}
+
+		raw = entries
+	}
+
+	var items []map[string]any
+
+	switch v := raw.(type) {
+	case []map[string]any:
+		items = v
+	case []any:
+		for _, item := range v {
+			m, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid blob schedule entry of type %T", item)
+			}
+
+			items = append(items, m)
+		}
+	default:
+		return nil, fmt.Errorf("invalid blob schedule of type %T", raw)
+	}
+
+	schedule := make(BlobSchedule, 0, len(items))
+
+	for _, item := range items {
+		epoch, err := cast.ToUint64E(item["EPOCH"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid blob schedule epoch: %w", err)
+		}
+
+		maxBlobs, err := cast.ToUint64E(item["MAX_BLOBS_PER_BLOCK"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid blob schedule max blobs per block: %w", err)
+		}
+
+		schedule = append(schedule, &BlobScheduleEntry{
+			Epoch:            phase0.Epoch(epoch),
+			MaxBlobsPerBlock: maxBlobs,
+		})
+	}
+
+	sort.SliceStable(schedule, func(i, j int) bool {
+		return schedule[i].Epoch < schedule[j].Epoch
+	})
+
+	for i := 1; i < len(schedule); i++ {
+		if schedule[i].Epoch == schedule[i-1].Epoch {
+			return nil, fmt.Errorf("duplicate blob schedule entries for epoch %d", schedule[i].Epoch)
+		}
+	}
+
+	return schedule, nil
+}
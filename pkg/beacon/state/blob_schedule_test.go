@@ -0,0 +1,57 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSpecBlobSchedule(t *testing.T) {
+	sp := state.NewSpec(map[string]any{
+		"BLOB_SCHEDULE": []any{
+			map[string]any{"EPOCH": "300", "MAX_BLOBS_PER_BLOCK": "15"},
+			map[string]any{"EPOCH": "100", "MAX_BLOBS_PER_BLOCK": "9"},
+			map[string]any{"EPOCH": "200", "MAX_BLOBS_PER_BLOCK": "9"},
+		},
+	})
+
+	require.NoError(t, sp.Validate())
+	require.Len(t, sp.BlobSchedule, 3)
+
+	for i, epoch := range []phase0.Epoch{100, 200, 300} {
+		assert.Equal(t, epoch, sp.BlobSchedule[i].Epoch)
+	}
+
+	assert.Nil(t, sp.BlobSchedule.Active(99))
+	assert.Equal(t, uint64(9), sp.BlobSchedule.Active(250).MaxBlobsPerBlock)
+	assert.Equal(t, uint64(15), sp.BlobSchedule.Active(300).MaxBlobsPerBlock)
+
+	// Entries that do not change the limit are skipped.
+	assert.Equal(t, phase0.Epoch(100), sp.BlobSchedule.NextBlobLimitChange(0).Epoch)
+	assert.Equal(t, phase0.Epoch(300), sp.BlobSchedule.NextBlobLimitChange(100).Epoch)
+	assert.Nil(t, sp.BlobSchedule.NextBlobLimitChange(300))
+}
+
+func TestNewSpecBlobScheduleJSON(t *testing.T) {
+	sp := state.NewSpec(map[string]any{
+		"BLOB_SCHEDULE": `[{"EPOCH":"10","MAX_BLOBS_PER_BLOCK":"12"}]`,
+	})
+
+	require.NoError(t, sp.Validate())
+	assert.Equal(t, state.BlobSchedule{{Epoch: 10, MaxBlobsPerBlock: 12}}, sp.BlobSchedule)
+}
+
+func TestNewSpecBlobScheduleDuplicateEpochs(t *testing.T) {
+	sp := state.NewSpec(map[string]any{
+		"BLOB_SCHEDULE": []any{
+			map[string]any{"EPOCH": "100", "MAX_BLOBS_PER_BLOCK": "9"},
+			map[string]any{"EPOCH": "100", "MAX_BLOBS_PER_BLOCK": "12"},
+		},
+	})
+
+	assert.Error(t, sp.Validate())
+	assert.Empty(t, sp.BlobSchedule)
+}
@@ -0,0 +1,187 @@
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+var (
+	// DomainBeaconProposer is the domain type for beacon block proposals.
+	DomainBeaconProposer = phase0.DomainType{0x00, 0x00, 0x00, 0x00}
+	// DomainBeaconAttester is the domain type for attestations.
+	DomainBeaconAttester = phase0.DomainType{0x01, 0x00, 0x00, 0x00}
+	// DomainRandao is the domain type for randao reveals.
+	DomainRandao = phase0.DomainType{0x02, 0x00, 0x00, 0x00}
+	// DomainDeposit is the domain type for deposits.
+	DomainDeposit = phase0.DomainType{0x03, 0x00, 0x00, 0x00}
+	// DomainVoluntaryExit is the domain type for voluntary exits.
+	DomainVoluntaryExit = phase0.DomainType{0x04, 0x00, 0x00, 0x00}
+	// DomainSelectionProof is the domain type for aggregator selection proofs.
+	DomainSelectionProof = phase0.DomainType{0x05, 0x00, 0x00, 0x00}
+	// DomainAggregateAndProof is the domain type for aggregate and proofs.
+	DomainAggregateAndProof = phase0.DomainType{0x06, 0x00, 0x00, 0x00}
+	// DomainSyncCommittee is the domain type for sync committee messages.
+	DomainSyncCommittee = phase0.DomainType{0x07, 0x00, 0x00, 0x00}
+	// DomainSyncCommitteeSelectionProof is the domain type for sync committee selection proofs.
+	DomainSyncCommitteeSelectionProof = phase0.DomainType{0x08, 0x00, 0x00, 0x00}
+	// DomainContributionAndProof is the domain type for sync committee contribution and proofs.
+	DomainContributionAndProof = phase0.DomainType{0x09, 0x00, 0x00, 0x00}
+	// DomainBLSToExecutionChange is the domain type for BLS to execution changes.
+	DomainBLSToExecutionChange = phase0.DomainType{0x0a, 0x00, 0x00, 0x00}
+	// DomainApplicationBuilder is the domain type for builder API messages.
+	DomainApplicationBuilder = phase0.DomainType{0x00, 0x00, 0x00, 0x01}
+)
+
+// ComputeForkDataRoot returns the hash tree root of the fork data for the given fork version and genesis validators root.
+func ComputeForkDataRoot(version phase0.Version, genesisValidatorsRoot phase0.Root) (phase0.Root, error) {
+	forkData := &phase0.ForkData{
+		CurrentVersion:        version,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}
+
+	root, err := forkData.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("failed to compute fork data root: %w", err)
+	}
+
+	return root, nil
+}
+
+// ComputeForkDigest returns the fork digest for the given fork version and genesis validators root.
+func ComputeForkDigest(version phase0.Version, genesisValidatorsRoot phase0.Root) (phase0.ForkDigest, error) {
+	root, err := ComputeForkDataRoot(version, genesisValidatorsRoot)
+	if err != nil {
+		return phase0.ForkDigest{}, err
+	}
+
+	var digest phase0.ForkDigest
+
+	copy(digest[:], root[:4])
+
+	return digest, nil
+}
+
+// ComputeFuluForkDigest returns the fork digest for the given fork version and genesis validators root from Fulu
+// onwards (EIP-7892), where the fork data root is XORed with the hash of the active blob parameters so that
+// blob parameter only forks get a digest of their own.
+func ComputeFuluForkDigest(version phase0.Version, genesisValidatorsRoot phase0.Root, blobParameters BlobScheduleEntry) (phase0.ForkDigest, error) {
+	root, err := ComputeForkDataRoot(version, genesisValidatorsRoot)
+	if err != nil {
+		return phase0.ForkDigest{}, err
+	}
+
+	var params [16]byte
+
+	binary.LittleEndian.PutUint64(params[:8], uint64(blobParameters.Epoch))
+	binary.LittleEndian.PutUint64(params[8:], blobParameters.MaxBlobsPerBlock)
+
+	mask := sha256.Sum256(params[:])
+
+	var digest phase0.ForkDigest
+
+	for i := range digest {
+		digest[i] = root[i] ^ mask[i]
+	}
+
+	return digest, nil
+}
+
+// ComputeDomain returns the signing domain for the given domain type, fork version and genesis validators root.
+// Note that some domains (e.g. DomainDeposit and DomainApplicationBuilder) are always computed with the genesis
+// fork version and an empty genesis validators root.
+func ComputeDomain(domainType phase0.DomainType, version phase0.Version, genesisValidatorsRoot phase0.Root) (phase0.Domain, error) {
+	root, err := ComputeForkDataRoot(version, genesisValidatorsRoot)
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+
+	var domain phase0.Domain
+
+	copy(domain[:4], domainType[:])
+	copy(domain[4:], root[:28])
+
+	return domain, nil
+}
+
+// ForkDigest returns the fork digest of the fork that is active at the given epoch. From Fulu onwards the digest
+// also depends on the blob parameters that are active at the epoch.
+func (s *Spec) ForkDigest(epoch phase0.Epoch, genesisValidatorsRoot phase0.Root) (phase0.ForkDigest, error) {
+	version, err := s.ForkVersionAt(epoch)
+	if err != nil {
+		return phase0.ForkDigest{}, err
+	}
+
+	if s.FuluForkVersion != "" && epoch >= s.FuluForkEpoch {
+		return ComputeFuluForkDigest(version, genesisValidatorsRoot, s.BlobParameters(epoch))
+	}
+
+	return ComputeForkDigest(version, genesisValidatorsRoot)
+}
+
+// Domain returns the signing domain for the given domain type using the fork that is active at the given epoch.
+// Deposits and builder API messages are valid across forks and networks with the same genesis fork, so
+// DomainDeposit and DomainApplicationBuilder always use the genesis fork version and an empty genesis validators
+// root instead.
+func (s *Spec) Domain(domainType phase0.DomainType, epoch phase0.Epoch, genesisValidatorsRoot phase0.Root) (phase0.Domain, error) {
+	if domainType == DomainDeposit || domainType == DomainApplicationBuilder {
+		version, err := parseVersion(s.GenesisForkVersion)
+		if err != nil {
+			return phase0.Domain{}, err
+		}
+
+		return ComputeDomain(domainType, version, phase0.Root{})
+	}
+
+	version, err := s.ForkVersionAt(epoch)
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+
+	return ComputeDomain(domainType, version, genesisValidatorsRoot)
+}
+
+// ForkVersionAt returns the version of the fork that is active at the given epoch, falling back to the genesis
+// fork version before any named fork is active.
+func (s *Spec) ForkVersionAt(epoch phase0.Epoch) (phase0.Version, error) {
+	switch {
+	case s.FuluForkVersion != "" && epoch >= s.FuluForkEpoch:
+		return parseVersion(s.FuluForkVersion)
+	case s.ElectraForkVersion != "" && epoch >= s.ElectraForkEpoch:
+		return parseVersion(s.ElectraForkVersion)
+	}
+
+	fork, err := s.ForkEpochs.CurrentFork(epoch)
+	if err != nil {
+		// No named fork is active yet, so we're still on the genesis fork.
+		if s.GenesisForkVersion == "" {
+			return phase0.Version{}, err
+		}
+
+		return parseVersion(s.GenesisForkVersion)
+	}
+
+	return fork.ForkVersion()
+}
+
+func parseVersion(version string) (phase0.Version, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(version, "0x"))
+	if err != nil {
+		return phase0.Version{}, fmt.Errorf("invalid fork version %q: %w", version, err)
+	}
+
+	if len(b) != 4 {
+		return phase0.Version{}, errors.New("incorrect length for fork version")
+	}
+
+	var v phase0.Version
+
+	copy(v[:], b)
+
+	return v, nil
+}
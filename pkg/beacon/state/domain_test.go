@@ -0,0 +1,275 @@
+package state_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mainnetGenesisValidatorsRoot(t *testing.T) phase0.Root {
+	t.Helper()
+
+	b, err := hex.DecodeString("4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95")
+	require.NoError(t, err)
+
+	var root phase0.Root
+
+	copy(root[:], b)
+
+	return root
+}
+
+func TestComputeForkDigest(t *testing.T) {
+	gvr := mainnetGenesisValidatorsRoot(t)
+
+	tests := []struct {
+		name     string
+		version  phase0.Version
+		expected string
+	}{
+		{
+			name:     "mainnet phase0",
+			version:  phase0.Version{0x00, 0x00, 0x00, 0x00},
+			expected: "0xb5303f2a",
+		},
+		{
+			name:     "mainnet capella",
+			version:  phase0.Version{0x03, 0x00, 0x00, 0x00},
+			expected: "0xbba4da96",
+		},
+		{
+			name:     "mainnet deneb",
+			version:  phase0.Version{0x04, 0x00, 0x00, 0x00},
+			expected: "0x6a95a1a9",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			digest, err := state.ComputeForkDigest(test.version, gvr)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, fmt.Sprintf("%#x", digest))
+		})
+	}
+}
+
+func TestComputeDomain(t *testing.T) {
+	gvr := mainnetGenesisValidatorsRoot(t)
+
+	root, err := state.ComputeForkDataRoot(phase0.Version{0x04, 0x00, 0x00, 0x00}, gvr)
+	require.NoError(t, err)
+
+	domain, err := state.ComputeDomain(state.DomainSyncCommittee, phase0.Version{0x04, 0x00, 0x00, 0x00}, gvr)
+	require.NoError(t, err)
+
+	assert.Equal(t, state.DomainSyncCommittee[:], domain[:4])
+	assert.Equal(t, root[:28], domain[4:])
+}
+
+func TestSpecDomain(t *testing.T) {
+	gvr := mainnetGenesisValidatorsRoot(t)
+
+	sp := &state.Spec{
+		GenesisForkVersion: "0x00000000",
+		ForkEpochs: state.ForkEpochs{
+			{
+				Epoch:   194048,
+				Name:    spec.DataVersionCapella,
+				Version: "0x03000000",
+			},
+		},
+	}
+
+	capella, err := state.ComputeDomain(state.DomainBeaconProposer, phase0.Version{0x03, 0x00, 0x00, 0x00}, gvr)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		domainType phase0.DomainType
+		expected   string
+	}{
+		{
+			name:       "beacon proposer uses the active fork",
+			domainType: state.DomainBeaconProposer,
+			expected:   fmt.Sprintf("%#x", capella),
+		},
+		{
+			name:       "deposit uses the genesis fork and an empty genesis validators root",
+			domainType: state.DomainDeposit,
+			expected:   "0x03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9",
+		},
+		{
+			name:       "application builder uses the genesis fork and an empty genesis validators root",
+			domainType: state.DomainApplicationBuilder,
+			expected:   "0x00000001f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			domain, err := sp.Domain(test.domainType, 200000, gvr)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, fmt.Sprintf("%#x", domain))
+		})
+	}
+}
+
+func TestSpecForkDigest(t *testing.T) {
+	gvr := mainnetGenesisValidatorsRoot(t)
+
+	sp := &state.Spec{
+		GenesisForkVersion: "0x00000000",
+		ForkEpochs: state.ForkEpochs{
+			{
+				Epoch:   194048,
+				Name:    spec.DataVersionCapella,
+				Version: "0x03000000",
+			},
+			{
+				Epoch:   269568,
+				Name:    spec.DataVersionDeneb,
+				Version: "0x04000000",
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		epoch    phase0.Epoch
+		expected string
+	}{
+		{
+			name:     "genesis fork before any named fork",
+			epoch:    0,
+			expected: "0xb5303f2a",
+		},
+		{
+			name:     "capella",
+			epoch:    200000,
+			expected: "0xbba4da96",
+		},
+		{
+			name:     "deneb",
+			epoch:    300000,
+			expected: "0x6a95a1a9",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			digest, err := sp.ForkDigest(test.epoch, gvr)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, fmt.Sprintf("%#x", digest))
+		})
+	}
+}
+
+func TestSpecForkVersionAt(t *testing.T) {
+	sp := &state.Spec{
+		GenesisForkVersion: "0x00000000",
+		ForkEpochs: state.ForkEpochs{
+			{
+				Epoch:   194048,
+				Name:    spec.DataVersionCapella,
+				Version: "0x03000000",
+			},
+		},
+	}
+
+	version, err := sp.ForkVersionAt(0)
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Version{0x00, 0x00, 0x00, 0x00}, version)
+
+	version, err = sp.ForkVersionAt(194048)
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Version{0x03, 0x00, 0x00, 0x00}, version)
+
+	_, err = (&state.ForkEpoch{Version: "0x0300"}).ForkVersion()
+	assert.Error(t, err)
+}
+
+func TestSpecForkDigestFulu(t *testing.T) {
+	gvr := mainnetGenesisValidatorsRoot(t)
+
+	sp := state.NewSpec(map[string]any{
+		"GENESIS_FORK_VERSION":        phase0.Version{0x00, 0x00, 0x00, 0x00},
+		"DENEB_FORK_EPOCH":            "269568",
+		"DENEB_FORK_VERSION":          phase0.Version{0x04, 0x00, 0x00, 0x00},
+		"ELECTRA_FORK_EPOCH":          "364032",
+		"ELECTRA_FORK_VERSION":        phase0.Version{0x05, 0x00, 0x00, 0x00},
+		"MAX_BLOBS_PER_BLOCK_ELECTRA": "9",
+		"FULU_FORK_EPOCH":             "411392",
+		"FULU_FORK_VERSION":           phase0.Version{0x06, 0x00, 0x00, 0x00},
+		"BLOB_SCHEDULE": []any{
+			map[string]any{"EPOCH": "412672", "MAX_BLOBS_PER_BLOCK": "15"},
+			map[string]any{"EPOCH": "419072", "MAX_BLOBS_PER_BLOCK": "21"},
+		},
+	})
+	require.NoError(t, sp.Validate())
+
+	// fuluDigest masks the fork data root with the hash of the blob parameters, as in the spec.
+	fuluDigest := func(epoch phase0.Epoch, maxBlobs uint64) string {
+		root, err := state.ComputeForkDataRoot(phase0.Version{0x06, 0x00, 0x00, 0x00}, gvr)
+		require.NoError(t, err)
+
+		params := make([]byte, 16)
+		binary.LittleEndian.PutUint64(params[:8], uint64(epoch))
+		binary.LittleEndian.PutUint64(params[8:], maxBlobs)
+
+		mask := sha256.Sum256(params)
+
+		digest := make([]byte, 4)
+		for i := range digest {
+			digest[i] = root[i] ^ mask[i]
+		}
+
+		return fmt.Sprintf("%#x", digest)
+	}
+
+	tests := []struct {
+		name     string
+		epoch    phase0.Epoch
+		expected string
+	}{
+		{
+			name:     "deneb",
+			epoch:    300000,
+			expected: "0x6a95a1a9",
+		},
+		{
+			name:     "fulu with the electra blob parameters",
+			epoch:    411392,
+			expected: fuluDigest(364032, 9),
+		},
+		{
+			name:     "first blob parameter only fork",
+			epoch:    412672,
+			expected: fuluDigest(412672, 15),
+		},
+		{
+			name:     "second blob parameter only fork",
+			epoch:    500000,
+			expected: fuluDigest(419072, 21),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			digest, err := sp.ForkDigest(test.epoch, gvr)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, fmt.Sprintf("%#x", digest))
+		})
+	}
+
+	version, err := sp.ForkVersionAt(400000)
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Version{0x05, 0x00, 0x00, 0x00}, version)
+}
@@ -2,6 +2,7 @@ package state
 
 import (
 	"errors"
+	"math"
 	"sort"
 
 	"github.com/attestantio/go-eth2-client/spec"
@@ -24,6 +25,11 @@ type ForkEpoch struct {
 	Epoch   phase0.Epoch     `yaml:"epoch"`
 	Version string           `json:"version"`
 	Name    spec.DataVersion `json:"name"`
+	// VersionBytes is Version parsed when the spec was loaded. It is only set on forks of a spec created with
+	// NewSpec and if Version is valid.
+	VersionBytes phase0.Version `json:"-" yaml:"-"`
+
+	versionParsed bool
 }
 
 // Active returns true if the fork is active at the given epoch.
@@ -31,6 +37,16 @@ func (f *ForkEpoch) Active(epoch phase0.Epoch) bool {
 	return epoch >= f.Epoch
 }
 
+// ForkVersion returns the fork version as parsed bytes. The version parsed when the spec was loaded is
+// returned if there is one, otherwise Version is parsed.
+func (f *ForkEpoch) ForkVersion() (phase0.Version, error) {
+	if f.versionParsed {
+		return f.VersionBytes, nil
+	}
+
+	return parseVersion(f.Version)
+}
+
 // ForkEpochs is a list of forks that activate at specific epochs.
 type ForkEpochs []*ForkEpoch
 
@@ -90,6 +106,29 @@ func (f *ForkEpochs) CurrentFork(epoch phase0.Epoch) (*ForkEpoch, error) {
 	return largest, nil
 }
 
+// NextScheduledFork returns the earliest fork that is not active yet at the given epoch. Forks at the far
+// future epoch are not scheduled and are ignored.
+func (f *ForkEpochs) NextScheduledFork(epoch phase0.Epoch) (*ForkEpoch, error) {
+	var next *ForkEpoch
+
+	for _, fork := range f.Scheduled(epoch) {
+		if fork.Epoch == phase0.Epoch(math.MaxUint64) {
+			continue
+		}
+
+		if next == nil || fork.Epoch < next.Epoch ||
+			(fork.Epoch == next.Epoch && f.IndexOf(fork.Name) > f.IndexOf(next.Name)) {
+			next = fork
+		}
+	}
+
+	if next == nil {
+		return &ForkEpoch{}, errors.New("no scheduled fork")
+	}
+
+	return next, nil
+}
+
 // PreviousFork returns the previous fork at the given epoch.
 func (f *ForkEpochs) PreviousFork(epoch phase0.Epoch) (*ForkEpoch, error) {
 	if len(*f) == 1 {
@@ -1,12 +1,14 @@
 package state_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/beacon/pkg/beacon/state"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestForkEpochActive(t *testing.T) {
@@ -369,3 +371,63 @@ func TestForkEpochsLatestActiveFork(t *testing.T) {
 		})
 	}
 }
+
+func TestForkEpochsNextScheduledFork(t *testing.T) {
+	forks := state.ForkEpochs{
+		{
+			Epoch: 0,
+			Name:  spec.DataVersionPhase0,
+		},
+		{
+			Epoch: 200,
+			Name:  spec.DataVersionCapella,
+		},
+		{
+			Epoch: 100,
+			Name:  spec.DataVersionAltair,
+		},
+		{
+			Epoch: 100,
+			Name:  spec.DataVersionBellatrix,
+		},
+		{
+			Epoch: phase0.Epoch(math.MaxUint64),
+			Name:  spec.DataVersionDeneb,
+		},
+	}
+
+	next, err := forks.NextScheduledFork(50)
+	assert.NoError(t, err)
+	assert.Equal(t, spec.DataVersionBellatrix, next.Name)
+
+	next, err = forks.NextScheduledFork(100)
+	assert.NoError(t, err)
+	assert.Equal(t, spec.DataVersionCapella, next.Name)
+
+	// Forks at the far future epoch are not scheduled.
+	_, err = forks.NextScheduledFork(200)
+	assert.Error(t, err)
+}
+
+func TestForkEpochVersionBytes(t *testing.T) {
+	sp := state.NewSpec(map[string]any{
+		"ALTAIR_FORK_EPOCH":   "74240",
+		"ALTAIR_FORK_VERSION": phase0.Version{0x01, 0x00, 0x00, 0x00},
+	})
+
+	altair, err := sp.ForkEpochs.GetByName("altair")
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Version{0x01, 0x00, 0x00, 0x00}, altair.VersionBytes)
+
+	version, err := altair.ForkVersion()
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Version{0x01, 0x00, 0x00, 0x00}, version)
+
+	// Forks that were not loaded from a spec parse their version string.
+	version, err = (&state.ForkEpoch{Version: "0x03000000"}).ForkVersion()
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Version{0x03, 0x00, 0x00, 0x00}, version)
+
+	_, err = (&state.ForkEpoch{Version: "0x03"}).ForkVersion()
+	assert.Error(t, err)
+}
@@ -1,37 +1,124 @@
 package state
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
-// ScheduledFork is an upcoming fork.
+// ScheduledFork is an upcoming fork. It is encoded in the format of the beacon API fork schedule, with the
+// versions as hex strings and the epoch as a decimal string. Use CurrentForkVersion, PreviousForkVersion and
+// ForkEpoch for the typed values.
 type ScheduledFork struct {
-	CurrentVersion  string `json:"current_version"`
-	Epoch           string `json:"epoch"`
-	PreviousVersion string `json:"previous_version"`
+	CurrentVersion  string `json:"current_version" yaml:"current_version"`
+	Epoch           string `json:"epoch" yaml:"epoch"`
+	PreviousVersion string `json:"previous_version" yaml:"previous_version"`
+}
+
+// scheduledForkFields has the fields of ScheduledFork without its methods, so it can be decoded without
+// recursing into UnmarshalJSON and UnmarshalYAML.
+type scheduledForkFields ScheduledFork
+
+// CurrentForkVersion returns the version of the fork.
+func (s *ScheduledFork) CurrentForkVersion() (phase0.Version, error) {
+	return parseVersion(s.CurrentVersion)
+}
+
+// PreviousForkVersion returns the version of the fork before it.
+func (s *ScheduledFork) PreviousForkVersion() (phase0.Version, error) {
+	return parseVersion(s.PreviousVersion)
+}
+
+// ForkEpoch returns the epoch the fork activates at.
+func (s *ScheduledFork) ForkEpoch() (phase0.Epoch, error) {
+	epoch, err := strconv.ParseUint(s.Epoch, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid epoch: %w", err)
+	}
+
+	return phase0.Epoch(epoch), nil
 }
 
-// ForkScheduleFromForkEpochs returns a fork schedule from a list of forks.
+// validate returns an error if the versions or the epoch can't be parsed.
+func (s *ScheduledFork) validate() error {
+	if _, err := s.PreviousForkVersion(); err != nil {
+		return fmt.Errorf("invalid previous version: %w", err)
+	}
+
+	if _, err := s.CurrentForkVersion(); err != nil {
+		return fmt.Errorf("invalid current version: %w", err)
+	}
+
+	_, err := s.ForkEpoch()
+
+	return err
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ScheduledFork) UnmarshalJSON(input []byte) error {
+	var fields scheduledForkFields
+	if err := json.Unmarshal(input, &fields); err != nil {
+		return err
+	}
+
+	decoded := ScheduledFork(fields)
+	if err := decoded.validate(); err != nil {
+		return err
+	}
+
+	*s = decoded
+
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (s *ScheduledFork) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var fields scheduledForkFields
+	if err := unmarshal(&fields); err != nil {
+		return err
+	}
+
+	decoded := ScheduledFork(fields)
+	if err := decoded.validate(); err != nil {
+		return err
+	}
+
+	*s = decoded
+
+	return nil
+}
+
+// ForkScheduleFromForkEpochs returns a fork schedule from a list of forks, sorted by epoch. The given list is not
+// modified.
 func ForkScheduleFromForkEpochs(forks ForkEpochs) ([]*ScheduledFork, error) {
+	sorted := make(ForkEpochs, len(forks))
+	copy(sorted, forks)
+
 	// Sort them by Epoch.
-	sort.Slice(forks, func(i, j int) bool {
-		return (forks)[i].Epoch < (forks)[j].Epoch
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Epoch < sorted[j].Epoch
 	})
 
 	scheduled := []*ScheduledFork{}
-	for i, fork := range forks {
-		scheduledFork := &ScheduledFork{
-			CurrentVersion:  fork.Version,
-			Epoch:           fmt.Sprintf("%d", fork.Epoch),
-			PreviousVersion: "0x00000000",
-		}
 
-		if i > 0 {
-			scheduledFork.PreviousVersion = (forks)[i-1].Version
+	var previous phase0.Version
+
+	for _, fork := range sorted {
+		current, err := fork.ForkVersion()
+		if err != nil {
+			return nil, fmt.Errorf("invalid version for fork %s: %w", fork.Name, err)
 		}
 
-		scheduled = append(scheduled, scheduledFork)
+		scheduled = append(scheduled, &ScheduledFork{
+			CurrentVersion:  fmt.Sprintf("%#x", current),
+			Epoch:           fmt.Sprintf("%d", fork.Epoch),
+			PreviousVersion: fmt.Sprintf("%#x", previous),
+		})
+
+		previous = current
 	}
 
 	return scheduled, nil
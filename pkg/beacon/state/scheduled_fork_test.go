@@ -0,0 +1,69 @@
+package state_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestForkScheduleFromForkEpochs(t *testing.T) {
+	forks := state.ForkEpochs{
+		{Epoch: 74240, Name: spec.DataVersionAltair, Version: "0x01000000"},
+		{Epoch: 0, Name: spec.DataVersionPhase0, Version: "0x00000000"},
+	}
+
+	scheduled, err := forks.AsScheduledForks()
+	require.NoError(t, err)
+
+	assert.Equal(t, []*state.ScheduledFork{
+		{CurrentVersion: "0x00000000", Epoch: "0", PreviousVersion: "0x00000000"},
+		{CurrentVersion: "0x01000000", Epoch: "74240", PreviousVersion: "0x00000000"},
+	}, scheduled)
+
+	version, err := scheduled[1].CurrentForkVersion()
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Version{0x01}, version)
+
+	epoch, err := scheduled[1].ForkEpoch()
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Epoch(74240), epoch)
+
+	// The forks are not reordered.
+	assert.Equal(t, spec.DataVersionAltair, forks[0].Name)
+
+	_, err = state.ForkScheduleFromForkEpochs(state.ForkEpochs{{Epoch: 1, Version: "0x01"}})
+	assert.Error(t, err)
+}
+
+func TestScheduledForkRoundTrip(t *testing.T) {
+	fork := &state.ScheduledFork{
+		CurrentVersion:  "0x04000000",
+		Epoch:           "269568",
+		PreviousVersion: "0x03000000",
+	}
+
+	encoded, err := json.Marshal(fork)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"previous_version":"0x03000000","current_version":"0x04000000","epoch":"269568"}`, string(encoded))
+
+	decoded := &state.ScheduledFork{}
+	require.NoError(t, json.Unmarshal(encoded, decoded))
+	assert.Equal(t, fork, decoded)
+
+	encoded, err = yaml.Marshal(fork)
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `epoch: "269568"`)
+
+	decoded = &state.ScheduledFork{}
+	require.NoError(t, yaml.Unmarshal(encoded, decoded))
+	assert.Equal(t, fork, decoded)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"previous_version":"0x03","current_version":"0x04000000","epoch":"1"}`), decoded))
+	assert.Error(t, yaml.Unmarshal([]byte("previous_version: \"0x03000000\"\ncurrent_version: \"0x04000000\"\nepoch: latest\n"), decoded))
+}
@@ -3,6 +3,7 @@ package state
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"strings"
 
@@ -43,14 +44,43 @@ type Spec struct {
 	MaxDeposits                    uint64           `json:"MAX_DEPOSITS,string"`
 	MinGenesisActiveValidatorCount uint64           `json:"MIN_GENESIS_ACTIVE_VALIDATOR_COUNT,string"`
 	Eth1FollowDistance             uint64           `json:"ETH1_FOLLOW_DISTANCE,string"`
+	EpochsPerEth1VotingPeriod      phase0.Epoch     `json:"EPOCHS_PER_ETH1_VOTING_PERIOD,string"`
+
+	MinValidatorWithdrawabilityDelay phase0.Epoch `json:"MIN_VALIDATOR_WITHDRAWABILITY_DELAY,string"`
+	MinPerEpochChurnLimit            uint64       `json:"MIN_PER_EPOCH_CHURN_LIMIT,string"`
+	ChurnLimitQuotient               uint64       `json:"CHURN_LIMIT_QUOTIENT,string"`
+
+	GenesisForkVersion string `json:"GENESIS_FORK_VERSION"`
+	// ElectraForkVersion and FuluForkVersion are kept separately since go-eth2-client has no data version for
+	// these forks yet, so they are missing from ForkEpochs. They are empty if the fork is not scheduled.
+	ElectraForkVersion string `json:"ELECTRA_FORK_VERSION"`
+	FuluForkVersion    string `json:"FULU_FORK_VERSION"`
+
+	// ElectraForkEpoch is the epoch Electra activates at. It is the maximum epoch if Electra is not scheduled.
+	ElectraForkEpoch phase0.Epoch `json:"ELECTRA_FORK_EPOCH,string"`
+	// MaxBlobsPerBlockElectra is the maximum number of blobs per block from Electra until the first blob
+	// schedule entry.
+	MaxBlobsPerBlockElectra uint64 `json:"MAX_BLOBS_PER_BLOCK_ELECTRA,string"`
+	// FuluForkEpoch is the epoch PeerDAS activates at. It is the maximum epoch if Fulu is not scheduled.
+	FuluForkEpoch                          phase0.Epoch `json:"FULU_FORK_EPOCH,string"`
+	ValidatorCustodyRequirement            uint64       `json:"VALIDATOR_CUSTODY_REQUIREMENT,string"`
+	BalancePerAdditionalCustodyGroup       phase0.Gwei  `json:"BALANCE_PER_ADDITIONAL_CUSTODY_GROUP,string"`
+	MinEpochsForDataColumnSidecarsRequests phase0.Epoch `json:"MIN_EPOCHS_FOR_DATA_COLUMN_SIDECARS_REQUESTS,string"`
 
 	ForkEpochs ForkEpochs `json:"-"`
+	// BlobSchedule is the parsed BLOB_SCHEDULE, sorted by epoch. It is empty if the spec has no blob schedule
+	// or it is invalid, in which case Validate returns an error.
+	BlobSchedule BlobSchedule `json:"-"`
+
+	blobScheduleErr error
 }
 
 // NewSpec creates a new spec instance.
 func NewSpec(data map[string]interface{}) Spec {
 	spec := Spec{
-		ForkEpochs: ForkEpochs{},
+		ForkEpochs:       ForkEpochs{},
+		ElectraForkEpoch: phase0.Epoch(math.MaxUint64),
+		FuluForkEpoch:    phase0.Epoch(math.MaxUint64),
 	}
 
 	if safeSlotsToUpdateJustified, exists := data["SAFE_SLOTS_TO_UPDATE_JUSTIFIED"]; exists {
@@ -136,6 +166,10 @@ func NewSpec(data map[string]interface{}) Spec {
 		spec.Eth1FollowDistance = cast.ToUint64(eth1FollowDistance)
 	}
 
+	if epochsPerEth1VotingPeriod, exists := data["EPOCHS_PER_ETH1_VOTING_PERIOD"]; exists {
+		spec.EpochsPerEth1VotingPeriod = phase0.Epoch(cast.ToUint64(epochsPerEth1VotingPeriod))
+	}
+
 	if terminalBlockHashActivationEpoch, exists := data["TERMINAL_BLOCK_HASH_ACTIVATION_EPOCH"]; exists {
 		spec.TerminalBlockHashActivationEpoch = phase0.Epoch(cast.ToUint64(terminalBlockHashActivationEpoch))
 	}
@@ -152,6 +186,51 @@ func NewSpec(data map[string]interface{}) Spec {
 		spec.PresetBase = cast.ToString(presetBase)
 	}
 
+	if minValidatorWithdrawabilityDelay, exists := data["MIN_VALIDATOR_WITHDRAWABILITY_DELAY"]; exists {
+		spec.MinValidatorWithdrawabilityDelay = phase0.Epoch(cast.ToUint64(minValidatorWithdrawabilityDelay))
+	}
+
+	if minPerEpochChurnLimit, exists := data["MIN_PER_EPOCH_CHURN_LIMIT"]; exists {
+		spec.MinPerEpochChurnLimit = cast.ToUint64(minPerEpochChurnLimit)
+	}
+
+	if churnLimitQuotient, exists := data["CHURN_LIMIT_QUOTIENT"]; exists {
+		spec.ChurnLimitQuotient = cast.ToUint64(churnLimitQuotient)
+	}
+
+	if electraForkEpoch, exists := data["ELECTRA_FORK_EPOCH"]; exists {
+		spec.ElectraForkEpoch = phase0.Epoch(cast.ToUint64(electraForkEpoch))
+	}
+
+	if maxBlobsPerBlockElectra, exists := data["MAX_BLOBS_PER_BLOCK_ELECTRA"]; exists {
+		spec.MaxBlobsPerBlockElectra = cast.ToUint64(maxBlobsPerBlockElectra)
+	}
+
+	if fuluForkEpoch, exists := data["FULU_FORK_EPOCH"]; exists {
+		spec.FuluForkEpoch = phase0.Epoch(cast.ToUint64(fuluForkEpoch))
+	}
+
+	if validatorCustodyRequirement, exists := data["VALIDATOR_CUSTODY_REQUIREMENT"]; exists {
+		spec.ValidatorCustodyRequirement = cast.ToUint64(validatorCustodyRequirement)
+	}
+
+	if balancePerAdditionalCustodyGroup, exists := data["BALANCE_PER_ADDITIONAL_CUSTODY_GROUP"]; exists {
+		spec.BalancePerAdditionalCustodyGroup = phase0.Gwei(cast.ToUint64(balancePerAdditionalCustodyGroup))
+	}
+
+	if minEpochsForDataColumnSidecarsRequests, exists := data["MIN_EPOCHS_FOR_DATA_COLUMN_SIDECARS_REQUESTS"]; exists {
+		spec.MinEpochsForDataColumnSidecarsRequests = phase0.Epoch(cast.ToUint64(minEpochsForDataColumnSidecarsRequests))
+	}
+
+	if blobSchedule, exists := data["BLOB_SCHEDULE"]; exists {
+		schedule, err := parseBlobSchedule(blobSchedule)
+		if err != nil {
+			spec.blobScheduleErr = err
+		} else {
+			spec.BlobSchedule = schedule
+		}
+	}
+
 	forkEpochs := make(map[string]phase0.Epoch)
 	forkVersions := make(map[string]string)
 
@@ -171,6 +250,10 @@ func NewSpec(data map[string]interface{}) Spec {
 		}
 	}
 
+	spec.GenesisForkVersion = forkVersions["GENESIS"]
+	spec.ElectraForkVersion = forkVersions["ELECTRA"]
+	spec.FuluForkVersion = forkVersions["FULU"]
+
 	for k, v := range forkEpochs {
 		version := ""
 		if v, exists := forkVersions[k]; exists {
@@ -183,11 +266,18 @@ func NewSpec(data map[string]interface{}) Spec {
 			continue
 		}
 
-		spec.ForkEpochs = append(spec.ForkEpochs, &ForkEpoch{
+		fork := &ForkEpoch{
 			Epoch:   v,
 			Name:    dataVersion,
 			Version: version,
-		})
+		}
+
+		if parsed, err := parseVersion(version); err == nil {
+			fork.VersionBytes = parsed
+			fork.versionParsed = true
+		}
+
+		spec.ForkEpochs = append(spec.ForkEpochs, fork)
 	}
 
 	return spec
@@ -195,6 +285,10 @@ func NewSpec(data map[string]interface{}) Spec {
 
 // Validate performs basic validation of the spec.
 func (s *Spec) Validate() error {
+	if s.blobScheduleErr != nil {
+		return s.blobScheduleErr
+	}
+
 	return nil
 }
 
@@ -0,0 +1,59 @@
+package state
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	// SafetyDecay is the maximum tolerable loss in the one-third safety margin of FFG finality (in percent).
+	SafetyDecay = 10
+
+	gweiPerEth = 1_000_000_000
+)
+
+// ValidatorChurnLimit returns the validator churn limit for the given number of active validators.
+func (s *Spec) ValidatorChurnLimit(activeValidatorCount uint64) uint64 {
+	limit := s.MinPerEpochChurnLimit
+
+	if s.ChurnLimitQuotient > 0 && activeValidatorCount/s.ChurnLimitQuotient > limit {
+		limit = activeValidatorCount / s.ChurnLimitQuotient
+	}
+
+	return limit
+}
+
+// WeakSubjectivityPeriod computes the weak subjectivity period (in epochs) for the given active validator count
+// and total active balance, as defined in the consensus specs' weak subjectivity guide.
+func (s *Spec) WeakSubjectivityPeriod(activeValidatorCount uint64, totalActiveBalance phase0.Gwei) phase0.Epoch {
+	period := uint64(s.MinValidatorWithdrawabilityDelay)
+
+	N := activeValidatorCount
+	if N == 0 {
+		return phase0.Epoch(period)
+	}
+
+	t := uint64(totalActiveBalance) / N / gweiPerEth
+	T := uint64(s.MaxEffectiveBalance) / gweiPerEth
+	delta := s.ValidatorChurnLimit(N)
+	Delta := s.MaxDeposits * uint64(s.SlotsPerEpoch)
+	D := uint64(SafetyDecay)
+
+	if delta == 0 || Delta == 0 {
+		return phase0.Epoch(period)
+	}
+
+	if T*(200+3*D) < t*(200+12*D) {
+		epochsForValidatorSetChurn := N * (t*(200+12*D) - T*(200+3*D)) / (600 * delta * (2*t + T))
+		epochsForBalanceTopUps := N * (200 + 3*D) / (600 * Delta)
+
+		if epochsForValidatorSetChurn > epochsForBalanceTopUps {
+			period += epochsForValidatorSetChurn
+		} else {
+			period += epochsForBalanceTopUps
+		}
+	} else if T > t {
+		period += 3 * N * D * t / (200 * Delta * (T - t))
+	}
+
+	return phase0.Epoch(period)
+}
@@ -0,0 +1,54 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeakSubjectivityPeriod(t *testing.T) {
+	sp := &state.Spec{
+		MinValidatorWithdrawabilityDelay: 256,
+		MinPerEpochChurnLimit:            4,
+		ChurnLimitQuotient:               65536,
+		MaxEffectiveBalance:              32_000_000_000,
+		MaxDeposits:                      16,
+		SlotsPerEpoch:                    32,
+	}
+
+	// Expected values are taken from the weak subjectivity guide in the consensus specs.
+	tests := []struct {
+		name       string
+		validators uint64
+		avgBalance phase0.Gwei
+		expected   phase0.Epoch
+	}{
+		{
+			name:       "28 eth average with 32768 validators",
+			validators: 32768,
+			avgBalance: 28_000_000_000,
+			expected:   504,
+		},
+		{
+			name:       "32 eth average with 32768 validators",
+			validators: 32768,
+			avgBalance: 32_000_000_000,
+			expected:   665,
+		},
+		{
+			name:       "no validators",
+			validators: 0,
+			expected:   256,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			total := phase0.Gwei(test.validators) * test.avgBalance
+
+			assert.Equal(t, test.expected, sp.WeakSubjectivityPeriod(test.validators, total))
+		})
+	}
+}
@@ -0,0 +1,262 @@
+// Package stateutil provides helpers for extracting data from SSZ encoded beacon states without unmarshalling
+// the whole state into memory.
+package stateutil
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cast"
+)
+
+const (
+	// validatorSize is the size of an SSZ encoded validator.
+	validatorSize = 121
+	// balanceSize is the size of an SSZ encoded balance.
+	balanceSize = 8
+	// offsetSize is the size of an SSZ offset.
+	offsetSize = 4
+	// eth1DataSize is the size of SSZ encoded eth1 data.
+	eth1DataSize = 72
+)
+
+// Layout holds the preset values that determine the position of the validators and balances in the state.
+type Layout struct {
+	SlotsPerHistoricalRoot    uint64
+	EpochsPerHistoricalVector uint64
+	EpochsPerSlashingsVector  uint64
+}
+
+var (
+	// MainnetLayout is the layout of states using the mainnet preset.
+	MainnetLayout = Layout{
+		SlotsPerHistoricalRoot:    8192,
+		EpochsPerHistoricalVector: 65536,
+		EpochsPerSlashingsVector:  8192,
+	}
+	// MinimalLayout is the layout of states using the minimal preset.
+	MinimalLayout = Layout{
+		SlotsPerHistoricalRoot:    64,
+		EpochsPerHistoricalVector: 64,
+		EpochsPerSlashingsVector:  64,
+	}
+)
+
+// LayoutFromSpec returns the layout for the given raw spec.
+func LayoutFromSpec(spec map[string]interface{}) (Layout, error) {
+	layout := Layout{}
+
+	for key, value := range map[string]*uint64{
+		"SLOTS_PER_HISTORICAL_ROOT":    &layout.SlotsPerHistoricalRoot,
+		"EPOCHS_PER_HISTORICAL_VECTOR": &layout.EpochsPerHistoricalVector,
+		"EPOCHS_PER_SLASHINGS_VECTOR":  &layout.EpochsPerSlashingsVector,
+	} {
+		raw, exists := spec[key]
+		if !exists {
+			return Layout{}, fmt.Errorf("spec is missing %s", key)
+		}
+
+		parsed, err := cast.ToUint64E(raw)
+		if err != nil {
+			return Layout{}, fmt.Errorf("invalid %s: %w", key, err)
+		}
+
+		*value = parsed
+	}
+
+	return layout, nil
+}
+
+// eth1DataPosition returns the position of the eth1_data in the state. The fields before it are identical in
+// every fork.
+func (l Layout) eth1DataPosition() uint64 {
+	return 8 + // genesis_time
+		32 + // genesis_validators_root
+		8 + // slot
+		16 + // fork
+		112 + // latest_block_header
+		l.SlotsPerHistoricalRoot*32*2 + // block_roots and state_roots
+		offsetSize // historical_roots
+}
+
+// validatorsOffsetPosition returns the position of the validators offset in the state. The fields before it
+// are identical in every fork.
+func (l Layout) validatorsOffsetPosition() uint64 {
+	return l.eth1DataPosition() +
+		eth1DataSize +
+		offsetSize + // eth1_data_votes
+		8 // eth1_deposit_index
+}
+
+// listBounds holds the offsets of the validators and balances lists and the end of the balances list.
+type listBounds struct {
+	validators uint64
+	balances   uint64
+	end        uint64
+}
+
+// countingReader tracks the position in the underlying reader.
+type countingReader struct {
+	r   io.Reader
+	pos uint64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.pos += uint64(n)
+
+	return n, err
+}
+
+func (c *countingReader) skipTo(pos uint64) error {
+	if pos < c.pos {
+		return fmt.Errorf("cannot skip backwards from %d to %d", c.pos, pos)
+	}
+
+	_, err := io.CopyN(io.Discard, c, int64(pos-c.pos))
+
+	return err
+}
+
+func (c *countingReader) readOffset() (uint64, error) {
+	var buf [offsetSize]byte
+
+	if _, err := io.ReadFull(c, buf[:]); err != nil {
+		return 0, err
+	}
+
+	return uint64(binary.LittleEndian.Uint32(buf[:])), nil
+}
+
+// readBounds reads the offsets of the validators and balances lists from the state.
+func readBounds(r *countingReader, layout Layout) (listBounds, error) {
+	bounds := listBounds{}
+
+	if err := r.skipTo(layout.validatorsOffsetPosition()); err != nil {
+		return bounds, fmt.Errorf("failed to read state: %w", err)
+	}
+
+	var err error
+
+	if bounds.validators, err = r.readOffset(); err != nil {
+		return bounds, fmt.Errorf("failed to read validators offset: %w", err)
+	}
+
+	if bounds.balances, err = r.readOffset(); err != nil {
+		return bounds, fmt.Errorf("failed to read balances offset: %w", err)
+	}
+
+	// The balances are followed by randao_mixes and slashings before the next variable sized field.
+	if err := r.skipTo(r.pos + layout.EpochsPerHistoricalVector*32 + layout.EpochsPerSlashingsVector*8); err != nil {
+		return bounds, fmt.Errorf("failed to read state: %w", err)
+	}
+
+	if bounds.end, err = r.readOffset(); err != nil {
+		return bounds, fmt.Errorf("failed to read offset after balances: %w", err)
+	}
+
+	if bounds.validators < r.pos || bounds.balances < bounds.validators || bounds.end < bounds.balances {
+		return bounds, errors.New("invalid state offsets")
+	}
+
+	if (bounds.balances-bounds.validators)%validatorSize != 0 {
+		return bounds, errors.New("invalid validators list size")
+	}
+
+	if (bounds.end-bounds.balances)%balanceSize != 0 {
+		return bounds, errors.New("invalid balances list size")
+	}
+
+	return bounds, nil
+}
+
+// ReadBalances reads the validator balances from an SSZ encoded beacon state, holding only the balances in
+// memory.
+func ReadBalances(r io.Reader, layout Layout) ([]phase0.Gwei, error) {
+	reader := &countingReader{r: r}
+
+	bounds, err := readBounds(reader, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reader.skipTo(bounds.balances); err != nil {
+		return nil, fmt.Errorf("failed to read state: %w", err)
+	}
+
+	balances := make([]phase0.Gwei, 0, (bounds.end-bounds.balances)/balanceSize)
+
+	var buf [balanceSize]byte
+
+	for i := bounds.balances; i < bounds.end; i += balanceSize {
+		if _, err := io.ReadFull(reader, buf[:]); err != nil {
+			return nil, fmt.Errorf("failed to read balance: %w", err)
+		}
+
+		balances = append(balances, phase0.Gwei(binary.LittleEndian.Uint64(buf[:])))
+	}
+
+	return balances, nil
+}
+
+// ReadValidators reads the validators from an SSZ encoded beacon state, holding only the validators in memory.
+func ReadValidators(r io.Reader, layout Layout) ([]*phase0.Validator, error) {
+	reader := &countingReader{r: r}
+
+	bounds, err := readBounds(reader, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reader.skipTo(bounds.validators); err != nil {
+		return nil, fmt.Errorf("failed to read state: %w", err)
+	}
+
+	validators := make([]*phase0.Validator, 0, (bounds.balances-bounds.validators)/validatorSize)
+
+	var buf [validatorSize]byte
+
+	for i := bounds.validators; i < bounds.balances; i += validatorSize {
+		if _, err := io.ReadFull(reader, buf[:]); err != nil {
+			return nil, fmt.Errorf("failed to read validator: %w", err)
+		}
+
+		validator := &phase0.Validator{}
+		if err := validator.UnmarshalSSZ(buf[:]); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal validator: %w", err)
+		}
+
+		validators = append(validators, validator)
+	}
+
+	return validators, nil
+}
+
+// ReadETH1Data reads the eth1_data and the eth1_deposit_index from an SSZ encoded beacon state. Only the start
+// of the state is read.
+func ReadETH1Data(r io.Reader, layout Layout) (*phase0.ETH1Data, uint64, error) {
+	reader := &countingReader{r: r}
+
+	if err := reader.skipTo(layout.eth1DataPosition()); err != nil {
+		return nil, 0, fmt.Errorf("failed to read state: %w", err)
+	}
+
+	var buf [eth1DataSize + offsetSize + 8]byte
+
+	if _, err := io.ReadFull(reader, buf[:]); err != nil {
+		return nil, 0, fmt.Errorf("failed to read eth1 data: %w", err)
+	}
+
+	eth1Data := &phase0.ETH1Data{}
+	if err := eth1Data.UnmarshalSSZ(buf[:eth1DataSize]); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal eth1 data: %w", err)
+	}
+
+	// The eth1_data_votes offset sits between the eth1_data and the eth1_deposit_index.
+	depositIndex := binary.LittleEndian.Uint64(buf[eth1DataSize+offsetSize:])
+
+	return eth1Data, depositIndex, nil
+}
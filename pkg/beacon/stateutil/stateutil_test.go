@@ -0,0 +1,145 @@
+package stateutil_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/stateutil"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testValidators() ([]*phase0.Validator, []phase0.Gwei) {
+	validators := make([]*phase0.Validator, 0, 3)
+	balances := make([]phase0.Gwei, 0, 3)
+
+	for i := 0; i < 3; i++ {
+		validators = append(validators, &phase0.Validator{
+			PublicKey:                  phase0.BLSPubKey{byte(i + 1)},
+			WithdrawalCredentials:      make([]byte, 32),
+			EffectiveBalance:           phase0.Gwei(32_000_000_000 + i),
+			ActivationEligibilityEpoch: phase0.Epoch(i),
+			ActivationEpoch:            phase0.Epoch(i + 1),
+			ExitEpoch:                  phase0.Epoch(1000),
+			WithdrawableEpoch:          phase0.Epoch(2000),
+		})
+
+		balances = append(balances, phase0.Gwei(31_000_000_000+i))
+	}
+
+	return validators, balances
+}
+
+func roots(n int) []phase0.Root {
+	return make([]phase0.Root, n)
+}
+
+func syncCommittee() *altair.SyncCommittee {
+	return &altair.SyncCommittee{
+		Pubkeys: make([]phase0.BLSPubKey, 512),
+	}
+}
+
+func TestReadPhase0State(t *testing.T) {
+	validators, balances := testValidators()
+
+	state := &phase0.BeaconState{
+		GenesisValidatorsRoot:       phase0.Root{0x01},
+		Fork:                        &phase0.Fork{},
+		LatestBlockHeader:           &phase0.BeaconBlockHeader{},
+		BlockRoots:                  roots(8192),
+		StateRoots:                  roots(8192),
+		ETH1Data:                    &phase0.ETH1Data{DepositRoot: phase0.Root{0x02}, DepositCount: 14, BlockHash: bytes.Repeat([]byte{0x03}, 32)},
+		ETH1DataVotes:               []*phase0.ETH1Data{{BlockHash: make([]byte, 32)}},
+		ETH1DepositIndex:            12,
+		Validators:                  validators,
+		Balances:                    balances,
+		RANDAOMixes:                 roots(65536),
+		Slashings:                   make([]phase0.Gwei, 8192),
+		JustificationBits:           bitfield.NewBitvector4(),
+		PreviousJustifiedCheckpoint: &phase0.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &phase0.Checkpoint{},
+		FinalizedCheckpoint:         &phase0.Checkpoint{},
+	}
+
+	data, err := state.MarshalSSZ()
+	require.NoError(t, err)
+
+	readBalances, err := stateutil.ReadBalances(bytes.NewReader(data), stateutil.MainnetLayout)
+	require.NoError(t, err)
+	assert.Equal(t, balances, readBalances)
+
+	readValidators, err := stateutil.ReadValidators(bytes.NewReader(data), stateutil.MainnetLayout)
+	require.NoError(t, err)
+	assert.Equal(t, validators, readValidators)
+
+	eth1Data, depositIndex, err := stateutil.ReadETH1Data(bytes.NewReader(data), stateutil.MainnetLayout)
+	require.NoError(t, err)
+	assert.Equal(t, state.ETH1Data, eth1Data)
+	assert.Equal(t, uint64(12), depositIndex)
+}
+
+func TestReadDenebState(t *testing.T) {
+	validators, balances := testValidators()
+
+	state := &deneb.BeaconState{
+		Fork:                        &phase0.Fork{},
+		LatestBlockHeader:           &phase0.BeaconBlockHeader{},
+		BlockRoots:                  roots(8192),
+		StateRoots:                  roots(8192),
+		ETH1Data:                    &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+		Validators:                  validators,
+		Balances:                    balances,
+		RANDAOMixes:                 roots(65536),
+		Slashings:                   make([]phase0.Gwei, 8192),
+		PreviousEpochParticipation:  make([]altair.ParticipationFlags, 3),
+		CurrentEpochParticipation:   make([]altair.ParticipationFlags, 3),
+		JustificationBits:           bitfield.NewBitvector4(),
+		PreviousJustifiedCheckpoint: &phase0.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &phase0.Checkpoint{},
+		FinalizedCheckpoint:         &phase0.Checkpoint{},
+		InactivityScores:            make([]uint64, 3),
+		CurrentSyncCommittee:        syncCommittee(),
+		NextSyncCommittee:           syncCommittee(),
+		LatestExecutionPayloadHeader: &deneb.ExecutionPayloadHeader{
+			BaseFeePerGas: uint256.NewInt(7),
+			ExtraData:     []byte{},
+			LogsBloom:     [256]byte{},
+		},
+		HistoricalSummaries: []*capella.HistoricalSummary{},
+	}
+
+	data, err := state.MarshalSSZ()
+	require.NoError(t, err)
+
+	readBalances, err := stateutil.ReadBalances(bytes.NewReader(data), stateutil.MainnetLayout)
+	require.NoError(t, err)
+	assert.Equal(t, balances, readBalances)
+
+	readValidators, err := stateutil.ReadValidators(bytes.NewReader(data), stateutil.MainnetLayout)
+	require.NoError(t, err)
+	assert.Equal(t, validators, readValidators)
+
+	// A layout for another preset does not match the state.
+	_, err = stateutil.ReadBalances(bytes.NewReader(data), stateutil.MinimalLayout)
+	assert.Error(t, err)
+}
+
+func TestLayoutFromSpec(t *testing.T) {
+	layout, err := stateutil.LayoutFromSpec(map[string]interface{}{
+		"SLOTS_PER_HISTORICAL_ROOT":    "64",
+		"EPOCHS_PER_HISTORICAL_VECTOR": "64",
+		"EPOCHS_PER_SLASHINGS_VECTOR":  "64",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, stateutil.MinimalLayout, layout)
+
+	_, err = stateutil.LayoutFromSpec(map[string]interface{}{})
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@ type Status struct {
 	health    *Health
 	networkID uint64
 	syncstate *v1.SyncState
+	elOffline bool
 }
 
 // NewStatus creates a new status.
@@ -42,11 +43,25 @@ func (s *Status) Syncing() bool {
 	return s.syncstate.IsSyncing
 }
 
+// Optimistic returns true if the beacon node is optimistically synced.
+func (s *Status) Optimistic() bool {
+	if s.syncstate == nil {
+		return false
+	}
+
+	return s.syncstate.IsOptimistic
+}
+
 // SyncState returns the sync state.
 func (s *Status) SyncState() *v1.SyncState {
 	return s.syncstate
 }
 
+// ELOffline returns true if the beacon node reports that its execution client is offline.
+func (s *Status) ELOffline() bool {
+	return s.elOffline
+}
+
 // UpdateNetworkID updates the network ID.
 func (s *Status) UpdateNetworkID(networkID uint64) {
 	s.networkID = networkID
@@ -56,3 +71,8 @@ func (s *Status) UpdateNetworkID(networkID uint64) {
 func (s *Status) UpdateSyncState(state *v1.SyncState) {
 	s.syncstate = state
 }
+
+// UpdateELOffline updates whether the execution client is offline.
+func (s *Status) UpdateELOffline(offline bool) {
+	s.elOffline = offline
+}
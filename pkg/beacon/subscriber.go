@@ -2,6 +2,9 @@ package beacon
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/altair"
@@ -14,118 +17,366 @@ func (n *node) handleSubscriberError(err error, topic string) {
 	}
 }
 
+// handlerRetryQueueSize is the number of failed events per handler that can wait to be retried. Events that
+// fail while the queue is full are not retried.
+const handlerRetryQueueSize = 64
+
+// handlerRetry is a failed event waiting to be passed to its handler again.
+type handlerRetry struct {
+	event any
+	call  func(ctx context.Context) error
+	// attempts is the number of times the handler has been called with the event.
+	attempts int
+}
+
+// handlerRetryQueue retries the failed events of a single handler in its own goroutine, so that the backoff
+// between attempts does not hold up the dispatch of events to the other handlers.
+type handlerRetryQueue struct {
+	topic    string
+	retries  chan *handlerRetry
+	start    sync.Once
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func newHandlerRetryQueue(topic string) *handlerRetryQueue {
+	return &handlerRetryQueue{
+		topic:   topic,
+		retries: make(chan *handlerRetry, handlerRetryQueueSize),
+		stopped: make(chan struct{}),
+	}
+}
+
+// enqueue queues the retry, starting the queue's goroutine on first use. It returns false if the retry could
+// not be queued because the queue is full or stopped.
+func (q *handlerRetryQueue) enqueue(ctx context.Context, n *node, retry *handlerRetry) bool {
+	select {
+	case <-q.stopped:
+		return false
+	default:
+	}
+
+	q.start.Do(func() {
+		go q.run(ctx, n)
+	})
+
+	select {
+	case q.retries <- retry:
+		return true
+	default:
+		n.log.WithField("topic", q.topic).Warn("Handler retry queue is full, not retrying event")
+
+		return false
+	}
+}
+
+// stop drops the queued retries and stops the queue's goroutine.
+func (q *handlerRetryQueue) stop() {
+	q.stopOnce.Do(func() {
+		close(q.stopped)
+	})
+}
+
+func (q *handlerRetryQueue) run(ctx context.Context, n *node) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.stopped:
+			return
+		case retry := <-q.retries:
+			// The backoff doubles with every retry.
+			backoff := n.handlerOptions().RetryBackoff.Duration << (retry.attempts - 1)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.stopped:
+				return
+			case <-time.After(backoff):
+			}
+
+			n.attemptHandler(ctx, q, retry)
+		}
+	}
+}
+
+// handlerOptions returns the configured handler options.
+func (n *node) handlerOptions() HandlerOptions {
+	if options := n.currentOptions(); options != nil {
+		return options.Handlers
+	}
+
+	return HandlerOptions{}
+}
+
+// runHandler calls handler for the event, applying the configured handler error policy if it fails. Failed
+// events are retried through the handler's retry queue, so runHandler returns after the first attempt.
+func (n *node) runHandler(ctx context.Context, event any, retries *handlerRetryQueue, handler func(ctx context.Context) error) {
+	n.attemptHandler(ctx, retries, &handlerRetry{event: event, call: handler})
+}
+
+// attemptHandler calls the handler with the event once more. If it fails, the failure is reported and the
+// event is queued for another retry if the error policy allows it.
+func (n *node) attemptHandler(ctx context.Context, retries *handlerRetryQueue, retry *handlerRetry) {
+	opts := n.handlerOptions()
+	topic := retries.topic
+
+	retry.attempts++
+
+	err := n.runHandlerAttempt(ctx, opts.Timeout.Duration, retry.call)
+	if err == nil {
+		return
+	}
+
+	n.handleSubscriberError(err, topic)
+
+	maxRetries := 0
+	if opts.ErrorPolicy == HandlerErrorPolicyRetry || opts.ErrorPolicy == HandlerErrorPolicyDeadLetter {
+		maxRetries = opts.MaxRetries
+	}
+
+	// The queued retry is a copy, as it may be picked up by the queue's goroutine straight away.
+	next := *retry
+
+	willRetry := retry.attempts <= maxRetries && ctx.Err() == nil && retries.enqueue(ctx, n, &next)
+	deadLettered := !willRetry && opts.ErrorPolicy == HandlerErrorPolicyDeadLetter && n.forwardDeadLetter(opts.DeadLetters, topic, retry.event, err)
+
+	// Failures of handler error handlers are only logged to avoid feedback loops.
+	if topic != topicHandlerError {
+		n.publishHandlerError(ctx, &HandlerErrorEvent{
+			Topic:        topic,
+			Err:          err,
+			Attempt:      retry.attempts,
+			WillRetry:    willRetry,
+			DeadLettered: deadLettered,
+		})
+	}
+}
+
+// runHandlerAttempt calls handler with a per-event context derived from ctx. If a timeout is given the
+// context is cancelled once it expires, and dispatch stops waiting for a handler that ignores it.
+func (n *node) runHandlerAttempt(ctx context.Context, timeout time.Duration, handler func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		return handler(ctx)
+	}
+
+	eventCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- handler(eventCtx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-eventCtx.Done():
+		return fmt.Errorf("handler did not complete: %w", eventCtx.Err())
+	}
+}
+
+// forwardDeadLetter sends the failed event to the dead letter channel without blocking. Returns false if
+// there is no channel or it is full.
+func (n *node) forwardDeadLetter(deadLetters chan<- *DeadLetter, topic string, event any, err error) bool {
+	if deadLetters == nil {
+		return false
+	}
+
+	select {
+	case deadLetters <- &DeadLetter{
+		Topic:    topic,
+		Event:    event,
+		Err:      err,
+		FailedAt: time.Now(),
+	}:
+		return true
+	default:
+		n.log.WithField("topic", topic).Warn("Dead letter channel is full, dropping event")
+
+		return false
+	}
+}
+
+// on registers a handler for the given topic with the broker.
+func on[T any](n *node, ctx context.Context, topic string, handler func(ctx context.Context, event T) error) {
+	retries := newHandlerRetryQueue(topic)
+
+	n.broker.On(topic, func(event T) {
+		n.runHandler(ctx, event, retries, func(ctx context.Context) error {
+			return handler(ctx, event)
+		})
+	})
+}
+
 // Official Beacon events
 func (n *node) OnBlock(ctx context.Context, handler func(ctx context.Context, event *v1.BlockEvent) error) {
-	n.broker.On(topicBlock, func(event *v1.BlockEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicBlock)
-	})
+	on(n, ctx, topicBlock, handler)
 }
 
 func (n *node) OnAttestation(ctx context.Context, handler func(ctx context.Context, event *phase0.Attestation) error) {
-	n.broker.On(topicAttestation, func(event *phase0.Attestation) {
-		n.handleSubscriberError(handler(ctx, event), topicAttestation)
-	})
+	on(n, ctx, topicAttestation, handler)
 }
 
 func (n *node) OnChainReOrg(ctx context.Context, handler func(ctx context.Context, event *v1.ChainReorgEvent) error) {
-	n.broker.On(topicChainReorg, func(event *v1.ChainReorgEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicChainReorg)
-	})
+	on(n, ctx, topicChainReorg, handler)
 }
 
 func (n *node) OnFinalizedCheckpoint(ctx context.Context, handler func(ctx context.Context, event *v1.FinalizedCheckpointEvent) error) {
-	n.broker.On(topicFinalizedCheckpoint, func(event *v1.FinalizedCheckpointEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicFinalizedCheckpoint)
-	})
+	on(n, ctx, topicFinalizedCheckpoint, handler)
 }
 
 func (n *node) OnHead(ctx context.Context, handler func(ctx context.Context, event *v1.HeadEvent) error) {
-	n.broker.On(topicHead, func(event *v1.HeadEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicHead)
-	})
+	on(n, ctx, topicHead, handler)
 }
 
 func (n *node) OnVoluntaryExit(ctx context.Context, handler func(ctx context.Context, event *phase0.SignedVoluntaryExit) error) {
-	n.broker.On(topicVoluntaryExit, func(event *phase0.SignedVoluntaryExit) {
-		n.handleSubscriberError(handler(ctx, event), topicVoluntaryExit)
-	})
+	on(n, ctx, topicVoluntaryExit, handler)
 }
 
 func (n *node) OnContributionAndProof(ctx context.Context, handler func(ctx context.Context, event *altair.SignedContributionAndProof) error) {
-	n.broker.On(topicContributionAndProof, func(event *altair.SignedContributionAndProof) {
-		n.handleSubscriberError(handler(ctx, event), topicContributionAndProof)
-	})
+	on(n, ctx, topicContributionAndProof, handler)
 }
 
 func (n *node) OnBlobSidecar(ctx context.Context, handler func(ctx context.Context, event *v1.BlobSidecarEvent) error) {
-	n.broker.On(topicBlobSidecar, func(event *v1.BlobSidecarEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicBlobSidecar)
-	})
+	on(n, ctx, topicBlobSidecar, handler)
 }
 
 func (n *node) OnEvent(ctx context.Context, handler func(ctx context.Context, event *v1.Event) error) {
-	n.broker.On(topicEvent, func(event *v1.Event) {
-		n.handleSubscriberError(handler(ctx, event), topicEvent)
-	})
+	on(n, ctx, topicEvent, handler)
 }
 
 // Custom Events
 func (n *node) OnReady(ctx context.Context, handler func(ctx context.Context, event *ReadyEvent) error) {
-	n.broker.On(topicReady, func(event *ReadyEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicReady)
-	})
+	on(n, ctx, topicReady, handler)
 }
 
 func (n *node) OnSyncStatus(ctx context.Context, handler func(ctx context.Context, event *SyncStatusEvent) error) {
-	n.broker.On(topicSyncStatus, func(event *SyncStatusEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicSyncStatus)
-	})
+	on(n, ctx, topicSyncStatus, handler)
 }
 
 func (n *node) OnNodeVersionUpdated(ctx context.Context, handler func(ctx context.Context, event *NodeVersionUpdatedEvent) error) {
-	n.broker.On(topicNodeVersionUpdated, func(event *NodeVersionUpdatedEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicNodeVersionUpdated)
-	})
+	on(n, ctx, topicNodeVersionUpdated, handler)
+}
+
+func (n *node) OnIdentityUpdated(ctx context.Context, handler func(ctx context.Context, event *IdentityUpdatedEvent) error) {
+	on(n, ctx, topicIdentityUpdated, handler)
 }
 
 func (n *node) OnPeersUpdated(ctx context.Context, handler func(ctx context.Context, event *PeersUpdatedEvent) error) {
-	n.broker.On(topicPeersUpdated, func(event *PeersUpdatedEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicPeersUpdated)
-	})
+	on(n, ctx, topicPeersUpdated, handler)
 }
 
 func (n *node) OnSpecUpdated(ctx context.Context, handler func(ctx context.Context, event *SpecUpdatedEvent) error) {
-	n.broker.On(topicSpecUpdated, func(event *SpecUpdatedEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicSpecUpdated)
-	})
+	on(n, ctx, topicSpecUpdated, handler)
 }
 
 func (n *node) OnEmptySlot(ctx context.Context, handler func(ctx context.Context, event *EmptySlotEvent) error) {
-	n.broker.On(topicEmptySlot, func(event *EmptySlotEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicEmptySlot)
-	})
+	on(n, ctx, topicEmptySlot, handler)
 }
 
 func (n *node) OnHealthCheckFailed(ctx context.Context, handler func(ctx context.Context, event *HealthCheckFailedEvent) error) {
-	n.broker.On(topicHealthCheckFailed, func(event *HealthCheckFailedEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicHealthCheckFailed)
-	})
+	on(n, ctx, topicHealthCheckFailed, handler)
 }
 
 func (n *node) OnHealthCheckSucceeded(ctx context.Context, handler func(ctx context.Context, event *HealthCheckSucceededEvent) error) {
-	n.broker.On(topicHealthCheckSucceeded, func(event *HealthCheckSucceededEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicHealthCheckSucceeded)
-	})
+	on(n, ctx, topicHealthCheckSucceeded, handler)
 }
 
 func (n *node) OnFinalityCheckpointUpdated(ctx context.Context, handler func(ctx context.Context, event *FinalityCheckpointUpdated) error) {
-	n.broker.On(topicFinalityCheckpointUpdated, func(event *FinalityCheckpointUpdated) {
-		n.handleSubscriberError(handler(ctx, event), topicFinalityCheckpointUpdated)
-	})
+	on(n, ctx, topicFinalityCheckpointUpdated, handler)
 }
 
 func (n *node) OnFirstTimeHealthy(ctx context.Context, handler func(ctx context.Context, event *FirstTimeHealthyEvent) error) {
-	n.broker.On(topicFirstTimeHealthy, func(event *FirstTimeHealthyEvent) {
-		n.handleSubscriberError(handler(ctx, event), topicFirstTimeHealthy)
-	})
+	on(n, ctx, topicFirstTimeHealthy, handler)
+}
+
+func (n *node) OnBlockOrphaned(ctx context.Context, handler func(ctx context.Context, event *BlockOrphanedEvent) error) {
+	on(n, ctx, topicBlockOrphaned, handler)
+}
+
+func (n *node) OnPossibleSlashing(ctx context.Context, handler func(ctx context.Context, event *PossibleSlashingEvent) error) {
+	on(n, ctx, topicPossibleSlashing, handler)
+}
+
+func (n *node) OnAnyEvent(ctx context.Context, handler func(ctx context.Context, event *EventEnvelope) error) {
+	on(n, ctx, topicAnyEvent, handler)
+}
+
+func (n *node) OnAnyCustomEvent(ctx context.Context, handler func(ctx context.Context, event *EventEnvelope) error) {
+	on(n, ctx, topicAnyCustomEvent, handler)
+}
+
+func (n *node) OnHeadLag(ctx context.Context, handler func(ctx context.Context, event *HeadLagEvent) error) {
+	on(n, ctx, topicHeadLag, handler)
+}
+
+func (n *node) OnClockSkewExceeded(ctx context.Context, handler func(ctx context.Context, event *ClockSkewExceededEvent) error) {
+	on(n, ctx, topicClockSkewExceeded, handler)
+}
+
+func (n *node) OnHandlerError(ctx context.Context, handler func(ctx context.Context, event *HandlerErrorEvent) error) {
+	on(n, ctx, topicHandlerError, handler)
+}
+
+func (n *node) OnConfigReloaded(ctx context.Context, handler func(ctx context.Context, event *ConfigReloadedEvent) error) {
+	on(n, ctx, topicConfigReloaded, handler)
+}
+
+func (n *node) OnEventStreamConnected(ctx context.Context, handler func(ctx context.Context, event *EventStreamConnectedEvent) error) {
+	on(n, ctx, topicEventStreamConnected, handler)
+}
+
+func (n *node) OnEventStreamDisconnected(ctx context.Context, handler func(ctx context.Context, event *EventStreamDisconnectedEvent) error) {
+	on(n, ctx, topicEventStreamDisconnected, handler)
+}
+
+func (n *node) OnDataIntegrity(ctx context.Context, handler func(ctx context.Context, event *DataIntegrityEvent) error) {
+	on(n, ctx, topicDataIntegrity, handler)
+}
+
+func (n *node) OnBlockGraffiti(ctx context.Context, handler func(ctx context.Context, event *BlockGraffitiEvent) error) {
+	on(n, ctx, topicBlockGraffiti, handler)
+}
+
+func (n *node) OnDepositDivergence(ctx context.Context, handler func(ctx context.Context, event *DepositDivergenceEvent) error) {
+	on(n, ctx, topicDepositDivergence, handler)
+}
+
+func (n *node) OnWithdrawal(ctx context.Context, handler func(ctx context.Context, event *WithdrawalEvent) error) {
+	on(n, ctx, topicWithdrawal, handler)
+}
+
+func (n *node) OnWithdrawalReverted(ctx context.Context, handler func(ctx context.Context, event *WithdrawalEvent) error) {
+	on(n, ctx, topicWithdrawalReverted, handler)
+}
+
+func (n *node) OnSyncStateChanged(ctx context.Context, handler func(ctx context.Context, event *SyncStateChangedEvent) error) {
+	on(n, ctx, topicSyncStateChanged, handler)
+}
+
+func (n *node) OnELOffline(ctx context.Context, handler func(ctx context.Context, event *ELOfflineEvent) error) {
+	on(n, ctx, topicELOffline, handler)
+}
+
+func (n *node) OnForkImminent(ctx context.Context, handler func(ctx context.Context, event *ForkImminentEvent) error) {
+	on(n, ctx, topicForkImminent, handler)
+}
+
+func (n *node) OnForkActivated(ctx context.Context, handler func(ctx context.Context, event *ForkActivatedEvent) error) {
+	on(n, ctx, topicForkActivated, handler)
+}
+
+func (n *node) OnBlobLimitChanged(ctx context.Context, handler func(ctx context.Context, event *BlobLimitChangedEvent) error) {
+	on(n, ctx, topicBlobLimitChanged, handler)
+}
+
+func (n *node) OnDataColumnSidecar(ctx context.Context, handler func(ctx context.Context, event *DataColumnSidecarEvent) error) {
+	on(n, ctx, topicDataColumnSidecar, handler)
+}
+
+func (n *node) OnBootstrapFailed(ctx context.Context, handler func(ctx context.Context, event *BootstrapFailedEvent) error) {
+	on(n, ctx, topicBootstrapFailed, handler)
 }
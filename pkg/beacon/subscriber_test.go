@@ -0,0 +1,198 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/ethpandaops/beacon/pkg/human"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunHandlerTimeout(t *testing.T) {
+	n := &node{
+		log:    logging.New(nil),
+		broker: emission.NewEmitter(),
+		options: &Options{
+			Handlers: HandlerOptions{Timeout: human.Duration{Duration: 50 * time.Millisecond}},
+		},
+	}
+
+	handlerErr := make(chan error, 1)
+
+	start := time.Now()
+
+	n.runHandler(context.Background(), nil, newHandlerRetryQueue(topicBlock), func(ctx context.Context) error {
+		<-ctx.Done()
+
+		handlerErr <- ctx.Err()
+
+		return ctx.Err()
+	})
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.True(t, errors.Is(<-handlerErr, context.DeadlineExceeded))
+
+	// A handler that ignores its context does not block dispatch.
+	release := make(chan struct{})
+	defer close(release)
+
+	start = time.Now()
+
+	n.runHandler(context.Background(), nil, newHandlerRetryQueue(topicBlock), func(ctx context.Context) error {
+		<-release
+
+		return nil
+	})
+
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRunHandlerWithoutTimeout(t *testing.T) {
+	n := &node{
+		log:     logging.New(nil),
+		broker:  emission.NewEmitter(),
+		options: &Options{},
+	}
+
+	called := false
+
+	n.runHandler(context.Background(), nil, newHandlerRetryQueue(topicBlock), func(ctx context.Context) error {
+		_, hasDeadline := ctx.Deadline()
+		assert.False(t, hasDeadline)
+
+		called = true
+
+		return nil
+	})
+
+	assert.True(t, called)
+}
+
+func TestRunHandlerErrorPolicy(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             HandlerOptions
+		expectedCalls    int
+		expectedErrors   int
+		expectedDeadLets int
+	}{
+		{
+			name:           "log",
+			opts:           HandlerOptions{ErrorPolicy: HandlerErrorPolicyLog, MaxRetries: 3},
+			expectedCalls:  1,
+			expectedErrors: 1,
+		},
+		{
+			name:           "retry",
+			opts:           HandlerOptions{ErrorPolicy: HandlerErrorPolicyRetry, MaxRetries: 2},
+			expectedCalls:  3,
+			expectedErrors: 3,
+		},
+		{
+			name:             "dead letter",
+			opts:             HandlerOptions{ErrorPolicy: HandlerErrorPolicyDeadLetter, MaxRetries: 1},
+			expectedCalls:    2,
+			expectedErrors:   2,
+			expectedDeadLets: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deadLetters := make(chan *DeadLetter, 1)
+
+			test.opts.RetryBackoff = human.Duration{Duration: time.Millisecond}
+			test.opts.DeadLetters = deadLetters
+
+			n := &node{
+				log:     logging.New(nil),
+				broker:  emission.NewEmitter(),
+				options: &Options{Handlers: test.opts},
+			}
+
+			errorEvents := make(chan *HandlerErrorEvent, 10)
+
+			n.OnHandlerError(context.Background(), func(ctx context.Context, event *HandlerErrorEvent) error {
+				errorEvents <- event
+
+				return nil
+			})
+
+			var calls atomic.Int32
+
+			start := time.Now()
+
+			n.runHandler(context.Background(), "payload", newHandlerRetryQueue(topicBlock), func(ctx context.Context) error {
+				calls.Add(1)
+
+				return errors.New("failed")
+			})
+
+			// Only the first attempt is made before dispatch moves on, retries are made in the background.
+			assert.Less(t, time.Since(start), time.Second)
+
+			var last *HandlerErrorEvent
+
+			for i := 0; i < test.expectedErrors; i++ {
+				select {
+				case last = <-errorEvents:
+					assert.Equal(t, i+1, last.Attempt)
+				case <-time.After(time.Second):
+					t.Fatal("timed out waiting for handler error event")
+				}
+			}
+
+			assert.Equal(t, int32(test.expectedCalls), calls.Load())
+			assert.Len(t, deadLetters, test.expectedDeadLets)
+
+			assert.False(t, last.WillRetry)
+			assert.Equal(t, test.expectedCalls, last.Attempt)
+			assert.Equal(t, test.expectedDeadLets == 1, last.DeadLettered)
+
+			if test.expectedDeadLets == 1 {
+				deadLetter := <-deadLetters
+				assert.Equal(t, topicBlock, deadLetter.Topic)
+				assert.Equal(t, "payload", deadLetter.Event)
+			}
+		})
+	}
+}
+
+func TestRunHandlerRetriesDoNotBlockDispatch(t *testing.T) {
+	n := &node{
+		log:    logging.New(nil),
+		broker: emission.NewEmitter(),
+		options: &Options{Handlers: HandlerOptions{
+			ErrorPolicy:  HandlerErrorPolicyRetry,
+			MaxRetries:   1,
+			RetryBackoff: human.Duration{Duration: time.Hour},
+		}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	retries := newHandlerRetryQueue(topicBlock)
+	defer retries.stop()
+
+	calls := 0
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		n.runHandler(ctx, i, retries, func(ctx context.Context) error {
+			calls++
+
+			return errors.New("failed")
+		})
+	}
+
+	// Every event gets its first attempt straight away, despite the pending retries.
+	assert.Equal(t, 3, calls)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Len(t, retries.retries, 3)
+}
@@ -0,0 +1,245 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Topics that can be passed to Subscribe.
+const (
+	TopicBlock                     = topicBlock
+	TopicAttestation               = topicAttestation
+	TopicChainReorg                = topicChainReorg
+	TopicFinalizedCheckpoint       = topicFinalizedCheckpoint
+	TopicHead                      = topicHead
+	TopicVoluntaryExit             = topicVoluntaryExit
+	TopicContributionAndProof      = topicContributionAndProof
+	TopicBlobSidecar               = topicBlobSidecar
+	TopicDataColumnSidecar         = topicDataColumnSidecar
+	TopicEvent                     = topicEvent
+	TopicReady                     = topicReady
+	TopicSyncStatus                = topicSyncStatus
+	TopicNodeVersionUpdated        = topicNodeVersionUpdated
+	TopicIdentityUpdated           = topicIdentityUpdated
+	TopicPeersUpdated              = topicPeersUpdated
+	TopicSpecUpdated               = topicSpecUpdated
+	TopicEmptySlot                 = topicEmptySlot
+	TopicHealthCheckSucceeded      = topicHealthCheckSucceeded
+	TopicHealthCheckFailed         = topicHealthCheckFailed
+	TopicFinalityCheckpointUpdated = topicFinalityCheckpointUpdated
+	TopicFirstTimeHealthy          = topicFirstTimeHealthy
+	TopicBlockOrphaned             = topicBlockOrphaned
+	TopicPossibleSlashing          = topicPossibleSlashing
+	TopicHeadLag                   = topicHeadLag
+	TopicClockSkewExceeded         = topicClockSkewExceeded
+	TopicAnyEvent                  = topicAnyEvent
+	TopicAnyCustomEvent            = topicAnyCustomEvent
+	TopicHandlerError              = topicHandlerError
+	TopicConfigReloaded            = topicConfigReloaded
+	TopicEventStreamConnected      = topicEventStreamConnected
+	TopicEventStreamDisconnected   = topicEventStreamDisconnected
+	TopicDataIntegrity             = topicDataIntegrity
+	TopicBlockGraffiti             = topicBlockGraffiti
+	TopicDepositDivergence         = topicDepositDivergence
+	TopicWithdrawal                = topicWithdrawal
+	TopicWithdrawalReverted        = topicWithdrawalReverted
+	TopicSyncStateChanged          = topicSyncStateChanged
+	TopicELOffline                 = topicELOffline
+	TopicForkImminent              = topicForkImminent
+	TopicForkActivated             = topicForkActivated
+	TopicBlobLimitChanged          = topicBlobLimitChanged
+	TopicBootstrapFailed           = topicBootstrapFailed
+)
+
+// topicEventTypes maps each topic published by the node to the type of its event.
+var topicEventTypes = map[string]reflect.Type{
+	topicBlock:                     reflect.TypeOf(&v1.BlockEvent{}),
+	topicAttestation:               reflect.TypeOf(&phase0.Attestation{}),
+	topicChainReorg:                reflect.TypeOf(&v1.ChainReorgEvent{}),
+	topicFinalizedCheckpoint:       reflect.TypeOf(&v1.FinalizedCheckpointEvent{}),
+	topicHead:                      reflect.TypeOf(&v1.HeadEvent{}),
+	topicVoluntaryExit:             reflect.TypeOf(&phase0.SignedVoluntaryExit{}),
+	topicContributionAndProof:      reflect.TypeOf(&altair.SignedContributionAndProof{}),
+	topicBlobSidecar:               reflect.TypeOf(&v1.BlobSidecarEvent{}),
+	topicDataColumnSidecar:         reflect.TypeOf(&DataColumnSidecarEvent{}),
+	topicEvent:                     reflect.TypeOf(&v1.Event{}),
+	topicReady:                     reflect.TypeOf(&ReadyEvent{}),
+	topicSyncStatus:                reflect.TypeOf(&SyncStatusEvent{}),
+	topicNodeVersionUpdated:        reflect.TypeOf(&NodeVersionUpdatedEvent{}),
+	topicIdentityUpdated:           reflect.TypeOf(&IdentityUpdatedEvent{}),
+	topicPeersUpdated:              reflect.TypeOf(&PeersUpdatedEvent{}),
+	topicSpecUpdated:               reflect.TypeOf(&SpecUpdatedEvent{}),
+	topicEmptySlot:                 reflect.TypeOf(&EmptySlotEvent{}),
+	topicHealthCheckSucceeded:      reflect.TypeOf(&HealthCheckSucceededEvent{}),
+	topicHealthCheckFailed:         reflect.TypeOf(&HealthCheckFailedEvent{}),
+	topicFinalityCheckpointUpdated: reflect.TypeOf(&FinalityCheckpointUpdated{}),
+	topicFirstTimeHealthy:          reflect.TypeOf(&FirstTimeHealthyEvent{}),
+	topicBlockOrphaned:             reflect.TypeOf(&BlockOrphanedEvent{}),
+	topicPossibleSlashing:          reflect.TypeOf(&PossibleSlashingEvent{}),
+	topicHeadLag:                   reflect.TypeOf(&HeadLagEvent{}),
+	topicClockSkewExceeded:         reflect.TypeOf(&ClockSkewExceededEvent{}),
+	topicAnyEvent:                  reflect.TypeOf(&EventEnvelope{}),
+	topicAnyCustomEvent:            reflect.TypeOf(&EventEnvelope{}),
+	topicHandlerError:              reflect.TypeOf(&HandlerErrorEvent{}),
+	topicConfigReloaded:            reflect.TypeOf(&ConfigReloadedEvent{}),
+	topicEventStreamConnected:      reflect.TypeOf(&EventStreamConnectedEvent{}),
+	topicEventStreamDisconnected:   reflect.TypeOf(&EventStreamDisconnectedEvent{}),
+	topicDataIntegrity:             reflect.TypeOf(&DataIntegrityEvent{}),
+	topicBlockGraffiti:             reflect.TypeOf(&BlockGraffitiEvent{}),
+	topicDepositDivergence:         reflect.TypeOf(&DepositDivergenceEvent{}),
+	topicWithdrawal:                reflect.TypeOf(&WithdrawalEvent{}),
+	topicWithdrawalReverted:        reflect.TypeOf(&WithdrawalEvent{}),
+	topicSyncStateChanged:          reflect.TypeOf(&SyncStateChangedEvent{}),
+	topicELOffline:                 reflect.TypeOf(&ELOfflineEvent{}),
+	topicForkImminent:              reflect.TypeOf(&ForkImminentEvent{}),
+	topicForkActivated:             reflect.TypeOf(&ForkActivatedEvent{}),
+	topicBlobLimitChanged:          reflect.TypeOf(&BlobLimitChangedEvent{}),
+	topicBootstrapFailed:           reflect.TypeOf(&BootstrapFailedEvent{}),
+}
+
+// Subscription is a handle to a handler registered with Subscribe.
+type Subscription interface {
+	// Topic returns the topic the handler is subscribed to.
+	Topic() string
+	// Unsubscribe stops the handler from receiving any further events.
+	Unsubscribe()
+}
+
+type subscription struct {
+	topic    string
+	id       uint64
+	registry *subscriptionRegistry
+	retries  *handlerRetryQueue
+	once     sync.Once
+}
+
+// subscribedHandler is a handler registered with SubscribeTopic, together with the queue its failed events are
+// retried from.
+type subscribedHandler struct {
+	handler func(ctx context.Context, event any) error
+	retries *handlerRetryQueue
+}
+
+func (s *subscription) Topic() string {
+	return s.topic
+}
+
+func (s *subscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.registry.remove(s.topic, s.id)
+		s.retries.stop()
+	})
+}
+
+// subscriptionRegistry holds the handlers registered with SubscribeTopic. A single listener is registered
+// with the broker per topic which dispatches to the handlers, as the broker cannot reliably remove
+// individual closures.
+type subscriptionRegistry struct {
+	mu       sync.RWMutex
+	nextID   uint64
+	handlers map[string]map[uint64]*subscribedHandler
+}
+
+func newSubscriptionRegistry() *subscriptionRegistry {
+	return &subscriptionRegistry{
+		handlers: make(map[string]map[uint64]*subscribedHandler),
+	}
+}
+
+// add registers the handler and returns its id, and whether it is the first handler for the topic.
+func (r *subscriptionRegistry) add(topic string, handler *subscribedHandler) (id uint64, first bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.handlers[topic]; !exists {
+		r.handlers[topic] = make(map[uint64]*subscribedHandler)
+		first = true
+	}
+
+	r.nextID++
+
+	r.handlers[topic][r.nextID] = handler
+
+	return r.nextID, first
+}
+
+func (r *subscriptionRegistry) remove(topic string, id uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.handlers[topic], id)
+}
+
+func (r *subscriptionRegistry) get(topic string) []*subscribedHandler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	handlers := make([]*subscribedHandler, 0, len(r.handlers[topic]))
+	for _, handler := range r.handlers[topic] {
+		handlers = append(handlers, handler)
+	}
+
+	return handlers
+}
+
+// Subscribe registers a handler for the given topic with Node.SubscribeTopic and returns a handle to
+// unsubscribe it. An error is returned if the topic is unknown or if T does not match the type of the events
+// published on the topic.
+func Subscribe[T any](n Node, topic string, handler func(ctx context.Context, event T) error) (Subscription, error) {
+	expected, exists := topicEventTypes[topic]
+	if !exists {
+		return nil, fmt.Errorf("unknown topic %q", topic)
+	}
+
+	if actual := reflect.TypeOf((*T)(nil)).Elem(); actual != expected {
+		return nil, fmt.Errorf("topic %q publishes %s events, not %s", topic, expected, actual)
+	}
+
+	return n.SubscribeTopic(topic, func(ctx context.Context, event any) error {
+		typed, ok := event.(T)
+		if !ok {
+			return fmt.Errorf("unexpected %T event on topic %q", event, topic)
+		}
+
+		return handler(ctx, typed)
+	})
+}
+
+// SubscribeTopic registers a handler for the given topic and returns a handle to unsubscribe it. An error is
+// returned if the topic is unknown. Handlers are called with the context the node was started with.
+func (n *node) SubscribeTopic(topic string, handler func(ctx context.Context, event any) error) (Subscription, error) {
+	if _, exists := topicEventTypes[topic]; !exists {
+		return nil, fmt.Errorf("unknown topic %q", topic)
+	}
+
+	retries := newHandlerRetryQueue(topic)
+
+	id, first := n.subscriptions.add(topic, &subscribedHandler{handler: handler, retries: retries})
+	if first {
+		n.broker.On(topic, func(event any) {
+			ctx := n.currentCtx()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			for _, h := range n.subscriptions.get(topic) {
+				n.runHandler(ctx, event, h.retries, func(ctx context.Context) error {
+					return h.handler(ctx, event)
+				})
+			}
+		})
+	}
+
+	return &subscription{
+		topic:    topic,
+		id:       id,
+		registry: n.subscriptions,
+		retries:  retries,
+	}, nil
+}
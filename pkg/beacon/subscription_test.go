@@ -0,0 +1,94 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribe(t *testing.T) {
+	n := &node{
+		log:           logging.New(nil),
+		config:        &Config{Name: "node-a"},
+		broker:        emission.NewEmitter(),
+		subscriptions: newSubscriptionRegistry(),
+	}
+
+	ctx := context.Background()
+
+	var first, second []phase0.Slot
+
+	sub, err := Subscribe(n, topicEmptySlot, func(ctx context.Context, event *EmptySlotEvent) error {
+		first = append(first, event.Slot)
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, topicEmptySlot, sub.Topic())
+
+	_, err = Subscribe(n, topicEmptySlot, func(ctx context.Context, event *EmptySlotEvent) error {
+		second = append(second, event.Slot)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	n.publishEmptySlot(ctx, phase0.Slot(1))
+
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+
+	n.publishEmptySlot(ctx, phase0.Slot(2))
+
+	assert.Equal(t, []phase0.Slot{1}, first)
+	assert.Equal(t, []phase0.Slot{1, 2}, second)
+}
+
+func TestSubscribeErrors(t *testing.T) {
+	n := &node{
+		broker:        emission.NewEmitter(),
+		subscriptions: newSubscriptionRegistry(),
+	}
+
+	_, err := Subscribe(n, "unknown", func(ctx context.Context, event *EmptySlotEvent) error {
+		return nil
+	})
+	assert.Error(t, err)
+
+	_, err = Subscribe(n, topicEmptySlot, func(ctx context.Context, event *HeadLagEvent) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
+
+// wrappedNode is a Node implementation other than *node that delegates to one.
+type wrappedNode struct {
+	Node
+}
+
+func TestSubscribeWrappedNode(t *testing.T) {
+	n := &node{
+		log:           logging.New(nil),
+		config:        &Config{Name: "node-a"},
+		broker:        emission.NewEmitter(),
+		subscriptions: newSubscriptionRegistry(),
+	}
+
+	var slots []phase0.Slot
+
+	_, err := Subscribe(&wrappedNode{Node: n}, topicEmptySlot, func(ctx context.Context, event *EmptySlotEvent) error {
+		slots = append(slots, event.Slot)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	n.publishEmptySlot(context.Background(), phase0.Slot(1))
+
+	assert.Equal(t, []phase0.Slot{1}, slots)
+}
@@ -2,6 +2,7 @@ package beacon
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -10,6 +11,8 @@ import (
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
 )
 
 func (n *node) ensureBeaconSubscription(ctx context.Context) error {
@@ -18,15 +21,15 @@ func (n *node) ensureBeaconSubscription(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-time.After(time.Second * 2):
-			if len(n.options.BeaconSubscription.Topics) == 0 {
+			if len(n.currentOptions().BeaconSubscription.Topics) == 0 {
 				continue
 			}
 
-			if n.client == nil {
+			if n.currentClient() == nil {
 				continue
 			}
 
-			if !n.options.BeaconSubscription.Enabled {
+			if !n.currentOptions().BeaconSubscription.Enabled {
 				continue
 			}
 
@@ -42,29 +45,261 @@ func (n *node) ensureBeaconSubscription(ctx context.Context) error {
 }
 
 func (n *node) subscribeToBeaconEvents(ctx context.Context) error {
-	provider, isProvider := n.client.(eth2client.EventsProvider)
+	provider, isProvider := n.currentClient().(eth2client.EventsProvider)
 	if !isProvider {
 		return errors.New("client does not implement eth2client.Subscriptions")
 	}
 
-	topics := n.options.BeaconSubscription.Topics
+	if n.ClientType() == types.AgentUnknown {
+		if _, err := n.FetchNodeVersion(ctx); err != nil {
+			n.log.WithError(err).Debug("Failed to fetch node version before subscribing to events")
+		}
+	}
+
+	topics := n.filterSupportedTopics(n.currentOptions().BeaconSubscription.Topics)
+	if len(topics) == 0 {
+		return errors.New("beacon node does not support any of the configured event topics")
+	}
 
 	n.log.WithField("topics", topics).Info("Subscribing to events upstream")
 
+	ctx, cancelCause := context.WithCancelCause(ctx)
+	cancel := func() { cancelCause(errEventStreamRestarted) }
+
+	n.cancelEventsMu.Lock()
+	if n.cancelEvents != nil {
+		n.cancelEvents()
+	}
+
+	n.cancelEvents = cancel
+	n.cancelEventsMu.Unlock()
+
+	topics, rawTopics := splitRawEventTopics(topics)
+
+	if len(rawTopics) > 0 {
+		go n.streamRawEvents(ctx, rawTopics)
+	}
+
+	for _, stream := range groupEventTopics(topics, n.currentOptions().BeaconSubscription.Groups) {
+		if err := n.subscribeEventStream(ctx, provider, stream.name, stream.topics); err != nil {
+			err = fmt.Errorf("failed to subscribe to %s event stream: %w", stream.name, err)
+
+			// Closes the streams of the previous groups as well.
+			cancelCause(err)
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// filterSupportedTopics returns the topics the node's client serves. The topics it does not serve are logged
+// and recorded, so they are reported in SubscribedTopics.
+func (n *node) filterSupportedTopics(topics EventTopics) EventTopics {
+	supported, unsupported := n.Quirks().FilterTopics(topics)
+	n.setUnsupportedTopics(unsupported)
+
+	if len(unsupported) > 0 {
+		n.log.WithField("topics", unsupported).WithField("client", n.ClientType()).Warn("Beacon node does not support some event topics, skipping them")
+	}
+
+	return supported
+}
+
+// eventStream is an upstream event stream and the topics it is subscribed to.
+type eventStream struct {
+	name   string
+	topics []string
+}
+
+// groupEventTopics splits the topics into one stream per group. Topics that are not part of any group share
+// EventStreamDefault, which comes first. A topic that is part of several groups is only subscribed to in the
+// first of them.
+func groupEventTopics(topics []string, groups []EventTopics) []eventStream {
+	grouped := make(map[string]bool, len(topics))
+	streams := []eventStream{}
+
+	for i, group := range groups {
+		stream := eventStream{name: EventStreamGroup(i)}
+
+		for _, topic := range group {
+			if grouped[topic] || !EventTopics(topics).Exists(topic) {
+				continue
+			}
+
+			grouped[topic] = true
+			stream.topics = append(stream.topics, topic)
+		}
+
+		if len(stream.topics) > 0 {
+			streams = append(streams, stream)
+		}
+	}
+
+	ungrouped := eventStream{name: EventStreamDefault}
+
+	for _, topic := range topics {
+		if !grouped[topic] {
+			ungrouped.topics = append(ungrouped.topics, topic)
+		}
+	}
+
+	if len(ungrouped.topics) > 0 {
+		streams = append([]eventStream{ungrouped}, streams...)
+	}
+
+	return streams
+}
+
+// subscribeEventStream subscribes to the topics through go-eth2-client. The stream is closed when the
+// context is cancelled.
+func (n *node) subscribeEventStream(ctx context.Context, provider eth2client.EventsProvider, stream string, topics []string) error {
 	if err := provider.Events(ctx, topics, func(event *v1.Event) {
-		n.lastEventTime = time.Now()
+		now := time.Now()
 
-		if err := n.handleEvent(ctx, event); err != nil {
+		n.lastEventTimeMu.Lock()
+		n.lastEventTime = now
+		n.lastEventTimeMu.Unlock()
+
+		n.recordEvent(event.Topic, event.Data)
+
+		if err := n.handleEvent(withReceivedAt(ctx, now), event); err != nil {
 			n.log.Errorf("Failed to handle event: %v", err)
 		}
 	}); err != nil {
 		return err
 	}
 
+	connectedAt := time.Now()
+	token := n.setStreamTopics(stream, topics)
+
+	n.publishEventStreamConnected(ctx, stream, topics, n.markStreamConnected(stream))
+
+	go func() {
+		<-ctx.Done()
+
+		n.clearStreamTopics(stream, token)
+
+		n.publishEventStreamDisconnected(ctx, stream, topics, context.Cause(ctx).Error(), eventStreamDisconnectCategory(ctx, nil), time.Since(connectedAt))
+	}()
+
 	return nil
 }
 
+// errEventStreamRestarted is the reason given when the upstream event stream is stopped to subscribe again.
+var errEventStreamRestarted = errors.New("event stream restarted")
+
+// eventStreamDisconnectCategory returns the EventStreamDisconnect constant for a stream that ended with the
+// given context and error.
+func eventStreamDisconnectCategory(ctx context.Context, err error) string {
+	switch {
+	case errors.Is(context.Cause(ctx), errEventStreamRestarted):
+		return EventStreamDisconnectRestarted
+	case ctx.Err() != nil:
+		return EventStreamDisconnectStopped
+	case err != nil:
+		return EventStreamDisconnectFailed
+	default:
+		return EventStreamDisconnectClosed
+	}
+}
+
+// rawEventTopics are the topics that go-eth2-client does not support yet but this library decodes. They are
+// streamed through the raw API client instead, along with any other topic go-eth2-client cannot decode.
+var rawEventTopics = EventTopics{
+	topicDataColumnSidecar,
+}
+
+// splitRawEventTopics splits the topics into those decoded through go-eth2-client and those streamed through
+// the raw API client, which are rawEventTopics and topics go-eth2-client cannot decode. Topics go-eth2-client
+// decodes but this library does not are still streamed through go-eth2-client, so OnEvent receives its types.
+func splitRawEventTopics(topics []string) (supported, raw []string) {
+	for _, topic := range topics {
+		if rawEventTopics.Exists(topic) || !v1.SupportedEventTopics[topic] {
+			raw = append(raw, topic)
+		} else {
+			supported = append(supported, topic)
+		}
+	}
+
+	return supported, raw
+}
+
+// streamRawEvents streams the given topics through the raw API client, reconnecting until the context is
+// cancelled.
+func (n *node) streamRawEvents(ctx context.Context, topics []string) {
+	for {
+		connectedAt := time.Now()
+		token := n.setStreamTopics(EventStreamRaw, topics)
+
+		n.publishEventStreamConnected(ctx, EventStreamRaw, topics, n.markStreamConnected(EventStreamRaw))
+
+		err := n.currentAPI().Events(ctx, topics, func(event *api.Event) {
+			n.lastEventTimeMu.Lock()
+			n.lastEventTime = event.ReceivedAt
+			n.lastEventTimeMu.Unlock()
+
+			n.recordEvent(event.Topic, event.Data)
+
+			if err := n.handleRawEvent(withReceivedAt(ctx, event.ReceivedAt), event); err != nil {
+				n.log.Errorf("Failed to handle event: %v", err)
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			n.log.WithError(err).WithField("topics", topics).Error("Raw event stream failed")
+		}
+
+		reason := "stream closed"
+
+		switch {
+		case ctx.Err() != nil:
+			reason = context.Cause(ctx).Error()
+		case err != nil:
+			reason = err.Error()
+		}
+
+		n.clearStreamTopics(EventStreamRaw, token)
+		n.publishEventStreamDisconnected(ctx, EventStreamRaw, topics, reason, eventStreamDisconnectCategory(ctx, err), time.Since(connectedAt))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second * 5):
+		}
+	}
+}
+
+func (n *node) handleRawEvent(ctx context.Context, event *api.Event) error {
+	switch event.Topic {
+	case topicDataColumnSidecar:
+		sidecar := &DataColumnSidecarEvent{}
+		if err := json.Unmarshal(event.Data, sidecar); err != nil {
+			return fmt.Errorf("failed to unmarshal data column sidecar event: %w", err)
+		}
+
+		n.publishEvent(ctx, &v1.Event{Topic: event.Topic, Data: sidecar})
+		n.publishDataColumnSidecar(ctx, sidecar)
+
+		return nil
+	default:
+		// Topics this library does not decode (e.g. introduced by a new fork) are passed through with their
+		// payload as json.RawMessage.
+		unhandled := &v1.Event{Topic: event.Topic, Data: json.RawMessage(event.Data)}
+
+		n.publishEvent(ctx, unhandled)
+		n.publishUnhandledEvent(ctx, unhandled)
+
+		return nil
+	}
+}
+
 func (n *node) handleEvent(ctx context.Context, event *v1.Event) error {
+	// Attestations are sampled before they reach any handler, including the generic event handlers.
+	if event.Topic == topicAttestation && n.attestationSampler != nil && !n.attestationSampler.sample(time.Now()) {
+		return nil
+	}
+
 	n.publishEvent(ctx, event)
 
 	switch event.Topic {
@@ -86,7 +321,10 @@ func (n *node) handleEvent(ctx context.Context, event *v1.Event) error {
 		return n.handleBlobSidecar(ctx, event)
 
 	default:
-		return fmt.Errorf("unknown event topic %s", event.Topic)
+		// Already published to the generic event handlers above.
+		n.publishUnhandledEvent(ctx, event)
+
+		return nil
 	}
 }
 
@@ -107,6 +345,10 @@ func (n *node) handleBlock(ctx context.Context, event *v1.Event) error {
 		return errors.New("invalid block event")
 	}
 
+	if n.isDuplicateEvent(topicBlock, block.Slot, block.Block) {
+		return nil
+	}
+
 	n.publishBlock(ctx, block)
 
 	return nil
@@ -118,6 +360,8 @@ func (n *node) handleChainReorg(ctx context.Context, event *v1.Event) error {
 		return errors.New("invalid chain reorg event")
 	}
 
+	n.reorgHeadSlot(chainReorg.Slot)
+
 	n.publishChainReOrg(ctx, chainReorg)
 
 	return nil
@@ -139,6 +383,12 @@ func (n *node) handleHead(ctx context.Context, event *v1.Event) error {
 		return errors.New("invalid head event")
 	}
 
+	n.setHeadSlot(head.Slot)
+
+	if n.isDuplicateEvent(topicHead, head.Slot, head.Block) {
+		return nil
+	}
+
 	n.publishHead(ctx, head)
 
 	return nil
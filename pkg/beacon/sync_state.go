@@ -0,0 +1,85 @@
+package beacon
+
+import (
+	"context"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Synced returns true if the node is healthy, neither syncing nor optimistic, and both its sync distance and
+// the distance between its head slot and the wallclock slot are within toleranceSlots.
+func (n *node) Synced(toleranceSlots uint64) bool {
+	if !n.Healthy() {
+		return false
+	}
+
+	state, err := n.SyncState()
+	if err != nil {
+		return false
+	}
+
+	if state.IsSyncing || state.IsOptimistic || uint64(state.SyncDistance) > toleranceSlots {
+		return false
+	}
+
+	wallclock := n.Wallclock()
+	if wallclock == nil {
+		return false
+	}
+
+	current, _, err := wallclock.Now()
+	if err != nil {
+		return false
+	}
+
+	headSlot, err := n.HeadSlot()
+	if err != nil {
+		headSlot = state.HeadSlot
+	}
+
+	if uint64(headSlot) >= current.Number() {
+		return true
+	}
+
+	return current.Number()-uint64(headSlot) <= toleranceSlots
+}
+
+// checkSyncStateChanged publishes a SyncStateChangedEvent if the sync state differs meaningfully from the sync
+// state of the previous poll. The head slot advancing steadily never adds up to a change, only jumps do.
+func (n *node) checkSyncStateChanged(ctx context.Context, current *v1.SyncState) {
+	if current == nil {
+		return
+	}
+
+	n.syncStateMu.Lock()
+
+	previous := n.syncState
+	n.syncState = current
+
+	n.syncStateMu.Unlock()
+
+	if !syncStateChanged(previous, current, n.currentOptions().SyncChangeThreshold) {
+		return
+	}
+
+	n.publishSyncStateChanged(ctx, previous, current)
+}
+
+// syncStateChanged returns true if the node started or stopped syncing, or its head slot moved by more than
+// threshold slots in either direction.
+func syncStateChanged(previous, current *v1.SyncState, threshold phase0.Slot) bool {
+	if previous == nil {
+		return true
+	}
+
+	if previous.IsSyncing != current.IsSyncing {
+		return true
+	}
+
+	if current.HeadSlot > previous.HeadSlot {
+		return current.HeadSlot-previous.HeadSlot > threshold
+	}
+
+	return previous.HeadSlot-current.HeadSlot > threshold
+}
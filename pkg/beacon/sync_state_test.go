@@ -0,0 +1,152 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/api/types"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/ethpandaops/ethwallclock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncStateChanged(t *testing.T) {
+	previous := &v1.SyncState{HeadSlot: 100}
+
+	assert.True(t, syncStateChanged(nil, previous, 32))
+	assert.False(t, syncStateChanged(previous, &v1.SyncState{HeadSlot: 132}, 32))
+	assert.True(t, syncStateChanged(previous, &v1.SyncState{HeadSlot: 133}, 32))
+	assert.True(t, syncStateChanged(previous, &v1.SyncState{HeadSlot: 67}, 32))
+	assert.True(t, syncStateChanged(previous, &v1.SyncState{HeadSlot: 100, IsSyncing: true}, 32))
+}
+
+func TestCheckSyncStateChanged(t *testing.T) {
+	n := &node{
+		log:     logging.New(nil),
+		broker:  emission.NewEmitter(),
+		options: DefaultOptions(),
+	}
+
+	events := make(chan *SyncStateChangedEvent, 4)
+
+	n.OnSyncStateChanged(context.Background(), func(ctx context.Context, event *SyncStateChangedEvent) error {
+		events <- event
+
+		return nil
+	})
+
+	// The head slot drifting past the threshold in small steps is not a change, a jump between two polls is.
+	n.checkSyncStateChanged(context.Background(), &v1.SyncState{HeadSlot: 100})
+	n.checkSyncStateChanged(context.Background(), &v1.SyncState{HeadSlot: 120})
+	n.checkSyncStateChanged(context.Background(), &v1.SyncState{HeadSlot: 140})
+	n.checkSyncStateChanged(context.Background(), &v1.SyncState{HeadSlot: 180})
+
+	for _, expected := range []struct {
+		previous *v1.SyncState
+		current  *v1.SyncState
+	}{
+		{nil, &v1.SyncState{HeadSlot: 100}},
+		{&v1.SyncState{HeadSlot: 140}, &v1.SyncState{HeadSlot: 180}},
+	} {
+		select {
+		case event := <-events:
+			assert.Equal(t, expected.previous, event.Previous)
+			assert.Equal(t, expected.current, event.Current)
+		case <-time.After(time.Second):
+			require.Fail(t, "expected a sync state changed event")
+		}
+	}
+
+	select {
+	case event := <-events:
+		require.Failf(t, "unexpected sync state changed event", "%+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSynced(t *testing.T) {
+	n := &node{
+		stat:      NewStatus(1, 1),
+		wallclock: ethwallclock.NewEthereumBeaconChain(time.Now().Add(-100*12*time.Second), 12*time.Second, 32),
+	}
+
+	// Not healthy and no sync state yet.
+	assert.False(t, n.Synced(2))
+
+	n.stat.Health().RecordSuccess()
+	assert.False(t, n.Synced(2))
+
+	n.stat.UpdateSyncState(&v1.SyncState{HeadSlot: 99})
+	assert.True(t, n.Synced(2))
+	assert.False(t, n.Synced(0))
+
+	// The head slot from head events takes precedence over the sync state.
+	n.setHeadSlot(90)
+	assert.False(t, n.Synced(2))
+
+	n.setHeadSlot(100)
+	assert.True(t, n.Synced(0))
+
+	n.stat.UpdateSyncState(&v1.SyncState{HeadSlot: 100, IsOptimistic: true})
+	assert.False(t, n.Synced(2))
+
+	n.stat.UpdateSyncState(&v1.SyncState{HeadSlot: 100, IsSyncing: true, SyncDistance: 5})
+	assert.False(t, n.Synced(2))
+
+	n.stat.Health().RecordFail(nil)
+	n.stat.UpdateSyncState(&v1.SyncState{HeadSlot: 100})
+	assert.False(t, n.Synced(2))
+}
+
+func TestFetchSyncStatusELOffline(t *testing.T) {
+	client := &fakeAPI{syncState: types.SyncState{HeadSlot: 10}}
+
+	n := &node{
+		log:     logging.New(nil),
+		broker:  emission.NewEmitter(),
+		options: DefaultOptions(),
+		stat:    NewStatus(1, 1),
+		api:     client,
+	}
+
+	events := make(chan *ELOfflineEvent, 4)
+
+	n.OnELOffline(context.Background(), func(ctx context.Context, event *ELOfflineEvent) error {
+		events <- event
+
+		return nil
+	})
+
+	state, err := n.FetchSyncStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &v1.SyncState{HeadSlot: 10}, state)
+	assert.False(t, n.Status().ELOffline())
+
+	client.syncState.ELOffline = true
+
+	// Only the flip to offline emits an event.
+	for i := 0; i < 2; i++ {
+		_, err = n.FetchSyncStatus(context.Background())
+		require.NoError(t, err)
+	}
+
+	assert.True(t, n.Status().ELOffline())
+
+	select {
+	case event := <-events:
+		assert.Equal(t, phase0.Slot(10), event.State.HeadSlot)
+	case <-time.After(time.Second):
+		require.Fail(t, "expected an el offline event")
+	}
+
+	select {
+	case event := <-events:
+		require.Failf(t, "unexpected el offline event", "%+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,71 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	eapi "github.com/attestantio/go-eth2-client/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+)
+
+const (
+	// maxThrottleRetries is the number of times a throttled request is retried before giving up.
+	maxThrottleRetries = 2
+	// throttleBackoff is how long to wait before retrying a throttled request. The eth2 client does not
+	// expose the Retry-After header so a fixed backoff is used instead.
+	throttleBackoff = time.Second
+)
+
+// isThrottled returns true if the node responded to the request with a 429.
+func isThrottled(err error) bool {
+	var apiErr *eapi.Error
+
+	return (errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests) || errors.Is(err, api.ErrTooManyRequests)
+}
+
+// wrapThrottled wraps err with ErrTooManyRequests if the node responded with a 429.
+func wrapThrottled(err error) error {
+	if err == nil || errors.Is(err, ErrTooManyRequests) || !isThrottled(err) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", ErrTooManyRequests, err)
+}
+
+// retryThrottled calls fn with the given opts, retrying if the node throttles the request. Errors from the
+// raw client are not retried as it already honors the Retry-After header itself.
+func retryThrottled[O, T any](ctx context.Context, n *node, fn func(context.Context, O) (T, error), opts O) (T, error) {
+	for attempt := 0; ; attempt++ {
+		rsp, err := fn(ctx, opts)
+		if err == nil || !isThrottled(err) || errors.Is(err, api.ErrTooManyRequests) {
+			return rsp, err
+		}
+
+		n.throttled.Add(1)
+
+		if attempt >= maxThrottleRetries {
+			return rsp, wrapThrottled(err)
+		}
+
+		n.log.WithError(err).Debug("Request throttled by beacon node, retrying")
+
+		select {
+		case <-ctx.Done():
+			return rsp, ctx.Err()
+		case <-time.After(throttleBackoff):
+		}
+	}
+}
+
+func (n *node) ThrottledRequests() uint64 {
+	total := n.throttled.Load()
+
+	if apiClient := n.currentAPI(); apiClient != nil {
+		total += apiClient.ThrottledRequests()
+	}
+
+	return total
+}
@@ -0,0 +1,88 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	eapi "github.com/attestantio/go-eth2-client/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapThrottled(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "eth2 client 429",
+			err:      &eapi.Error{StatusCode: 429},
+			expected: true,
+		},
+		{
+			name:     "consensus client 429",
+			err:      &api.TooManyRequestsError{},
+			expected: true,
+		},
+		{
+			name:     "eth2 client 500",
+			err:      &eapi.Error{StatusCode: 500},
+			expected: false,
+		},
+		{
+			name:     "other error",
+			err:      errors.New("connection refused"),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := wrapThrottled(test.err)
+
+			assert.Equal(t, test.expected, errors.Is(err, ErrTooManyRequests))
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestRetryThrottled(t *testing.T) {
+	n := &node{log: logging.New(nil)}
+
+	calls := 0
+
+	rsp, err := retryThrottled(context.Background(), n, func(_ context.Context, opts string) (string, error) {
+		calls++
+
+		if calls == 1 {
+			return "", &eapi.Error{StatusCode: 429}
+		}
+
+		return opts, nil
+	}, "ok")
+
+	require.NoError(t, err)
+	assert.Equal(t, "ok", rsp)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, uint64(1), n.ThrottledRequests())
+}
+
+func TestRetryThrottledRawClient(t *testing.T) {
+	n := &node{log: logging.New(nil)}
+
+	calls := 0
+
+	_, err := retryThrottled(context.Background(), n, func(_ context.Context, _ string) (string, error) {
+		calls++
+
+		return "", &api.TooManyRequestsError{}
+	}, "")
+
+	// The raw client has already retried the request itself.
+	require.ErrorIs(t, err, ErrTooManyRequests)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,128 @@
+package beacon
+
+import "slices"
+
+// supportedEventTopics are the upstream event topics this library decodes and dispatches to handlers.
+var supportedEventTopics = EventTopics{
+	topicAttestation,
+	topicBlock,
+	topicChainReorg,
+	topicFinalizedCheckpoint,
+	topicHead,
+	topicVoluntaryExit,
+	topicContributionAndProof,
+	topicBlobSidecar,
+	topicDataColumnSidecar,
+}
+
+// TopicSubscription describes the upstream event subscription of a node.
+type TopicSubscription struct {
+	// Requested are the topics configured in Options.BeaconSubscription.
+	Requested EventTopics
+	// Unsupported are the requested topics that were skipped because the node's client does not serve them.
+	Unsupported EventTopics
+	// Undecodable are the requested topics that this library cannot decode, see SupportedTopics. Their events
+	// are only published through OnEvent, with the go-eth2-client type if it decodes the topic and with the
+	// raw JSON payload otherwise.
+	Undecodable EventTopics
+	// Active are the topics of the upstream event streams that are currently open, in the order the streams were opened.
+	Active EventTopics
+}
+
+func (n *node) SupportedTopics() EventTopics {
+	topics := make(EventTopics, len(supportedEventTopics))
+	copy(topics, supportedEventTopics)
+
+	return topics
+}
+
+func (n *node) SubscribedTopics() *TopicSubscription {
+	subscription := &TopicSubscription{
+		Requested:   EventTopics{},
+		Unsupported: EventTopics{},
+		Undecodable: EventTopics{},
+		Active:      EventTopics{},
+	}
+
+	if n.currentOptions().BeaconSubscription.Enabled {
+		subscription.Requested = append(subscription.Requested, n.currentOptions().BeaconSubscription.Topics...)
+	}
+
+	for _, topic := range subscription.Requested {
+		if !supportedEventTopics.Exists(topic) {
+			subscription.Undecodable = append(subscription.Undecodable, topic)
+		}
+	}
+
+	n.topicsMu.RLock()
+	defer n.topicsMu.RUnlock()
+
+	subscription.Unsupported = append(subscription.Unsupported, n.unsupportedTopics...)
+
+	for _, stream := range n.streamTopics {
+		subscription.Active = append(subscription.Active, *stream.topics...)
+	}
+
+	return subscription
+}
+
+// setUnsupportedTopics records the requested topics that were skipped because the client does not serve them.
+func (n *node) setUnsupportedTopics(topics EventTopics) {
+	n.topicsMu.Lock()
+	defer n.topicsMu.Unlock()
+
+	n.unsupportedTopics = topics
+}
+
+// setStreamTopics records the topics of an open upstream event stream. The returned token clears them again
+// with clearStreamTopics, unless the stream has been reopened since.
+func (n *node) setStreamTopics(stream string, topics EventTopics) *EventTopics {
+	n.topicsMu.Lock()
+	defer n.topicsMu.Unlock()
+
+	token := &topics
+
+	for i := range n.streamTopics {
+		if n.streamTopics[i].stream == stream {
+			n.streamTopics[i].topics = token
+
+			return token
+		}
+	}
+
+	n.streamTopics = append(n.streamTopics, openStream{stream: stream, topics: token})
+
+	return token
+}
+
+// clearStreamTopics marks the upstream event stream opened with the given token as closed.
+func (n *node) clearStreamTopics(stream string, token *EventTopics) {
+	n.topicsMu.Lock()
+	defer n.topicsMu.Unlock()
+
+	n.streamTopics = slices.DeleteFunc(n.streamTopics, func(open openStream) bool {
+		return open.stream == stream && open.topics == token
+	})
+}
+
+// markStreamConnected records that the upstream event stream connected and returns whether it has connected
+// before.
+func (n *node) markStreamConnected(stream string) bool {
+	n.topicsMu.Lock()
+	defer n.topicsMu.Unlock()
+
+	if n.connectedStreams == nil {
+		n.connectedStreams = make(map[string]bool)
+	}
+
+	reconnect := n.connectedStreams[stream]
+	n.connectedStreams[stream] = true
+
+	return reconnect
+}
+
+// openStream is an open upstream event stream, see setStreamTopics.
+type openStream struct {
+	stream string
+	topics *EventTopics
+}
@@ -0,0 +1,136 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribedTopics(t *testing.T) {
+	options := DefaultOptions()
+	options.BeaconSubscription.Enable()
+	options.BeaconSubscription.Topics = EventTopics{topicHead, topicDataColumnSidecar, "light_client_finality_update", "payload_attributes"}
+
+	n := &node{options: options}
+
+	subscription := n.SubscribedTopics()
+	assert.Equal(t, options.BeaconSubscription.Topics, subscription.Requested)
+	assert.Equal(t, EventTopics{"light_client_finality_update", "payload_attributes"}, subscription.Undecodable)
+	assert.Empty(t, subscription.Active)
+
+	n.setUnsupportedTopics(EventTopics{"light_client_finality_update"})
+
+	stale := n.setStreamTopics(EventStreamDefault, EventTopics{topicHead})
+	current := n.setStreamTopics(EventStreamDefault, EventTopics{topicHead, "payload_attributes"})
+	n.setStreamTopics(EventStreamRaw, EventTopics{topicDataColumnSidecar})
+
+	// Closing a stream that has been reopened since does not clear the reopened stream.
+	n.clearStreamTopics(EventStreamDefault, stale)
+
+	subscription = n.SubscribedTopics()
+	assert.Equal(t, EventTopics{"light_client_finality_update"}, subscription.Unsupported)
+	assert.Equal(t, EventTopics{topicHead, "payload_attributes", topicDataColumnSidecar}, subscription.Active)
+
+	n.clearStreamTopics(EventStreamDefault, current)
+
+	assert.Equal(t, EventTopics{topicDataColumnSidecar}, n.SubscribedTopics().Active)
+}
+
+func TestSupportedTopics(t *testing.T) {
+	n := &node{}
+
+	topics := n.SupportedTopics()
+	assert.True(t, topics.Exists(topicHead))
+	assert.True(t, topics.Exists(topicDataColumnSidecar))
+	assert.False(t, topics.Exists("payload_attributes"))
+
+	// Every decodable topic is either replayable through go-eth2-client types or a raw topic.
+	for _, topic := range topics {
+		_, replayable := replayEventTypes[topic]
+		assert.True(t, replayable || rawEventTopics.Exists(topic), topic)
+	}
+}
+
+func TestGroupEventTopics(t *testing.T) {
+	topics := []string{topicHead, topicBlock, topicAttestation, topicChainReorg}
+
+	assert.Equal(t, []eventStream{
+		{name: EventStreamDefault, topics: topics},
+	}, groupEventTopics(topics, nil))
+
+	// Topics the node does not serve are skipped, and a topic is only subscribed to in its first group.
+	groups := []EventTopics{
+		{topicAttestation},
+		{"light_client_finality_update"},
+		{topicHead, topicAttestation, topicBlock},
+	}
+
+	assert.Equal(t, []eventStream{
+		{name: EventStreamDefault, topics: []string{topicChainReorg}},
+		{name: EventStreamGroup(0), topics: []string{topicAttestation}},
+		{name: EventStreamGroup(2), topics: []string{topicHead, topicBlock}},
+	}, groupEventTopics(topics, groups))
+}
+
+func TestSeparateTopics(t *testing.T) {
+	options := DefaultEnabledBeaconSubscriptionOptions()
+	options.SeparateTopics()
+
+	streams := groupEventTopics(options.Topics, options.Groups)
+	assert.Len(t, streams, len(options.Topics))
+
+	for i, stream := range streams {
+		assert.Equal(t, EventStreamGroup(i), stream.name)
+		assert.Equal(t, []string{options.Topics[i]}, stream.topics)
+	}
+}
+
+func TestUnknownTopicPassthrough(t *testing.T) {
+	supported, raw := splitRawEventTopics([]string{topicHead, "light_client_optimistic_update", topicDataColumnSidecar, "payload_attributes"})
+	// Topics go-eth2-client decodes stay on its stream even if this library does not handle them.
+	assert.Equal(t, []string{topicHead, "payload_attributes"}, supported)
+	assert.Equal(t, []string{"light_client_optimistic_update", topicDataColumnSidecar}, raw)
+
+	n := &node{log: logging.New(nil), broker: emission.NewEmitter(), options: DefaultOptions()}
+
+	events := make(chan *v1.Event, 1)
+
+	n.OnEvent(context.Background(), func(ctx context.Context, event *v1.Event) error {
+		events <- event
+
+		return nil
+	})
+
+	data := []byte(`{"version":"electra","data":{"signature_slot":"1"}}`)
+
+	require.NoError(t, n.handleRawEvent(context.Background(), &api.Event{Topic: "light_client_optimistic_update", Data: data}))
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "light_client_optimistic_update", event.Topic)
+		assert.Equal(t, json.RawMessage(data), event.Data)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	// Events of topics go-eth2-client decodes but this library does not handle are published without error.
+	attributes := &v1.PayloadAttributesEvent{}
+
+	require.NoError(t, n.handleEvent(context.Background(), &v1.Event{Topic: "payload_attributes", Data: attributes}))
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "payload_attributes", event.Topic)
+		assert.Same(t, attributes, event.Data)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
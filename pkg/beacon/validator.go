@@ -0,0 +1,111 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// ProduceBlockOpts holds the optional parameters for ProduceBlock.
+type ProduceBlockOpts struct {
+	// BuilderBoostFactor is the relative weight (as a percentage) of a builder payload versus a locally produced
+	// payload. Nil leaves the node's default (100) in place, 0 always uses the local payload.
+	BuilderBoostFactor *uint64
+	// SkipRandaoVerification skips the randao reveal verification on the node. The randao reveal must then be
+	// the point at infinity.
+	SkipRandaoVerification bool
+}
+
+// ProduceBlock requests an unsigned block proposal for the given slot via the v3 block production endpoint.
+// The returned proposal may be blinded depending on the payload selected by the node.
+func (n *node) ProduceBlock(ctx context.Context, slot phase0.Slot, randaoReveal phase0.BLSSignature, graffiti [32]byte, opts *ProduceBlockOpts) (*api.VersionedProposal, error) {
+	provider, isProvider := n.currentClient().(eth2client.ProposalProvider)
+	if !isProvider {
+		return nil, errors.New("client does not implement eth2client.ProposalProvider")
+	}
+
+	proposalOpts := &api.ProposalOpts{
+		Slot:         slot,
+		RandaoReveal: randaoReveal,
+		Graffiti:     graffiti,
+	}
+
+	if opts != nil {
+		proposalOpts.BuilderBoostFactor = opts.BuilderBoostFactor
+		proposalOpts.SkipRandaoVerification = opts.SkipRandaoVerification
+	}
+
+	rsp, err := retryThrottled(ctx, n, provider.Proposal, proposalOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Data, nil
+}
+
+// SubmitBeaconCommitteeSubscriptions instructs the node to subscribe to the attestation subnets of the given
+// beacon committees.
+func (n *node) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscriptions []*v1.BeaconCommitteeSubscription) error {
+	submitter, isSubmitter := n.currentClient().(eth2client.BeaconCommitteeSubscriptionsSubmitter)
+	if !isSubmitter {
+		return errors.New("client does not implement eth2client.BeaconCommitteeSubscriptionsSubmitter")
+	}
+
+	return submitter.SubmitBeaconCommitteeSubscriptions(ctx, subscriptions)
+}
+
+// SubmitSyncCommitteeSubscriptions instructs the node to subscribe to the subnets of the given sync committees.
+func (n *node) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptions []*v1.SyncCommitteeSubscription) error {
+	submitter, isSubmitter := n.currentClient().(eth2client.SyncCommitteeSubscriptionsSubmitter)
+	if !isSubmitter {
+		return errors.New("client does not implement eth2client.SyncCommitteeSubscriptionsSubmitter")
+	}
+
+	return submitter.SubmitSyncCommitteeSubscriptions(ctx, subscriptions)
+}
+
+// SubmitSyncCommitteeMessages submits sync committee messages to the node's pool.
+func (n *node) SubmitSyncCommitteeMessages(ctx context.Context, messages []*altair.SyncCommitteeMessage) error {
+	submitter, isSubmitter := n.currentClient().(eth2client.SyncCommitteeMessagesSubmitter)
+	if !isSubmitter {
+		return errors.New("client does not implement eth2client.SyncCommitteeMessagesSubmitter")
+	}
+
+	return submitter.SubmitSyncCommitteeMessages(ctx, messages)
+}
+
+// SubmitSyncCommitteeContributions submits signed sync committee contribution and proofs to the node for gossip.
+func (n *node) SubmitSyncCommitteeContributions(ctx context.Context, contributionAndProofs []*altair.SignedContributionAndProof) error {
+	submitter, isSubmitter := n.currentClient().(eth2client.SyncCommitteeContributionsSubmitter)
+	if !isSubmitter {
+		return errors.New("client does not implement eth2client.SyncCommitteeContributionsSubmitter")
+	}
+
+	return submitter.SubmitSyncCommitteeContributions(ctx, contributionAndProofs)
+}
+
+// PrepareBeaconProposer provides the node with the fee recipients of validators that may propose in the
+// upcoming epochs.
+func (n *node) PrepareBeaconProposer(ctx context.Context, preparations []*v1.ProposalPreparation) error {
+	submitter, isSubmitter := n.currentClient().(eth2client.ProposalPreparationsSubmitter)
+	if !isSubmitter {
+		return errors.New("client does not implement eth2client.ProposalPreparationsSubmitter")
+	}
+
+	return submitter.SubmitProposalPreparations(ctx, preparations)
+}
+
+// RegisterValidators passes the signed builder registrations of validators through the node to its builder.
+func (n *node) RegisterValidators(ctx context.Context, registrations []*api.VersionedSignedValidatorRegistration) error {
+	submitter, isSubmitter := n.currentClient().(eth2client.ValidatorRegistrationsSubmitter)
+	if !isSubmitter {
+		return errors.New("client does not implement eth2client.ValidatorRegistrationsSubmitter")
+	}
+
+	return submitter.SubmitValidatorRegistrations(ctx, registrations)
+}
@@ -0,0 +1,313 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// farFutureEpoch is the epoch used for validator lifecycle events that have not been scheduled yet.
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+// validatorSnapshot is the head validator set, fetched once and then kept up to date from the operations in
+// head blocks. Only the effects of operations that can be derived from the block alone are applied:
+// deposits add validators or top up balances before Electra, voluntary exits mark validators as exiting and
+// slashings mark them as slashed. From Electra on deposits only join the pending deposits queue, so they are
+// not applied. Rewards, penalties, activations and the exit queue are not tracked, so balances, epochs and
+// statuses are approximations until the set is fetched again, which happens on every finalized checkpoint.
+type validatorSnapshot struct {
+	mu         sync.RWMutex
+	validators map[phase0.ValidatorIndex]*v1.Validator
+	pubkeys    map[phase0.BLSPubKey]phase0.ValidatorIndex
+	// slot is the slot of the last block applied to the snapshot.
+	slot phase0.Slot
+}
+
+func newValidatorSnapshot(validators map[phase0.ValidatorIndex]*v1.Validator, slot phase0.Slot) *validatorSnapshot {
+	s := &validatorSnapshot{
+		validators: validators,
+		pubkeys:    make(map[phase0.BLSPubKey]phase0.ValidatorIndex, len(validators)),
+		slot:       slot,
+	}
+
+	for index, validator := range validators {
+		if validator.Validator != nil {
+			s.pubkeys[validator.Validator.PublicKey] = index
+		}
+	}
+
+	return s
+}
+
+// copy returns a copy of the validators that is safe to modify.
+func (s *validatorSnapshot) copy() map[phase0.ValidatorIndex]*v1.Validator {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	validators := make(map[phase0.ValidatorIndex]*v1.Validator, len(s.validators))
+
+	for index, validator := range s.validators {
+		c := *validator
+
+		if validator.Validator != nil {
+			inner := *validator.Validator
+			c.Validator = &inner
+		}
+
+		validators[index] = &c
+	}
+
+	return validators
+}
+
+// apply updates the snapshot with the operations in the block. Blocks at or before the last applied slot
+// are ignored. pendingDeposits is set for blocks from Electra on, whose deposits are not credited straight
+// away.
+func (s *validatorSnapshot) apply(block *spec.VersionedSignedBeaconBlock, pendingDeposits bool) error {
+	slot, err := block.Slot()
+	if err != nil {
+		return err
+	}
+
+	deposits, err := block.Deposits()
+	if err != nil {
+		return err
+	}
+
+	exits, err := block.VoluntaryExits()
+	if err != nil {
+		return err
+	}
+
+	proposerSlashings, err := block.ProposerSlashings()
+	if err != nil {
+		return err
+	}
+
+	attesterSlashings, err := block.AttesterSlashings()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if slot <= s.slot {
+		return nil
+	}
+
+	s.slot = slot
+
+	// From Electra on deposits are added to the pending deposits and only credited once processed at an epoch
+	// boundary.
+	if !pendingDeposits {
+		for _, deposit := range deposits {
+			if deposit != nil && deposit.Data != nil {
+				s.applyDeposit(deposit.Data)
+			}
+		}
+	}
+
+	for _, exit := range exits {
+		if exit == nil || exit.Message == nil {
+			continue
+		}
+
+		if validator, exists := s.validators[exit.Message.ValidatorIndex]; exists && validator.Status.IsActive() {
+			validator.Status = v1.ValidatorStateActiveExiting
+		}
+	}
+
+	for _, slashing := range proposerSlashings {
+		if slashing == nil || slashing.SignedHeader1 == nil || slashing.SignedHeader1.Message == nil {
+			continue
+		}
+
+		s.slash(slashing.SignedHeader1.Message.ProposerIndex)
+	}
+
+	for _, slashing := range attesterSlashings {
+		if slashing == nil || slashing.Attestation1 == nil || slashing.Attestation2 == nil {
+			continue
+		}
+
+		// Only validators that signed both attestations are slashed.
+		signed := make(map[uint64]struct{}, len(slashing.Attestation1.AttestingIndices))
+		for _, index := range slashing.Attestation1.AttestingIndices {
+			signed[index] = struct{}{}
+		}
+
+		for _, index := range slashing.Attestation2.AttestingIndices {
+			if _, exists := signed[index]; exists {
+				s.slash(phase0.ValidatorIndex(index))
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyDeposit tops up the balance of an existing validator or adds a new one. The deposit signature is not
+// verified, so deposits that the state transition would reject are added too.
+func (s *validatorSnapshot) applyDeposit(data *phase0.DepositData) {
+	if index, exists := s.pubkeys[data.PublicKey]; exists {
+		s.validators[index].Balance += data.Amount
+
+		return
+	}
+
+	index := phase0.ValidatorIndex(len(s.validators))
+
+	s.validators[index] = &v1.Validator{
+		Index:   index,
+		Balance: data.Amount,
+		Status:  v1.ValidatorStatePendingInitialized,
+		Validator: &phase0.Validator{
+			PublicKey:                  data.PublicKey,
+			WithdrawalCredentials:      data.WithdrawalCredentials,
+			ActivationEligibilityEpoch: farFutureEpoch,
+			ActivationEpoch:            farFutureEpoch,
+			ExitEpoch:                  farFutureEpoch,
+			WithdrawableEpoch:          farFutureEpoch,
+		},
+	}
+	s.pubkeys[data.PublicKey] = index
+}
+
+func (s *validatorSnapshot) slash(index phase0.ValidatorIndex) {
+	validator, exists := s.validators[index]
+	if !exists {
+		return
+	}
+
+	if validator.Validator != nil {
+		validator.Validator.Slashed = true
+	}
+
+	if validator.Status.IsActive() {
+		validator.Status = v1.ValidatorStateActiveSlashed
+	}
+}
+
+// SnapshotValidators returns the head validator set. It is fetched on the first call and kept up to date from
+// the deposits, voluntary exits and slashings in head blocks afterwards, see validatorSnapshot for its
+// limitations. The set is fetched again on every finalized checkpoint and on the next call after a reorg.
+// Requires the head topic to be enabled in the beacon subscription.
+func (n *node) SnapshotValidators(ctx context.Context) (map[phase0.ValidatorIndex]*v1.Validator, error) {
+	snapshot, err := singleFlight(ctx, &n.inflight, "validator_snapshot", func(ctx context.Context) (*validatorSnapshot, error) {
+		if snapshot := n.currentValidatorSnapshot(); snapshot != nil {
+			return snapshot, nil
+		}
+
+		snapshot, err := n.fetchValidatorSnapshot(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		n.setValidatorSnapshot(snapshot)
+
+		return snapshot, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.copy(), nil
+}
+
+// fetchValidatorSnapshot fetches the validators of the head state. The head is resolved to its block first,
+// so that the snapshot starts at the slot of the state the validators were fetched from.
+func (n *node) fetchValidatorSnapshot(ctx context.Context) (*validatorSnapshot, error) {
+	block, err := n.FetchBlock(ctx, "head")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch head block: %w", err)
+	}
+
+	slot, err := block.Slot()
+	if err != nil {
+		return nil, err
+	}
+
+	stateRoot, err := block.StateRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := n.FetchValidators(ctx, fmt.Sprintf("%#x", stateRoot), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch validators: %w", err)
+	}
+
+	return newValidatorSnapshot(validators, slot), nil
+}
+
+func (n *node) currentValidatorSnapshot() *validatorSnapshot {
+	n.validatorSnapshotMu.Lock()
+	defer n.validatorSnapshotMu.Unlock()
+
+	return n.validatorSnapshot
+}
+
+func (n *node) setValidatorSnapshot(snapshot *validatorSnapshot) {
+	n.validatorSnapshotMu.Lock()
+	defer n.validatorSnapshotMu.Unlock()
+
+	n.validatorSnapshot = snapshot
+}
+
+func (n *node) subscribeValidatorSnapshot(ctx context.Context) {
+	n.OnHead(ctx, func(ctx context.Context, event *v1.HeadEvent) error {
+		snapshot := n.currentValidatorSnapshot()
+		if snapshot == nil {
+			return nil
+		}
+
+		sp, err := n.Spec()
+		if err != nil {
+			return err
+		}
+
+		block, err := n.FetchBlock(ctx, fmt.Sprintf("%#x", event.Block))
+		if err != nil {
+			return fmt.Errorf("failed to fetch head block to update the validator snapshot: %w", err)
+		}
+
+		if sp.SlotsPerEpoch == 0 {
+			return errors.New("spec has no slots per epoch")
+		}
+
+		epoch := phase0.Epoch(event.Slot / sp.SlotsPerEpoch)
+
+		return snapshot.apply(block, epoch >= sp.ElectraForkEpoch)
+	})
+
+	// Blocks of the abandoned fork have already been applied, so the snapshot is dropped and fetched again on
+	// the next call.
+	n.OnChainReOrg(ctx, func(ctx context.Context, event *v1.ChainReorgEvent) error {
+		n.setValidatorSnapshot(nil)
+
+		return nil
+	})
+
+	// The approximations drift further from the real validator set with every epoch, so the snapshot is
+	// replaced whenever the chain finalizes.
+	n.OnFinalizedCheckpoint(ctx, func(ctx context.Context, event *v1.FinalizedCheckpointEvent) error {
+		if n.currentValidatorSnapshot() == nil {
+			return nil
+		}
+
+		snapshot, err := n.fetchValidatorSnapshot(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to refresh the validator snapshot: %w", err)
+		}
+
+		n.setValidatorSnapshot(snapshot)
+
+		return nil
+	})
+}
@@ -0,0 +1,112 @@
+package beacon
+
+import (
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorSnapshotApply(t *testing.T) {
+	validator := func(index phase0.ValidatorIndex) *v1.Validator {
+		return &v1.Validator{
+			Index:     index,
+			Balance:   32_000_000_000,
+			Status:    v1.ValidatorStateActiveOngoing,
+			Validator: &phase0.Validator{PublicKey: phase0.BLSPubKey{byte(index)}},
+		}
+	}
+
+	s := newValidatorSnapshot(map[phase0.ValidatorIndex]*v1.Validator{
+		0: validator(0),
+		1: validator(1),
+		2: validator(2),
+		3: validator(3),
+	}, 10)
+
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.SignedBeaconBlock{
+			Message: &phase0.BeaconBlock{
+				Slot: 11,
+				Body: &phase0.BeaconBlockBody{
+					Deposits: []*phase0.Deposit{
+						{Data: &phase0.DepositData{PublicKey: phase0.BLSPubKey{0}, Amount: 1_000_000_000}},
+						{Data: &phase0.DepositData{PublicKey: phase0.BLSPubKey{0xff}, Amount: 32_000_000_000}},
+					},
+					VoluntaryExits: []*phase0.SignedVoluntaryExit{
+						{Message: &phase0.VoluntaryExit{ValidatorIndex: 1}},
+					},
+					ProposerSlashings: []*phase0.ProposerSlashing{
+						{SignedHeader1: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{ProposerIndex: 2}}},
+					},
+					AttesterSlashings: []*phase0.AttesterSlashing{
+						{
+							Attestation1: &phase0.IndexedAttestation{AttestingIndices: []uint64{0, 3}},
+							Attestation2: &phase0.IndexedAttestation{AttestingIndices: []uint64{3}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, s.apply(block, false))
+
+	validators := s.copy()
+	require.Len(t, validators, 5)
+
+	// The top up is added to the existing validator and the new key becomes a new validator.
+	assert.Equal(t, phase0.Gwei(33_000_000_000), validators[0].Balance)
+	assert.Equal(t, phase0.BLSPubKey{0xff}, validators[4].Validator.PublicKey)
+	assert.Equal(t, v1.ValidatorStatePendingInitialized, validators[4].Status)
+
+	assert.Equal(t, v1.ValidatorStateActiveExiting, validators[1].Status)
+	assert.True(t, validators[2].Validator.Slashed)
+	assert.Equal(t, v1.ValidatorStateActiveSlashed, validators[2].Status)
+	assert.True(t, validators[3].Validator.Slashed)
+	assert.False(t, validators[0].Validator.Slashed)
+
+	// A block that was already applied is ignored.
+	require.NoError(t, s.apply(block, false))
+	assert.Len(t, s.copy(), 5)
+
+	// Modifying the copy does not affect the snapshot.
+	validators[0].Validator.Slashed = true
+	assert.False(t, s.copy()[0].Validator.Slashed)
+}
+
+func TestValidatorSnapshotSkipsPendingDeposits(t *testing.T) {
+	s := newValidatorSnapshot(map[phase0.ValidatorIndex]*v1.Validator{
+		0: {Index: 0, Balance: 32_000_000_000, Status: v1.ValidatorStateActiveOngoing, Validator: &phase0.Validator{PublicKey: phase0.BLSPubKey{0}}},
+	}, 10)
+
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.SignedBeaconBlock{
+			Message: &phase0.BeaconBlock{
+				Slot: 11,
+				Body: &phase0.BeaconBlockBody{
+					Deposits: []*phase0.Deposit{
+						{Data: &phase0.DepositData{PublicKey: phase0.BLSPubKey{0}, Amount: 1_000_000_000}},
+						{Data: &phase0.DepositData{PublicKey: phase0.BLSPubKey{0xff}, Amount: 32_000_000_000}},
+					},
+					VoluntaryExits: []*phase0.SignedVoluntaryExit{
+						{Message: &phase0.VoluntaryExit{ValidatorIndex: 0}},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, s.apply(block, true))
+
+	// Pending deposits are neither credited nor turned into validators, while the other operations still apply.
+	validators := s.copy()
+	require.Len(t, validators, 1)
+	assert.Equal(t, phase0.Gwei(32_000_000_000), validators[0].Balance)
+	assert.Equal(t, v1.ValidatorStateActiveExiting, validators[0].Status)
+}
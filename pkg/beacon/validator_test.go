@@ -0,0 +1,52 @@
+package beacon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/api"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchValidator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/states/head/validators/7" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"index":"7","balance":"32000000000","status":"active_ongoing","validator":{` +
+			`"pubkey":"0x` + strings.Repeat("aa", 48) + `",` +
+			`"withdrawal_credentials":"0x` + strings.Repeat("00", 32) + `",` +
+			`"effective_balance":"32000000000","slashed":false,` +
+			`"activation_eligibility_epoch":"0","activation_epoch":"0",` +
+			`"exit_epoch":"18446744073709551615","withdrawable_epoch":"18446744073709551615"}}}`))
+	}))
+	defer server.Close()
+
+	n := &node{
+		log:     logging.New(nil),
+		broker:  emission.NewEmitter(),
+		options: DefaultOptions(),
+		api:     api.NewConsensusClient(context.Background(), logging.New(nil), server.URL, http.Client{}, nil),
+	}
+
+	validator, err := n.FetchValidator(context.Background(), "head", "7")
+	require.NoError(t, err)
+	assert.Equal(t, phase0.ValidatorIndex(7), validator.Index)
+	assert.Equal(t, phase0.Gwei(32000000000), validator.Balance)
+	assert.Equal(t, v1.ValidatorStateActiveOngoing, validator.Status)
+
+	_, err = n.FetchValidator(context.Background(), "head", "8")
+	assert.ErrorIs(t, err, ErrValidatorNotFound)
+}
@@ -0,0 +1,77 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// WeakSubjectivityCheckpoint is a weak subjectivity checkpoint along with the inputs used to derive its period.
+type WeakSubjectivityCheckpoint struct {
+	// Checkpoint is the latest finalized checkpoint, which is the most recent safe weak subjectivity checkpoint.
+	Checkpoint *phase0.Checkpoint
+	// Period is the weak subjectivity period (in epochs).
+	Period phase0.Epoch
+	// ActiveValidators is the number of active validators in the head state.
+	ActiveValidators uint64
+	// TotalActiveBalance is the sum of the effective balances of the active validators in the head state.
+	TotalActiveBalance phase0.Gwei
+	// CurrentEpoch is the wallclock epoch at the time of calculation.
+	CurrentEpoch phase0.Epoch
+}
+
+// IsWithinPeriod returns true if the checkpoint is still within the weak subjectivity period at the current epoch.
+func (w *WeakSubjectivityCheckpoint) IsWithinPeriod() bool {
+	if w.Checkpoint == nil {
+		return false
+	}
+
+	return w.CurrentEpoch <= w.Checkpoint.Epoch+w.Period
+}
+
+// FetchWeakSubjectivityCheckpoint computes the current weak subjectivity checkpoint and period from
+// the active validator set in the head state.
+func (n *node) FetchWeakSubjectivityCheckpoint(ctx context.Context) (*WeakSubjectivityCheckpoint, error) {
+	sp, err := n.Spec()
+	if err != nil {
+		return nil, err
+	}
+
+	wallclock := n.Wallclock()
+	if wallclock == nil {
+		return nil, errors.New("wallclock is not available")
+	}
+
+	finality, err := n.FetchFinality(ctx, "head")
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := n.FetchValidators(ctx, "head", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	active := uint64(0)
+	balance := phase0.Gwei(0)
+
+	for _, validator := range validators {
+		if validator == nil || validator.Validator == nil || !validator.Status.IsActive() {
+			continue
+		}
+
+		active++
+		balance += validator.Validator.EffectiveBalance
+	}
+
+	epoch := wallclock.Epochs().Current()
+
+	return &WeakSubjectivityCheckpoint{
+		Checkpoint:         finality.Finalized,
+		Period:             sp.WeakSubjectivityPeriod(active, balance),
+		ActiveValidators:   active,
+		TotalActiveBalance: balance,
+		CurrentEpoch:       phase0.Epoch(epoch.Number()),
+	}, nil
+}
@@ -0,0 +1,146 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	// withdrawalsChainSlotWindow is the number of slots behind the head that emitted blocks are kept for, so
+	// that their withdrawals can be reverted if they are reorged out.
+	withdrawalsChainSlotWindow = 64
+)
+
+// withdrawalsBlock is a canonical block whose withdrawals have been emitted.
+type withdrawalsBlock struct {
+	root        phase0.Root
+	slot        phase0.Slot
+	withdrawals []WithdrawalEvent
+}
+
+// subscribeWithdrawalExtraction emits the withdrawals of every new canonical block when the head changes.
+func (n *node) subscribeWithdrawalExtraction(ctx context.Context) {
+	n.OnHead(ctx, func(ctx context.Context, event *v1.HeadEvent) error {
+		return n.extractWithdrawals(ctx, event.Block)
+	})
+}
+
+// extractWithdrawals emits the withdrawals of the blocks that became canonical with the given head, oldest
+// first. The parent roots are walked back to the newest emitted block that is still canonical, so no block is
+// skipped when the head jumps by more than one block. The withdrawals of emitted blocks that are no longer
+// canonical are reverted first, in the reverse order they were emitted. The first head only emits the
+// withdrawals of its own block.
+func (n *node) extractWithdrawals(ctx context.Context, head phase0.Root) error {
+	n.withdrawalsChainMu.Lock()
+	defer n.withdrawalsChainMu.Unlock()
+
+	chain := n.withdrawalsChain
+
+	positions := make(map[phase0.Root]int, len(chain))
+	for i, block := range chain {
+		positions[block.root] = i
+	}
+
+	// ancestor is the position of the newest emitted block that is an ancestor of the head, -1 if none is.
+	ancestor := -1
+
+	var blocks []withdrawalsBlock
+
+	for root := head; ; {
+		if i, exists := positions[root]; exists {
+			ancestor = i
+
+			break
+		}
+
+		block, err := n.FetchBlock(ctx, fmt.Sprintf("%#x", root))
+		if err != nil {
+			return fmt.Errorf("failed to fetch head block to extract withdrawals: %w", err)
+		}
+
+		slot, err := block.Slot()
+		if err != nil {
+			return err
+		}
+
+		// None of the emitted blocks is an ancestor of the head once it is older than all of them.
+		if len(chain) > 0 && slot < chain[0].slot {
+			break
+		}
+
+		withdrawals, err := blockWithdrawals(root, slot, block)
+		if err != nil {
+			return err
+		}
+
+		blocks = append(blocks, withdrawalsBlock{root: root, slot: slot, withdrawals: withdrawals})
+
+		if len(chain) == 0 {
+			break
+		}
+
+		root, err = block.ParentRoot()
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := len(chain) - 1; i > ancestor; i-- {
+		for j := len(chain[i].withdrawals) - 1; j >= 0; j-- {
+			withdrawal := chain[i].withdrawals[j]
+
+			n.publishWithdrawalReverted(ctx, &withdrawal)
+		}
+	}
+
+	chain = chain[:ancestor+1]
+
+	for i := len(blocks) - 1; i >= 0; i-- {
+		for _, withdrawal := range blocks[i].withdrawals {
+			n.publishWithdrawal(ctx, &withdrawal)
+		}
+
+		chain = append(chain, blocks[i])
+	}
+
+	for len(chain) > 1 && chain[0].slot+withdrawalsChainSlotWindow < chain[len(chain)-1].slot {
+		chain = chain[1:]
+	}
+
+	n.withdrawalsChain = chain
+
+	return nil
+}
+
+// blockWithdrawals returns a WithdrawalEvent for every withdrawal in the block. Blocks produced before Capella
+// have no withdrawals.
+func blockWithdrawals(root phase0.Root, slot phase0.Slot, block *spec.VersionedSignedBeaconBlock) ([]WithdrawalEvent, error) {
+	switch block.Version {
+	case spec.DataVersionPhase0, spec.DataVersionAltair, spec.DataVersionBellatrix:
+		return nil, nil
+	}
+
+	withdrawals, err := block.Withdrawals()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]WithdrawalEvent, 0, len(withdrawals))
+
+	for _, withdrawal := range withdrawals {
+		events = append(events, WithdrawalEvent{
+			BlockRoot:      root,
+			Slot:           slot,
+			Index:          withdrawal.Index,
+			ValidatorIndex: withdrawal.ValidatorIndex,
+			Address:        withdrawal.Address,
+			Amount:         withdrawal.Amount,
+		})
+	}
+
+	return events, nil
+}
@@ -0,0 +1,94 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/chuckpreslar/emission"
+	"github.com/ethpandaops/beacon/pkg/beacon/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractWithdrawals(t *testing.T) {
+	client := &fakeClient{blocks: byRoot(map[phase0.Root]*spec.VersionedSignedBeaconBlock{
+		{0x01}: capellaBlock(8, phase0.Root{0x00}, 1, 2),
+		{0x02}: capellaBlock(9, phase0.Root{0x01}, 3),
+		// Slot 10 is empty and only the blocks of the canonical chain are walked.
+		{0x03}: capellaBlock(11, phase0.Root{0x02}, 4),
+		{0x04}: capellaBlock(12, phase0.Root{0x03}, 5),
+		// 0x05 and 0x06 reorg out 0x04 and 0x03.
+		{0x05}: capellaBlock(11, phase0.Root{0x02}, 4),
+		{0x06}: capellaBlock(13, phase0.Root{0x05}, 5, 6),
+	})}
+
+	n := &node{
+		log:     logging.New(nil),
+		broker:  emission.NewEmitter(),
+		options: DefaultOptions().EnableWithdrawalExtraction(),
+		client:  client,
+	}
+
+	var emitted, reverted []*WithdrawalEvent
+
+	n.OnWithdrawal(context.Background(), func(ctx context.Context, event *WithdrawalEvent) error {
+		emitted = append(emitted, event)
+
+		return nil
+	})
+
+	n.OnWithdrawalReverted(context.Background(), func(ctx context.Context, event *WithdrawalEvent) error {
+		reverted = append(reverted, event)
+
+		return nil
+	})
+
+	indices := func(events []*WithdrawalEvent) []capella.WithdrawalIndex {
+		indices := make([]capella.WithdrawalIndex, 0, len(events))
+		for _, event := range events {
+			indices = append(indices, event.Index)
+		}
+
+		return indices
+	}
+
+	// The first head only emits the withdrawals of its own block.
+	require.NoError(t, n.extractWithdrawals(context.Background(), phase0.Root{0x01}))
+
+	require.Len(t, emitted, 2)
+	assert.Equal(t, phase0.Root{0x01}, emitted[1].BlockRoot)
+	assert.Equal(t, phase0.Slot(8), emitted[1].Slot)
+	assert.Equal(t, phase0.ValidatorIndex(2), emitted[1].ValidatorIndex)
+	assert.Equal(t, phase0.Gwei(200), emitted[1].Amount)
+
+	// Later heads emit every block since the previous head, oldest first.
+	require.NoError(t, n.extractWithdrawals(context.Background(), phase0.Root{0x02}))
+	require.NoError(t, n.extractWithdrawals(context.Background(), phase0.Root{0x04}))
+	assert.Equal(t, []capella.WithdrawalIndex{1, 2, 3, 4, 5}, indices(emitted))
+
+	// A head that was already processed emits nothing.
+	require.NoError(t, n.extractWithdrawals(context.Background(), phase0.Root{0x04}))
+	assert.Len(t, emitted, 5)
+	assert.Empty(t, reverted)
+
+	// A reorg reverts the blocks that are no longer canonical in reverse, before emitting the new ones.
+	require.NoError(t, n.extractWithdrawals(context.Background(), phase0.Root{0x06}))
+	assert.Equal(t, []capella.WithdrawalIndex{5, 4}, indices(reverted))
+	assert.Equal(t, phase0.Root{0x04}, reverted[0].BlockRoot)
+	assert.Equal(t, []capella.WithdrawalIndex{1, 2, 3, 4, 5, 4, 5, 6}, indices(emitted))
+	assert.Equal(t, phase0.Root{0x06}, emitted[7].BlockRoot)
+
+	// A head that moves back to an ancestor reverts the blocks after it.
+	require.NoError(t, n.extractWithdrawals(context.Background(), phase0.Root{0x05}))
+	assert.Equal(t, []capella.WithdrawalIndex{5, 4, 6, 5}, indices(reverted))
+	assert.Len(t, emitted, 8)
+}
+
+func TestBlockWithdrawalsBeforeCapella(t *testing.T) {
+	withdrawals, err := blockWithdrawals(phase0.Root{}, 0, &spec.VersionedSignedBeaconBlock{Version: spec.DataVersionBellatrix})
+	require.NoError(t, err)
+	assert.Empty(t, withdrawals)
+}
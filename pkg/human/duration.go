@@ -2,6 +2,9 @@ package human
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,7 +27,7 @@ func (d *Duration) UnmarshalJSON(data []byte) error {
 }
 
 func (d *Duration) Unmarshal(s string) (err error) {
-	d.Duration, err = time.ParseDuration(s)
+	d.Duration, err = ParseDuration(s)
 	return
 }
 
@@ -35,3 +38,85 @@ func (d Duration) MarshalText() ([]byte, error) {
 func (d Duration) MarshalJSON() ([]byte, error) {
 	return json.Marshal(d.Duration.String())
 }
+
+// Add returns the sum of d and o.
+func (d Duration) Add(o Duration) Duration {
+	return Duration{Duration: d.Duration + o.Duration}
+}
+
+// Sub returns the difference of d and o.
+func (d Duration) Sub(o Duration) Duration {
+	return Duration{Duration: d.Duration - o.Duration}
+}
+
+// Mul returns d multiplied by f.
+func (d Duration) Mul(f float64) Duration {
+	return Duration{Duration: time.Duration(float64(d.Duration) * f)}
+}
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+// ParseDuration parses a duration string. On top of the formats accepted by time.ParseDuration
+// (e.g. "1h30m", "1.5h") it accepts days ("d") and weeks ("w") and whitespace between the
+// components (e.g. "1d 12h").
+func ParseDuration(s string) (time.Duration, error) {
+	trimmed := strings.Join(strings.Fields(s), "")
+	if trimmed == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	negative := false
+
+	switch trimmed[0] {
+	case '-':
+		negative = true
+		trimmed = trimmed[1:]
+	case '+':
+		trimmed = trimmed[1:]
+	}
+
+	total := time.Duration(0)
+
+	// Consume the day and week components, leaving the rest to time.ParseDuration.
+	for {
+		i := 0
+		for i < len(trimmed) && (trimmed[i] == '.' || (trimmed[i] >= '0' && trimmed[i] <= '9')) {
+			i++
+		}
+
+		if i == 0 || i == len(trimmed) || (trimmed[i] != 'd' && trimmed[i] != 'w') {
+			break
+		}
+
+		value, err := strconv.ParseFloat(trimmed[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+
+		unit := day
+		if trimmed[i] == 'w' {
+			unit = week
+		}
+
+		total += time.Duration(value * float64(unit))
+		trimmed = trimmed[i+1:]
+	}
+
+	if trimmed != "" {
+		rest, err := time.ParseDuration(trimmed)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+
+		total += rest
+	}
+
+	if negative {
+		total = -total
+	}
+
+	return total, nil
+}
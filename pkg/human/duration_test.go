@@ -0,0 +1,60 @@
+package human
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		err      bool
+	}{
+		{input: "12s", expected: 12 * time.Second},
+		{input: "1h30m", expected: 90 * time.Minute},
+		{input: "1.5h", expected: 90 * time.Minute},
+		{input: "1d", expected: 24 * time.Hour},
+		{input: "1d 12h", expected: 36 * time.Hour},
+		{input: "1w", expected: 7 * 24 * time.Hour},
+		{input: "0.5d", expected: 12 * time.Hour},
+		{input: "-1d", expected: -24 * time.Hour},
+		{input: "", err: true},
+		{input: "soon", err: true},
+		{input: "1d soon", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			d, err := ParseDuration(test.input)
+			if test.err {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, d)
+		})
+	}
+}
+
+func TestDurationUnmarshalJSON(t *testing.T) {
+	var d Duration
+
+	require.NoError(t, json.Unmarshal([]byte(`"1d 30m"`), &d))
+	assert.Equal(t, 24*time.Hour+30*time.Minute, d.Duration)
+}
+
+func TestDurationArithmetic(t *testing.T) {
+	a := Duration{Duration: time.Minute}
+	b := Duration{Duration: 30 * time.Second}
+
+	assert.Equal(t, 90*time.Second, a.Add(b).Duration)
+	assert.Equal(t, 30*time.Second, a.Sub(b).Duration)
+	assert.Equal(t, 90*time.Second, a.Mul(1.5).Duration)
+}
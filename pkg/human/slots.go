@@ -0,0 +1,76 @@
+package human
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Slots is a number of slots that can be converted to a duration given the slot duration of a network.
+// It can be unmarshalled from values such as "4", "4 slots" or "1 slot".
+type Slots uint64
+
+// Duration returns how long the slots take given the duration of a single slot.
+func (s Slots) Duration(slotDuration time.Duration) time.Duration {
+	return time.Duration(s) * slotDuration
+}
+
+func (s *Slots) UnmarshalText(text []byte) error {
+	v, err := parseUnits(string(text), "slot")
+	if err != nil {
+		return err
+	}
+
+	*s = Slots(v)
+
+	return nil
+}
+
+func (s Slots) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(s), 10)), nil
+}
+
+// Epochs is a number of epochs that can be converted to slots or a duration given the spec of a network.
+// It can be unmarshalled from values such as "2", "2 epochs" or "1 epoch".
+type Epochs uint64
+
+// Slots returns the number of slots in the epochs.
+func (e Epochs) Slots(slotsPerEpoch uint64) Slots {
+	return Slots(uint64(e) * slotsPerEpoch)
+}
+
+// Duration returns how long the epochs take given the duration of a single slot and the number of slots
+// per epoch.
+func (e Epochs) Duration(slotDuration time.Duration, slotsPerEpoch uint64) time.Duration {
+	return e.Slots(slotsPerEpoch).Duration(slotDuration)
+}
+
+func (e *Epochs) UnmarshalText(text []byte) error {
+	v, err := parseUnits(string(text), "epoch")
+	if err != nil {
+		return err
+	}
+
+	*e = Epochs(v)
+
+	return nil
+}
+
+func (e Epochs) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(e), 10)), nil
+}
+
+// parseUnits parses a count that is optionally followed by the given unit in singular or plural form.
+func parseUnits(s, unit string) (uint64, error) {
+	trimmed := strings.TrimSpace(strings.ToLower(s))
+	trimmed = strings.TrimSuffix(trimmed, unit+"s")
+	trimmed = strings.TrimSuffix(trimmed, unit)
+
+	v, err := strconv.ParseUint(strings.TrimSpace(trimmed), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number of %ss %q: %w", unit, s, err)
+	}
+
+	return v, nil
+}
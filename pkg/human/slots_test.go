@@ -0,0 +1,51 @@
+package human
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlots(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Slots
+		err      bool
+	}{
+		{input: "4", expected: 4},
+		{input: "4 slots", expected: 4},
+		{input: "1 slot", expected: 1},
+		{input: "4slots", expected: 4},
+		{input: "four", err: true},
+		{input: "4 epochs", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			var s Slots
+
+			err := s.UnmarshalText([]byte(test.input))
+			if test.err {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, s)
+		})
+	}
+
+	assert.Equal(t, 48*time.Second, Slots(4).Duration(12*time.Second))
+}
+
+func TestEpochs(t *testing.T) {
+	var e Epochs
+
+	require.NoError(t, e.UnmarshalText([]byte("2 epochs")))
+	assert.Equal(t, Epochs(2), e)
+	assert.Equal(t, Slots(64), e.Slots(32))
+	assert.Equal(t, 768*time.Second, e.Duration(12*time.Second, 32))
+}